@@ -41,17 +41,11 @@ func startAutomation(ctx context.Context, opts automationOptions) {
 }
 
 func runAutomationSweep(opts automationOptions) {
-	now := time.Now().UTC()
-	if opts.JobTTL > 0 {
-		before := now.Add(-opts.JobTTL)
-		if removed, err := opts.Store.CleanupJobsBefore(before, store.JobDone, store.JobFailed, store.JobCancelled); err == nil && removed > 0 {
-			log.Printf("automation: purged %d stale jobs", removed)
-		}
-	}
-	if opts.HistoryTTL > 0 {
-		before := now.Add(-opts.HistoryTTL)
-		if removed, err := opts.Store.CleanupHistoryBefore(before); err == nil && removed > 0 {
-			log.Printf("automation: purged %d history entries", removed)
+	if opts.Handler != nil && (opts.JobTTL > 0 || opts.HistoryTTL > 0) {
+		if result, err := opts.Handler.CleanupRetention(opts.JobTTL, opts.HistoryTTL); err != nil {
+			log.Printf("automation: retention cleanup failed: %v", err)
+		} else {
+			log.Printf("automation: purged %d stale jobs and %d history entries", result.JobsRemoved, result.HistoryRemoved)
 		}
 	}
 	if opts.WeightTTL > 0 && opts.Weights != nil {
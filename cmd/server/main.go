@@ -19,10 +19,12 @@ import (
 	"github.com/oremus-labs/ol-model-manager/internal/graphqlapi"
 	"github.com/oremus-labs/ol-model-manager/internal/handlers"
 	"github.com/oremus-labs/ol-model-manager/internal/hfcache"
+	"github.com/oremus-labs/ol-model-manager/internal/inventory"
 	"github.com/oremus-labs/ol-model-manager/internal/jobs"
 	"github.com/oremus-labs/ol-model-manager/internal/kserve"
 	"github.com/oremus-labs/ol-model-manager/internal/kube"
 	"github.com/oremus-labs/ol-model-manager/internal/logutil"
+	"github.com/oremus-labs/ol-model-manager/internal/notify"
 	"github.com/oremus-labs/ol-model-manager/internal/queue"
 	"github.com/oremus-labs/ol-model-manager/internal/recommendations"
 	"github.com/oremus-labs/ol-model-manager/internal/redisx"
@@ -59,6 +61,13 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if cfg.TestMode {
+		runTestMode(cfg)
+		return
+	}
+	if !weights.ValidStorageBackend(cfg.StorageBackend) {
+		log.Fatalf("Invalid STORAGE_BACKEND %q (expected pvc, s3, or gcs)", cfg.StorageBackend)
+	}
 	log.Printf("Configuration loaded - Catalog: %s/%s, Namespace: %s, InferenceService: %s",
 		cfg.CatalogRoot, cfg.CatalogModelsDir, cfg.Namespace, cfg.InferenceServiceName)
 	logutil.Info("server_bootstrap", map[string]interface{}{
@@ -68,6 +77,7 @@ func main() {
 		"catalogRoot":       cfg.CatalogRoot,
 		"catalogModelsDir":  cfg.CatalogModelsDir,
 		"weightsPVC":        cfg.WeightsPVCName,
+		"storageBackend":    cfg.StorageBackend,
 		"redisAddr":         cfg.RedisAddr,
 		"redisJobStream":    cfg.RedisJobStream,
 		"redisJobGroup":     cfg.RedisJobGroup,
@@ -104,23 +114,46 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize Kubernetes clientset: %v", err)
 	}
-	secretMgr := secrets.NewManager(coreClient, cfg.Namespace)
 
 	// Initialize weights/vLLM services
 	weightManager := weights.New(cfg.WeightsStoragePath)
+	stateStore, err := store.Open(cfg.DataStoreDSN, cfg.DataStoreDriver)
+	if err != nil {
+		log.Fatalf("Failed to initialize state store: %v", err)
+	}
+	defer stateStore.Close()
+	secretOpts := secrets.Options{Namespace: cfg.Namespace, SyncToKube: cfg.SecretsSyncToKube}
+	if !cfg.SecretsSyncToKube {
+		secretOpts.Store = stateStore
+		if cfg.SecretsMasterKey == "" {
+			log.Fatalf("SECRETS_MASTER_KEY is required when SECRETS_SYNC_TO_KUBE=false so datastore secrets are encrypted")
+		}
+		secretOpts.Keyring, err = secrets.NewKeyring(cfg.SecretsMasterKey, cfg.SecretsPreviousKeys...)
+		if err != nil {
+			log.Fatalf("Failed to initialize secrets keyring: %v", err)
+		}
+	}
+	secretMgr := secrets.NewManagerWithOptions(coreClient, secretOpts)
+	if rotated, err := secretMgr.RotateKeys(); err != nil {
+		log.Printf("Failed to re-encrypt secrets: %v", err)
+	} else if rotated > 0 {
+		log.Printf("Re-encrypted %d secret(s) with the current master key", rotated)
+	}
+
 	vllmDiscovery := vllm.New(
 		vllm.WithGitHubToken(cfg.GitHubToken),
 		vllm.WithHuggingFaceToken(cfg.HuggingFaceToken),
 		vllm.WithHuggingFaceCacheTTL(cfg.HuggingFaceCacheTTL),
 		vllm.WithVLLMCacheTTL(cfg.VLLMCacheTTL),
+		vllm.WithDescribeConcurrency(cfg.HuggingFaceDescribeWorkers),
+		vllm.WithRateLimit(cfg.HuggingFaceRateLimit, cfg.HuggingFaceRateBurst),
+		vllm.WithMaxRetries(cfg.HuggingFaceMaxRetries),
+		vllm.WithArchitectureSources(cfg.VLLMArchitectureSources...),
+		vllm.WithArchitectureFile(cfg.VLLMArchitectureFile),
+		vllm.WithInlineSourceLimit(cfg.VLLMSourceInlineLimit),
+		vllm.WithArchitectureSnapshot(stateStore),
 	)
 
-	stateStore, err := store.Open(cfg.DataStoreDSN, cfg.DataStoreDriver)
-	if err != nil {
-		log.Fatalf("Failed to initialize state store: %v", err)
-	}
-	defer stateStore.Close()
-
 	if cat.Count() == 0 {
 		if snapshot, updatedAt, err := stateStore.LoadCatalogSnapshot(); err == nil && len(snapshot) > 0 {
 			cat.Restore(snapshot)
@@ -156,10 +189,21 @@ func main() {
 	})
 
 	var runtimeStatus status.Provider
-	statusManager, err := status.NewManager(kubeConfig, cfg.Namespace, cfg.InferenceServiceName, eventBus)
+	statusTargets := []status.Target{{Namespace: cfg.Namespace, InferenceService: cfg.InferenceServiceName}}
+	for _, raw := range cfg.StatusTargets {
+		target, err := status.ParseTarget(raw, cfg.Namespace)
+		if err != nil {
+			log.Fatalf("Invalid STATUS_TARGETS entry: %v", err)
+		}
+		statusTargets = append(statusTargets, target)
+	}
+	statusManager, err := status.NewManager(kubeConfig, statusTargets, eventBus)
 	if err != nil {
 		log.Printf("Failed to initialize runtime status manager: %v", err)
 	} else {
+		if cfg.GPUMetricsURL != "" {
+			statusManager.EnableGPUMetrics(status.NewGPUMetricsSource(cfg.GPUMetricsURL, 5*time.Second), cfg.GPUMetricsInterval)
+		}
 		runtimeStatus = statusManager
 		go func() {
 			if err := statusManager.Run(rootCtx); err != nil && err != context.Canceled {
@@ -181,14 +225,31 @@ func main() {
 		jobQueue = queue.NewProducer(redisClient, cfg.RedisJobStream)
 	}
 
+	// One bounded queue delivers notifications from both the job manager and
+	// the API handlers.
+	notifications := notify.NewQueue(notify.New(notify.Options{
+		Store:           stateStore,
+		SlackWebhookURL: cfg.SlackWebhookURL,
+	}), notify.DefaultQueueSize)
+
 	jobManager := jobs.New(jobs.Options{
 		Store:              stateStore,
 		Weights:            weightManager,
 		HuggingFaceToken:   cfg.HuggingFaceToken,
 		WeightsPVCName:     cfg.WeightsPVCName,
 		InferenceModelRoot: cfg.InferenceModelRoot,
+		StorageBackend:     cfg.StorageBackend,
+		StorageBucket:      cfg.StorageBucket,
+		StoragePrefix:      cfg.StoragePrefix,
 		EventPublisher:     eventBus,
+		Notifier:           notifications,
 	})
+	// In-process installs (no Redis queue) may run on another API replica.
+	go jobManager.WatchCancellations(rootCtx, eventBus)
+	if jobQueue == nil {
+		// Without Redis, retries run here once their backoff elapses.
+		go jobManager.RunDueRetries(rootCtx, 5*time.Second)
+	}
 
 	// Initialize catalog validator
 	catalogValidator, err := validator.New(validator.Options{
@@ -230,6 +291,15 @@ func main() {
 		log.Println("Catalog writer disabled (CATALOG_REPO not set)")
 	}
 
+	var gpuInventory handlers.GPUInventory
+	switch cfg.GPUInventorySource {
+	case "k8s-nodes":
+		gpuInventory = inventory.New(coreClient, inventory.Options{})
+	case "", "none":
+	default:
+		log.Printf("Unsupported GPU_INVENTORY_SOURCE %q; GPU inventory disabled", cfg.GPUInventorySource)
+	}
+
 	// Initialize handlers
 	h := handlers.New(cat, ksClient, weightManager, vllmDiscovery, catalogValidator, catWriter, advisor, stateStore, jobManager, eventBus, jobQueue, hfCache, runtimeStatus, secretMgr, handlers.Options{
 		CatalogTTL:             cfg.CatalogRefreshInterval,
@@ -238,6 +308,9 @@ func main() {
 		GitHubToken:            cfg.GitHubToken,
 		WeightsPVCName:         cfg.WeightsPVCName,
 		InferenceModelRoot:     cfg.InferenceModelRoot,
+		StorageBackend:         cfg.StorageBackend,
+		StorageBucket:          cfg.StorageBucket,
+		StoragePrefix:          cfg.StoragePrefix,
 		HistoryLimit:           100,
 		Version:                version,
 		CatalogRoot:            cfg.CatalogRoot,
@@ -253,11 +326,34 @@ func main() {
 		DatabasePVCName:        cfg.DatabasePVCName,
 		GPUProfilesPath:        cfg.GPUProfilesPath,
 		GPUInventorySource:     cfg.GPUInventorySource,
+		GPUInventory:           gpuInventory,
+		Notifications:          notifications,
 		SlackWebhookURL:        cfg.SlackWebhookURL,
 		PVCAlertThreshold:      cfg.PVCAlertThreshold,
+		DescribeConcurrency:    cfg.DescribeConcurrency,
+		DescribeTimeout:        cfg.DescribeTimeout,
+		CatalogStatusEnabled:   cfg.CatalogStatusEnabled,
+		CatalogStatusBranch:    cfg.CatalogStatusBranch,
+		RetryBackoffBase:       cfg.JobRetryBackoffBase,
+		RetryBackoffMax:        cfg.JobRetryBackoffMax,
+		ActivationReadyTimeout: cfg.ActivationReadyTimeout,
+		IdempotencyKeyTTL:      cfg.IdempotencyKeyTTL,
+		HFAllowedOrgs:          cfg.HFAllowedOrgs,
+		HFDeniedOrgs:           cfg.HFDeniedOrgs,
+		JobRetention:           cfg.AutomationJobTTL,
+		HistoryRetention:       cfg.AutomationHistoryTTL,
+		WeightEviction:         cfg.WeightEvictionEnabled,
+		WeightEvictionMinFree:  int64(cfg.WeightEvictionMinFreeGB) << 30,
 	})
 
-	startWeightMonitor(rootCtx, weightManager)
+	if err := h.UpgradeLegacyPolicies(rootCtx); err != nil {
+		log.Printf("Failed to upgrade stored policies: %v", err)
+	}
+	h.KeepCatalogFresh(rootCtx)
+	if cfg.CatalogWatch {
+		h.WatchCatalog(rootCtx)
+	}
+	startWeightMonitor(rootCtx, weightManager, h)
 	startAutomation(rootCtx, automationOptions{
 		Store:      stateStore,
 		Weights:    weightManager,
@@ -276,6 +372,8 @@ func main() {
 		Runtime:   runtimeStatus,
 		HFCache:   stateStore,
 		Discovery: vllmDiscovery,
+		Installer: h,
+		Events:    eventBus,
 	}); err != nil {
 		log.Printf("GraphQL handler disabled: %v", err)
 	} else {
@@ -283,8 +381,13 @@ func main() {
 	}
 
 	server := api.NewServer(h, api.Options{
-		APIToken:       cfg.APIToken,
-		GraphQLHandler: gqlHandler,
+		APIToken:          cfg.APIToken,
+		GraphQLHandler:    gqlHandler,
+		EnablePprof:       cfg.PprofEnabled,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		MaxBodyBytes:      int64(cfg.HTTPMaxBodyBytes),
 	})
 	srv := server.Start(":" + cfg.ServerPort)
 	log.Printf("Server listening on :%s", cfg.ServerPort)
@@ -304,11 +407,14 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	if err := notifications.Close(ctx); err != nil {
+		log.Printf("Undelivered notifications at shutdown: %v", err)
+	}
 
 	log.Println("Server stopped")
 }
 
-func startWeightMonitor(ctx context.Context, wm *weights.Manager) {
+func startWeightMonitor(ctx context.Context, wm *weights.Manager, h *handlers.Handler) {
 	if wm == nil {
 		return
 	}
@@ -328,6 +434,7 @@ func startWeightMonitor(ctx context.Context, wm *weights.Manager) {
 					continue
 				}
 				weightUsageBytes.Set(float64(stats.UsedBytes))
+				h.EvaluateStorageAlerts(stats)
 			}
 		}
 	}()
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/oremus-labs/ol-model-manager/config"
+	"github.com/oremus-labs/ol-model-manager/internal/testenv"
+)
+
+// runTestMode serves the API against in-memory stubs with an embedded worker.
+// Catalog entries are read from CATALOG_ROOT; everything else lives in a
+// throwaway directory that is removed on shutdown.
+func runTestMode(cfg *config.Config) {
+	log.Println("MODEL_MANAGER_TEST_MODE enabled: external services are stubbed")
+
+	env, err := testenv.New(testenv.Options{
+		CatalogRoot:          cfg.CatalogRoot,
+		CatalogModelsDir:     cfg.CatalogModelsDir,
+		APIToken:             cfg.APIToken,
+		Namespace:            cfg.Namespace,
+		InferenceServiceName: cfg.InferenceServiceName,
+		WeightsPVCName:       cfg.WeightsPVCName,
+		InferenceModelRoot:   cfg.InferenceModelRoot,
+		Logger:               log.Default(),
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize test environment: %v", err)
+	}
+	defer env.Close()
+	if cfg.APIToken == "" {
+		log.Printf("Test mode API token: %s", env.APIToken)
+	}
+	log.Printf("Loaded %d models from catalog", env.Catalog.Count())
+
+	env.StartWorker()
+	srv := env.Server.Start(":" + cfg.ServerPort)
+	log.Printf("Server listening on :%s (test mode)", cfg.ServerPort)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+	log.Println("Server stopped")
+}
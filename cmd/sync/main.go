@@ -35,12 +35,18 @@ func main() {
 		"redisJobStream": cfg.RedisJobStream,
 		"eventsChannel":  cfg.EventsChannel,
 		"huggingfaceTTL": cfg.HuggingFaceCacheTTL.String(),
+		"seedCatalog":    cfg.HuggingFaceSyncSeedCatalog,
 	})
 	discovery := vllm.New(
 		vllm.WithGitHubToken(cfg.GitHubToken),
 		vllm.WithHuggingFaceToken(cfg.HuggingFaceToken),
 		vllm.WithHuggingFaceCacheTTL(cfg.HuggingFaceCacheTTL),
 		vllm.WithVLLMCacheTTL(cfg.VLLMCacheTTL),
+		vllm.WithDescribeConcurrency(cfg.HuggingFaceDescribeWorkers),
+		vllm.WithRateLimit(cfg.HuggingFaceRateLimit, cfg.HuggingFaceRateBurst),
+		vllm.WithMaxRetries(cfg.HuggingFaceMaxRetries),
+		vllm.WithArchitectureSources(cfg.VLLMArchitectureSources...),
+		vllm.WithArchitectureFile(cfg.VLLMArchitectureFile),
 	)
 
 	stateStore, err := store.Open(cfg.DataStoreDSN, cfg.DataStoreDriver)
@@ -79,12 +85,14 @@ func main() {
 	})
 
 	service := syncsvc.New(syncsvc.Options{
-		Discovery: discovery,
-		Cache:     hfCache,
-		EventBus:  eventBus,
-		Logger:    log.Default(),
-		Interval:  cfg.HuggingFaceSyncInterval,
-		Queries:   buildSyncQueries(cfg),
+		Discovery:  discovery,
+		Cache:      hfCache,
+		EventBus:   eventBus,
+		Logger:     log.Default(),
+		Interval:   cfg.HuggingFaceSyncInterval,
+		Queries:    buildSyncQueries(cfg),
+		SeedDrafts: cfg.HuggingFaceSyncSeedCatalog,
+		Drafts:     stateStore,
 	})
 
 	if err := service.Run(ctx); err != nil && err != context.Canceled {
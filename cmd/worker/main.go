@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -15,11 +16,13 @@ import (
 	"github.com/oremus-labs/ol-model-manager/internal/events"
 	"github.com/oremus-labs/ol-model-manager/internal/jobs"
 	"github.com/oremus-labs/ol-model-manager/internal/logutil"
+	"github.com/oremus-labs/ol-model-manager/internal/notify"
 	"github.com/oremus-labs/ol-model-manager/internal/queue"
 	"github.com/oremus-labs/ol-model-manager/internal/redisx"
 	"github.com/oremus-labs/ol-model-manager/internal/store"
 	"github.com/oremus-labs/ol-model-manager/internal/weights"
 	"github.com/oremus-labs/ol-model-manager/internal/worker"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const workerVersion = "0.5.29-go"
@@ -66,16 +69,26 @@ func main() {
 	})
 
 	weightManager := weights.New(cfg.WeightsStoragePath)
+	notifications := notify.NewQueue(notify.New(notify.Options{
+		Store:           stateStore,
+		SlackWebhookURL: cfg.SlackWebhookURL,
+	}), notify.DefaultQueueSize)
 	jobManager := jobs.New(jobs.Options{
 		Store:              stateStore,
 		Weights:            weightManager,
 		HuggingFaceToken:   cfg.HuggingFaceToken,
 		WeightsPVCName:     cfg.WeightsPVCName,
 		InferenceModelRoot: cfg.InferenceModelRoot,
+		StorageBackend:     cfg.StorageBackend,
+		StorageBucket:      cfg.StorageBucket,
+		StoragePrefix:      cfg.StoragePrefix,
 		EventPublisher:     eventBus,
+		Notifier:           notifications,
 	})
 
-	var jobConsumer *queue.Consumer
+	go jobManager.WatchCancellations(ctx, eventBus)
+
+	var jobConsumer worker.Queue
 	if redisClient != nil {
 		host, _ := os.Hostname()
 		consumerName := fmt.Sprintf("%s-%d", host, time.Now().UnixNano())
@@ -83,16 +96,49 @@ func main() {
 	}
 
 	runner := worker.New(worker.Options{
-		Store:    stateStore,
-		Jobs:     jobManager,
-		Logger:   log.Default(),
-		Interval: 1 * time.Minute,
-		Queue:    jobConsumer,
+		Store:         stateStore,
+		Jobs:          jobManager,
+		Logger:        log.Default(),
+		Interval:      1 * time.Minute,
+		Queue:         jobConsumer,
+		ShutdownGrace: cfg.WorkerShutdownGrace,
 	})
 
-	if err := runner.Run(ctx); err != nil && err != context.Canceled {
+	startMetricsServer(ctx, cfg.WorkerMetricsAddr)
+
+	err = runner.Run(ctx)
+	drainCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := notifications.Close(drainCtx); err != nil {
+		log.Printf("undelivered notifications at shutdown: %v", err)
+	}
+	if err != nil && err != context.Canceled {
 		log.Printf("worker stopped: %v", err)
 		os.Exit(1)
 	}
 	log.Println("worker exited cleanly")
 }
+
+// startMetricsServer exposes Prometheus metrics for the worker, which has no
+// API server of its own.
+func startMetricsServer(ctx context.Context, addr string) {
+	if addr == "" || addr == "off" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		log.Printf("worker metrics listening on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("worker metrics server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+}
@@ -19,19 +19,37 @@ type Config struct {
 	CatalogRoot            string
 	CatalogModelsDir       string
 	CatalogRefreshInterval time.Duration
+	CatalogWatch           bool
+	SecretsSyncToKube      bool
+	SecretsMasterKey       string
+	SecretsPreviousKeys    []string
 	CatalogSchemaPath      string
 	CatalogRepo            string
 	CatalogBaseBranch      string
+	CatalogStatusEnabled   bool
+	CatalogStatusBranch    string
 
 	// KServe configuration
 	Namespace            string
 	ValidationNamespace  string
 	InferenceServiceName string
+	// StatusTargets lists extra "namespace/name" InferenceServices the
+	// status manager watches besides the active one.
+	StatusTargets []string
+	// GPUMetricsURL is a DCGM exporter /metrics endpoint scraped for per-pod
+	// GPU utilization; empty disables it.
+	GPUMetricsURL      string
+	GPUMetricsInterval time.Duration
 
 	// Weights / storage configuration
 	WeightsStoragePath    string
 	WeightsInstallTimeout time.Duration
 	WeightsPVCName        string
+	// StorageBackend selects the storage URI scheme handed to KServe
+	// ("pvc", "s3", or "gcs"); weights are still cached on the PVC.
+	StorageBackend string
+	StorageBucket  string
+	StoragePrefix  string
 
 	// Inference runtime expectations
 	InferenceModelRoot string
@@ -45,16 +63,37 @@ type Config struct {
 	HuggingFaceCacheTTL         time.Duration
 	HuggingFaceSyncInterval     time.Duration
 	VLLMCacheTTL                time.Duration
+	VLLMArchitectureSources     []string
+	VLLMArchitectureFile        string
+	VLLMSourceInlineLimit       int
 	RecommendationCacheTTL      time.Duration
+	DescribeConcurrency         int
+	DescribeTimeout             time.Duration
+	ActivationReadyTimeout      time.Duration
+	IdempotencyKeyTTL           time.Duration
+	HFAllowedOrgs               []string
+	HFDeniedOrgs                []string
+	WeightEvictionEnabled       bool
+	WeightEvictionMinFreeGB     int
 	GPUInventorySource          string
 	PVCAlertThreshold           float64
 	HuggingFaceSyncPipelineTags []string
 	HuggingFaceSyncSearchTerms  []string
 	HuggingFaceSyncLimit        int
+	HuggingFaceSyncSeedCatalog  bool
+	HuggingFaceDescribeWorkers  int
+	HuggingFaceRateLimit        float64
+	HuggingFaceRateBurst        int
+	HuggingFaceMaxRetries       int
 	AutomationCleanupInterval   time.Duration
 	AutomationJobTTL            time.Duration
 	AutomationHistoryTTL        time.Duration
 	AutomationWeightTTL         time.Duration
+	PprofEnabled                bool
+	HTTPReadHeaderTimeout       time.Duration
+	HTTPReadTimeout             time.Duration
+	HTTPWriteTimeout            time.Duration
+	HTTPMaxBodyBytes            int
 
 	// Redis / events configuration
 	RedisAddr        string
@@ -67,6 +106,16 @@ type Config struct {
 	RedisJobStream   string
 	RedisJobGroup    string
 
+	// Worker configuration
+	WorkerShutdownGrace time.Duration
+	WorkerMetricsAddr   string
+	JobRetryBackoffBase time.Duration
+	JobRetryBackoffMax  time.Duration
+
+	// TestMode runs the server against in-memory stubs (no Redis, Kubernetes,
+	// Hugging Face, or GitHub) with an embedded worker.
+	TestMode bool
+
 	// External tokens
 	HuggingFaceToken string
 	GitHubToken      string
@@ -98,14 +147,26 @@ func Load() *Config {
 		CatalogModelsDir:        getEnv("MODEL_CATALOG_MODELS_SUBDIR", "models"),
 		CatalogSchemaPath:       getEnv("MODEL_CATALOG_SCHEMA_PATH", ""),
 		CatalogRefreshInterval:  getEnvDuration("CATALOG_REFRESH_INTERVAL", 30*time.Second),
+		CatalogWatch:            getEnvBool("CATALOG_WATCH", false),
+		SecretsSyncToKube:       getEnvBool("SECRETS_SYNC_TO_KUBE", true),
+		SecretsMasterKey:        getEnv("SECRETS_MASTER_KEY", ""),
+		SecretsPreviousKeys:     getEnvList("SECRETS_PREVIOUS_MASTER_KEYS", nil),
 		CatalogRepo:             getEnv("CATALOG_REPO", ""),
 		CatalogBaseBranch:       getEnv("CATALOG_BASE_BRANCH", "main"),
+		CatalogStatusEnabled:    getEnvBool("CATALOG_STATUS_ENABLED", false),
+		CatalogStatusBranch:     getEnv("CATALOG_STATUS_BRANCH", ""),
 		Namespace:               namespace,
 		ValidationNamespace:     getEnv("VALIDATION_NAMESPACE", namespace),
 		InferenceServiceName:    getEnv("ACTIVE_INFERENCESERVICE_NAME", "active-llm"),
+		StatusTargets:           getEnvList("STATUS_TARGETS", nil),
+		GPUMetricsURL:           getEnv("GPU_METRICS_URL", ""),
+		GPUMetricsInterval:      getEnvDuration("GPU_METRICS_INTERVAL", 30*time.Second),
 		WeightsStoragePath:      getEnv("WEIGHTS_STORAGE_PATH", "/mnt/models"),
 		WeightsInstallTimeout:   getEnvDuration("WEIGHTS_INSTALL_TIMEOUT", 30*time.Minute),
 		WeightsPVCName:          getEnv("WEIGHTS_PVC_NAME", "venus-model-storage"),
+		StorageBackend:          getEnv("STORAGE_BACKEND", "pvc"),
+		StorageBucket:           getEnv("STORAGE_BUCKET", ""),
+		StoragePrefix:           getEnv("STORAGE_PREFIX", ""),
 		InferenceModelRoot:      getEnv("INFERENCE_MODEL_ROOT", "/mnt/models"),
 		GPUProfilesPath:         getEnv("GPU_PROFILE_PATH", "/app/config/gpu-profiles.json"),
 		StatePath:               statePath,
@@ -115,7 +176,18 @@ func Load() *Config {
 		HuggingFaceCacheTTL:     getEnvDuration("HUGGINGFACE_CACHE_TTL", 5*time.Minute),
 		HuggingFaceSyncInterval: getEnvDuration("HUGGINGFACE_SYNC_INTERVAL", 30*time.Minute),
 		VLLMCacheTTL:            getEnvDuration("VLLM_CACHE_TTL", 10*time.Minute),
+		VLLMArchitectureSources: getEnvList("VLLM_ARCHITECTURE_SOURCES", []string{"github", "embedded"}),
+		VLLMArchitectureFile:    getEnv("VLLM_ARCHITECTURE_FILE", ""),
+		VLLMSourceInlineLimit:   getEnvInt("VLLM_SOURCE_INLINE_LIMIT", 32*1024),
 		RecommendationCacheTTL:  getEnvDuration("RECOMMENDATION_CACHE_TTL", 15*time.Minute),
+		DescribeConcurrency:     getEnvInt("DESCRIBE_PROFILE_CONCURRENCY", 4),
+		DescribeTimeout:         getEnvDuration("DESCRIBE_PROFILE_TIMEOUT", 10*time.Second),
+		ActivationReadyTimeout:  getEnvDuration("ACTIVATION_READY_TIMEOUT", 10*time.Minute),
+		IdempotencyKeyTTL:       getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		HFAllowedOrgs:           getEnvList("HF_ALLOWED_ORGS", nil),
+		HFDeniedOrgs:            getEnvList("HF_DENIED_ORGS", nil),
+		WeightEvictionEnabled:   getEnvBool("WEIGHTS_EVICTION_ENABLED", false),
+		WeightEvictionMinFreeGB: getEnvInt("WEIGHTS_EVICTION_MIN_FREE_GB", 0),
 		GPUInventorySource:      getEnv("GPU_INVENTORY_SOURCE", "k8s-nodes"),
 		PVCAlertThreshold:       getEnvFloat("PVC_ALERT_THRESHOLD", 0.85),
 		HuggingFaceSyncPipelineTags: getEnvList("HUGGINGFACE_SYNC_PIPELINE_TAGS", []string{
@@ -132,26 +204,41 @@ func Load() *Config {
 			"phi",
 			"deepseek",
 		}),
-		HuggingFaceSyncLimit:      getEnvInt("HUGGINGFACE_SYNC_LIMIT", 50),
-		AutomationCleanupInterval: getEnvDuration("AUTOMATION_CLEANUP_INTERVAL", 6*time.Hour),
-		AutomationJobTTL:          getEnvDuration("AUTOMATION_JOB_TTL", 72*time.Hour),
-		AutomationHistoryTTL:      getEnvDuration("AUTOMATION_HISTORY_TTL", 14*24*time.Hour),
-		AutomationWeightTTL:       getEnvDuration("AUTOMATION_WEIGHT_TTL", 30*24*time.Hour),
-		RedisAddr:                 getEnv("REDIS_ADDR", ""),
-		RedisUsername:             getEnv("REDIS_USERNAME", ""),
-		RedisPassword:             os.Getenv("REDIS_PASSWORD"),
-		RedisDB:                   getEnvInt("REDIS_DB", 0),
-		RedisTLSEnabled:           getEnvBool("REDIS_TLS_ENABLED", false),
-		RedisTLSInsecure:          getEnvBool("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
-		EventsChannel:             getEnv("EVENTS_CHANNEL", "model-manager-events"),
-		RedisJobStream:            getEnv("REDIS_JOB_STREAM", "model-manager:jobs"),
-		RedisJobGroup:             getEnv("REDIS_JOB_GROUP", "weights-workers"),
-		HuggingFaceToken:          os.Getenv("HUGGINGFACE_API_TOKEN"),
-		GitHubToken:               os.Getenv("GITHUB_TOKEN"),
-		GitAuthorName:             getEnv("GIT_AUTHOR_NAME", ""),
-		GitAuthorEmail:            getEnv("GIT_AUTHOR_EMAIL", ""),
-		APIToken:                  os.Getenv("MODEL_MANAGER_API_TOKEN"),
-		SlackWebhookURL:           os.Getenv("SLACK_WEBHOOK_URL"),
+		HuggingFaceSyncLimit:       getEnvInt("HUGGINGFACE_SYNC_LIMIT", 50),
+		HuggingFaceSyncSeedCatalog: getEnvBool("HUGGINGFACE_SYNC_SEED_CATALOG", false),
+		HuggingFaceDescribeWorkers: getEnvInt("HUGGINGFACE_DESCRIBE_CONCURRENCY", 5),
+		HuggingFaceRateLimit:       getEnvFloat("HUGGINGFACE_RATE_LIMIT", 0),
+		HuggingFaceRateBurst:       getEnvInt("HUGGINGFACE_RATE_BURST", 5),
+		HuggingFaceMaxRetries:      getEnvInt("HUGGINGFACE_MAX_RETRIES", 3),
+		AutomationCleanupInterval:  getEnvDuration("AUTOMATION_CLEANUP_INTERVAL", 6*time.Hour),
+		AutomationJobTTL:           getEnvDuration("AUTOMATION_JOB_TTL", 72*time.Hour),
+		AutomationHistoryTTL:       getEnvDuration("AUTOMATION_HISTORY_TTL", 14*24*time.Hour),
+		AutomationWeightTTL:        getEnvDuration("AUTOMATION_WEIGHT_TTL", 30*24*time.Hour),
+		RedisAddr:                  getEnv("REDIS_ADDR", ""),
+		RedisUsername:              getEnv("REDIS_USERNAME", ""),
+		RedisPassword:              os.Getenv("REDIS_PASSWORD"),
+		RedisDB:                    getEnvInt("REDIS_DB", 0),
+		RedisTLSEnabled:            getEnvBool("REDIS_TLS_ENABLED", false),
+		RedisTLSInsecure:           getEnvBool("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
+		EventsChannel:              getEnv("EVENTS_CHANNEL", "model-manager-events"),
+		RedisJobStream:             getEnv("REDIS_JOB_STREAM", "model-manager:jobs"),
+		RedisJobGroup:              getEnv("REDIS_JOB_GROUP", "weights-workers"),
+		WorkerShutdownGrace:        getEnvDuration("WORKER_SHUTDOWN_GRACE", 20*time.Second),
+		WorkerMetricsAddr:          getEnv("WORKER_METRICS_ADDR", ":9090"),
+		JobRetryBackoffBase:        getEnvDuration("JOB_RETRY_BACKOFF_BASE", 30*time.Second),
+		JobRetryBackoffMax:         getEnvDuration("JOB_RETRY_BACKOFF_MAX", 30*time.Minute),
+		TestMode:                   getEnvBool("MODEL_MANAGER_TEST_MODE", false),
+		PprofEnabled:               getEnvBool("PPROF_ENABLED", false),
+		HTTPReadHeaderTimeout:      getEnvDuration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+		HTTPReadTimeout:            getEnvDuration("HTTP_READ_TIMEOUT", 15*time.Second),
+		HTTPWriteTimeout:           getEnvDuration("HTTP_WRITE_TIMEOUT", 15*time.Second),
+		HTTPMaxBodyBytes:           getEnvInt("HTTP_MAX_BODY_BYTES", 16<<20),
+		HuggingFaceToken:           os.Getenv("HUGGINGFACE_API_TOKEN"),
+		GitHubToken:                os.Getenv("GITHUB_TOKEN"),
+		GitAuthorName:              getEnv("GIT_AUTHOR_NAME", ""),
+		GitAuthorEmail:             getEnv("GIT_AUTHOR_EMAIL", ""),
+		APIToken:                   os.Getenv("MODEL_MANAGER_API_TOKEN"),
+		SlackWebhookURL:            os.Getenv("SLACK_WEBHOOK_URL"),
 	}
 }
 
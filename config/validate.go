@@ -0,0 +1,323 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/weights"
+)
+
+// Issue severities reported by Validate.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is one problem Validate found with a setting.
+type Issue struct {
+	// Setting is the environment variable the problem is about.
+	Setting  string `json:"setting"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// ValidateOptions tunes Validate.
+type ValidateOptions struct {
+	// CheckNetwork dials the datastore and Redis to confirm they are
+	// reachable from where the check runs.
+	CheckNetwork bool
+	// DialTimeout bounds each network check (default 3s).
+	DialTimeout time.Duration
+}
+
+const redactedValue = "[redacted]"
+
+// Validate reports settings that are missing, malformed, or point at paths
+// and services that don't exist. Errors are settings the server cannot start
+// or work with; warnings are likely mistakes it tolerates. Paths are checked
+// on the local filesystem, so run it where the server runs.
+func (c *Config) Validate(opts ValidateOptions) []Issue {
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 3 * time.Second
+	}
+	var issues []Issue
+	add := func(setting, severity, format string, args ...interface{}) {
+		issues = append(issues, Issue{Setting: setting, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(c.Namespace) == "" {
+		add("ACTIVE_NAMESPACE", SeverityError, "namespace is empty")
+	}
+	if strings.TrimSpace(c.InferenceServiceName) == "" {
+		add("ACTIVE_INFERENCESERVICE_NAME", SeverityError, "InferenceService name is empty")
+	}
+	for _, target := range c.StatusTargets {
+		parts := strings.Split(target, "/")
+		if len(parts) > 2 || parts[len(parts)-1] == "" || (len(parts) == 2 && parts[0] == "") {
+			add("STATUS_TARGETS", SeverityError, "entry %q is not \"namespace/name\" or \"name\"", target)
+		}
+	}
+
+	switch c.DataStoreDriver {
+	case "sqlite":
+		if strings.TrimSpace(c.DataStoreDSN) == "" {
+			add("DATASTORE_DSN", SeverityError, "datastore path is empty")
+		} else if dir := filepath.Dir(c.DataStoreDSN); !dirExists(dir) {
+			add("DATASTORE_DSN", SeverityWarning, "directory %s does not exist; the server will try to create it", dir)
+		}
+	case "postgres":
+		addr, err := postgresAddr(c.DataStoreDSN)
+		switch {
+		case err != nil:
+			add("DATASTORE_DSN", SeverityError, "%v", err)
+		case opts.CheckNetwork:
+			if err := dial(addr, opts.DialTimeout); err != nil {
+				add("DATASTORE_DSN", SeverityError, "postgres at %s is unreachable: %v", addr, err)
+			}
+		}
+	default:
+		add("DATASTORE_DRIVER", SeverityError, "unsupported driver %q (expected sqlite or postgres)", c.DataStoreDriver)
+	}
+
+	if c.RedisAddr == "" {
+		add("REDIS_ADDR", SeverityWarning, "Redis is not configured; events and jobs stay in-process, so run a single replica")
+	} else if opts.CheckNetwork {
+		if err := dial(c.RedisAddr, opts.DialTimeout); err != nil {
+			add("REDIS_ADDR", SeverityError, "Redis at %s is unreachable: %v", c.RedisAddr, err)
+		}
+	}
+	if c.RedisTLSInsecure {
+		add("REDIS_TLS_INSECURE_SKIP_VERIFY", SeverityWarning, "Redis TLS certificates are not verified")
+	}
+
+	if !weights.ValidStorageBackend(c.StorageBackend) {
+		add("STORAGE_BACKEND", SeverityError, "unsupported backend %q (expected pvc, s3, or gcs)", c.StorageBackend)
+	} else if backend := strings.ToLower(strings.TrimSpace(c.StorageBackend)); (backend == weights.StorageBackendS3 || backend == weights.StorageBackendGCS) && c.StorageBucket == "" {
+		add("STORAGE_BUCKET", SeverityError, "a bucket is required for the %s storage backend", backend)
+	}
+	if strings.TrimSpace(c.WeightsPVCName) == "" {
+		add("WEIGHTS_PVC_NAME", SeverityError, "weights PVC name is empty")
+	}
+	if !dirExists(c.WeightsStoragePath) {
+		add("WEIGHTS_STORAGE_PATH", SeverityError, "directory %s does not exist; is the weights PVC mounted?", c.WeightsStoragePath)
+	}
+	if modelsDir := filepath.Join(c.CatalogRoot, c.CatalogModelsDir); !dirExists(modelsDir) {
+		add("MODEL_CATALOG_ROOT", SeverityWarning, "catalog directory %s does not exist (git-sync may still be warming up)", modelsDir)
+	}
+	if c.CatalogSchemaPath != "" && !fileExists(c.CatalogSchemaPath) {
+		add("MODEL_CATALOG_SCHEMA_PATH", SeverityError, "file %s does not exist", c.CatalogSchemaPath)
+	}
+	if c.GPUProfilesPath != "" && !fileExists(c.GPUProfilesPath) {
+		add("GPU_PROFILE_PATH", SeverityWarning, "file %s does not exist; recommendations use no GPU profiles", c.GPUProfilesPath)
+	}
+	for _, source := range c.VLLMArchitectureSources {
+		if source != "file" {
+			continue
+		}
+		if c.VLLMArchitectureFile == "" {
+			add("VLLM_ARCHITECTURE_FILE", SeverityError, "the file architecture source is enabled but no file is set")
+		} else if !fileExists(c.VLLMArchitectureFile) {
+			add("VLLM_ARCHITECTURE_FILE", SeverityError, "file %s does not exist", c.VLLMArchitectureFile)
+		}
+	}
+
+	if !c.SecretsSyncToKube && c.SecretsMasterKey == "" {
+		add("SECRETS_MASTER_KEY", SeverityError, "a master key is required to keep secrets in the datastore (SECRETS_SYNC_TO_KUBE=false)")
+	}
+	if c.CatalogStatusEnabled && (c.CatalogRepo == "" || c.GitHubToken == "") {
+		add("CATALOG_STATUS_ENABLED", SeverityError, "catalog status publishing needs CATALOG_REPO and GITHUB_TOKEN")
+	}
+	switch c.GPUInventorySource {
+	case "", "none", "k8s-nodes":
+	default:
+		add("GPU_INVENTORY_SOURCE", SeverityWarning, "unsupported source %q; GPU inventory will be disabled", c.GPUInventorySource)
+	}
+	if c.PVCAlertThreshold <= 0 || c.PVCAlertThreshold > 1 {
+		add("PVC_ALERT_THRESHOLD", SeverityWarning, "threshold %.2f is outside (0, 1]", c.PVCAlertThreshold)
+	}
+	if c.APIToken == "" {
+		add("MODEL_MANAGER_API_TOKEN", SeverityWarning, "no API token is set; the API is unauthenticated")
+	}
+	return issues
+}
+
+// Redacted returns a copy of the configuration safe to print: tokens, keys,
+// and passwords are replaced when set and the datastore DSN loses its
+// password.
+func (c *Config) Redacted() Config {
+	out := *c
+	for _, secret := range []*string{
+		&out.SecretsMasterKey, &out.RedisPassword, &out.HuggingFaceToken,
+		&out.GitHubToken, &out.APIToken, &out.SlackWebhookURL,
+	} {
+		if *secret != "" {
+			*secret = redactedValue
+		}
+	}
+	if len(out.SecretsPreviousKeys) > 0 {
+		out.SecretsPreviousKeys = make([]string, len(c.SecretsPreviousKeys))
+		for i := range out.SecretsPreviousKeys {
+			out.SecretsPreviousKeys[i] = redactedValue
+		}
+	}
+	out.DataStoreDSN = redactDSN(c.DataStoreDSN)
+	return out
+}
+
+// redactDSN hides the password of a URL or key=value connection string.
+func redactDSN(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		if u, err := url.Parse(dsn); err == nil {
+			return u.Redacted()
+		}
+		return redactedValue
+	}
+	params, err := parseKeyValueDSN(dsn)
+	if err != nil {
+		// A plain path (sqlite) isn't key=value; anything else that fails to
+		// parse might still carry a password, so hide it all.
+		if strings.Contains(strings.ToLower(dsn), "password") {
+			return redactedValue
+		}
+		return dsn
+	}
+	out := dsn
+	for i := len(params) - 1; i >= 0; i-- {
+		if p := params[i]; strings.EqualFold(p.key, "password") {
+			out = out[:p.valueStart] + redactedValue + out[p.valueEnd:]
+		}
+	}
+	return out
+}
+
+// postgresAddr extracts host:port from a postgres URL or key=value DSN.
+func postgresAddr(dsn string) (string, error) {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return "", fmt.Errorf("postgres DSN is empty")
+	}
+	host, port := "", ""
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("postgres DSN is not a valid URL")
+		}
+		host, port = u.Hostname(), u.Port()
+	} else {
+		params, err := parseKeyValueDSN(dsn)
+		if err != nil {
+			return "", fmt.Errorf("postgres DSN %w", err)
+		}
+		for _, p := range params {
+			switch strings.ToLower(p.key) {
+			case "host":
+				host = p.value
+			case "port":
+				port = p.value
+			}
+		}
+	}
+	if host == "" {
+		return "", fmt.Errorf("postgres DSN has no host")
+	}
+	if port == "" {
+		port = "5432"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// dsnParam is one key=value entry of a connection string. valueStart and
+// valueEnd span the raw value, quotes included, in the original string.
+type dsnParam struct {
+	key        string
+	value      string
+	valueStart int
+	valueEnd   int
+}
+
+// parseKeyValueDSN splits a libpq key=value connection string. Values may be
+// single-quoted to hold spaces, and a backslash escapes the next character
+// in both quoted and bare values.
+func parseKeyValueDSN(dsn string) ([]dsnParam, error) {
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+	var params []dsnParam
+	i, n := 0, len(dsn)
+	for {
+		for i < n && isSpace(dsn[i]) {
+			i++
+		}
+		if i >= n {
+			return params, nil
+		}
+		keyStart := i
+		for i < n && dsn[i] != '=' && !isSpace(dsn[i]) {
+			i++
+		}
+		key := dsn[keyStart:i]
+		for i < n && isSpace(dsn[i]) {
+			i++
+		}
+		if i >= n || dsn[i] != '=' || key == "" {
+			return nil, fmt.Errorf("entry %q is not key=value", key)
+		}
+		i++
+		for i < n && isSpace(dsn[i]) {
+			i++
+		}
+		valueStart := i
+		var value strings.Builder
+		if i < n && dsn[i] == '\'' {
+			i++
+			for {
+				if i >= n {
+					return nil, fmt.Errorf("entry %q has an unterminated quoted value", key)
+				}
+				if dsn[i] == '\\' && i+1 < n {
+					value.WriteByte(dsn[i+1])
+					i += 2
+					continue
+				}
+				if dsn[i] == '\'' {
+					i++
+					break
+				}
+				value.WriteByte(dsn[i])
+				i++
+			}
+		} else {
+			for i < n && !isSpace(dsn[i]) {
+				if dsn[i] == '\\' && i+1 < n {
+					value.WriteByte(dsn[i+1])
+					i += 2
+					continue
+				}
+				value.WriteByte(dsn[i])
+				i++
+			}
+		}
+		params = append(params, dsnParam{key: key, value: value.String(), valueStart: valueStart, valueEnd: i})
+	}
+}
+
+func dial(addr string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// validConfig returns a configuration Validate accepts without issues, with
+// every path under a temporary directory.
+func validConfig(t *testing.T) Config {
+	t.Helper()
+	dir := t.TempDir()
+	weightsDir := filepath.Join(dir, "weights")
+	catalogRoot := filepath.Join(dir, "catalog")
+	for _, d := range []string{weightsDir, filepath.Join(catalogRoot, "models")} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", d, err)
+		}
+	}
+	return Config{
+		Namespace:            "ai",
+		InferenceServiceName: "active-llm",
+		DataStoreDriver:      "sqlite",
+		DataStoreDSN:         filepath.Join(dir, "state.db"),
+		RedisAddr:            "redis:6379",
+		StorageBackend:       "pvc",
+		WeightsPVCName:       "model-weights",
+		WeightsStoragePath:   weightsDir,
+		CatalogRoot:          catalogRoot,
+		CatalogModelsDir:     "models",
+		SecretsSyncToKube:    true,
+		GPUInventorySource:   "k8s-nodes",
+		PVCAlertThreshold:    0.85,
+		APIToken:             "token",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		mutate   func(*Config)
+		setting  string
+		severity string
+	}{
+		{"valid", func(*Config) {}, "", ""},
+		{"empty namespace", func(c *Config) { c.Namespace = " " }, "ACTIVE_NAMESPACE", SeverityError},
+		{"bare status target", func(c *Config) { c.StatusTargets = []string{"other-llm"} }, "", ""},
+		{"namespaced status target", func(c *Config) { c.StatusTargets = []string{"ai/other-llm"} }, "", ""},
+		{"status target without namespace", func(c *Config) { c.StatusTargets = []string{"/other-llm"} }, "STATUS_TARGETS", SeverityError},
+		{"status target with extra segment", func(c *Config) { c.StatusTargets = []string{"a/b/c"} }, "STATUS_TARGETS", SeverityError},
+		{"unknown driver", func(c *Config) { c.DataStoreDriver = "mysql" }, "DATASTORE_DRIVER", SeverityError},
+		{"sqlite without path", func(c *Config) { c.DataStoreDSN = "" }, "DATASTORE_DSN", SeverityError},
+		{"sqlite directory missing", func(c *Config) { c.DataStoreDSN = filepath.Join(c.WeightsStoragePath, "missing", "state.db") }, "DATASTORE_DSN", SeverityWarning},
+		{"postgres without host", func(c *Config) {
+			c.DataStoreDriver, c.DataStoreDSN = "postgres", "dbname=models"
+		}, "DATASTORE_DSN", SeverityError},
+		{"postgres quoted password", func(c *Config) {
+			c.DataStoreDriver, c.DataStoreDSN = "postgres", "host=db password='a b' dbname=models"
+		}, "", ""},
+		{"postgres unterminated quote", func(c *Config) {
+			c.DataStoreDriver, c.DataStoreDSN = "postgres", "host=db password='a b"
+		}, "DATASTORE_DSN", SeverityError},
+		{"no redis", func(c *Config) { c.RedisAddr = "" }, "REDIS_ADDR", SeverityWarning},
+		{"unknown storage backend", func(c *Config) { c.StorageBackend = "ftp" }, "STORAGE_BACKEND", SeverityError},
+		{"s3 without bucket", func(c *Config) { c.StorageBackend = "s3" }, "STORAGE_BUCKET", SeverityError},
+		{"s3 with bucket", func(c *Config) { c.StorageBackend, c.StorageBucket = "s3", "weights" }, "", ""},
+		{"weights path missing", func(c *Config) { c.WeightsStoragePath = filepath.Join(c.WeightsStoragePath, "missing") }, "WEIGHTS_STORAGE_PATH", SeverityError},
+		{"catalog missing", func(c *Config) { c.CatalogModelsDir = "missing" }, "MODEL_CATALOG_ROOT", SeverityWarning},
+		{"file architecture source without file", func(c *Config) { c.VLLMArchitectureSources = []string{"file"} }, "VLLM_ARCHITECTURE_FILE", SeverityError},
+		{"datastore secrets without master key", func(c *Config) { c.SecretsSyncToKube = false }, "SECRETS_MASTER_KEY", SeverityError},
+		{"datastore secrets with master key", func(c *Config) { c.SecretsSyncToKube, c.SecretsMasterKey = false, "key" }, "", ""},
+		{"catalog status without token", func(c *Config) { c.CatalogStatusEnabled = true }, "CATALOG_STATUS_ENABLED", SeverityError},
+		{"unknown inventory source", func(c *Config) { c.GPUInventorySource = "dcgm" }, "GPU_INVENTORY_SOURCE", SeverityWarning},
+		{"threshold out of range", func(c *Config) { c.PVCAlertThreshold = 1.5 }, "PVC_ALERT_THRESHOLD", SeverityWarning},
+		{"no api token", func(c *Config) { c.APIToken = "" }, "MODEL_MANAGER_API_TOKEN", SeverityWarning},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tc.mutate(&cfg)
+			issues := cfg.Validate(ValidateOptions{})
+			if tc.setting == "" {
+				if len(issues) != 0 {
+					t.Fatalf("expected no issues, got %+v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Setting != tc.setting || issues[0].Severity != tc.severity {
+				t.Fatalf("expected one %s issue for %s, got %+v", tc.severity, tc.setting, issues)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Config{
+		SecretsMasterKey:    "master",
+		SecretsPreviousKeys: []string{"old-1", "old-2"},
+		RedisPassword:       "redis",
+		HuggingFaceToken:    "hf",
+		GitHubToken:         "gh",
+		APIToken:            "api",
+		DataStoreDSN:        "postgres://app:secret@db:5432/models",
+		Namespace:           "ai",
+	}
+	out := cfg.Redacted()
+
+	for name, value := range map[string]string{
+		"SecretsMasterKey": out.SecretsMasterKey,
+		"RedisPassword":    out.RedisPassword,
+		"HuggingFaceToken": out.HuggingFaceToken,
+		"GitHubToken":      out.GitHubToken,
+		"APIToken":         out.APIToken,
+	} {
+		if value != redactedValue {
+			t.Errorf("%s = %q, want %q", name, value, redactedValue)
+		}
+	}
+	if out.SlackWebhookURL != "" {
+		t.Errorf("unset SlackWebhookURL became %q", out.SlackWebhookURL)
+	}
+	if len(out.SecretsPreviousKeys) != 2 || out.SecretsPreviousKeys[0] != redactedValue || out.SecretsPreviousKeys[1] != redactedValue {
+		t.Errorf("previous keys not redacted: %v", out.SecretsPreviousKeys)
+	}
+	if strings.Contains(out.DataStoreDSN, "secret") {
+		t.Errorf("DSN password leaked: %s", out.DataStoreDSN)
+	}
+	if out.Namespace != "ai" {
+		t.Errorf("non-secret setting changed: %q", out.Namespace)
+	}
+	if cfg.SecretsMasterKey != "master" || cfg.SecretsPreviousKeys[0] != "old-1" {
+		t.Error("Redacted modified the original configuration")
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	cases := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{"url", "postgres://app:secret@db:5432/models", "postgres://app:xxxxx@db:5432/models"},
+		{"url without password", "postgres://app@db/models", "postgres://app@db/models"},
+		{"sqlite path", "/data/state.db", "/data/state.db"},
+		{"key value", "host=db password=secret dbname=models", "host=db password=[redacted] dbname=models"},
+		{"uppercase key", "host=db PASSWORD=secret", "host=db PASSWORD=[redacted]"},
+		{"quoted", "host=db password='a b' dbname=models", "host=db password=[redacted] dbname=models"},
+		{"quoted with escapes", `password='it\'s a \\ secret' host=db`, "password=[redacted] host=db"},
+		{"spaces around equals", "host=db password = 'a b'", "host=db password = [redacted]"},
+		{"no password", "host=db dbname=models", "host=db dbname=models"},
+		{"unterminated quote", "host=db password='a b", redactedValue},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := redactDSN(tc.dsn); got != tc.want {
+				t.Fatalf("redactDSN(%q) = %q, want %q", tc.dsn, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPostgresAddr(t *testing.T) {
+	cases := []struct {
+		dsn     string
+		want    string
+		wantErr bool
+	}{
+		{dsn: "postgres://app:secret@db:6432/models", want: "db:6432"},
+		{dsn: "postgres://db/models", want: "db:5432"},
+		{dsn: "host=db port=6432 password='a b'", want: "db:6432"},
+		{dsn: `host='db.internal' password=a\ b`, want: "db.internal:5432"},
+		{dsn: "", wantErr: true},
+		{dsn: "dbname=models", wantErr: true},
+		{dsn: "host=db stray", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := postgresAddr(tc.dsn)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("postgresAddr(%q) = %q, want an error", tc.dsn, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("postgresAddr(%q) = %q, %v; want %q", tc.dsn, got, err, tc.want)
+		}
+	}
+}
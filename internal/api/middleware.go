@@ -2,11 +2,12 @@ package api
 
 import (
 	"fmt"
-	"log"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/oremus-labs/ol-model-manager/internal/logutil"
 )
 
 func requestLogger() gin.HandlerFunc {
@@ -17,11 +18,14 @@ func requestLogger() gin.HandlerFunc {
 
 		c.Next()
 
-		latency := time.Since(start)
-		statusCode := c.Writer.Status()
-
-		requestID, _ := c.Get("requestID")
-		log.Printf("%s %s %d %s request_id=%v", method, path, statusCode, latency, requestID)
+		logutil.Info("http_request", map[string]interface{}{
+			"requestId": c.GetString("requestID"),
+			"method":    method,
+			"path":      path,
+			"status":    c.Writer.Status(),
+			"latencyMs": time.Since(start).Milliseconds(),
+			"clientIp":  c.ClientIP(),
+		})
 	}
 }
 
@@ -32,11 +36,45 @@ func requestIDMiddleware() gin.HandlerFunc {
 			id = uuid.NewString()
 		}
 		c.Set("requestID", id)
+		c.Request = c.Request.WithContext(logutil.WithRequestID(c.Request.Context(), id))
 		c.Writer.Header().Set("X-Request-ID", id)
 		c.Next()
 	}
 }
 
+// bodyLimitExempt lists routes that enforce their own, larger body limit.
+var bodyLimitExempt = map[string]bool{
+	"/catalog/import": true,
+}
+
+// bodyLimitMiddleware caps the request body of mutating requests at limit
+// bytes; reads past it fail with *http.MaxBytesError.
+func bodyLimitMiddleware(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if c.Request.Body != nil && !bodyLimitExempt[c.FullPath()] {
+				c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+			}
+		}
+		c.Next()
+	}
+}
+
+// noWriteTimeout clears the server write deadline for long-lived streaming
+// responses such as SSE.
+func noWriteTimeout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+			logutil.Info("write_deadline_unsupported", map[string]interface{}{
+				"path":  c.FullPath(),
+				"error": err.Error(),
+			})
+		}
+		c.Next()
+	}
+}
+
 func metricsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http/pprof"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerPprof mounts the net/http/pprof handlers under /debug/pprof on the
+// protected group, so profiling requires the same credentials as other admin
+// routes. CPU profiles and traces run for the requested seconds, so the
+// routes are exempt from the server write timeout.
+func registerPprof(group *gin.RouterGroup) {
+	group.GET("/debug/pprof/*profile", noWriteTimeout(), pprofHandler)
+	group.POST("/debug/pprof/symbol", gin.WrapF(pprof.Symbol))
+}
+
+func pprofHandler(c *gin.Context) {
+	switch strings.TrimPrefix(c.Param("profile"), "/") {
+	case "cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		// Index serves the listing at "/" and named profiles such as heap
+		// and goroutine based on the request path.
+		pprof.Index(c.Writer, c.Request)
+	}
+}
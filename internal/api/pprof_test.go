@@ -0,0 +1,37 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/handlers"
+)
+
+func TestPprofProfilesOutlastWriteTimeout(t *testing.T) {
+	h := handlers.New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, handlers.Options{})
+	server := NewServer(h, Options{APIToken: "secret", EnablePprof: true, WriteTimeout: 500 * time.Millisecond})
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config = server.httpServer("")
+	ts.Start()
+	defer ts.Close()
+
+	for _, path := range []string{"/debug/pprof/profile?seconds=1", "/debug/pprof/trace?seconds=1"} {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: reading the profile past the write timeout: %v", path, err)
+		}
+		if resp.StatusCode != http.StatusOK || len(body) == 0 {
+			t.Fatalf("%s: expected a profile, got %d: %s", path, resp.StatusCode, body)
+		}
+	}
+}
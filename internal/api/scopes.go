@@ -0,0 +1,153 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oremus-labs/ol-model-manager/internal/graphqlapi"
+)
+
+// ScopeAll grants access to every protected route. Tokens issued without any
+// scopes are treated the same way so existing tokens keep working.
+const ScopeAll = "*"
+
+// routeScopes maps protected routes ("METHOD /path" as registered with gin) to
+// the scope a datastore-issued token must carry. A "<resource>:write" scope also
+// satisfies "<resource>:read". Protected routes missing from this map require
+// ScopeAll.
+var routeScopes = map[string]string{
+	"POST /models/activate":               "models:write",
+	"POST /models/deactivate":             "models:write",
+	"POST /runtime/activate":              "models:write",
+	"POST /runtime/deactivate":            "models:write",
+	"POST /runtime/promote":               "models:write",
+	"POST /runtime/rollback":              "models:write",
+	"POST /models/test":                   "models:write",
+	"POST /catalog/preview":               "catalog:read",
+	"POST /catalog/validate":              "catalog:read",
+	"POST /catalog/validate/bulk":         "catalog:read",
+	"POST /refresh":                       "catalog:write",
+	"POST /catalog/pr":                    "catalog:write",
+	"POST /catalog/import":                "catalog:write",
+	"GET /catalog/export":                 "catalog:read",
+	"POST /catalog/pr/preview":            "catalog:read",
+	"PATCH /catalog/models/:id":           "catalog:write",
+	"POST /weights/install":               "weights:write",
+	"GET /weights/verify":                 "weights:read",
+	"DELETE /weights":                     "weights:write",
+	"POST /weights/prune":                 "weights:write",
+	"POST /cleanup/weights":               "weights:write",
+	"GET /weights/install/status/:id":     "jobs:read",
+	"GET /jobs":                           "jobs:read",
+	"GET /jobs/deadletter":                "jobs:read",
+	"GET /jobs/:id":                       "jobs:read",
+	"GET /jobs/:id/logs":                  "jobs:read",
+	"GET /jobs/:id/logs/stream":           "jobs:read",
+	"POST /jobs/:id/cancel":               "jobs:write",
+	"POST /jobs/:id/retry":                "jobs:write",
+	"DELETE /jobs":                        "jobs:write",
+	"GET /history":                        "history:read",
+	"GET /models/:id/events":              "history:read",
+	"DELETE /history":                     "history:write",
+	"GET /secrets":                        "secrets:read",
+	"GET /secrets/:name":                  "secrets:read",
+	"PUT /secrets/:name":                  "secrets:write",
+	"DELETE /secrets/:name":               "secrets:write",
+	"GET /notifications":                  "notifications:read",
+	"PUT /notifications/:name":            "notifications:write",
+	"POST /notifications/:name/rotate":    "notifications:write",
+	"DELETE /notifications/:name":         "notifications:write",
+	"GET /notifications/:name/history":    "notifications:read",
+	"GET /notifications/:name/deliveries": "notifications:read",
+	"POST /notifications/test":            "notifications:write",
+	"GET /tokens":                         "tokens:admin",
+	"POST /tokens":                        "tokens:admin",
+	"DELETE /tokens/:id":                  "tokens:admin",
+	"GET /policies":                       "policies:read",
+	"GET /policies/bundle":                "policies:read",
+	"POST /policies/lint":                 "policies:read",
+	"PUT /policies/:name":                 "policies:write",
+	"GET /policies/:name":                 "policies:read",
+	"GET /policies/:name/versions":        "policies:read",
+	"POST /policies/:name/lint":           "policies:read",
+	"POST /policies/:name/rollback":       "policies:write",
+	"DELETE /policies/:name":              "policies:write",
+	"GET /playbooks":                      "playbooks:read",
+	"GET /playbooks/:name":                "playbooks:read",
+	"PUT /playbooks/:name":                "playbooks:write",
+	"DELETE /playbooks/:name":             "playbooks:write",
+	"POST /playbooks/:name/run":           "playbooks:write",
+	"GET /backups":                        "backups:read",
+	"POST /backups":                       "backups:write",
+	"POST /backups/run":                   "backups:write",
+	"POST /backups/restore":               "backups:write",
+	"GET /support/bundle":                 "support:read",
+	"GET /system/runtime":                 "support:read",
+}
+
+// requiredScope returns the scope needed for a route.
+func requiredScope(method, path string) string {
+	if scope, ok := routeScopes[method+" "+path]; ok {
+		return scope
+	}
+	return ScopeAll
+}
+
+// scopeAllows reports whether granted satisfies required.
+func scopeAllows(granted []string, required string) bool {
+	if len(granted) == 0 {
+		return true
+	}
+	for _, scope := range granted {
+		scope = strings.TrimSpace(scope)
+		if scope == ScopeAll || scope == required {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(required, ":read"); ok && scope == resource+":write" {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeMiddleware rejects datastore-issued tokens that lack the scope a route
+// requires. It must run after the auth middleware, which records the token's
+// scopes; the static API token is not scoped.
+func scopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, ok := c.Get("apiTokenScopes")
+		if !ok {
+			c.Next()
+			return
+		}
+		granted, _ := raw.([]string)
+		required := requiredScope(c.Request.Method, c.FullPath())
+		if scopeAllows(granted, required) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error":         fmt.Sprintf("token is missing required scope %q", required),
+			"requiredScope": required,
+		})
+	}
+}
+
+// graphqlRoute serves GraphQL requests. Most queries stay public; callers that
+// presented a valid token (see OptionalAuthMiddleware) get an authorizer so
+// mutations and the history query can check the same scopes as the REST
+// routes.
+func graphqlRoute(h http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("apiAuthenticated") {
+			granted := c.GetStringSlice("apiTokenScopes")
+			ctx := graphqlapi.WithAuthorizer(c.Request.Context(), func(scope string) bool {
+				return scopeAllows(granted, scope)
+			})
+			c.Request = c.Request.WithContext(ctx)
+		}
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -13,54 +13,94 @@ import (
 type Options struct {
 	APIToken       string
 	GraphQLHandler http.Handler
+	// EnablePprof mounts the net/http/pprof handlers under /debug/pprof
+	// behind authentication.
+	EnablePprof bool
+	// ReadHeaderTimeout, ReadTimeout, and WriteTimeout configure the
+	// http.Server. Streaming endpoints are exempt from the write timeout.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	// MaxBodyBytes caps request bodies on mutating requests.
+	MaxBodyBytes int64
 }
 
+// Defaults applied when Options leaves the limits unset.
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultReadTimeout       = 15 * time.Second
+	defaultWriteTimeout      = 15 * time.Second
+	defaultMaxBodyBytes      = 16 << 20
+)
+
 // Server wraps the Gin engine and associated configuration.
 type Server struct {
 	engine *gin.Engine
+	opts   Options
 }
 
 // NewServer constructs a Server with all HTTP routes configured.
 func NewServer(handler *handlers.Handler, opts Options) *Server {
 	gin.SetMode(gin.ReleaseMode)
 
+	if opts.ReadHeaderTimeout <= 0 {
+		opts.ReadHeaderTimeout = defaultReadHeaderTimeout
+	}
+	if opts.ReadTimeout <= 0 {
+		opts.ReadTimeout = defaultReadTimeout
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = defaultWriteTimeout
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+
 	engine := gin.New()
-	engine.Use(gin.Recovery(), requestIDMiddleware(), metricsMiddleware(), requestLogger())
+	engine.Use(gin.Recovery(), requestIDMiddleware(), metricsMiddleware(), requestLogger(), bodyLimitMiddleware(opts.MaxBodyBytes))
 
 	// Health + meta
 	engine.GET("/healthz", handler.Health)
+	engine.GET("/readyz", handler.Ready)
 	engine.GET("/system/info", handler.SystemInfo)
 	engine.GET("/system/summary", handler.SystemSummary)
 	engine.GET("/metrics/summary", handler.MetricsSummary)
 	engine.GET("/openapi", handler.OpenAPISpec)
 	engine.GET("/docs", handler.APIDocs)
-	engine.GET("/events", handler.StreamEvents)
+	engine.GET("/events", noWriteTimeout(), handler.StreamEvents)
+	engine.GET("/events/ws", handler.StreamEventsWebSocket)
 	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	engine.GET("/search", handler.Search)
 
 	// Models
 	engine.GET("/models", handler.ListModels)
+	engine.GET("/models/compare", handler.CompareModels)
 	engine.GET("/models/:id", handler.GetModel)
 	engine.GET("/models/:id/compatibility", handler.ModelCompatibility)
+	engine.GET("/models/:id/recommendation/best", handler.BestGPURecommendation)
 	engine.GET("/models/:id/manifest", handler.GetModelManifest)
+	engine.GET("/models/:id/plan", handler.GetModelPlan)
 	engine.GET("/models/status", handler.GetRuntimeStatus)
 	engine.GET("/active", handler.GetActiveModel)
 	engine.POST("/catalog/generate", handler.GenerateCatalogEntry)
 	engine.GET("/recommendations/:gpuType", handler.GPURecommendations)
 	engine.GET("/recommendations/profiles", handler.ListProfiles)
+	engine.GET("/gpu/inventory", handler.GetGPUInventory)
 
 	// Weights
 	engine.GET("/weights", handler.ListWeights)
 	engine.GET("/weights/usage", handler.GetWeightUsage)
+	engine.GET("/weights/orphaned", handler.ListOrphanedWeights)
 	engine.GET("/weights/info", handler.GetWeightInfo)
 
 	// HuggingFace discovery
 	engine.GET("/huggingface/search", handler.SearchHuggingFace)
+	engine.GET("/huggingface/discovered", handler.ListDiscoveredModels)
 	engine.GET("/huggingface/models/*id", handler.GetHuggingFaceModel)
 
 	if opts.GraphQLHandler != nil {
 		engine.GET("/graphql", gin.WrapH(opts.GraphQLHandler))
-		engine.POST("/graphql", gin.WrapH(opts.GraphQLHandler))
+		engine.POST("/graphql", handler.OptionalAuthMiddleware(opts.APIToken), graphqlRoute(opts.GraphQLHandler))
 	}
 
 	// vLLM discovery
@@ -70,28 +110,39 @@ func NewServer(handler *handlers.Handler, opts Options) *Server {
 	engine.POST("/vllm/model-info", handler.DescribeVLLMModel)
 
 	protected := engine.Group("/")
-	protected.Use(handler.AuthMiddleware(opts.APIToken))
+	protected.Use(handler.AuthMiddleware(opts.APIToken), scopeMiddleware())
 
 	protected.POST("/models/activate", handler.ActivateModel)
 	protected.POST("/models/deactivate", handler.DeactivateModel)
 	protected.POST("/runtime/activate", handler.RuntimeActivate)
 	protected.POST("/runtime/deactivate", handler.RuntimeDeactivate)
 	protected.POST("/runtime/promote", handler.RuntimePromote)
+	protected.POST("/runtime/rollback", handler.RuntimeRollback)
 	protected.POST("/models/test", handler.TestModel)
 	protected.POST("/catalog/preview", handler.PreviewCatalog)
 	protected.POST("/refresh", handler.RefreshCatalog)
 	protected.POST("/catalog/validate", handler.ValidateCatalog)
+	protected.POST("/catalog/validate/bulk", handler.ValidateCatalogBulk)
 	protected.POST("/catalog/pr", handler.CreateCatalogPR)
+	protected.POST("/catalog/import", handler.ImportCatalog)
+	protected.GET("/catalog/export", handler.ExportCatalog)
+	protected.POST("/catalog/pr/preview", handler.PreviewCatalogPR)
+	protected.PATCH("/catalog/models/:id", handler.PatchCatalogModel)
 	protected.POST("/weights/install", handler.InstallWeights)
+	protected.GET("/weights/verify", handler.VerifyWeights)
 	protected.DELETE("/weights", handler.DeleteWeights)
+	protected.POST("/weights/prune", handler.PruneWeights)
 	protected.GET("/weights/install/status/:id", handler.GetJob)
 	protected.GET("/jobs", handler.ListJobs)
+	protected.GET("/jobs/deadletter", handler.ListDeadLetterJobs)
 	protected.GET("/jobs/:id", handler.GetJob)
 	protected.GET("/jobs/:id/logs", handler.JobLogs)
+	protected.GET("/jobs/:id/logs/stream", noWriteTimeout(), handler.StreamJobLogs)
 	protected.POST("/jobs/:id/cancel", handler.CancelJob)
 	protected.POST("/jobs/:id/retry", handler.RetryJob)
 	protected.DELETE("/jobs", handler.DeleteJobs)
 	protected.GET("/history", handler.ListHistory)
+	protected.GET("/models/:id/events", handler.ModelEvents)
 	protected.DELETE("/history", handler.ClearHistory)
 	protected.GET("/secrets", handler.ListSecrets)
 	protected.GET("/secrets/:name", handler.GetSecret)
@@ -102,6 +153,7 @@ func NewServer(handler *handlers.Handler, opts Options) *Server {
 	protected.POST("/notifications/:name/rotate", handler.RotateNotification)
 	protected.DELETE("/notifications/:name", handler.DeleteNotification)
 	protected.GET("/notifications/:name/history", handler.NotificationHistory)
+	protected.GET("/notifications/:name/deliveries", handler.NotificationDeliveries)
 	protected.POST("/notifications/test", handler.TestNotification)
 	protected.GET("/tokens", handler.ListTokens)
 	protected.POST("/tokens", handler.IssueToken)
@@ -125,9 +177,14 @@ func NewServer(handler *handlers.Handler, opts Options) *Server {
 	protected.POST("/backups/run", handler.RunBackup)
 	protected.POST("/backups/restore", handler.RestoreBackup)
 	protected.POST("/cleanup/weights", handler.CleanupWeights)
+	protected.POST("/admin/cleanup", handler.AdminCleanup)
 	protected.GET("/support/bundle", handler.SupportBundle)
+	protected.GET("/system/runtime", handler.SystemRuntime)
+	if opts.EnablePprof {
+		registerPprof(protected)
+	}
 
-	return &Server{engine: engine}
+	return &Server{engine: engine, opts: opts}
 }
 
 // Engine exposes the underlying Gin engine for advanced use (testing, etc.).
@@ -137,13 +194,7 @@ func (s *Server) Engine() *gin.Engine {
 
 // Start launches the HTTP server on the provided address.
 func (s *Server) Start(addr string) *http.Server {
-	srv := &http.Server{
-		Addr:         addr,
-		Handler:      s.engine,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	srv := s.httpServer(addr)
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			panic(err)
@@ -151,3 +202,15 @@ func (s *Server) Start(addr string) *http.Server {
 	}()
 	return srv
 }
+
+// httpServer builds the http.Server Start runs, with the configured timeouts.
+func (s *Server) httpServer(addr string) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           s.engine,
+		ReadHeaderTimeout: s.opts.ReadHeaderTimeout,
+		ReadTimeout:       s.opts.ReadTimeout,
+		WriteTimeout:      s.opts.WriteTimeout,
+		IdleTimeout:       60 * time.Second,
+	}
+}
@@ -2,12 +2,16 @@
 package catalog
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -19,19 +23,30 @@ type Catalog struct {
 	catalogRoot string
 	modelsDir   string
 	models      map[string]*Model
+	aliases     map[string]string
+	loadErrors  []LoadError
 	mu          sync.RWMutex
 }
 
+// LoadError records a model file that could not be loaded.
+type LoadError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
 // New creates a new Catalog instance.
 func New(catalogRoot, modelsDir string) *Catalog {
 	return &Catalog{
 		catalogRoot: catalogRoot,
 		modelsDir:   modelsDir,
 		models:      make(map[string]*Model),
+		aliases:     make(map[string]string),
 	}
 }
 
-// Load loads all model configurations from disk.
+// Load loads all model configurations from disk. Files that fail to parse are
+// skipped and reported through LoadErrors; only a missing or unreadable models
+// directory fails the load.
 func (c *Catalog) Load() error {
 	modelsPath := filepath.Join(c.catalogRoot, c.modelsDir)
 
@@ -50,15 +65,37 @@ func (c *Catalog) Load() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.loadErrors = nil
 	for _, file := range files {
 		if err := c.loadModelFile(file); err != nil {
 			log.Printf("Failed to load model config %s: %v", file, err)
+			c.loadErrors = append(c.loadErrors, LoadError{
+				File:  filepath.Base(file),
+				Error: err.Error(),
+			})
 		}
 	}
+	if len(c.loadErrors) > 0 {
+		log.Printf("Skipped %d malformed model file(s)", len(c.loadErrors))
+	}
+	c.indexAliases()
 
 	return nil
 }
 
+// LoadErrors returns the files skipped by the most recent Load.
+func (c *Catalog) LoadErrors() []LoadError {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.loadErrors) == 0 {
+		return nil
+	}
+	out := make([]LoadError, len(c.loadErrors))
+	copy(out, c.loadErrors)
+	return out
+}
+
 func (c *Catalog) loadModelFile(filePath string) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -97,24 +134,66 @@ func (c *Catalog) List() []ModelSummary {
 			DisplayName: displayName,
 			HFModelID:   model.HFModelID,
 			Runtime:     model.Runtime,
+			Lifecycle:   model.Lifecycle,
 		})
 	}
 
 	return models
 }
 
-// Get returns a specific model configuration by ID.
+// Get returns a specific model configuration by ID or by one of its aliases.
+// Callers should use the returned model's ID as the canonical identifier.
 func (c *Catalog) Get(modelID string) *Model {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	return c.models[modelID]
+	if model, ok := c.models[modelID]; ok {
+		return model
+	}
+	if canonical, ok := c.aliases[modelID]; ok {
+		return c.models[canonical]
+	}
+	return nil
+}
+
+// indexAliases rebuilds the alias lookup. Canonical IDs always win over
+// aliases, and an alias claimed by several models resolves to the lowest ID.
+// Callers must hold the write lock.
+func (c *Catalog) indexAliases() {
+	ids := make([]string, 0, len(c.models))
+	for id := range c.models {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	c.aliases = make(map[string]string)
+	for _, id := range ids {
+		model := c.models[id]
+		if model == nil {
+			continue
+		}
+		for _, alias := range model.Aliases {
+			if alias == "" || alias == id {
+				continue
+			}
+			if _, isModel := c.models[alias]; isModel {
+				log.Printf("Ignoring alias %s on %s: it is the ID of another model", alias, id)
+				continue
+			}
+			if owner, taken := c.aliases[alias]; taken {
+				log.Printf("Ignoring alias %s on %s: already used by %s", alias, id, owner)
+				continue
+			}
+			c.aliases[alias] = id
+		}
+	}
 }
 
 // Reload clears the current catalog and reloads from disk.
 func (c *Catalog) Reload() error {
 	c.mu.Lock()
 	c.models = make(map[string]*Model)
+	c.aliases = make(map[string]string)
 	c.mu.Unlock()
 
 	return c.Load()
@@ -143,6 +222,108 @@ func (c *Catalog) All() []*Model {
 	return cloneModels(models)
 }
 
+// SearchOptions filters and pages catalog models. Matching is
+// case-insensitive; every listed tag must be present on a model.
+type SearchOptions struct {
+	// Query is a substring matched against the ID, display name, and HF model ID.
+	Query   string
+	Runtime string
+	Tags    []string
+	// Lifecycle matches LifecycleState, so "active" includes models with no
+	// lifecycle set.
+	Lifecycle string
+	Offset    int
+	// Limit caps the page size; <= 0 returns every match from Offset onward.
+	Limit int
+}
+
+// Slice returns a deep copy of up to limit models ordered by ID, starting at
+// offset, along with the total number of models. Only the requested page is
+// copied; limit <= 0 returns everything from offset onward.
+func (c *Catalog) Slice(offset, limit int) ([]*Model, int) {
+	return c.Search(SearchOptions{Offset: offset, Limit: limit})
+}
+
+// Search returns a deep copy of the requested page of models matching opts,
+// ordered by ID, along with the total number of matches.
+func (c *Catalog) Search(opts SearchOptions) ([]*Model, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	query := strings.ToLower(strings.TrimSpace(opts.Query))
+	ids := make([]string, 0, len(c.models))
+	for id, model := range c.models {
+		if model == nil {
+			continue
+		}
+		if query != "" && !containsFold(query, model.ID, model.DisplayName, model.HFModelID) {
+			continue
+		}
+		if opts.Runtime != "" && !strings.EqualFold(model.Runtime, opts.Runtime) {
+			continue
+		}
+		if !hasAllTags(model.Tags, opts.Tags) {
+			continue
+		}
+		if opts.Lifecycle != "" && !strings.EqualFold(model.LifecycleState(), opts.Lifecycle) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	total := len(ids)
+
+	offset := opts.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return []*Model{}, total
+	}
+	end := total
+	if opts.Limit > 0 && offset+opts.Limit < total {
+		end = offset + opts.Limit
+	}
+	page := make([]*Model, 0, end-offset)
+	for _, id := range ids[offset:end] {
+		page = append(page, c.models[id])
+	}
+	cloned := cloneModels(page)
+	if cloned == nil {
+		cloned = []*Model{}
+	}
+	return cloned, total
+}
+
+func containsFold(query string, fields ...string) bool {
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAllTags(tags []string, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	set := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		set[strings.ToLower(tag)] = struct{}{}
+	}
+	for _, req := range required {
+		req = strings.ToLower(strings.TrimSpace(req))
+		if req == "" {
+			continue
+		}
+		if _, ok := set[req]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // Restore replaces the in-memory catalog with the supplied models.
 func (c *Catalog) Restore(models []*Model) {
 	cloned := cloneModels(models)
@@ -151,12 +332,28 @@ func (c *Catalog) Restore(models []*Model) {
 	defer c.mu.Unlock()
 
 	c.models = make(map[string]*Model, len(cloned))
+	c.loadErrors = nil
 	for _, model := range cloned {
 		if model == nil || model.ID == "" {
 			continue
 		}
 		c.models[model.ID] = model
 	}
+	c.indexAliases()
+}
+
+// ContentHash returns a stable digest of the model definition. Clients can
+// echo it back to detect catalog changes between review and activation.
+func ContentHash(model *Model) string {
+	if model == nil {
+		return ""
+	}
+	data, err := json.Marshal(model)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
 
 func cloneModels(models []*Model) []*Model {
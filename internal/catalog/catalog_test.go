@@ -0,0 +1,123 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadSkipsMalformedFiles(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	files := map[string]string{
+		"good-a.json":     `{"id":"good-a","hfModelId":"org/good-a"}`,
+		"good-b.json":     `{"id":"good-b","hfModelId":"org/good-b"}`,
+		"corrupt.json":    `{"id":"corrupt",`,
+		"missing-id.json": `{"hfModelId":"org/no-id"}`,
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(modelsDir, name), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	cat := New(root, "models")
+	if err := cat.Load(); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cat.Count() != 2 {
+		t.Fatalf("expected 2 models, got %d", cat.Count())
+	}
+	if cat.Get("good-a") == nil || cat.Get("good-b") == nil {
+		t.Fatalf("expected good models to load")
+	}
+
+	loadErrors := cat.LoadErrors()
+	if len(loadErrors) != 2 {
+		t.Fatalf("expected 2 load errors, got %+v", loadErrors)
+	}
+	skipped := map[string]bool{}
+	for _, le := range loadErrors {
+		if le.Error == "" {
+			t.Fatalf("expected error message for %s", le.File)
+		}
+		skipped[le.File] = true
+	}
+	if !skipped["corrupt.json"] || !skipped["missing-id.json"] {
+		t.Fatalf("unexpected skipped files: %+v", loadErrors)
+	}
+
+	if err := os.Remove(filepath.Join(modelsDir, "corrupt.json")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := os.Remove(filepath.Join(modelsDir, "missing-id.json")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := cat.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	if len(cat.LoadErrors()) != 0 {
+		t.Fatalf("expected load errors to clear after reload, got %+v", cat.LoadErrors())
+	}
+}
+
+func TestWatchReportsModelChanges(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cat := New(root, "models")
+	changed := make(chan struct{}, 1)
+	err := cat.Watch(ctx, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	body := []byte(`{"id":"watched","hfModelId":"org/watched"}`)
+	if err := os.WriteFile(filepath.Join(modelsDir, "watched.json"), body, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected change notification")
+	}
+}
+
+func TestGetResolvesAliases(t *testing.T) {
+	cat := New("", "")
+	cat.Restore([]*Model{
+		{ID: "new-name", HFModelID: "org/model", Aliases: []string{"old-name", "other"}},
+		{ID: "other", HFModelID: "org/other"},
+	})
+
+	model := cat.Get("old-name")
+	if model == nil || model.ID != "new-name" {
+		t.Fatalf("expected alias to resolve to new-name, got %+v", model)
+	}
+	if got := cat.Get("other"); got == nil || got.ID != "other" {
+		t.Fatalf("expected canonical ID to win over an alias, got %+v", got)
+	}
+	if cat.Get("missing") != nil {
+		t.Fatalf("expected unknown ID to return nil")
+	}
+	if cat.Count() != 2 {
+		t.Fatalf("aliases must not count as models, got %d", cat.Count())
+	}
+}
@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldChange describes a single field that differs between two models. Field
+// uses JSON names joined by dots; map entries and env vars append their key
+// (e.g. "env.HF_HOME", "resources.limits.nvidia.com/gpu"). Old or New is nil
+// when the field is only set on one side.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// Diff compares the deployment-relevant configuration of two models: Hugging
+// Face source, runtime, storage, env vars, resources, node selector,
+// tolerations, and vLLM settings. Changes are sorted by field; identical
+// configurations return an empty slice.
+func Diff(a, b *Model) []FieldChange {
+	if a == nil {
+		a = &Model{}
+	}
+	if b == nil {
+		b = &Model{}
+	}
+	d := &differ{changes: []FieldChange{}}
+	d.value("hfModelId", a.HFModelID, b.HFModelID)
+	d.value("servedModelName", a.ServedModelName, b.ServedModelName)
+	d.value("runtime", a.Runtime, b.Runtime)
+	d.value("storageUri", a.StorageURI, b.StorageURI)
+	d.stringMap("env", envMap(a.Env), envMap(b.Env))
+	var aReq, aLim, bReq, bLim map[string]string
+	if a.Resources != nil {
+		aReq, aLim = a.Resources.Requests, a.Resources.Limits
+	}
+	if b.Resources != nil {
+		bReq, bLim = b.Resources.Requests, b.Resources.Limits
+	}
+	d.stringMap("resources.requests", aReq, bReq)
+	d.stringMap("resources.limits", aLim, bLim)
+	d.stringMap("nodeSelector", a.NodeSelector, b.NodeSelector)
+	d.value("tolerations", a.Tolerations, b.Tolerations)
+	d.vllm(a.VLLM, b.VLLM)
+
+	sort.Slice(d.changes, func(i, j int) bool {
+		return d.changes[i].Field < d.changes[j].Field
+	})
+	return d.changes
+}
+
+type differ struct {
+	changes []FieldChange
+}
+
+// value records a change when old and new differ, treating zero values (empty
+// strings, nil pointers, empty slices) as unset.
+func (d *differ) value(field string, old, new interface{}) {
+	old, new = normalize(old), normalize(new)
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+	d.changes = append(d.changes, FieldChange{Field: field, Old: old, New: new})
+}
+
+func (d *differ) stringMap(prefix string, old, new map[string]string) {
+	keys := make(map[string]struct{}, len(old)+len(new))
+	for key := range old {
+		keys[key] = struct{}{}
+	}
+	for key := range new {
+		keys[key] = struct{}{}
+	}
+	for key := range keys {
+		d.value(prefix+"."+key, old[key], new[key])
+	}
+}
+
+func (d *differ) vllm(old, new *VLLMConfig) {
+	if old == nil {
+		old = &VLLMConfig{}
+	}
+	if new == nil {
+		new = &VLLMConfig{}
+	}
+	d.value("vllm.tensorParallelSize", old.TensorParallelSize, new.TensorParallelSize)
+	d.value("vllm.dtype", old.Dtype, new.Dtype)
+	d.value("vllm.gpuMemoryUtilization", old.GPUMemoryUtilization, new.GPUMemoryUtilization)
+	d.value("vllm.maxModelLen", old.MaxModelLen, new.MaxModelLen)
+	d.value("vllm.trustRemoteCode", old.TrustRemoteCode, new.TrustRemoteCode)
+	d.value("vllm.quantization", old.Quantization, new.Quantization)
+	d.value("vllm.extraArgs", old.ExtraArgs, new.ExtraArgs)
+}
+
+// normalize dereferences pointers and maps zero values to nil so unset and
+// empty fields compare equal.
+func normalize(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil
+	}
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.IsZero() || ((rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0) {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// envMap renders env vars as name → value, describing secret and ConfigMap
+// references rather than resolving them.
+func envMap(env []EnvVar) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(env))
+	for _, item := range env {
+		out[item.Name] = envValue(item)
+	}
+	return out
+}
+
+func envValue(item EnvVar) string {
+	if item.ValueFrom == nil {
+		return item.Value
+	}
+	switch {
+	case item.ValueFrom.SecretKeyRef != nil:
+		return fmt.Sprintf("secretKeyRef:%s/%s", item.ValueFrom.SecretKeyRef.Name, item.ValueFrom.SecretKeyRef.Key)
+	case item.ValueFrom.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("configMapKeyRef:%s/%s", item.ValueFrom.ConfigMapKeyRef.Name, item.ValueFrom.ConfigMapKeyRef.Key)
+	default:
+		return strings.TrimSpace(item.Value)
+	}
+}
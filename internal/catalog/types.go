@@ -2,12 +2,16 @@ package catalog
 
 // Model represents a complete model configuration.
 type Model struct {
-	ID              string            `json:"id"`
-	DisplayName     string            `json:"displayName,omitempty"`
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	// Aliases are former or alternate IDs that Catalog.Get resolves to this
+	// entry, so renaming a model doesn't break stored references.
+	Aliases         []string          `json:"aliases,omitempty"`
 	HFModelID       string            `json:"hfModelId,omitempty"`
 	ServedModelName string            `json:"servedModelName,omitempty"`
 	StorageURI      string            `json:"storageUri,omitempty"`
 	Runtime         string            `json:"runtime,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
 	Env             []EnvVar          `json:"env,omitempty"`
 	Storage         *Storage          `json:"storage,omitempty"`
 	VLLM            *VLLMConfig       `json:"vllm,omitempty"`
@@ -16,6 +20,36 @@ type Model struct {
 	Resources       *Resources        `json:"resources,omitempty"`
 	VolumeMounts    []VolumeMount     `json:"volumeMounts,omitempty"`
 	Volumes         []Volume          `json:"volumes,omitempty"`
+	// Lifecycle is one of the Lifecycle* constants; empty means active.
+	Lifecycle string `json:"lifecycle,omitempty"`
+	// DeprecationMessage explains why the model is deprecated or retired and
+	// what to use instead.
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+}
+
+// Lifecycle states for catalog models.
+const (
+	LifecycleActive     = "active"
+	LifecycleDeprecated = "deprecated"
+	LifecycleRetired    = "retired"
+)
+
+// LifecycleState returns the model's lifecycle, treating an empty value as
+// active.
+func (m *Model) LifecycleState() string {
+	if m == nil || m.Lifecycle == "" {
+		return LifecycleActive
+	}
+	return m.Lifecycle
+}
+
+// ValidLifecycle reports whether state is empty or a known lifecycle value.
+func ValidLifecycle(state string) bool {
+	switch state {
+	case "", LifecycleActive, LifecycleDeprecated, LifecycleRetired:
+		return true
+	}
+	return false
 }
 
 // ModelSummary is a simplified model representation for listing.
@@ -24,6 +58,7 @@ type ModelSummary struct {
 	DisplayName string `json:"displayName"`
 	HFModelID   string `json:"hfModelId,omitempty"`
 	Runtime     string `json:"runtime,omitempty"`
+	Lifecycle   string `json:"lifecycle,omitempty"`
 }
 
 // EnvVar represents an environment variable.
@@ -68,7 +103,9 @@ type VLLMConfig struct {
 	GPUMemoryUtilization *float64 `json:"gpuMemoryUtilization,omitempty"`
 	MaxModelLen          *int     `json:"maxModelLen,omitempty"`
 	TrustRemoteCode      *bool    `json:"trustRemoteCode,omitempty"`
-	ExtraArgs            []string `json:"extraArgs,omitempty"`
+	// Quantization is passed to vLLM as --quantization (e.g. awq, gptq, fp8).
+	Quantization string   `json:"quantization,omitempty"`
+	ExtraArgs    []string `json:"extraArgs,omitempty"`
 }
 
 // Toleration represents a Kubernetes toleration.
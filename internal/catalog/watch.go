@@ -0,0 +1,96 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups the burst of events produced by a git-sync checkout
+// into a single reload.
+const watchDebounce = 500 * time.Millisecond
+
+// Watch calls onChange after files in the models directory change. It also
+// watches the catalog root and its parent so a missing models directory or a
+// git-sync symlink swap is picked up; watches are re-established after every
+// change. Watch returns an error when no watch could be set up, otherwise it
+// runs until ctx is cancelled.
+func (c *Catalog) Watch(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("catalog watch: %w", err)
+	}
+	if err := c.addWatches(watcher); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go c.watchLoop(ctx, watcher, onChange)
+	return nil
+}
+
+// watchPaths returns the models directory, the catalog root and the root's
+// parent.
+func (c *Catalog) watchPaths() []string {
+	root := filepath.Clean(c.catalogRoot)
+	return []string{filepath.Join(root, c.modelsDir), root, filepath.Dir(root)}
+}
+
+// addWatches (re-)registers every watch path. Each path is removed first so a
+// git-sync symlink swap moves the watch onto the new worktree. It fails only
+// when none of the paths could be watched.
+func (c *Catalog) addWatches(watcher *fsnotify.Watcher) error {
+	var errs []error
+	added := 0
+	for _, path := range c.watchPaths() {
+		_ = watcher.Remove(path)
+		if err := watcher.Add(path); err != nil {
+			errs = append(errs, fmt.Errorf("watch %s: %w", path, err))
+			continue
+		}
+		added++
+	}
+	if added == 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (c *Catalog) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, onChange func()) {
+	defer watcher.Close()
+
+	debounce := time.NewTimer(watchDebounce)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			debounce.Stop()
+			return
+		case evt, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if evt.Op == fsnotify.Chmod {
+				continue
+			}
+			debounce.Reset(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("catalog watch: %v", err)
+		case <-debounce.C:
+			onChange()
+			if err := c.addWatches(watcher); err != nil {
+				log.Printf("catalog watch: %v", err)
+			}
+		}
+	}
+}
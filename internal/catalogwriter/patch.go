@@ -0,0 +1,80 @@
+package catalogwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+)
+
+// ApplyMergePatch applies an RFC 7386 JSON merge patch to model and returns the
+// patched copy; model itself is left untouched. Objects merge recursively, null
+// removes a field, and any other value (including arrays) replaces it. Fields
+// that don't exist on catalog.Model are an error.
+func ApplyMergePatch(model *catalog.Model, patch []byte) (*catalog.Model, error) {
+	if model == nil {
+		return nil, errors.New("model cannot be nil")
+	}
+	patchDoc, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	if _, ok := patchDoc.(map[string]interface{}); !ok {
+		return nil, errors.New("invalid merge patch: expected a JSON object")
+	}
+
+	current, err := json.Marshal(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode model: %w", err)
+	}
+	target, err := decodeJSON(current)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := json.Marshal(mergePatch(target, patchDoc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patched model: %w", err)
+	}
+	// Reject fields the catalog doesn't know so a typo in the patch fails
+	// instead of being dropped silently.
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	var patched catalog.Model
+	if err := dec.Decode(&patched); err != nil {
+		return nil, fmt.Errorf("patched model is invalid: %w", err)
+	}
+	return &patched, nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{}, len(patchObj))
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
+
+// decodeJSON keeps numbers as json.Number so integers survive the round trip.
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
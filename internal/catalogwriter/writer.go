@@ -12,9 +12,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"sigs.k8s.io/yaml"
 )
 
 // Options configures a Writer instance.
@@ -27,6 +29,7 @@ type Options struct {
 	AuthorEmail string
 	GitBinary   string
 	HTTPClient  *http.Client
+	StatusPath  string
 }
 
 // Writer automates model catalog contributions.
@@ -39,12 +42,30 @@ type Writer struct {
 	authorEmail string
 	gitBinary   string
 	httpClient  *http.Client
+	statusPath  string
+
+	gitMu sync.Mutex
 }
 
 // SaveResult describes the outcome of persisting a model file.
 type SaveResult struct {
 	AbsolutePath string
 	RelativePath string
+	// Exists reports whether a catalog file for the model was already on disk.
+	Exists bool
+}
+
+// ActiveStatus mirrors the live runtime selection back into the catalog repo.
+// Revision is the Hugging Face revision of the installed weights the model
+// serves from; CatalogHash identifies the catalog entry itself.
+type ActiveStatus struct {
+	ModelID     string    `json:"modelId"`
+	DisplayName string    `json:"displayName,omitempty"`
+	HFModelID   string    `json:"hfModelId,omitempty"`
+	Revision    string    `json:"revision,omitempty"`
+	CatalogHash string    `json:"catalogHash,omitempty"`
+	ActivatedAt time.Time `json:"activatedAt"`
+	ActivatedBy string    `json:"activatedBy,omitempty"`
 }
 
 // PullRequestOptions describe how to open a GitHub PR.
@@ -83,6 +104,10 @@ func New(opts Options) (*Writer, error) {
 	if client == nil {
 		client = &http.Client{Timeout: 15 * time.Second}
 	}
+	statusPath := opts.StatusPath
+	if statusPath == "" {
+		statusPath = filepath.Join("status", "active.yaml")
+	}
 
 	return &Writer{
 		root:        opts.Root,
@@ -93,32 +118,152 @@ func New(opts Options) (*Writer, error) {
 		authorEmail: opts.AuthorEmail,
 		gitBinary:   gitBinary,
 		httpClient:  client,
+		statusPath:  statusPath,
 	}, nil
 }
 
-// Save writes the catalog entry to disk and returns the file metadata.
+// ErrModelNotFound is returned by Load when no catalog file exists for a model.
+var ErrModelNotFound = errors.New("catalog model not found")
+
+// Load reads the on-disk catalog entry for id from the writer's checkout,
+// accepting JSON or YAML files.
+func (w *Writer) Load(id string) (*catalog.Model, error) {
+	if id == "" {
+		return nil, errors.New("model id is required")
+	}
+	path, data, err := w.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, ErrModelNotFound
+	}
+	var model catalog.Model
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return &model, nil
+}
+
+// Save writes the catalog entry to disk and returns the file metadata. An
+// existing entry is overwritten in place, keeping its JSON or YAML format.
 func (w *Writer) Save(model *catalog.Model) (*SaveResult, error) {
+	result, data, _, err := w.prepare(model)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(result.AbsolutePath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create model directory: %w", err)
+	}
+	if err := os.WriteFile(result.AbsolutePath, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write model file: %w", err)
+	}
+	return result, nil
+}
+
+// Diff returns the file Save would write for model and a unified diff against
+// the current on-disk entry, without writing anything. New entries diff against
+// /dev/null; an empty diff means the entry is unchanged.
+func (w *Writer) Diff(model *catalog.Model) (*SaveResult, string, error) {
+	result, data, existing, err := w.prepare(model)
+	if err != nil {
+		return nil, "", err
+	}
+	fromName := ""
+	if result.Exists {
+		fromName = filepath.ToSlash(result.RelativePath)
+	}
+	return result, unifiedDiff(fromName, filepath.ToSlash(result.RelativePath), string(existing), string(data)), nil
+}
+
+// prepare resolves the target file for model, renders its new content, and
+// loads the current content when the file exists.
+func (w *Writer) prepare(model *catalog.Model) (*SaveResult, []byte, []byte, error) {
 	if model == nil {
-		return nil, errors.New("model cannot be nil")
+		return nil, nil, nil, errors.New("model cannot be nil")
 	}
 	if model.ID == "" {
+		return nil, nil, nil, errors.New("model id is required")
+	}
+
+	absPath, existing, err := w.find(model.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	exists := absPath != ""
+	if !exists {
+		absPath = filepath.Join(w.root, w.modelsDir, fmt.Sprintf("%s.json", model.ID))
+	}
+
+	data, err := Marshal(model, filepath.Ext(absPath))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rel, err := filepath.Rel(w.root, absPath)
+	if err != nil {
+		rel = absPath
+	}
+	return &SaveResult{AbsolutePath: absPath, RelativePath: rel, Exists: exists}, data, existing, nil
+}
+
+// Marshal renders model the way catalog files are written: YAML for a ".yaml"
+// or ".yml" extension, otherwise indented JSON with a trailing newline.
+func Marshal(model *catalog.Model, ext string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if ext == ".yaml" || ext == ".yml" {
+		data, err = yaml.Marshal(model)
+	} else {
+		data, err = json.MarshalIndent(model, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal model: %w", err)
+	}
+	return data, nil
+}
+
+// find locates the existing catalog file for id, returning an empty path when
+// none exists.
+func (w *Writer) find(id string) (string, []byte, error) {
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		candidate := filepath.Join(w.root, w.modelsDir, id+ext)
+		data, err := os.ReadFile(candidate)
+		if err == nil {
+			return candidate, data, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", nil, fmt.Errorf("failed to read existing model file: %w", err)
+		}
+	}
+	return "", nil, nil
+}
+
+// SaveStatus writes the active runtime summary (status/active.yaml by default).
+// It holds the git lock so the write can't land mid-checkout of another
+// CommitAndPush.
+func (w *Writer) SaveStatus(status ActiveStatus) (*SaveResult, error) {
+	if status.ModelID == "" {
 		return nil, errors.New("model id is required")
 	}
+	w.gitMu.Lock()
+	defer w.gitMu.Unlock()
 
-	fileName := fmt.Sprintf("%s.json", model.ID)
-	absPath := filepath.Join(w.root, w.modelsDir, fileName)
+	absPath := filepath.Join(w.root, w.statusPath)
 	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
-		return nil, fmt.Errorf("failed to create model directory: %w", err)
+		return nil, fmt.Errorf("failed to create status directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(model, "", "  ")
+	data, err := yaml.Marshal(status)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal model: %w", err)
+		return nil, fmt.Errorf("failed to marshal status: %w", err)
 	}
-	data = append(data, '\n')
 
 	if err := os.WriteFile(absPath, data, 0o644); err != nil {
-		return nil, fmt.Errorf("failed to write model file: %w", err)
+		return nil, fmt.Errorf("failed to write status file: %w", err)
 	}
 
 	rel, err := filepath.Rel(w.root, absPath)
@@ -141,6 +286,9 @@ func (w *Writer) CommitAndPush(ctx context.Context, branch, base, message string
 		return errors.New("at least one path must be provided")
 	}
 
+	w.gitMu.Lock()
+	defer w.gitMu.Unlock()
+
 	if err := w.ensureAuthor(ctx); err != nil {
 		return err
 	}
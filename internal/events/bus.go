@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,16 +20,38 @@ type Event struct {
 	Type      string      `json:"type"`
 	Timestamp time.Time   `json:"timestamp"`
 	Data      interface{} `json:"data,omitempty"`
+	// RequestID links the event to the HTTP request that caused it.
+	RequestID string `json:"requestId,omitempty"`
+	// StreamID is assigned by the bus that publishes the event and travels
+	// with it through Redis, so every replica knows the event by the same
+	// id. It is the publish time in milliseconds, the publishing node, and a
+	// per-node counter, which keeps it unique across replicas. Streaming
+	// clients resume from it; it is empty for events that never went
+	// through a bus.
+	StreamID string `json:"streamId,omitempty"`
 }
 
+// DefaultReplaySize is how many recent events a bus keeps for replay when
+// Options.ReplaySize is unset.
+const DefaultReplaySize = 512
+
 // Bus multiplexes events to connected clients (local + Redis backed).
 type Bus struct {
 	client redis.UniversalClient
 	logger *log.Logger
 	ch     string
 
+	// node identifies this bus in the stream ids it assigns.
+	node string
+	seq  atomic.Uint64
+
 	mu          sync.RWMutex
 	subscribers map[chan Event]struct{}
+	// recent is a ring buffer of the last replaySize broadcast events;
+	// next is the slot the following event is written to.
+	recent     []Event
+	next       int
+	replaySize int
 }
 
 // Options configure the bus.
@@ -35,6 +59,9 @@ type Options struct {
 	Client  redis.UniversalClient
 	Logger  *log.Logger
 	Channel string
+	// ReplaySize bounds the ring buffer of recent events kept for clients
+	// that reconnect (default DefaultReplaySize).
+	ReplaySize int
 }
 
 // NewBus creates a new event bus.
@@ -43,11 +70,17 @@ func NewBus(opts Options) *Bus {
 	if channel == "" {
 		channel = "model-manager-events"
 	}
+	replaySize := opts.ReplaySize
+	if replaySize <= 0 {
+		replaySize = DefaultReplaySize
+	}
 	bus := &Bus{
 		client:      opts.Client,
 		logger:      opts.Logger,
 		ch:          channel,
+		node:        strings.ReplaceAll(uuid.NewString(), "-", "")[:12],
 		subscribers: make(map[chan Event]struct{}),
+		replaySize:  replaySize,
 	}
 	if bus.client != nil {
 		go bus.observeRedis()
@@ -63,6 +96,7 @@ func (b *Bus) Publish(ctx context.Context, evt Event) error {
 	if evt.Timestamp.IsZero() {
 		evt.Timestamp = time.Now().UTC()
 	}
+	evt.StreamID = fmt.Sprintf("%d-%s-%d", time.Now().UnixMilli(), b.node, b.seq.Add(1))
 
 	if b.client != nil {
 		payload, err := json.Marshal(evt)
@@ -102,9 +136,96 @@ func (b *Bus) Subscribe(ctx context.Context) (<-chan Event, func(), error) {
 	return ch, cancel, nil
 }
 
-func (b *Bus) broadcast(evt Event) {
+// SubscribeFiltered behaves like Subscribe but only delivers events accepted by
+// match. The returned channel closes when ctx is done or cancel is called.
+func (b *Bus) SubscribeFiltered(ctx context.Context, match func(Event) bool) (<-chan Event, func(), error) {
+	ctx, stop := context.WithCancel(ctx)
+	raw, unsubscribe, err := b.Subscribe(ctx)
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+
+	out := make(chan Event, 16)
+	go func() {
+		defer close(out)
+		for evt := range raw {
+			if match != nil && !match(evt) {
+				continue
+			}
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	cancel := func() {
+		stop()
+		unsubscribe()
+	}
+	return out, cancel, nil
+}
+
+// Recent returns the buffered events, oldest first.
+func (b *Bus) Recent() []Event {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
+	return b.recentLocked()
+}
+
+// Replay returns the buffered events received after the one with stream id
+// after, oldest first. ok is false when that event is no longer buffered (it
+// fell out of the last replaySize events, or this replica never saw it), in
+// which case the caller can't tell what was missed and should resync.
+func (b *Bus) Replay(after string) (missed []Event, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	recent := b.recentLocked()
+	for i, evt := range recent {
+		if evt.StreamID == after {
+			return recent[i+1:], true
+		}
+	}
+	return nil, false
+}
+
+func (b *Bus) recentLocked() []Event {
+	out := make([]Event, 0, len(b.recent))
+	for i := 0; i < len(b.recent); i++ {
+		out = append(out, b.recent[(b.next+i)%len(b.recent)])
+	}
+	return out
+}
+
+// buffered reports whether an event with the stream id is in the replay
+// buffer. Callers must hold mu.
+func (b *Bus) buffered(streamID string) bool {
+	for _, evt := range b.recent {
+		if evt.StreamID == streamID {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bus) broadcast(evt Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Redis echoes this bus's own publishes back to it; they were already
+	// delivered locally.
+	if evt.StreamID != "" && b.buffered(evt.StreamID) {
+		return
+	}
+	if len(b.recent) < b.replaySize {
+		b.recent = append(b.recent, evt)
+	} else {
+		b.recent[b.next] = evt
+		b.next = (b.next + 1) % b.replaySize
+	}
 
 	for ch := range b.subscribers {
 		select {
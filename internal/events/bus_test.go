@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBusStreamIDsAreUniqueAcrossBuses(t *testing.T) {
+	first := NewBus(Options{})
+	second := NewBus(Options{})
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		for _, bus := range []*Bus{first, second} {
+			if err := bus.Publish(context.Background(), Event{Type: "job.created"}); err != nil {
+				t.Fatalf("Publish: %v", err)
+			}
+			recent := bus.Recent()
+			id := recent[len(recent)-1].StreamID
+			if id == "" || seen[id] {
+				t.Fatalf("expected a fresh stream id, got %q", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestBusReplayReportsEvictedEvents(t *testing.T) {
+	bus := NewBus(Options{ReplaySize: 2})
+	for _, typ := range []string{"a", "b", "c"} {
+		if err := bus.Publish(context.Background(), Event{Type: typ}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	recent := bus.Recent()
+	if len(recent) != 2 || recent[0].Type != "b" || recent[1].Type != "c" {
+		t.Fatalf("expected the last two events buffered, got %+v", recent)
+	}
+
+	missed, ok := bus.Replay(recent[0].StreamID)
+	if !ok || len(missed) != 1 || missed[0].Type != "c" {
+		t.Fatalf("expected to replay c after b, got %+v ok=%v", missed, ok)
+	}
+	if missed, ok := bus.Replay(recent[1].StreamID); !ok || len(missed) != 0 {
+		t.Fatalf("expected nothing missed after the newest event, got %+v ok=%v", missed, ok)
+	}
+	if _, ok := bus.Replay("0-evicted-1"); ok {
+		t.Fatalf("expected an unknown id to report that events may have been missed")
+	}
+
+	// An event arriving again (Redis echoing a local publish) is not
+	// delivered or buffered twice.
+	bus.broadcast(recent[1])
+	if got := bus.Recent(); len(got) != 2 || got[0].StreamID != recent[0].StreamID {
+		t.Fatalf("expected a duplicate broadcast to be ignored, got %+v", got)
+	}
+}
@@ -0,0 +1,296 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+)
+
+// Known event types. Job status events are "job.<status>" (see JobEventType).
+const (
+	TypeActivationStarted    = "model.activation.started"
+	TypeActivationCompleted  = "model.activation.completed"
+	TypeActivationFailed     = "model.activation.failed"
+	TypeActivationRolledBack = "model.activation.rolled_back"
+	TypeRollbackCompleted    = "model.rollback.completed"
+	TypeCanaryPromoted       = "model.canary.promoted"
+	TypeDeactivationStarted  = "model.deactivation.started"
+	TypeDeactivationFailed   = "model.deactivation.failed"
+	TypeDeactivationComplete = "model.deactivation.completed"
+	TypeStatusUpdated        = "model.status.updated"
+	TypeAlertTriggered       = "alert.triggered"
+	TypeAlertResolved        = "alert.resolved"
+	TypePlaybookRun          = "playbook.run"
+	TypeJobLog               = "job.log"
+	TypeJobCancel            = "job.cancel"
+)
+
+// ErrUnknownType is returned by Decode for event types nothing registered.
+var ErrUnknownType = errors.New("events: unknown event type")
+
+// Payload is a typed event body that knows its event type.
+type Payload interface {
+	EventType() string
+}
+
+// ActivationStarted is published when an activation begins.
+type ActivationStarted struct {
+	ModelID         string    `json:"modelId"`
+	DisplayName     string    `json:"displayName"`
+	StorageURI      string    `json:"storageUri"`
+	Runtime         string    `json:"runtime"`
+	HFModelID       string    `json:"hfModelId"`
+	RequestedBy     string    `json:"requestedBy"`
+	RequestedAt     time.Time `json:"requestedAt"`
+	Strategy        string    `json:"strategy"`
+	TrafficPercent  int       `json:"trafficPercent,omitempty"`
+	PreviousModelID string    `json:"previousModelId,omitempty"`
+}
+
+// ActivationCompleted is published once the InferenceService was applied.
+type ActivationCompleted struct {
+	Action          string `json:"action"`
+	ModelID         string `json:"modelId"`
+	DisplayName     string `json:"displayName"`
+	Strategy        string `json:"strategy"`
+	TrafficPercent  int    `json:"trafficPercent,omitempty"`
+	PreviousModelID string `json:"previousModelId,omitempty"`
+}
+
+// ActivationFailed is published when applying the InferenceService fails.
+type ActivationFailed struct {
+	ModelID     string `json:"modelId"`
+	DisplayName string `json:"displayName"`
+	Error       string `json:"error"`
+}
+
+// ActivationRolledBack is published when an activation never became ready
+// and the previous model was restored (or the restore was attempted).
+type ActivationRolledBack struct {
+	ModelID     string `json:"modelId"`
+	DisplayName string `json:"displayName"`
+	PreviousID  string `json:"previousId"`
+	RolledBack  bool   `json:"rolledBack"`
+	Error       string `json:"error"`
+}
+
+// RollbackCompleted is published after an explicit runtime rollback.
+type RollbackCompleted struct {
+	ModelID        string `json:"modelId"`
+	RolledBackFrom string `json:"rolledBackFrom"`
+}
+
+// CanaryPromoted is published when a canary receives all traffic.
+type CanaryPromoted struct {
+	ModelID         string `json:"modelId"`
+	PreviousPercent int    `json:"previousPercent"`
+	TrafficPercent  int    `json:"trafficPercent"`
+}
+
+// DeactivationStarted is published when the runtime is being torn down.
+type DeactivationStarted struct {
+	RequestedBy string    `json:"requestedBy"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+// DeactivationFailed is published when deactivation fails.
+type DeactivationFailed struct {
+	Error string `json:"error"`
+}
+
+// DeactivationCompleted is published once the runtime was removed.
+type DeactivationCompleted struct {
+	Action string `json:"action"`
+}
+
+// AlertTriggered is published when weights PVC usage crosses the threshold.
+type AlertTriggered struct {
+	Kind         string  `json:"kind"`
+	UsagePercent float64 `json:"usagePercent"`
+}
+
+// AlertResolved is published when PVC usage drops back below the threshold.
+type AlertResolved struct {
+	Kind         string  `json:"kind"`
+	UsagePercent float64 `json:"usagePercent"`
+}
+
+// PlaybookRun is published when a playbook is executed; Steps holds the
+// per-step results keyed by step name.
+type PlaybookRun struct {
+	Name  string                 `json:"name"`
+	Steps map[string]interface{} `json:"steps"`
+}
+
+// JobLog carries one log entry appended to a job.
+type JobLog struct {
+	JobID string            `json:"jobId"`
+	Log   store.JobLogEntry `json:"log"`
+}
+
+// JobCancel asks whichever replica runs the job to stop it.
+type JobCancel struct {
+	JobID string `json:"jobId"`
+}
+
+func (ActivationStarted) EventType() string     { return TypeActivationStarted }
+func (ActivationCompleted) EventType() string   { return TypeActivationCompleted }
+func (ActivationFailed) EventType() string      { return TypeActivationFailed }
+func (ActivationRolledBack) EventType() string  { return TypeActivationRolledBack }
+func (RollbackCompleted) EventType() string     { return TypeRollbackCompleted }
+func (CanaryPromoted) EventType() string        { return TypeCanaryPromoted }
+func (DeactivationStarted) EventType() string   { return TypeDeactivationStarted }
+func (DeactivationFailed) EventType() string    { return TypeDeactivationFailed }
+func (DeactivationCompleted) EventType() string { return TypeDeactivationComplete }
+func (AlertTriggered) EventType() string        { return TypeAlertTriggered }
+func (AlertResolved) EventType() string         { return TypeAlertResolved }
+func (PlaybookRun) EventType() string           { return TypePlaybookRun }
+func (JobLog) EventType() string                { return TypeJobLog }
+func (JobCancel) EventType() string             { return TypeJobCancel }
+
+// New builds an event for a typed payload, stamped with the current time.
+func New(payload Payload) Event {
+	return Event{
+		Type:      payload.EventType(),
+		Timestamp: time.Now().UTC(),
+		Data:      payload,
+	}
+}
+
+// JobEventType is the event type published when a job enters status.
+func JobEventType(status store.JobStatus) string {
+	return fmt.Sprintf("job.%s", status)
+}
+
+// NewJobEvent builds the job.<status> event for job. Its ID is the job ID so
+// subscribers can follow one job, and the payload is a copy of the job.
+func NewJobEvent(job *store.Job) Event {
+	timestamp := job.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = job.CreatedAt
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+	return Event{
+		ID:        job.ID,
+		Type:      JobEventType(job.Status),
+		Timestamp: timestamp,
+		Data:      *job,
+	}
+}
+
+// NewJobLogEvent builds the job.log event for entry. The ID is derived from
+// the entry timestamp so replays of the same entry can be de-duplicated.
+func NewJobLogEvent(jobID string, entry store.JobLogEntry) Event {
+	return Event{
+		ID:        fmt.Sprintf("%s-log-%d", jobID, entry.Timestamp.UnixNano()),
+		Type:      TypeJobLog,
+		Timestamp: entry.Timestamp,
+		Data:      JobLog{JobID: jobID, Log: entry},
+	}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]func() interface{}{}
+)
+
+// Register associates eventType with its payload type; newPayload returns a
+// pointer to a zero payload. Packages whose payload types events can't import
+// (such as the status manager) register themselves.
+func Register(eventType string, newPayload func() interface{}) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[eventType] = newPayload
+}
+
+// Types lists the registered event types, sorted.
+func Types() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	types := make([]string, 0, len(registry))
+	for eventType := range registry {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Decode returns a pointer to the registered payload type for evt.Type filled
+// from evt.Data, or ErrUnknownType.
+func Decode(evt Event) (interface{}, error) {
+	registryMu.RLock()
+	newPayload, ok := registry[evt.Type]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, evt.Type)
+	}
+	payload := newPayload()
+	if err := DecodeInto(evt, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// DecodeInto fills out (a pointer) from evt.Data. Data is the typed payload
+// for events published in-process and decoded JSON for events relayed through
+// Redis; both are handled.
+func DecodeInto(evt Event, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return errors.New("events: DecodeInto requires a non-nil pointer")
+	}
+	if data := reflect.ValueOf(evt.Data); data.IsValid() {
+		want := target.Elem().Type()
+		switch {
+		case data.Type() == want:
+			target.Elem().Set(data)
+			return nil
+		case data.Kind() == reflect.Ptr && !data.IsNil() && data.Elem().Type() == want:
+			target.Elem().Set(data.Elem())
+			return nil
+		}
+	}
+	raw, err := json.Marshal(evt.Data)
+	if err != nil {
+		return fmt.Errorf("events: encode %s payload: %w", evt.Type, err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("events: decode %s payload: %w", evt.Type, err)
+	}
+	return nil
+}
+
+// Fields flattens a payload into the map form used for history metadata and
+// notifications, with the same keys as the event JSON.
+func Fields(payload Payload) map[string]interface{} {
+	fields := map[string]interface{}{}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(raw, &fields)
+	return fields
+}
+
+func init() {
+	for _, payload := range []Payload{
+		ActivationStarted{}, ActivationCompleted{}, ActivationFailed{}, ActivationRolledBack{},
+		RollbackCompleted{}, CanaryPromoted{},
+		DeactivationStarted{}, DeactivationFailed{}, DeactivationCompleted{},
+		AlertTriggered{}, AlertResolved{}, PlaybookRun{}, JobLog{}, JobCancel{},
+	} {
+		typ := reflect.TypeOf(payload)
+		Register(payload.EventType(), func() interface{} { return reflect.New(typ).Interface() })
+	}
+	for _, status := range []store.JobStatus{store.JobPending, store.JobRunning, store.JobDone, store.JobFailed, store.JobCancelled} {
+		Register(JobEventType(status), func() interface{} { return &store.Job{} })
+	}
+}
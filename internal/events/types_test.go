@@ -0,0 +1,153 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+)
+
+var samplePayloads = []Payload{
+	ActivationStarted{
+		ModelID: "qwen", DisplayName: "Qwen", StorageURI: "pvc://weights/qwen", Runtime: "vllm",
+		HFModelID: "Qwen/Qwen2.5-7B", RequestedBy: "ops", RequestedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Strategy: "canary", TrafficPercent: 10, PreviousModelID: "llama",
+	},
+	ActivationCompleted{Action: "updated", ModelID: "qwen", DisplayName: "Qwen", Strategy: "replace", PreviousModelID: "llama"},
+	ActivationFailed{ModelID: "qwen", DisplayName: "Qwen", Error: "boom"},
+	ActivationRolledBack{ModelID: "qwen", DisplayName: "Qwen", PreviousID: "llama", RolledBack: true, Error: "not ready"},
+	RollbackCompleted{ModelID: "llama", RolledBackFrom: "qwen"},
+	CanaryPromoted{ModelID: "qwen", PreviousPercent: 10, TrafficPercent: 100},
+	DeactivationStarted{RequestedBy: "ops", RequestedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+	DeactivationFailed{Error: "boom"},
+	DeactivationCompleted{Action: "deleted"},
+	AlertTriggered{Kind: "pvc", UsagePercent: 91.5},
+	AlertResolved{Kind: "pvc", UsagePercent: 42},
+	PlaybookRun{Name: "warmup", Steps: map[string]interface{}{"activate": "ok"}},
+	JobLog{JobID: "job-1", Log: store.JobLogEntry{Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Level: "info", Message: "hi"}},
+	JobCancel{JobID: "job-1"},
+}
+
+// relay mimics an event crossing Redis: the payload arrives as decoded JSON.
+func relay(t *testing.T, evt Event) Event {
+	t.Helper()
+	raw, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", evt.Type, err)
+	}
+	var out Event
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("unmarshal %s: %v", evt.Type, err)
+	}
+	return out
+}
+
+func TestDecodeRoundTripsRegisteredPayloads(t *testing.T) {
+	for _, payload := range samplePayloads {
+		evt := New(payload)
+		for name, candidate := range map[string]Event{"in-process": evt, "relayed": relay(t, evt)} {
+			decoded, err := Decode(candidate)
+			if err != nil {
+				t.Fatalf("%s %s: Decode: %v", name, evt.Type, err)
+			}
+			got := reflect.ValueOf(decoded)
+			if got.Kind() != reflect.Ptr || !reflect.DeepEqual(got.Elem().Interface(), payload) {
+				t.Errorf("%s %s: decoded %#v, want %#v", name, evt.Type, decoded, payload)
+			}
+		}
+	}
+}
+
+func TestDecodeJobEvents(t *testing.T) {
+	job := &store.Job{
+		ID:        "job-1",
+		Type:      "weights.install",
+		Status:    store.JobRunning,
+		Progress:  40,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt: time.Date(2026, 1, 2, 3, 5, 5, 0, time.UTC),
+	}
+	evt := NewJobEvent(job)
+	if evt.Type != "job.running" || evt.ID != job.ID || !evt.Timestamp.Equal(job.UpdatedAt) {
+		t.Fatalf("unexpected job event %+v", evt)
+	}
+	decoded, err := Decode(relay(t, evt))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got, ok := decoded.(*store.Job); !ok || !reflect.DeepEqual(*got, *job) {
+		t.Fatalf("decoded %#v, want %#v", decoded, job)
+	}
+}
+
+func TestDecodeUnknownType(t *testing.T) {
+	_, err := Decode(Event{Type: "model.unheard.of", Data: map[string]interface{}{"a": 1}})
+	if !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("expected ErrUnknownType, got %v", err)
+	}
+}
+
+func TestDecodeInto(t *testing.T) {
+	want := CanaryPromoted{ModelID: "qwen", PreviousPercent: 10, TrafficPercent: 100}
+	cases := []struct {
+		name string
+		data interface{}
+	}{
+		{"value", want},
+		{"pointer", &want},
+		{"json map", map[string]interface{}{"modelId": "qwen", "previousPercent": 10, "trafficPercent": 100}},
+	}
+	for _, tc := range cases {
+		var got CanaryPromoted
+		if err := DecodeInto(Event{Type: TypeCanaryPromoted, Data: tc.data}, &got); err != nil {
+			t.Fatalf("%s: DecodeInto: %v", tc.name, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %+v, want %+v", tc.name, got, want)
+		}
+	}
+
+	var got CanaryPromoted
+	if err := DecodeInto(Event{Type: TypeCanaryPromoted, Data: want}, got); err == nil {
+		t.Error("expected an error for a non-pointer target")
+	}
+	if err := DecodeInto(Event{Type: TypeCanaryPromoted, Data: map[string]interface{}{"trafficPercent": "all"}}, &got); err == nil {
+		t.Error("expected an error for a payload of the wrong shape")
+	}
+}
+
+func TestTypesListsBuiltinPayloads(t *testing.T) {
+	types := Types()
+	if !sort.StringsAreSorted(types) {
+		t.Fatalf("Types is not sorted: %v", types)
+	}
+	registered := make(map[string]bool, len(types))
+	for _, typ := range types {
+		registered[typ] = true
+	}
+	for _, payload := range samplePayloads {
+		if !registered[payload.EventType()] {
+			t.Errorf("%s is not registered", payload.EventType())
+		}
+	}
+	if !registered[JobEventType(store.JobDone)] {
+		t.Errorf("%s is not registered", JobEventType(store.JobDone))
+	}
+}
+
+func TestFieldsUsesJSONKeys(t *testing.T) {
+	fields := Fields(ActivationCompleted{Action: "updated", ModelID: "qwen", Strategy: "replace"})
+	want := map[string]interface{}{
+		"action":      "updated",
+		"modelId":     "qwen",
+		"displayName": "",
+		"strategy":    "replace",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("Fields = %v, want %v", fields, want)
+	}
+}
@@ -1,7 +1,10 @@
 package graphqlapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
@@ -10,6 +13,7 @@ import (
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/handler"
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/events"
 	"github.com/oremus-labs/ol-model-manager/internal/status"
 	"github.com/oremus-labs/ol-model-manager/internal/store"
 	"github.com/oremus-labs/ol-model-manager/internal/vllm"
@@ -19,6 +23,7 @@ import (
 type CatalogProvider interface {
 	All() []*catalog.Model
 	Get(id string) *catalog.Model
+	Slice(offset, limit int) ([]*catalog.Model, int)
 }
 
 // HFStore exposes cached Hugging Face access.
@@ -31,6 +36,16 @@ type DiscoveryProvider interface {
 	SearchModels(vllm.SearchOptions) ([]*vllm.ModelInsight, error)
 }
 
+// WeightInstaller queues weight installs for the installWeights mutation.
+type WeightInstaller interface {
+	ScheduleWeightInstallJob(ctx context.Context, hfModelID, revision, target string, overwrite bool) (*store.Job, error)
+}
+
+// EventSource feeds GraphQL subscriptions from the control-plane event bus.
+type EventSource interface {
+	SubscribeFiltered(context.Context, func(events.Event) bool) (<-chan events.Event, func(), error)
+}
+
 // Config wires the GraphQL schema.
 type Config struct {
 	Catalog   CatalogProvider
@@ -38,6 +53,28 @@ type Config struct {
 	Runtime   status.Provider
 	HFCache   HFStore
 	Discovery DiscoveryProvider
+	Installer WeightInstaller
+	Events    EventSource
+}
+
+type authorizerKey struct{}
+
+// WithAuthorizer attaches the caller's scope check to ctx. Mutations and the
+// history query require an authorizer, so anonymous requests can read the
+// catalog and runtime but never mutate or read the audit history.
+func WithAuthorizer(ctx context.Context, allow func(scope string) bool) context.Context {
+	return context.WithValue(ctx, authorizerKey{}, allow)
+}
+
+func authorize(ctx context.Context, scope string) error {
+	allow, _ := ctx.Value(authorizerKey{}).(func(string) bool)
+	if allow == nil {
+		return errors.New("unauthorized: an API token is required")
+	}
+	if !allow(scope) {
+		return fmt.Errorf("forbidden: token is missing required scope %q", scope)
+	}
+	return nil
 }
 
 // NewHandler returns an http.Handler that serves /graphql requests.
@@ -48,10 +85,17 @@ func NewHandler(cfg Config) (http.Handler, error) {
 		return nil, err
 	}
 
-	return handler.New(&handler.Config{
+	queries := handler.New(&handler.Config{
 		Schema:   schema,
 		Pretty:   true,
 		GraphiQL: true,
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			serveSubscription(w, r, schema)
+			return
+		}
+		queries.ServeHTTP(w, r)
 	}), nil
 }
 
@@ -111,6 +155,7 @@ func (b schemaBuilder) buildSchema() (*graphql.Schema, error) {
 			"servedModelName": {Type: graphql.String},
 			"storageUri":      {Type: graphql.String},
 			"runtime":         {Type: graphql.String},
+			"tags":            {Type: graphql.NewList(graphql.String)},
 			"env":             {Type: graphql.NewList(envVarType)},
 			"resources":       {Type: resourceType},
 		},
@@ -132,6 +177,17 @@ func (b schemaBuilder) buildSchema() (*graphql.Schema, error) {
 		},
 	})
 
+	historyEntryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "HistoryEntry",
+		Fields: graphql.Fields{
+			"id":        {Type: graphql.NewNonNull(graphql.ID)},
+			"event":     {Type: graphql.NewNonNull(graphql.String)},
+			"modelId":   {Type: graphql.String},
+			"metadata":  {Type: jsonScalar},
+			"createdAt": {Type: graphql.String},
+		},
+	})
+
 	containerType := graphql.NewObject(graphql.ObjectConfig{
 		Name: "ContainerStatus",
 		Fields: graphql.Fields{
@@ -218,11 +274,18 @@ func (b schemaBuilder) buildSchema() (*graphql.Schema, error) {
 	queryFields := graphql.Fields{
 		"models": {
 			Type: graphql.NewList(modelType),
+			Args: graphql.FieldConfigArgument{
+				"limit":  {Type: graphql.Int},
+				"offset": {Type: graphql.Int},
+			},
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 				if b.cfg.Catalog == nil {
 					return []interface{}{}, nil
 				}
-				return mapModels(b.cfg.Catalog.All()), nil
+				limit, _ := p.Args["limit"].(int)
+				offset, _ := p.Args["offset"].(int)
+				models, _ := b.cfg.Catalog.Slice(offset, limit)
+				return mapModels(models), nil
 			},
 		},
 		"model": {
@@ -277,6 +340,42 @@ func (b schemaBuilder) buildSchema() (*graphql.Schema, error) {
 				return mapJob(job), nil
 			},
 		},
+		"history": {
+			Type: graphql.NewList(historyEntryType),
+			Args: graphql.FieldConfigArgument{
+				"limit":   {Type: graphql.Int},
+				"event":   {Type: graphql.String},
+				"modelId": {Type: graphql.String},
+				"since":   {Type: graphql.String},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if err := authorize(p.Context, "history:read"); err != nil {
+					return nil, err
+				}
+				if b.cfg.Store == nil {
+					return []interface{}{}, nil
+				}
+				limit := 50
+				if l, ok := p.Args["limit"].(int); ok && l > 0 {
+					limit = l
+				}
+				var since time.Time
+				if value, _ := p.Args["since"].(string); strings.TrimSpace(value) != "" {
+					parsed, err := store.ParseSince(strings.TrimSpace(value))
+					if err != nil {
+						return nil, err
+					}
+					since = parsed
+				}
+				entries, err := b.cfg.Store.ListHistory(limit)
+				if err != nil {
+					return nil, err
+				}
+				event, _ := p.Args["event"].(string)
+				modelID, _ := p.Args["modelId"].(string)
+				return mapHistory(store.FilterHistory(entries, event, modelID, since)), nil
+			},
+		},
 		"runtimeStatus": {
 			Type: runtimeStatusType,
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -324,11 +423,62 @@ func (b schemaBuilder) buildSchema() (*graphql.Schema, error) {
 		},
 	}
 
+	mutationFields := graphql.Fields{
+		"installWeights": {
+			Type:        graphql.NewNonNull(jobType),
+			Description: "Queue a Hugging Face weight install (same path as POST /weights/install). Requires a token with weights:write.",
+			Args: graphql.FieldConfigArgument{
+				"hfModelId": {Type: graphql.NewNonNull(graphql.String)},
+				"revision":  {Type: graphql.String},
+				"target":    {Type: graphql.String},
+				"overwrite": {Type: graphql.Boolean},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if err := authorize(p.Context, "weights:write"); err != nil {
+					return nil, err
+				}
+				if b.cfg.Installer == nil {
+					return nil, errors.New("weight installation is disabled")
+				}
+				hfModelID, _ := p.Args["hfModelId"].(string)
+				hfModelID = strings.TrimSpace(hfModelID)
+				if !vllm.ValidHuggingFaceModelID(hfModelID) {
+					return nil, fmt.Errorf("invalid Hugging Face model id %q: expected owner/name", hfModelID)
+				}
+				revision, _ := p.Args["revision"].(string)
+				target, _ := p.Args["target"].(string)
+				overwrite, _ := p.Args["overwrite"].(bool)
+				job, err := b.cfg.Installer.ScheduleWeightInstallJob(p.Context, hfModelID, strings.TrimSpace(revision), strings.TrimSpace(target), overwrite)
+				if err != nil {
+					return nil, err
+				}
+				return mapJob(job), nil
+			},
+		},
+	}
+
 	schema, err := graphql.NewSchema(graphql.SchemaConfig{
 		Query: graphql.NewObject(graphql.ObjectConfig{
 			Name:   "Query",
 			Fields: queryFields,
 		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Mutation",
+			Fields: mutationFields,
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"runtimeStatus": {
+					Type:        runtimeStatusType,
+					Description: "Current runtime status, then every change published as model.status.updated.",
+					Subscribe:   b.subscribeRuntimeStatus,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+				},
+			},
+		}),
 	})
 	if err != nil {
 		return nil, err
@@ -370,6 +520,7 @@ func mapModel(model *catalog.Model) map[string]interface{} {
 		"servedModelName": model.ServedModelName,
 		"storageUri":      model.StorageURI,
 		"runtime":         model.Runtime,
+		"tags":            model.Tags,
 		"env":             env,
 		"resources":       res,
 	}
@@ -420,6 +571,20 @@ func mapJob(job *store.Job) map[string]interface{} {
 	}
 }
 
+func mapHistory(entries []store.HistoryEntry) []interface{} {
+	out := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, map[string]interface{}{
+			"id":        entry.ID,
+			"event":     entry.Event,
+			"modelId":   entry.ModelID,
+			"metadata":  entry.Metadata,
+			"createdAt": entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return out
+}
+
 func mapRuntimeStatus(status status.RuntimeStatus) map[string]interface{} {
 	result := map[string]interface{}{
 		"updatedAt": status.UpdatedAt.Format(time.RFC3339),
@@ -0,0 +1,67 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+)
+
+func TestHistoryQueryRequiresHistoryReadScope(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	if err := s.AppendHistory(&store.HistoryEntry{Event: "activate", ModelID: "qwen"}); err != nil {
+		t.Fatalf("AppendHistory: %v", err)
+	}
+	h, err := NewHandler(Config{Store: s})
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	query := func(ctx context.Context) (data map[string]interface{}, errs []interface{}) {
+		body := strings.NewReader(`{"query":"{ history { event modelId } }"}`)
+		req := httptest.NewRequest(http.MethodPost, "/graphql", body).WithContext(ctx)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var resp struct {
+			Data   map[string]interface{} `json:"data"`
+			Errors []interface{}          `json:"errors"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode %s: %v", rec.Body.String(), err)
+		}
+		return resp.Data, resp.Errors
+	}
+
+	cases := []struct {
+		name  string
+		ctx   context.Context
+		allow bool
+	}{
+		{"anonymous", context.Background(), false},
+		{"missing scope", WithAuthorizer(context.Background(), func(scope string) bool { return scope == "jobs:read" }), false},
+		{"history:read", WithAuthorizer(context.Background(), func(scope string) bool { return scope == "history:read" }), true},
+	}
+	for _, tc := range cases {
+		data, errs := query(tc.ctx)
+		entries, _ := data["history"].([]interface{})
+		if tc.allow {
+			if len(errs) != 0 || len(entries) != 1 {
+				t.Errorf("%s: expected one history entry, got %v (errors %v)", tc.name, entries, errs)
+			}
+			continue
+		}
+		if len(errs) == 0 || len(entries) != 0 {
+			t.Errorf("%s: expected the query to be denied, got %v", tc.name, data)
+		}
+	}
+}
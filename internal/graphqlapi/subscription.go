@@ -0,0 +1,183 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/status"
+)
+
+// subscribeRuntimeStatus emits the current runtime status (when a provider is
+// configured) followed by each model.status.updated event for the same target
+// whose content differs from the last one sent.
+func (b schemaBuilder) subscribeRuntimeStatus(p graphql.ResolveParams) (interface{}, error) {
+	if b.cfg.Events == nil {
+		return nil, errors.New("subscriptions are unavailable: event bus not configured")
+	}
+	stream, cancel, err := b.cfg.Events.SubscribeFiltered(p.Context, func(evt events.Event) bool {
+		return evt.Type == "model.status.updated"
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		var last map[string]interface{}
+		send := func(current status.RuntimeStatus) bool {
+			mapped := mapRuntimeStatus(current)
+			if sameRuntimeStatus(last, mapped) {
+				return true
+			}
+			last = mapped
+			select {
+			case out <- mapped:
+				return true
+			case <-p.Context.Done():
+				return false
+			}
+		}
+
+		// The status manager may watch several InferenceServices; only follow
+		// the active one.
+		var target string
+		if b.cfg.Runtime != nil {
+			initial := b.cfg.Runtime.CurrentStatus()
+			target = initial.Target
+			if !send(initial) {
+				return
+			}
+		}
+		for evt := range stream {
+			current, err := decodeRuntimeStatus(evt)
+			if err != nil || (target != "" && current.Target != target) {
+				continue
+			}
+			if !send(current) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// decodeRuntimeStatus accepts status payloads published locally (typed) or
+// relayed through Redis (decoded JSON).
+func decodeRuntimeStatus(evt events.Event) (status.RuntimeStatus, error) {
+	var out status.RuntimeStatus
+	err := events.DecodeInto(evt, &out)
+	return out, err
+}
+
+// sameRuntimeStatus compares mapped statuses, ignoring the refresh timestamp.
+func sameRuntimeStatus(a, b map[string]interface{}) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	strip := func(m map[string]interface{}) map[string]interface{} {
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if k != "updatedAt" {
+				out[k] = v
+			}
+		}
+		return out
+	}
+	return reflect.DeepEqual(strip(a), strip(b))
+}
+
+type subscriptionRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// serveSubscription runs a subscription operation and streams each result as an
+// SSE "next" event, finishing with "complete" (GraphQL over SSE, distinct
+// connections mode). GET requests pass query, variables, and operationName as
+// URL parameters; POST requests send them as a JSON body.
+func serveSubscription(w http.ResponseWriter, r *http.Request, schema *graphql.Schema) {
+	var req subscriptionRequest
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				http.Error(w, "invalid variables", http.StatusBadRequest)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Subscriptions outlive the server's write timeout, which still applies
+	// to ordinary queries on the same route.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("graphql subscription: clearing write deadline: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	results := graphql.Subscribe(graphql.Params{
+		Schema:         *schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	defer func() {
+		cancel()
+		// The executor blocks on unread results; drain so it can exit.
+		go func() {
+			for range results {
+			}
+		}()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				fmt.Fprint(w, "event: complete\ndata:\n\n")
+				flusher.Flush()
+				return
+			}
+			payload, err := json.Marshal(res)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: next\ndata: %s\n\n", payload)
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package graphqlapi
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/status"
+)
+
+type staticRuntime struct {
+	status status.RuntimeStatus
+}
+
+func (r staticRuntime) CurrentStatus() status.RuntimeStatus {
+	return r.status
+}
+
+func TestSubscriptionOutlivesWriteTimeout(t *testing.T) {
+	bus := events.NewBus(events.Options{})
+	h, err := NewHandler(Config{
+		Runtime: staticRuntime{status: status.RuntimeStatus{Target: "llm"}},
+		Events:  bus,
+	})
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	const writeTimeout = 200 * time.Millisecond
+	srv := httptest.NewUnstartedServer(h)
+	srv.Config.WriteTimeout = writeTimeout
+	srv.Start()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	query := url.Values{"query": {"subscription { runtimeStatus { inferenceService { name ready } } }"}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/graphql?"+query.Encode(), nil)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer resp.Body.Close()
+
+	lines := bufio.NewScanner(resp.Body)
+	nextEvent := func() string {
+		t.Helper()
+		for lines.Scan() {
+			if strings.HasPrefix(lines.Text(), "data: ") {
+				return lines.Text()
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return ""
+	}
+	nextEvent()
+
+	time.Sleep(2 * writeTimeout)
+	_ = bus.Publish(ctx, events.Event{
+		Type: events.TypeStatusUpdated,
+		Data: status.RuntimeStatus{Target: "llm", InferenceService: &status.InferenceServiceStatus{Name: "llm", Ready: "True"}},
+	})
+	if got := nextEvent(); !strings.Contains(got, `"ready":"True"`) {
+		t.Fatalf("expected the status update after the write timeout, got %s", got)
+	}
+}
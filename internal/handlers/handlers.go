@@ -2,24 +2,30 @@
 package handlers
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"net/http"
 	"path"
 	"reflect"
-	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/PaesslerAG/jsonpath"
@@ -29,11 +35,14 @@ import (
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
 	"github.com/oremus-labs/ol-model-manager/internal/catalogwriter"
 	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/inventory"
 	"github.com/oremus-labs/ol-model-manager/internal/jobs"
 	"github.com/oremus-labs/ol-model-manager/internal/kserve"
 	"github.com/oremus-labs/ol-model-manager/internal/logutil"
 	"github.com/oremus-labs/ol-model-manager/internal/metrics"
+	"github.com/oremus-labs/ol-model-manager/internal/notify"
 	"github.com/oremus-labs/ol-model-manager/internal/openapi"
+	"github.com/oremus-labs/ol-model-manager/internal/policy"
 	"github.com/oremus-labs/ol-model-manager/internal/queue"
 	"github.com/oremus-labs/ol-model-manager/internal/recommendations"
 	"github.com/oremus-labs/ol-model-manager/internal/secrets"
@@ -45,6 +54,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
+	"golang.org/x/net/websocket"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 )
 
@@ -56,6 +69,9 @@ type Options struct {
 	GitHubToken            string
 	WeightsPVCName         string
 	InferenceModelRoot     string
+	StorageBackend         string
+	StorageBucket          string
+	StoragePrefix          string
 	HistoryLimit           int
 	Version                string
 	CatalogRoot            string
@@ -73,22 +89,55 @@ type Options struct {
 	GPUInventorySource     string
 	SlackWebhookURL        string
 	PVCAlertThreshold      float64
+	DescribeConcurrency    int
+	DescribeTimeout        time.Duration
+	CatalogStatusEnabled   bool
+	CatalogStatusBranch    string
+	RetryBackoffBase       time.Duration
+	RetryBackoffMax        time.Duration
+	ActivationReadyTimeout time.Duration
+	IdempotencyKeyTTL      time.Duration
+	// HFAllowedOrgs, when non-empty, restricts installs to these Hugging Face
+	// organizations. HFDeniedOrgs always wins over the allowlist.
+	HFAllowedOrgs []string
+	HFDeniedOrgs  []string
+	// JobRetention and HistoryRetention are the default max ages used by
+	// CleanupRetention; zero keeps rows forever.
+	JobRetention     time.Duration
+	HistoryRetention time.Duration
+	// WeightEviction lets the install preflight evict least-recently-modified
+	// weights (never the active model's) to make room, keeping at least
+	// WeightEvictionMinFree bytes free after the download.
+	WeightEviction        bool
+	WeightEvictionMinFree int64
+	// GPUInventory lists live node GPU capacity; nil disables
+	// GET /gpu/inventory.
+	GPUInventory GPUInventory
+	// Notifications queues event notifications for delivery, shared with the
+	// job manager. When nil the handler starts its own queue.
+	Notifications *notify.Queue
 }
 
 type weightStore interface {
 	List() ([]weights.WeightInfo, error)
 	Get(string) (*weights.WeightInfo, error)
+	Manifest(string) ([]weights.FileInfo, error)
 	Delete(string) error
 	GetStats() (*weights.StorageStats, error)
 	InstallFromHuggingFace(context.Context, weights.InstallOptions) (*weights.WeightInfo, error)
+	Verify(string, []vllm.HFSibling) (*weights.VerifyResult, error)
+	EvictToFree(int64, []string) ([]weights.WeightInfo, error)
+	PruneCandidates(time.Duration) ([]weights.WeightInfo, error)
 }
 
 type discoveryService interface {
 	ListSupportedArchitectures() ([]vllm.ModelArchitecture, error)
-	GetArchitectureDetail(string) (*vllm.ArchitectureDetail, error)
+	GetArchitectureDetail(string, bool) (*vllm.ArchitectureDetail, error)
 	GenerateModelConfig(vllm.GenerateRequest) (*catalog.Model, error)
 	GetHuggingFaceModel(string) (*vllm.HuggingFaceModel, error)
+	GetHuggingFaceModelRevision(string, string) (*vllm.HuggingFaceModel, error)
 	DescribeModel(string, bool) (*vllm.ModelInsight, error)
+	DescribeModelWithCard(string, bool) (*vllm.ModelInsight, error)
 	SearchModels(vllm.SearchOptions) ([]*vllm.ModelInsight, error)
 }
 
@@ -97,7 +146,10 @@ type catalogValidator interface {
 }
 
 type catalogWriter interface {
+	Load(string) (*catalog.Model, error)
 	Save(*catalog.Model) (*catalogwriter.SaveResult, error)
+	Diff(*catalog.Model) (*catalogwriter.SaveResult, string, error)
+	SaveStatus(catalogwriter.ActiveStatus) (*catalogwriter.SaveResult, error)
 	CommitAndPush(context.Context, string, string, string, ...string) error
 	CreatePullRequest(context.Context, catalogwriter.PullRequestOptions) (*catalogwriter.PullRequest, error)
 }
@@ -106,20 +158,37 @@ type jobManager interface {
 	EnqueueWeightInstall(jobs.InstallRequest) (*store.Job, error)
 	CreateJob(jobs.InstallRequest) (*store.Job, error)
 	ExecuteJob(*store.Job, jobs.InstallRequest)
+	CancelJob(context.Context, string) bool
+}
+
+type jobQueue interface {
+	Enqueue(context.Context, string, jobs.InstallRequest) error
+	Length(context.Context) (int64, error)
+	ListDeadLetter(context.Context, int64) ([]queue.DeadLetterEntry, error)
+	Ping(context.Context) error
 }
 
 type eventBus interface {
 	Publish(context.Context, events.Event) error
 	Subscribe(context.Context) (<-chan events.Event, func(), error)
+	SubscribeFiltered(context.Context, func(events.Event) bool) (<-chan events.Event, func(), error)
+	Replay(after string) ([]events.Event, bool)
+	Recent() []events.Event
 }
 
 type recommendationService interface {
 	Compatibility(*catalog.Model, string) recommendations.CompatibilityReport
+	CompatibilityWithConfig(*catalog.Model, string, map[string]interface{}) recommendations.CompatibilityReport
 	Recommend(string) recommendations.Recommendation
 	RecommendForModel(*catalog.Model, string) recommendations.Recommendation
 	Profiles() []recommendations.GPUProfile
 }
 
+// GPUInventory reports the GPUs cluster nodes can allocate and already use.
+type GPUInventory interface {
+	Inventory(context.Context) (*inventory.Inventory, error)
+}
+
 type secretManager interface {
 	List(context.Context) ([]secrets.Meta, error)
 	Get(context.Context, string) (*secrets.Record, error)
@@ -137,58 +206,105 @@ type runtimeStatusProvider interface {
 	CurrentStatus() status.RuntimeStatus
 }
 
+// multiRuntimeStatusProvider is implemented by providers that watch more than
+// the active InferenceService.
+type multiRuntimeStatusProvider interface {
+	Statuses() []status.RuntimeStatus
+}
+
 type Handler struct {
-	catalog *catalog.Catalog
-	kserve  *kserve.Client
-	weights weightStore
-	vllm    discoveryService
-	checker catalogValidator
-	writer  catalogWriter
-	advisor recommendationService
-	store   *store.Store
-	jobs    jobManager
-	events  eventBus
-	queue   *queue.Producer
-	hfCache huggingFaceCache
-	runtime runtimeStatusProvider
-	secrets secretManager
-	opts    Options
+	catalog  *catalog.Catalog
+	kserve   *kserve.Client
+	weights  weightStore
+	vllm     discoveryService
+	checker  catalogValidator
+	writer   catalogWriter
+	advisor  recommendationService
+	store    *store.Store
+	jobs     jobManager
+	events   eventBus
+	queue    jobQueue
+	hfCache  huggingFaceCache
+	runtime  runtimeStatusProvider
+	secrets  secretManager
+	notifier *notify.Dispatcher
+	// notifications bounds the events waiting for notifier delivery.
+	notifications *notify.Queue
+	opts          Options
 
 	catalogMu          sync.Mutex
 	lastCatalogRefresh time.Time
 	catalogStatus      string
 	catalogCacheTime   time.Time
-	pvcAlertActive     bool
+	// catalogLoaded and catalogLoadErr record the outcome of the last load
+	// attempt so readiness probes don't reload the catalog themselves.
+	catalogLoaded  bool
+	catalogLoadErr error
+	// catalogWatching is read outside catalogMu (e.g. by the status endpoint).
+	catalogWatching atomic.Bool
+	// catalogHash is the hash of the last snapshot persisted to the store.
+	catalogHash string
+	// catalogVersion is the content hash of the loaded catalog; list ETags
+	// derive from it.
+	catalogVersion string
+
+	alertMu        sync.Mutex
+	pvcAlertActive bool
+
+	// activationMu serializes activations within this replica; the datastore
+	// lease in lockActivation covers other replicas.
+	activationMu sync.Mutex
 }
 
 // AuthMiddleware enforces either the static token or datastore-issued tokens.
 func (h *Handler) AuthMiddleware(staticToken string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token := strings.TrimSpace(getBearerToken(c))
-		if token == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-			return
-		}
-		if staticToken != "" && token == staticToken {
-			c.Next()
+		if errMsg := h.authenticate(c, staticToken); errMsg != "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": errMsg})
 			return
 		}
-		if h.store != nil {
-			rec, err := h.store.LookupAPITokenByHash(store.HashToken(token))
-			if err == nil && rec != nil {
-				if rec.ExpiresAt != nil && time.Now().After(*rec.ExpiresAt) {
-					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token expired"})
-					return
-				}
-				_ = h.store.TouchAPIToken(rec.ID)
-				c.Set("apiTokenId", rec.ID)
-				c.Set("apiTokenName", rec.Name)
-				c.Next()
-				return
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware records the caller's token like AuthMiddleware when a
+// valid one is supplied, but lets anonymous requests through. Handlers check
+// the apiAuthenticated context key before allowing privileged operations.
+func (h *Handler) OptionalAuthMiddleware(staticToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = h.authenticate(c, staticToken)
+		c.Next()
+	}
+}
+
+// authenticate validates the request's bearer token and, on success, stores
+// apiAuthenticated (plus the token's ID, name, and scopes for datastore-issued
+// tokens) on the context. It returns an error message when the token is
+// missing or invalid.
+func (h *Handler) authenticate(c *gin.Context, staticToken string) string {
+	token := strings.TrimSpace(getBearerToken(c))
+	if token == "" {
+		return "unauthorized"
+	}
+	if staticToken != "" && token == staticToken {
+		c.Set("apiAuthenticated", true)
+		return ""
+	}
+	if h.store != nil {
+		rec, err := h.store.LookupAPITokenByHash(store.HashToken(token))
+		if err == nil && rec != nil {
+			if rec.ExpiresAt != nil && time.Now().After(*rec.ExpiresAt) {
+				return "token expired"
 			}
+			_ = h.store.TouchAPIToken(rec.ID)
+			c.Set("apiAuthenticated", true)
+			c.Set("apiTokenId", rec.ID)
+			c.Set("apiTokenName", rec.Name)
+			c.Set("apiTokenScopes", rec.Scopes)
+			return ""
 		}
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 	}
+	return "unauthorized"
 }
 
 func getBearerToken(c *gin.Context) string {
@@ -228,8 +344,26 @@ func newRequestError(code int, message string, err error) *requestError {
 
 var errModelNotFound = errors.New("model not found")
 
+// errActivationInProgress is returned when another activation holds the
+// activation lock.
+var errActivationInProgress = errors.New("activation in progress")
+
+const (
+	activationLockName = "activation"
+	// activationLockTTL bounds how long a crashed replica can block others;
+	// waitForReady activations extend it by their readiness timeout.
+	activationLockTTL = 2 * time.Minute
+)
+
+// maxModelsPageSize caps the limit accepted by GET /models.
+const maxModelsPageSize = 500
+
+// modelTimelineScan caps how many recent history entries and jobs
+// GET /models/:id/events searches for the model.
+const modelTimelineScan = 1000
+
 // New creates a new Handler instance.
-func New(cat *catalog.Catalog, ks *kserve.Client, wm weightStore, vdisc discoveryService, val catalogValidator, writer catalogWriter, advisor recommendationService, dataStore *store.Store, jobMgr jobManager, evt eventBus, q *queue.Producer, hfCache huggingFaceCache, runtime runtimeStatusProvider, secretMgr secretManager, opts Options) *Handler {
+func New(cat *catalog.Catalog, ks *kserve.Client, wm weightStore, vdisc discoveryService, val catalogValidator, writer catalogWriter, advisor recommendationService, dataStore *store.Store, jobMgr jobManager, evt eventBus, q jobQueue, hfCache huggingFaceCache, runtime runtimeStatusProvider, secretMgr secretManager, opts Options) *Handler {
 	if opts.CatalogTTL <= 0 {
 		opts.CatalogTTL = time.Minute
 	}
@@ -266,10 +400,42 @@ func New(cat *catalog.Catalog, ks *kserve.Client, wm weightStore, vdisc discover
 	if opts.PVCAlertThreshold <= 0 {
 		opts.PVCAlertThreshold = 0.85
 	}
+	if opts.DescribeConcurrency <= 0 {
+		opts.DescribeConcurrency = 4
+	}
+	if opts.DescribeTimeout <= 0 {
+		opts.DescribeTimeout = 10 * time.Second
+	}
+	if opts.ActivationReadyTimeout <= 0 {
+		opts.ActivationReadyTimeout = 10 * time.Minute
+	}
+	if opts.IdempotencyKeyTTL <= 0 {
+		opts.IdempotencyKeyTTL = 24 * time.Hour
+	}
 
 	if advisor != nil && isNilInterface(advisor) {
 		advisor = nil
 	}
+	if writer != nil && isNilInterface(writer) {
+		writer = nil
+	}
+	if q != nil && isNilInterface(q) {
+		q = nil
+	}
+	if opts.GPUInventory != nil && isNilInterface(opts.GPUInventory) {
+		opts.GPUInventory = nil
+	}
+
+	notifyOpts := notify.Options{SlackWebhookURL: opts.SlackWebhookURL}
+	if dataStore != nil {
+		notifyOpts.Store = dataStore
+	}
+
+	notifier := notify.New(notifyOpts)
+	notifications := opts.Notifications
+	if notifications == nil {
+		notifications = notify.NewQueue(notifier, notify.DefaultQueueSize)
+	}
 
 	return &Handler{
 		catalog:            cat,
@@ -286,6 +452,8 @@ func New(cat *catalog.Catalog, ks *kserve.Client, wm weightStore, vdisc discover
 		hfCache:            hfCache,
 		runtime:            runtime,
 		secrets:            secretMgr,
+		notifier:           notifier,
+		notifications:      notifications,
 		opts:               opts,
 		lastCatalogRefresh: time.Time{},
 		catalogStatus:      "unknown",
@@ -305,14 +473,81 @@ type searchResult struct {
 }
 
 type activateRequest struct {
-	ID string `json:"id" binding:"required"`
+	ID                  string `json:"id" binding:"required"`
+	CatalogHash         string `json:"catalogHash,omitempty"`
+	Force               bool   `json:"force,omitempty"`
+	WaitForReady        bool   `json:"waitForReady,omitempty"`
+	ReadyTimeoutSeconds int    `json:"readyTimeoutSeconds,omitempty"`
 }
 
 type runtimeActivateRequest struct {
-	ModelID        string `json:"modelId" binding:"required"`
-	Strategy       string `json:"strategy,omitempty"`
-	TrafficPercent int    `json:"trafficPercent,omitempty"`
-	Force          bool   `json:"force,omitempty"`
+	ModelID             string `json:"modelId" binding:"required"`
+	CatalogHash         string `json:"catalogHash,omitempty"`
+	Strategy            string `json:"strategy,omitempty"`
+	TrafficPercent      int    `json:"trafficPercent,omitempty"`
+	Force               bool   `json:"force,omitempty"`
+	WaitForReady        bool   `json:"waitForReady,omitempty"`
+	ReadyTimeoutSeconds int    `json:"readyTimeoutSeconds,omitempty"`
+}
+
+// activationOptions tune how activateModelInternal treats the catalog entry.
+// With waitForReady set it blocks until the InferenceService reports Ready and
+// rolls back to the previously active model if that does not happen within
+// readyTimeout.
+type activationOptions struct {
+	catalogHash    string
+	force          bool
+	strategy       string
+	trafficPercent int
+	waitForReady   bool
+	readyTimeout   time.Duration
+	// lockHeld means the caller already holds the activation lock.
+	lockHeld bool
+}
+
+// activationRollbackError reports an activation that never became ready and
+// was rolled back.
+type activationRollbackError struct {
+	modelID     string
+	previousID  string
+	timeout     time.Duration
+	rollbackErr error
+}
+
+func (e *activationRollbackError) Error() string {
+	switch {
+	case e.rollbackErr != nil:
+		return fmt.Sprintf("%s did not become ready within %s and rollback to %s failed: %v", e.modelID, e.timeout, e.previousID, e.rollbackErr)
+	case e.previousID == "":
+		return fmt.Sprintf("%s did not become ready within %s; no previous model to roll back to", e.modelID, e.timeout)
+	default:
+		return fmt.Sprintf("%s did not become ready within %s; rolled back to %s", e.modelID, e.timeout, e.previousID)
+	}
+}
+
+func (e *activationRollbackError) rolledBack() bool {
+	return e.previousID != "" && e.rollbackErr == nil
+}
+
+type catalogConflictError struct {
+	modelID  string
+	expected string
+	current  string
+}
+
+func (e *catalogConflictError) Error() string {
+	return fmt.Sprintf("catalog entry for %s changed since it was reviewed", e.modelID)
+}
+
+// modelRetiredError blocks activation of a model whose catalog lifecycle is
+// retired.
+type modelRetiredError struct {
+	modelID string
+	message string
+}
+
+func (e *modelRetiredError) Error() string {
+	return fmt.Sprintf("model %s is retired and cannot be activated", e.modelID)
 }
 
 type runtimePromoteRequest struct {
@@ -329,10 +564,20 @@ type installWeightsRequest struct {
 	Target    string   `json:"target,omitempty"`
 	Files     []string `json:"files,omitempty"`
 	Overwrite bool     `json:"overwrite"`
+	// FallbackRevisions are tried in order when revision is missing the
+	// requested files (e.g. a broken main but a working tag).
+	FallbackRevisions []string `json:"fallbackRevisions,omitempty"`
+	// Priority orders the queued job; higher values are processed first.
+	Priority int `json:"priority,omitempty"`
+	// IdempotencyKey comes from the Idempotency-Key header.
+	IdempotencyKey string `json:"-"`
 }
 
 type installScheduleResult struct {
-	Async         bool
+	Async bool
+	// Replayed is set when an Idempotency-Key matched an earlier request and
+	// Job is the job that request created.
+	Replayed      bool
 	Job           *store.Job
 	Weight        *weights.WeightInfo
 	Target        string
@@ -345,8 +590,9 @@ type deleteWeightsRequest struct {
 }
 
 type modelInfoRequest struct {
-	HFModelID  string `json:"hfModelId" binding:"required"`
-	AutoDetect bool   `json:"autoDetect"`
+	HFModelID   string `json:"hfModelId" binding:"required"`
+	AutoDetect  bool   `json:"autoDetect"`
+	IncludeCard bool   `json:"includeCard"`
 }
 
 type testModelRequest struct {
@@ -377,6 +623,7 @@ type playbookInstallStep struct {
 type playbookActivateStep struct {
 	ModelID        string `json:"modelId"`
 	Strategy       string `json:"strategy,omitempty"`
+	TrafficPercent int    `json:"trafficPercent,omitempty"`
 	WaitForInstall bool   `json:"waitForInstall"`
 }
 
@@ -431,58 +678,11 @@ func (h *Handler) StreamEvents(c *gin.Context) {
 	c.Writer.Header().Set("Cache-Control", "no-cache")
 	c.Writer.Header().Set("Connection", "keep-alive")
 
-	out := make(chan events.Event, 32)
-
-	if h.store != nil {
-		if jobs, err := h.store.ListJobs(5); err == nil && len(jobs) > 0 {
-			seedID := fmt.Sprintf("seed-%d", time.Now().UnixNano())
-			meta := gin.H{"count": len(jobs)}
-			now := time.Now().UTC()
-			out <- events.Event{
-				ID:        seedID,
-				Type:      "stream.seed.start",
-				Timestamp: now,
-				Data:      meta,
-			}
-			for i := len(jobs) - 1; i >= 0; i-- {
-				job := jobs[i]
-				evtTime := job.UpdatedAt
-				if evtTime.IsZero() {
-					evtTime = job.CreatedAt
-				}
-				out <- events.Event{
-					ID:        job.ID,
-					Type:      fmt.Sprintf("job.%s", job.Status),
-					Timestamp: evtTime,
-					Data:      job,
-				}
-			}
-			out <- events.Event{
-				ID:        seedID + ".complete",
-				Type:      "stream.seed.complete",
-				Timestamp: time.Now().UTC(),
-				Data:      meta,
-			}
-		}
-	}
-
-	eventStream, unsubscribe, err := h.events.Subscribe(ctx)
+	out, err := h.subscribeEventStream(ctx, lastEventID(c), eventTypeFilter(c))
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to subscribe"})
 		return
 	}
-	defer unsubscribe()
-
-	go func() {
-		for evt := range eventStream {
-			select {
-			case out <- evt:
-			case <-ctx.Done():
-				return
-			}
-		}
-		close(out)
-	}()
 
 	c.Stream(func(w io.Writer) bool {
 		select {
@@ -491,8 +691,14 @@ func (h *Handler) StreamEvents(c *gin.Context) {
 				return false
 			}
 			metrics.ObserveSSEEvent(evt.Type)
+			// Bus events carry their stream id as the SSE id so a
+			// reconnecting browser resumes via Last-Event-ID.
+			id := evt.ID
+			if evt.StreamID != "" {
+				id = evt.StreamID
+			}
 			c.Render(-1, sse.Event{
-				Id:    evt.ID,
+				Id:    id,
 				Event: evt.Type,
 				Data:  evt,
 			})
@@ -503,11 +709,352 @@ func (h *Handler) StreamEvents(c *gin.Context) {
 	})
 }
 
+// StreamEventsWebSocket streams the same events as StreamEvents over a
+// WebSocket, one JSON-encoded events.Event per text frame, for clients and
+// proxies that buffer text/event-stream.
+func (h *Handler) StreamEventsWebSocket(c *gin.Context) {
+	if h.events == nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "event streaming unavailable"})
+		return
+	}
+
+	server := websocket.Server{
+		// Accept any Origin; the endpoint is as public as the SSE stream.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+			// The hijacked connection keeps the server's read/write
+			// deadlines, which would cut a long-lived stream short.
+			_ = conn.SetDeadline(time.Time{})
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+
+			releaseGauge := metrics.TrackWebSocketConnection()
+			logutil.Info("ws_stream_opened", map[string]interface{}{
+				"clientIp":  c.ClientIP(),
+				"userAgent": c.Request.UserAgent(),
+			})
+			defer func() {
+				releaseGauge()
+				fields := map[string]interface{}{
+					"clientIp": c.ClientIP(),
+				}
+				if err := ctx.Err(); err != nil {
+					fields["disconnectReason"] = err.Error()
+				}
+				logutil.Info("ws_stream_closed", fields)
+			}()
+
+			out, err := h.subscribeEventStream(ctx, lastEventID(c), eventTypeFilter(c))
+			if err != nil {
+				_ = websocket.JSON.Send(conn, gin.H{"error": "failed to subscribe"})
+				return
+			}
+
+			// Clients never send anything meaningful; a failed read means the
+			// peer went away.
+			go func() {
+				var discard string
+				for websocket.Message.Receive(conn, &discard) == nil {
+				}
+				cancel()
+			}()
+
+			for {
+				select {
+				case evt, ok := <-out:
+					if !ok {
+						return
+					}
+					if err := websocket.JSON.Send(conn, evt); err != nil {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// subscribeEventStream subscribes to the event bus for a streaming client.
+// A client resuming after resumeAfter (a bus stream id) first receives the
+// buffered events it missed. When that event is no longer buffered the client
+// gets a stream.reset event instead, telling it to refetch state, followed
+// by the job seed; new clients just get the most recent jobs bracketed by
+// stream.seed.start/complete. Live events follow until ctx ends or the bus
+// closes. A non-nil match limits every event, seeded or live, to the ones it
+// accepts.
+func (h *Handler) subscribeEventStream(ctx context.Context, resumeAfter string, match func(events.Event) bool) (<-chan events.Event, error) {
+	eventStream, unsubscribe, err := h.events.SubscribeFiltered(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribing before reading the backlog means nothing published in
+	// between is lost; live events already replayed are skipped below.
+	var backlog []events.Event
+	replayed := make(map[string]struct{})
+	resumeAfter = strings.TrimSpace(resumeAfter)
+	missed, ok := h.events.Replay(resumeAfter)
+	switch {
+	case resumeAfter != "" && ok:
+		for _, evt := range missed {
+			replayed[evt.StreamID] = struct{}{}
+			if match == nil || match(evt) {
+				backlog = append(backlog, evt)
+			}
+		}
+	case resumeAfter != "":
+		backlog = append([]events.Event{{
+			ID:        fmt.Sprintf("reset-%d", time.Now().UnixNano()),
+			Type:      "stream.reset",
+			Timestamp: time.Now().UTC(),
+			Data: gin.H{
+				"lastEventId": resumeAfter,
+				"reason":      "the last event received is no longer buffered; refetch current state",
+			},
+		}}, h.jobSeedEvents(match)...)
+	default:
+		backlog = h.jobSeedEvents(match)
+	}
+
+	out := make(chan events.Event, 32)
+	go func() {
+		defer unsubscribe()
+		defer close(out)
+		send := func(evt events.Event) bool {
+			select {
+			case out <- evt:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for _, evt := range backlog {
+			if !send(evt) {
+				return
+			}
+		}
+		for evt := range eventStream {
+			if _, ok := replayed[evt.StreamID]; ok && evt.StreamID != "" {
+				continue
+			}
+			if !send(evt) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// jobSeedEvents describes the five most recent jobs so new stream clients
+// start with current job state. Job events rejected by match are left out, as
+// are the seed markers when no job event remains.
+func (h *Handler) jobSeedEvents(match func(events.Event) bool) []events.Event {
+	if h.store == nil {
+		return nil
+	}
+	jobs, err := h.store.ListJobs(5)
+	if err != nil || len(jobs) == 0 {
+		return nil
+	}
+	var jobEvents []events.Event
+	for i := len(jobs) - 1; i >= 0; i-- {
+		evt := events.NewJobEvent(&jobs[i])
+		if match == nil || match(evt) {
+			jobEvents = append(jobEvents, evt)
+		}
+	}
+	if len(jobEvents) == 0 {
+		return nil
+	}
+	seedID := fmt.Sprintf("seed-%d", time.Now().UnixNano())
+	meta := gin.H{"count": len(jobEvents)}
+	seed := append([]events.Event{{
+		ID:        seedID,
+		Type:      "stream.seed.start",
+		Timestamp: time.Now().UTC(),
+		Data:      meta,
+	}}, jobEvents...)
+	return append(seed, events.Event{
+		ID:        seedID + ".complete",
+		Type:      "stream.seed.complete",
+		Timestamp: time.Now().UTC(),
+		Data:      meta,
+	})
+}
+
+// eventTypeFilter builds a matcher from the comma-separated "types" query
+// parameter: an event passes when its type starts with any listed prefix. It
+// returns nil (no filtering) when the parameter is absent.
+func eventTypeFilter(c *gin.Context) func(events.Event) bool {
+	var prefixes []string
+	for _, raw := range c.QueryArray("types") {
+		for _, prefix := range strings.Split(raw, ",") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+	if len(prefixes) == 0 {
+		return nil
+	}
+	return func(evt events.Event) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(evt.Type, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// lastEventID returns the event a reconnecting client saw last, from the SSE
+// Last-Event-ID header or the lastEventId query parameter (WebSocket clients
+// can't set the header).
+func lastEventID(c *gin.Context) string {
+	if id := c.GetHeader("Last-Event-ID"); id != "" {
+		return id
+	}
+	return c.Query("lastEventId")
+}
+
 // Health returns the health status of the service.
 func (h *Handler) Health(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
+// readinessCheckTimeout bounds each dependency probe in Ready.
+const readinessCheckTimeout = 2 * time.Second
+
+// Ready reports whether the server's dependencies are usable: the datastore
+// and Redis answer a ping and the last catalog load produced entries (live or
+// restored from a snapshot). Any failing dependency turns the response into a
+// 503.
+func (h *Handler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	checks := gin.H{}
+	ready := true
+	record := func(name string, err error) {
+		if err != nil {
+			ready = false
+			checks[name] = gin.H{"status": "error", "error": err.Error()}
+			return
+		}
+		checks[name] = gin.H{"status": "ok"}
+	}
+
+	if h.store != nil {
+		record("datastore", h.store.Ping(ctx))
+	} else {
+		checks["datastore"] = gin.H{"status": "disabled"}
+	}
+	if h.queue != nil {
+		record("redis", h.queue.Ping(ctx))
+	} else {
+		checks["redis"] = gin.H{"status": "disabled"}
+	}
+	record("catalog", h.catalogReady())
+
+	code := http.StatusOK
+	status := "ready"
+	if !ready {
+		code = http.StatusServiceUnavailable
+		status = "degraded"
+	}
+	c.JSON(code, gin.H{"status": status, "checks": checks})
+}
+
+// catalogReady reports the outcome of the last catalog load without
+// triggering a reload; KeepCatalogFresh and the watcher keep it current.
+func (h *Handler) catalogReady() error {
+	if h.catalog == nil {
+		return errors.New("catalog not configured")
+	}
+	h.catalogMu.Lock()
+	loaded, loadErr := h.catalogLoaded, h.catalogLoadErr
+	h.catalogMu.Unlock()
+	switch {
+	case loadErr != nil:
+		return fmt.Errorf("catalog failed to load: %w", loadErr)
+	case !loaded:
+		return errors.New("catalog has not been loaded yet")
+	case h.catalog.Count() == 0:
+		return errors.New("catalog is empty and no snapshot is available")
+	}
+	return nil
+}
+
+// respondBodyReadError reports a failed request body read, using 413 when the
+// body exceeded the server's size limit.
+func respondBodyReadError(c *gin.Context, err error, message string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": message})
+}
+
+// recentGCPauses is how many of the latest GC pauses SystemRuntime reports.
+const recentGCPauses = 10
+
+// SystemRuntime reports Go runtime statistics (goroutines, heap, and GC
+// pauses) for diagnosing memory growth in-cluster.
+func (h *Handler) SystemRuntime(c *gin.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	// PauseNs is a ring buffer; the most recent pause is at (NumGC+255)%256.
+	count := int(mem.NumGC)
+	if count > recentGCPauses {
+		count = recentGCPauses
+	}
+	pauses := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		idx := (int(mem.NumGC) - 1 - i + len(mem.PauseNs)) % len(mem.PauseNs)
+		pauses = append(pauses, time.Duration(mem.PauseNs[idx]).String())
+	}
+	var lastGC interface{}
+	if mem.LastGC > 0 {
+		lastGC = time.Unix(0, int64(mem.LastGC)).UTC()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"goVersion":  runtime.Version(),
+		"goroutines": runtime.NumGoroutine(),
+		"cpus":       runtime.NumCPU(),
+		"heap": gin.H{
+			"allocBytes":    mem.HeapAlloc,
+			"inuseBytes":    mem.HeapInuse,
+			"idleBytes":     mem.HeapIdle,
+			"releasedBytes": mem.HeapReleased,
+			"sysBytes":      mem.HeapSys,
+			"objects":       mem.HeapObjects,
+			"allocHuman":    weights.FormatBytes(int64(mem.HeapAlloc)),
+		},
+		"memory": gin.H{
+			"totalAllocBytes": mem.TotalAlloc,
+			"sysBytes":        mem.Sys,
+			"mallocs":         mem.Mallocs,
+			"frees":           mem.Frees,
+		},
+		"gc": gin.H{
+			"count":        mem.NumGC,
+			"forcedCount":  mem.NumForcedGC,
+			"nextGCBytes":  mem.NextGC,
+			"lastGC":       lastGC,
+			"pauseTotal":   time.Duration(mem.PauseTotalNs).String(),
+			"recentPauses": pauses,
+			"cpuFraction":  mem.GCCPUFraction,
+		},
+	})
+}
+
 // SystemInfo exposes metadata for UI bootstrapping.
 func (h *Handler) SystemInfo(c *gin.Context) {
 	if err := h.ensureCatalogFresh(false); err != nil {
@@ -522,12 +1069,16 @@ func (h *Handler) SystemInfo(c *gin.Context) {
 		"status":      h.catalogStatus,
 		"lastPersist": h.catalogCacheTime,
 		"source":      "git",
+		"watching":    h.catalogWatching.Load(),
 	}
 	if h.catalogStatus == "cache" {
 		catalogInfo["source"] = "datastore"
 	}
 	if h.catalog != nil {
 		catalogInfo["count"] = h.catalog.Count()
+		if loadErrors := h.catalog.LoadErrors(); len(loadErrors) > 0 {
+			catalogInfo["loadErrors"] = loadErrors
+		}
 	}
 
 	info := gin.H{
@@ -652,6 +1203,19 @@ func (h *Handler) buildSystemSummary(ctx context.Context) (gin.H, *weights.Stora
 	if h.runtime != nil {
 		summary["runtime"] = h.runtime.CurrentStatus()
 	}
+	if h.opts.GPUInventory != nil {
+		if inv, err := h.opts.GPUInventory.Inventory(ctx); err == nil {
+			summary["gpus"] = gin.H{
+				"nodes": len(inv.Nodes),
+				"total": inv.Total,
+				"used":  inv.Used,
+				"free":  inv.Free,
+				"types": inv.Types,
+			}
+		} else {
+			log.Printf("Failed to collect GPU inventory: %v", err)
+		}
+	}
 
 	jobCard := gin.H{}
 	if h.store != nil {
@@ -766,7 +1330,7 @@ func (h *Handler) Search(c *gin.Context) {
 	}
 	sort.Strings(typesList)
 
-	h.recordHistory("search_performed", "", map[string]interface{}{
+	h.recordHistory(c.Request.Context(), "search_performed", "", map[string]interface{}{
 		"query":   query,
 		"types":   typesList,
 		"results": len(results),
@@ -785,7 +1349,7 @@ func (h *Handler) SupportBundle(c *gin.Context) {
 		return
 	}
 
-	h.recordHistory("support_bundle", "", map[string]interface{}{
+	h.recordHistory(c.Request.Context(), "support_bundle", "", map[string]interface{}{
 		"filename": filename,
 		"size":     buf.Len(),
 	})
@@ -818,7 +1382,42 @@ func (h *Handler) ListModels(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, h.catalog.All())
+	offset := 0
+	if raw := strings.TrimSpace(c.Query("offset")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = n
+	}
+	limit := parseLimit(c, "limit", 0, maxModelsPageSize)
+	lifecycle := strings.ToLower(strings.TrimSpace(c.Query("lifecycle")))
+	if !catalog.ValidLifecycle(lifecycle) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lifecycle must be one of active, deprecated, retired"})
+		return
+	}
+
+	models, total := h.catalog.Search(catalog.SearchOptions{
+		Query:     c.Query("q"),
+		Runtime:   strings.TrimSpace(c.Query("runtime")),
+		Tags:      c.QueryArray("tag"),
+		Lifecycle: lifecycle,
+		Offset:    offset,
+		Limit:     limit,
+	})
+	resp := gin.H{"models": models, "total": total}
+	if next := offset + len(models); limit > 0 && next < total {
+		resp["nextOffset"] = next
+	}
+	if version := h.currentCatalogVersion(); version != "" {
+		// The listing depends on the query, so fold it into the tag.
+		sum := sha256.Sum256([]byte(version + "?" + c.Request.URL.RawQuery))
+		if notModified(c, "W/"+strconv.Quote(hex.EncodeToString(sum[:16]))) {
+			return
+		}
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // GetModel returns details for a specific model.
@@ -836,9 +1435,31 @@ func (h *Handler) GetModel(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, strconv.Quote(catalog.ContentHash(model))) {
+		return
+	}
 	c.JSON(http.StatusOK, model)
 }
 
+// notModified sets the ETag header and, when the request's If-None-Match
+// matches it (weak comparison), responds 304 and returns true.
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	want := normalizeCatalogHash(etag)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || normalizeCatalogHash(candidate) == want {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
 // ActivateModel activates a model by creating/updating the InferenceService.
 func (h *Handler) ActivateModel(c *gin.Context) {
 	var req activateRequest
@@ -846,17 +1467,22 @@ func (h *Handler) ActivateModel(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	model, result, err := h.activateModelInternal(c.GetString("subject"), req.ID)
+	model, result, err := h.activateModelInternal(c.Request.Context(), c.GetString("subject"), req.ID, activationOptions{
+		catalogHash:  req.CatalogHash,
+		force:        req.Force,
+		waitForReady: req.WaitForReady,
+		readyTimeout: time.Duration(req.ReadyTimeoutSeconds) * time.Second,
+	})
 	if err != nil {
 		h.respondActivationError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, addLifecycleWarning(gin.H{
 		"status":           "success",
-		"message":          "Model " + req.ID + " activated",
+		"message":          "Model " + model.ID + " activated",
 		"model":            model,
 		"inferenceservice": result,
-	})
+	}, model))
 }
 
 // RuntimeActivate activates a model with runtime metadata/strategy hints.
@@ -866,59 +1492,210 @@ func (h *Handler) RuntimeActivate(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	model, result, err := h.activateModelInternal(c.GetString("subject"), req.ModelID)
+	model, result, err := h.activateModelInternal(c.Request.Context(), c.GetString("subject"), req.ModelID, activationOptions{
+		catalogHash:    req.CatalogHash,
+		force:          req.Force,
+		strategy:       req.Strategy,
+		trafficPercent: req.TrafficPercent,
+		waitForReady:   req.WaitForReady,
+		readyTimeout:   time.Duration(req.ReadyTimeoutSeconds) * time.Second,
+	})
 	if err != nil {
 		h.respondActivationError(c, err)
 		return
 	}
-	strategy := strings.TrimSpace(req.Strategy)
-	if strategy == "" {
-		strategy = "direct"
-	}
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"status":           "success",
-		"strategy":         strategy,
+		"strategy":         result.Strategy,
 		"model":            model,
 		"inferenceservice": result,
-	})
+	}
+	if result.TrafficPercent > 0 {
+		response["trafficPercent"] = result.TrafficPercent
+	}
+	c.JSON(http.StatusOK, addLifecycleWarning(response, model))
 }
 
-// RuntimePromote promotes a staged model to active.
-func (h *Handler) RuntimePromote(c *gin.Context) {
-	var req runtimePromoteRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+// RuntimeRollback re-activates the model that was running before the active
+// one, as recorded in the model_activated history entry. The activation lock
+// is held from reading the current model until the rollback is applied.
+func (h *Handler) RuntimeRollback(c *gin.Context) {
+	if h.store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
 		return
 	}
-	currentID, err := h.currentRuntimeModelID()
+	unlock, err := h.lockActivation(activationLockTTL)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to inspect current runtime"})
+		h.respondActivationError(c, err)
 		return
 	}
-	if req.CurrentID != "" && currentID != "" && req.CurrentID != currentID {
-		c.JSON(http.StatusConflict, gin.H{
-			"error":        "active model mismatch",
-			"expected":     req.CurrentID,
-			"currentModel": currentID,
-		})
+	defer unlock()
+
+	current, err := h.currentRuntimeModelID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	model, result, err := h.activateModelInternal(c.GetString("subject"), req.CandidateID)
+	if current == "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "no model is active"})
+		return
+	}
+	previousID, err := h.previousModelID(current)
+	if err != nil {
+		log.Printf("Failed to list history: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load history"})
+		return
+	}
+	if previousID == "" {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "no previous model recorded for the active runtime",
+			"modelId": current,
+		})
+		return
+	}
+	ctx := c.Request.Context()
+	model, result, err := h.activateModelInternal(ctx, c.GetString("subject"), previousID, activationOptions{force: true, lockHeld: true})
 	if err != nil {
 		h.respondActivationError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{
+	rolledBack := events.RollbackCompleted{ModelID: previousID, RolledBackFrom: current}
+	h.recordHistory(ctx, "model_rolled_back", previousID, events.Fields(rolledBack))
+	h.publishEvent(ctx, rolledBack)
+	c.JSON(http.StatusOK, addLifecycleWarning(gin.H{
+		"status":           "rolled_back",
+		"rolledBackFrom":   current,
+		"model":            model,
+		"inferenceservice": result,
+	}, model))
+}
+
+// previousModelID returns the previousModelId recorded by the latest
+// activation of current, or "" when none is on record.
+func (h *Handler) previousModelID(current string) (string, error) {
+	entry, err := h.store.LatestHistory("model_activated")
+	if err != nil || entry == nil {
+		return "", err
+	}
+	if entry.ModelID != current {
+		// The runtime changed without an activation we recorded.
+		return "", nil
+	}
+	previous, _ := entry.Metadata["previousModelId"].(string)
+	return previous, nil
+}
+
+// RuntimePromote promotes a staged model to active.
+func (h *Handler) RuntimePromote(c *gin.Context) {
+	var req runtimePromoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	currentID, err := h.currentRuntimeModelID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to inspect current runtime"})
+		return
+	}
+	if req.CurrentID != "" && currentID != "" && req.CurrentID != currentID {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":        "active model mismatch",
+			"expected":     req.CurrentID,
+			"currentModel": currentID,
+		})
+		return
+	}
+	if currentID != "" && currentID == req.CandidateID {
+		if percent, splitting, err := h.kserve.CanaryTrafficPercent(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		} else if splitting && percent < 100 {
+			h.promoteCanary(c, req)
+			return
+		}
+	}
+	model, result, err := h.activateModelInternal(c.Request.Context(), c.GetString("subject"), req.CandidateID, activationOptions{
+		force:          req.Force,
+		strategy:       req.Strategy,
+		trafficPercent: req.TrafficPercent,
+	})
+	if err != nil {
+		h.respondActivationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, addLifecycleWarning(gin.H{
 		"status":           "promoted",
 		"previousModelId":  currentID,
 		"model":            model,
 		"inferenceservice": result,
+	}, model))
+}
+
+// promoteCanary shifts all traffic to a canary revision once the
+// InferenceService reports Ready (force skips the readiness check). It holds
+// the activation lock so the split can't change underneath it.
+func (h *Handler) promoteCanary(c *gin.Context, req runtimePromoteRequest) {
+	unlock, err := h.lockActivation(activationLockTTL)
+	if err != nil {
+		h.respondActivationError(c, err)
+		return
+	}
+	defer unlock()
+
+	if currentID, err := h.currentRuntimeModelID(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to inspect current runtime"})
+		return
+	} else if currentID != req.CandidateID {
+		c.JSON(http.StatusConflict, gin.H{"error": "active model mismatch", "expected": req.CandidateID, "currentModel": currentID})
+		return
+	}
+	// Re-read the split under the lock; it may have moved since RuntimePromote
+	// looked.
+	previousPercent, splitting, err := h.kserve.CanaryTrafficPercent()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !splitting || previousPercent >= 100 {
+		c.JSON(http.StatusConflict, gin.H{"error": "canary is no longer in progress", "modelId": req.CandidateID})
+		return
+	}
+
+	if !req.Force {
+		isvc, err := h.kserve.GetActive()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !kserve.IsReady(isvc) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":          "canary is not ready",
+				"modelId":        req.CandidateID,
+				"trafficPercent": previousPercent,
+				"hint":           "wait for the InferenceService to report Ready or retry with force=true",
+			})
+			return
+		}
+	}
+	result, err := h.kserve.SetTrafficPercent(100)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	promoted := events.CanaryPromoted{ModelID: req.CandidateID, PreviousPercent: previousPercent, TrafficPercent: 100}
+	h.recordHistory(c.Request.Context(), "model_canary_promoted", req.CandidateID, events.Fields(promoted))
+	h.publishEvent(c.Request.Context(), promoted)
+	c.JSON(http.StatusOK, gin.H{
+		"status":           "promoted",
+		"strategy":         kserve.StrategyCanary,
+		"model":            h.catalog.Get(req.CandidateID),
+		"inferenceservice": result,
 	})
 }
 
 // RuntimeDeactivate deactivates the runtime for CLI/UI callers.
 func (h *Handler) RuntimeDeactivate(c *gin.Context) {
-	result, err := h.deactivateRuntime(c.GetString("subject"))
+	result, err := h.deactivateRuntime(c.Request.Context(), c.GetString("subject"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -932,7 +1709,7 @@ func (h *Handler) RuntimeDeactivate(c *gin.Context) {
 
 // DeactivateModel deactivates the active model.
 func (h *Handler) DeactivateModel(c *gin.Context) {
-	result, err := h.deactivateRuntime(c.GetString("subject"))
+	result, err := h.deactivateRuntime(c.Request.Context(), c.GetString("subject"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -944,7 +1721,7 @@ func (h *Handler) DeactivateModel(c *gin.Context) {
 	})
 }
 
-func (h *Handler) activateModelInternal(subject, modelID string) (*catalog.Model, *kserve.Result, error) {
+func (h *Handler) activateModelInternal(ctx context.Context, subject, modelID string, opts activationOptions) (*catalog.Model, *kserve.Result, error) {
 	if err := h.ensureCatalogFresh(true); err != nil {
 		return nil, nil, err
 	}
@@ -952,44 +1729,310 @@ func (h *Handler) activateModelInternal(subject, modelID string) (*catalog.Model
 	if model == nil {
 		return nil, nil, errModelNotFound
 	}
-	meta := gin.H{
-		"modelId":     modelID,
-		"displayName": modelDisplayName(model),
-		"storageUri":  model.StorageURI,
-		"runtime":     model.Runtime,
-		"hfModelId":   model.HFModelID,
-		"requestedBy": subject,
-		"requestedAt": time.Now().UTC(),
+	// modelID may be an alias; record everything under the canonical ID.
+	modelID = model.ID
+	if model.LifecycleState() == catalog.LifecycleRetired {
+		return nil, nil, &modelRetiredError{modelID: modelID, message: model.DeprecationMessage}
+	}
+	if warning := lifecycleWarning(model); warning != "" {
+		log.Printf("Warning: %s; activating anyway", warning)
+	}
+	if err := h.checkActivationPolicies(model); err != nil {
+		return nil, nil, err
+	}
+	activateOpts, err := kserve.NormalizeActivateOptions(kserve.ActivateOptions{
+		Strategy:       opts.strategy,
+		TrafficPercent: opts.trafficPercent,
+		Provenance:     h.modelProvenance(model),
+	})
+	if err != nil {
+		return nil, nil, newRequestError(http.StatusBadRequest, err.Error(), err)
+	}
+	if expected := normalizeCatalogHash(opts.catalogHash); expected != "" && !opts.force {
+		if current := catalog.ContentHash(model); current != expected {
+			return nil, nil, &catalogConflictError{modelID: modelID, expected: expected, current: current}
+		}
+	}
+	readyTimeout := opts.readyTimeout
+	if readyTimeout <= 0 {
+		readyTimeout = h.opts.ActivationReadyTimeout
+	}
+	if !opts.lockHeld {
+		lockTTL := activationLockTTL
+		if opts.waitForReady {
+			lockTTL += readyTimeout
+		}
+		unlock, err := h.lockActivation(lockTTL)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer unlock()
 	}
-	h.publishEvent("model.activation.started", meta)
 
-	result, err := h.kserve.Activate(model)
+	previousID, _ := h.currentRuntimeModelID()
+	started := events.ActivationStarted{
+		ModelID:         modelID,
+		DisplayName:     modelDisplayName(model),
+		StorageURI:      model.StorageURI,
+		Runtime:         model.Runtime,
+		HFModelID:       model.HFModelID,
+		RequestedBy:     subject,
+		RequestedAt:     time.Now().UTC(),
+		Strategy:        activateOpts.Strategy,
+		PreviousModelID: previousID,
+	}
+	if activateOpts.Strategy == kserve.StrategyCanary {
+		started.TrafficPercent = activateOpts.TrafficPercent
+	}
+	h.publishEvent(ctx, started)
+
+	startedAt := time.Now()
+	result, err := h.kserve.Activate(model, activateOpts)
+	metrics.ObserveActivation(err == nil)
 	if err != nil {
 		log.Printf("Failed to activate model %s: %v", modelID, err)
-		failMeta := gin.H{
-			"modelId":     modelID,
-			"displayName": modelDisplayName(model),
-			"error":       err.Error(),
-		}
-		h.publishEvent("model.activation.failed", failMeta)
+		failed := events.ActivationFailed{ModelID: modelID, DisplayName: modelDisplayName(model), Error: err.Error()}
+		h.publishEvent(ctx, failed)
+		h.notify(failed.EventType(), modelID, fmt.Sprintf("Activation of %s failed: %v", modelDisplayName(model), err), events.Fields(failed))
 		return nil, nil, err
 	}
+	if opts.waitForReady && !h.waitForRuntimeReady(ctx, startedAt, readyTimeout) {
+		return nil, nil, h.rollbackActivation(ctx, model, previousID, readyTimeout)
+	}
 
-	successMeta := map[string]interface{}{
-		"action":      result.Action,
-		"modelId":     modelID,
-		"displayName": modelDisplayName(model),
+	completed := events.ActivationCompleted{
+		Action:          result.Action,
+		ModelID:         modelID,
+		DisplayName:     modelDisplayName(model),
+		Strategy:        result.Strategy,
+		TrafficPercent:  result.TrafficPercent,
+		PreviousModelID: previousID,
 	}
-	h.recordHistory("model_activated", modelID, successMeta)
-	h.publishEvent("model.activation.completed", successMeta)
+	h.recordHistory(ctx, "model_activated", modelID, events.Fields(completed))
+	h.publishEvent(ctx, completed)
+	h.notify(completed.EventType(), modelID, fmt.Sprintf("Activated %s", modelDisplayName(model)), events.Fields(completed))
+	h.recordActiveStatus(subject, model)
 	return model, result, nil
 }
 
+// waitForRuntimeReady polls until the InferenceService reports Ready=True,
+// timeout elapses, or ctx is done. Status snapshots older than since are
+// ignored so a Ready condition left over from the previous revision does not
+// count.
+func (h *Handler) waitForRuntimeReady(ctx context.Context, since time.Time, timeout time.Duration) bool {
+	interval := timeout / 10
+	if interval > 2*time.Second {
+		interval = 2 * time.Second
+	}
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if h.runtimeReady(since) {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline.C:
+			return h.runtimeReady(since)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Handler) runtimeReady(since time.Time) bool {
+	if h.runtime != nil {
+		st := h.runtime.CurrentStatus()
+		return st.InferenceService != nil && st.InferenceService.Ready == "True" && !st.UpdatedAt.Before(since)
+	}
+	isvc, err := h.kserve.GetActive()
+	return err == nil && kserve.IsReadyAtLatestGeneration(isvc)
+}
+
+// lockActivation claims the activation lock for ttl, failing fast with
+// errActivationInProgress if another activation holds it here or, when a
+// datastore is configured, on another replica. The returned func releases it.
+func (h *Handler) lockActivation(ttl time.Duration) (func(), error) {
+	if !h.activationMu.TryLock() {
+		return nil, errActivationInProgress
+	}
+	if h.store == nil {
+		return h.activationMu.Unlock, nil
+	}
+	holder := uuid.NewString()
+	acquired, err := h.store.AcquireLock(activationLockName, holder, ttl)
+	if err != nil {
+		h.activationMu.Unlock()
+		return nil, fmt.Errorf("failed to acquire activation lock: %w", err)
+	}
+	if !acquired {
+		h.activationMu.Unlock()
+		return nil, errActivationInProgress
+	}
+	return func() {
+		if err := h.store.ReleaseLock(activationLockName, holder); err != nil {
+			log.Printf("Failed to release activation lock: %v", err)
+		}
+		h.activationMu.Unlock()
+	}, nil
+}
+
+// rollbackActivation re-activates previousID after model failed to become
+// ready and returns the error reported to the caller.
+func (h *Handler) rollbackActivation(ctx context.Context, model *catalog.Model, previousID string, timeout time.Duration) error {
+	rbErr := &activationRollbackError{modelID: model.ID, timeout: timeout}
+	if previousID != "" && previousID != model.ID {
+		rbErr.previousID = previousID
+		if previous := h.catalog.Get(previousID); previous == nil {
+			rbErr.rollbackErr = errModelNotFound
+		} else if _, err := h.kserve.Activate(previous, kserve.ActivateOptions{
+			Strategy:   kserve.StrategyDirect,
+			Provenance: h.modelProvenance(previous),
+		}); err != nil {
+			rbErr.rollbackErr = err
+		}
+	}
+	rolledBack := events.ActivationRolledBack{
+		ModelID:     model.ID,
+		DisplayName: modelDisplayName(model),
+		PreviousID:  previousID,
+		RolledBack:  rbErr.rolledBack(),
+		Error:       rbErr.Error(),
+	}
+	log.Printf("Activation of %s was not ready within %s: %v", model.ID, timeout, rbErr)
+	h.recordHistory(ctx, "model_activation_rolled_back", model.ID, events.Fields(rolledBack))
+	h.publishEvent(ctx, rolledBack)
+	h.notify(rolledBack.EventType(), model.ID, rbErr.Error(), events.Fields(rolledBack))
+	return rbErr
+}
+
+// recordActiveStatus mirrors the activation into status/active.yaml in the
+// catalog repo when enabled. The change is proposed as a pull request against
+// the base branch; CatalogStatusBranch opts into pushing to that branch
+// directly instead.
+func (h *Handler) recordActiveStatus(subject string, model *catalog.Model) {
+	if !h.opts.CatalogStatusEnabled || h.writer == nil || model == nil {
+		return
+	}
+	go h.publishActiveStatus(h.activeStatus(subject, model))
+}
+
+func (h *Handler) activeStatus(subject string, model *catalog.Model) catalogwriter.ActiveStatus {
+	status := catalogwriter.ActiveStatus{
+		ModelID:     model.ID,
+		DisplayName: modelDisplayName(model),
+		HFModelID:   model.HFModelID,
+		CatalogHash: catalog.ContentHash(model),
+		ActivatedAt: time.Now().UTC(),
+		ActivatedBy: subject,
+	}
+	if provenance := h.modelProvenance(model); provenance != nil {
+		status.Revision = provenance.Revision
+	}
+	return status
+}
+
+func (h *Handler) publishActiveStatus(status catalogwriter.ActiveStatus) {
+	saved, err := h.writer.SaveStatus(status)
+	if err != nil {
+		logutil.Error("catalog_status_failed", err, map[string]interface{}{"modelId": status.ModelID})
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	message := fmt.Sprintf("Record active model %s", status.ModelID)
+	branch := strings.TrimSpace(h.opts.CatalogStatusBranch)
+	direct := branch != ""
+	base := branch
+	if !direct {
+		branch = fmt.Sprintf("status/active-%s", status.ActivatedAt.Format("20060102-150405"))
+		base = ""
+	}
+	if err := h.writer.CommitAndPush(ctx, branch, base, message, saved.RelativePath); err != nil {
+		logutil.Error("catalog_status_failed", err, map[string]interface{}{"modelId": status.ModelID, "branch": branch})
+		return
+	}
+	fields := map[string]interface{}{
+		"modelId": status.ModelID,
+		"branch":  branch,
+		"path":    saved.RelativePath,
+	}
+	if !direct {
+		pr, err := h.writer.CreatePullRequest(ctx, catalogwriter.PullRequestOptions{
+			Branch: branch,
+			Title:  message,
+			Body:   fmt.Sprintf("Automated status update recording `%s` as the active model.", status.ModelID),
+			Token:  h.opts.GitHubToken,
+		})
+		if err != nil {
+			logutil.Error("catalog_status_failed", err, fields)
+			return
+		}
+		fields["pullRequest"] = pr.HTMLURL
+	}
+	logutil.Info("catalog_status_recorded", fields)
+}
+
 func (h *Handler) respondActivationError(c *gin.Context, err error) {
 	if errors.Is(err, errModelNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "model not found"})
 		return
 	}
+	if errors.Is(err, errActivationInProgress) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"hint":  "wait for the running activation to finish and retry",
+		})
+		return
+	}
+	var conflict *catalogConflictError
+	if errors.As(err, &conflict) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":       conflict.Error(),
+			"modelId":     conflict.modelID,
+			"expected":    conflict.expected,
+			"catalogHash": conflict.current,
+			"hint":        "re-review the catalog entry or retry with force=true",
+		})
+		return
+	}
+	var violation *policy.Violation
+	if errors.As(err, &violation) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":  violation.Error(),
+			"policy": violation.Policy,
+			"rule":   violation.Rule,
+		})
+		return
+	}
+	var retired *modelRetiredError
+	if errors.As(err, &retired) {
+		resp := gin.H{
+			"error":     retired.Error(),
+			"modelId":   retired.modelID,
+			"lifecycle": catalog.LifecycleRetired,
+		}
+		if retired.message != "" {
+			resp["deprecationMessage"] = retired.message
+		}
+		c.JSON(http.StatusConflict, resp)
+		return
+	}
+	var rollback *activationRollbackError
+	if errors.As(err, &rollback) {
+		c.JSON(http.StatusGatewayTimeout, gin.H{
+			"error":      rollback.Error(),
+			"modelId":    rollback.modelID,
+			"previousId": rollback.previousID,
+			"rolledBack": rollback.rolledBack(),
+		})
+		return
+	}
 	if reqErr, ok := err.(*requestError); ok {
 		c.JSON(reqErr.code, gin.H{"error": reqErr.message})
 		return
@@ -997,25 +2040,47 @@ func (h *Handler) respondActivationError(c *gin.Context, err error) {
 	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 }
 
-func (h *Handler) deactivateRuntime(subject string) (*kserve.Result, error) {
-	h.publishEvent("model.deactivation.started", gin.H{
-		"requestedBy": subject,
-		"requestedAt": time.Now().UTC(),
-	})
+// lifecycleWarning describes a deprecated model for activation responses, or
+// returns "" for any other lifecycle.
+func lifecycleWarning(model *catalog.Model) string {
+	if model.LifecycleState() != catalog.LifecycleDeprecated {
+		return ""
+	}
+	warning := fmt.Sprintf("model %s is deprecated", model.ID)
+	if msg := strings.TrimSpace(model.DeprecationMessage); msg != "" {
+		warning += ": " + msg
+	}
+	return warning
+}
+
+// addLifecycleWarning sets "warning" on an activation response when the model
+// is deprecated.
+func addLifecycleWarning(resp gin.H, model *catalog.Model) gin.H {
+	if warning := lifecycleWarning(model); warning != "" {
+		resp["warning"] = warning
+	}
+	return resp
+}
+
+// normalizeCatalogHash accepts raw hashes as well as quoted/weak ETag values.
+func normalizeCatalogHash(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "W/")
+	return strings.Trim(value, "\"")
+}
+
+func (h *Handler) deactivateRuntime(ctx context.Context, subject string) (*kserve.Result, error) {
+	h.publishEvent(ctx, events.DeactivationStarted{RequestedBy: subject, RequestedAt: time.Now().UTC()})
 	result, err := h.kserve.Deactivate()
 	if err != nil {
 		log.Printf("Failed to deactivate model: %v", err)
-		h.publishEvent("model.deactivation.failed", gin.H{
-			"error": err.Error(),
-		})
+		h.publishEvent(ctx, events.DeactivationFailed{Error: err.Error()})
 		return nil, err
 	}
-	h.recordHistory("model_deactivated", "", map[string]interface{}{
-		"action": result.Action,
-	})
-	h.publishEvent("model.deactivation.completed", gin.H{
+	h.recordHistory(ctx, "model_deactivated", "", map[string]interface{}{
 		"action": result.Action,
 	})
+	h.publishEvent(ctx, events.DeactivationCompleted{Action: result.Action})
 	return result, nil
 }
 
@@ -1024,18 +2089,20 @@ func (h *Handler) currentRuntimeModelID() (string, error) {
 	if err != nil || isvc == nil {
 		return "", err
 	}
-	meta, _ := isvc["metadata"].(map[string]interface{})
+	return annotationValue(isvc, "model-manager/model-id"), nil
+}
+
+func annotationValue(obj map[string]interface{}, key string) string {
+	meta, _ := obj["metadata"].(map[string]interface{})
 	if meta == nil {
-		return "", nil
+		return ""
 	}
 	annotations, _ := meta["annotations"].(map[string]interface{})
 	if annotations == nil {
-		return "", nil
-	}
-	if val, ok := annotations["model-manager/model-id"].(string); ok {
-		return val, nil
+		return ""
 	}
-	return "", nil
+	val, _ := annotations[key].(string)
+	return val
 }
 
 // GetActiveModel returns information about the currently active model.
@@ -1087,7 +2154,7 @@ func (h *Handler) ValidateCatalog(c *gin.Context) {
 
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		respondBodyReadError(c, err, "failed to read request body")
 		return
 	}
 
@@ -1096,14 +2163,554 @@ func (h *Handler) ValidateCatalog(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid model payload: " + err.Error()})
 		return
 	}
-
-	result := h.checker.Validate(c.Request.Context(), body, &model)
-	status := http.StatusOK
-	if !result.Valid {
-		status = http.StatusBadRequest
+
+	result := h.checker.Validate(c.Request.Context(), body, &model)
+	status := http.StatusOK
+	if !result.Valid {
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, result)
+}
+
+type bulkValidateRequest struct {
+	Models []json.RawMessage `json:"models"`
+}
+
+// ValidateCatalogBulk validates many catalog entries in one call. The body may
+// list models to check; an empty body (or empty list) validates every entry in
+// the loaded catalog. Every entry is validated even after a failure so CI can
+// report all broken entries at once.
+func (h *Handler) ValidateCatalogBulk(c *gin.Context) {
+	if h.checker == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog validation is disabled"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respondBodyReadError(c, err, "failed to read request body")
+		return
+	}
+	var req bulkValidateRequest
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload: " + err.Error()})
+			return
+		}
+	}
+
+	source := "request"
+	if len(req.Models) == 0 {
+		if err := h.ensureCatalogFresh(false); err != nil {
+			log.Printf("Failed to ensure catalog freshness: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load model catalog"})
+			return
+		}
+		source = "catalog"
+		for _, model := range h.catalog.All() {
+			raw, err := json.Marshal(model)
+			if err != nil {
+				log.Printf("Failed to encode catalog model %s: %v", model.ID, err)
+				continue
+			}
+			req.Models = append(req.Models, raw)
+		}
+	}
+
+	results := make(map[string]validator.Result, len(req.Models))
+	failed := 0
+	for i, raw := range req.Models {
+		var model catalog.Model
+		key := fmt.Sprintf("models[%d]", i)
+		var result validator.Result
+		switch err := json.Unmarshal(raw, &model); {
+		case err != nil:
+			result = validator.Result{Errors: []string{"invalid model payload: " + err.Error()}, GeneratedAt: time.Now()}
+		case model.ID == "":
+			result = validator.Result{Errors: []string{"model id is required"}, GeneratedAt: time.Now()}
+		default:
+			key = model.ID
+			if _, dup := results[key]; dup {
+				key = fmt.Sprintf("%s (models[%d])", model.ID, i)
+				result = validator.Result{Errors: []string{fmt.Sprintf("duplicate model id %s", model.ID)}, GeneratedAt: time.Now()}
+				break
+			}
+			result = h.checker.Validate(c.Request.Context(), raw, &model)
+		}
+		if !result.Valid {
+			failed++
+		}
+		results[key] = result
+	}
+
+	status := http.StatusOK
+	if failed > 0 {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, gin.H{
+		"valid":   failed == 0,
+		"source":  source,
+		"total":   len(results),
+		"failed":  failed,
+		"results": results,
+	})
+}
+
+const (
+	maxImportArchiveBytes = 64 << 20
+	maxImportEntryBytes   = 1 << 20
+	maxImportEntries      = 2000
+	// maxImportTotalBytes caps the extracted files held in memory, so a
+	// small, highly compressed archive can't expand past it.
+	maxImportTotalBytes = 64 << 20
+)
+
+// catalogImportFile is a model definition extracted from an import archive.
+type catalogImportFile struct {
+	Name string
+	Data []byte
+}
+
+// catalogImportResult reports what happened to one file of an import.
+type catalogImportResult struct {
+	File       string            `json:"file"`
+	ModelID    string            `json:"modelId,omitempty"`
+	Valid      bool              `json:"valid"`
+	Errors     []string          `json:"errors,omitempty"`
+	Validation *validator.Result `json:"validation,omitempty"`
+	Path       string            `json:"path,omitempty"`
+}
+
+// ImportCatalog bulk-loads catalog entries from an uploaded tar.gz, tar, or zip
+// archive (multipart field "file"). Every .json/.yaml/.yml file is validated;
+// with strict=true any failure rejects the whole import, otherwise invalid
+// entries are skipped and reported. With commit=true the valid entries are
+// saved and opened as a single pull request (branch, base, title, body, and
+// draft form fields tune it).
+func (h *Handler) ImportCatalog(c *gin.Context) {
+	if h.checker == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog validation is disabled"})
+		return
+	}
+	commit := formBool(c, "commit")
+	if commit && h.writer == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog contribution automation is disabled"})
+		return
+	}
+	strict := formBool(c, "strict")
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportArchiveBytes+(1<<20))
+	upload, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "multipart field \"file\" is required: " + err.Error()})
+		return
+	}
+	if upload.Size > maxImportArchiveBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("archive exceeds %d bytes", maxImportArchiveBytes)})
+		return
+	}
+	src, err := upload.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer src.Close()
+	archive, err := io.ReadAll(io.LimitReader(src, maxImportArchiveBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read archive: " + err.Error()})
+		return
+	}
+	files, err := extractCatalogArchive(archive)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "archive contains no .json, .yaml, or .yml files"})
+		return
+	}
+
+	results := make([]catalogImportResult, 0, len(files))
+	var valid []*catalog.Model
+	seen := make(map[string]string, len(files))
+	for _, file := range files {
+		result := catalogImportResult{File: file.Name}
+		model, raw, err := decodeCatalogEntry(file.Data)
+		switch {
+		case err != nil:
+			result.Errors = []string{err.Error()}
+		case model.ID == "":
+			result.Errors = []string{"model id is required"}
+		case seen[model.ID] != "":
+			result.ModelID = model.ID
+			result.Errors = []string{fmt.Sprintf("duplicate model id %s (also in %s)", model.ID, seen[model.ID])}
+		default:
+			result.ModelID = model.ID
+			seen[model.ID] = file.Name
+			validation := h.checker.Validate(c.Request.Context(), raw, model)
+			result.Validation = &validation
+			result.Valid = validation.Valid
+			if validation.Valid {
+				valid = append(valid, model)
+			}
+		}
+		results = append(results, result)
+	}
+
+	skipped := len(results) - len(valid)
+	response := gin.H{
+		"strict":   strict,
+		"total":    len(results),
+		"imported": len(valid),
+		"skipped":  skipped,
+		"results":  results,
+	}
+	if strict && skipped > 0 {
+		response["status"] = "rejected"
+		response["error"] = fmt.Sprintf("%d of %d entries failed validation", skipped, len(results))
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+	if !commit {
+		response["status"] = "validated"
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	if len(valid) == 0 {
+		response["status"] = "rejected"
+		response["error"] = "no valid entries to commit"
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	paths := make([]string, 0, len(valid))
+	pathByID := make(map[string]string, len(valid))
+	for _, model := range valid {
+		saved, err := h.writer.Save(model)
+		if err != nil {
+			log.Printf("Failed to save imported catalog entry %s: %v", model.ID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("save %s: %v", model.ID, err)})
+			return
+		}
+		paths = append(paths, saved.RelativePath)
+		pathByID[model.ID] = saved.RelativePath
+	}
+	for i := range results {
+		if results[i].Valid {
+			results[i].Path = pathByID[results[i].ModelID]
+		}
+	}
+
+	branch := strings.TrimSpace(c.PostForm("branch"))
+	if branch == "" {
+		branch = fmt.Sprintf("catalog/import-%s", time.Now().UTC().Format("20060102-150405"))
+	}
+	base := strings.TrimSpace(c.PostForm("base"))
+	title := strings.TrimSpace(c.PostForm("title"))
+	if title == "" {
+		title = fmt.Sprintf("Import %d catalog entries", len(valid))
+	}
+	body := strings.TrimSpace(c.PostForm("body"))
+	if body == "" {
+		ids := make([]string, 0, len(valid))
+		for _, model := range valid {
+			ids = append(ids, "- `"+model.ID+"`")
+		}
+		body = "Automated catalog import:\n\n" + strings.Join(ids, "\n")
+	}
+	if err := h.writer.CommitAndPush(c.Request.Context(), branch, base, title, paths...); err != nil {
+		log.Printf("Failed to commit/push catalog import: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response["status"] = "success"
+	response["branch"] = branch
+	response["files"] = paths
+	if h.opts.GitHubToken == "" {
+		response["message"] = "changes committed locally; set GITHUB_TOKEN to enable automatic PR creation"
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	pr, err := h.writer.CreatePullRequest(c.Request.Context(), catalogwriter.PullRequestOptions{
+		Branch: branch,
+		Base:   base,
+		Title:  title,
+		Body:   body,
+		Draft:  formBool(c, "draft"),
+		Token:  h.opts.GitHubToken,
+	})
+	if err != nil {
+		log.Printf("Failed to open pull request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response["pullRequest"] = pr
+	c.JSON(http.StatusOK, response)
+}
+
+// formBool reads a multipart/form flag, falling back to the query string.
+func formBool(c *gin.Context, key string) bool {
+	value, ok := c.GetPostForm(key)
+	if !ok {
+		return parseBool(c, key)
+	}
+	switch strings.TrimSpace(strings.ToLower(value)) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeCatalogEntry parses a JSON or YAML catalog entry and returns it with
+// its JSON encoding for schema validation.
+func decodeCatalogEntry(data []byte) (*catalog.Model, []byte, error) {
+	raw := bytes.TrimSpace(data)
+	if len(raw) == 0 {
+		return nil, nil, fmt.Errorf("file is empty")
+	}
+	if raw[0] != '{' {
+		converted, err := yaml.YAMLToJSON(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+		raw = converted
+	}
+	var model catalog.Model
+	if err := json.Unmarshal(raw, &model); err != nil {
+		return nil, nil, fmt.Errorf("invalid model payload: %w", err)
+	}
+	return &model, raw, nil
+}
+
+// extractCatalogArchive returns the model definition files in a zip, tar, or
+// tar.gz archive, sorted by path. Hidden files and anything outside
+// .json/.yaml/.yml are ignored. The extracted files may not exceed
+// maxImportTotalBytes together.
+func extractCatalogArchive(data []byte) ([]catalogImportFile, error) {
+	var files []catalogImportFile
+	var total int
+	add := func(name string, r io.Reader) error {
+		name = path.Clean(strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "/"))
+		if !isCatalogImportFile(name) {
+			return nil
+		}
+		if len(files) >= maxImportEntries {
+			return fmt.Errorf("archive has more than %d model files", maxImportEntries)
+		}
+		content, err := io.ReadAll(io.LimitReader(r, maxImportEntryBytes+1))
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		if len(content) > maxImportEntryBytes {
+			return fmt.Errorf("%s exceeds %d bytes", name, maxImportEntryBytes)
+		}
+		total += len(content)
+		if total > maxImportTotalBytes {
+			return fmt.Errorf("extracted model files exceed %d bytes", maxImportTotalBytes)
+		}
+		files = append(files, catalogImportFile{Name: name, Data: content})
+		return nil
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid zip archive: %w", err)
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("open %s: %w", f.Name, err)
+			}
+			err = add(f.Name, rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		var r io.Reader = bytes.NewReader(data)
+		if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gzip archive: %w", err)
+			}
+			defer gz.Close()
+			r = gz
+		}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid tar archive: %w", err)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			if err := add(hdr.Name, tr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
+
+func isCatalogImportFile(name string) bool {
+	if name == "." || strings.HasPrefix(name, "../") {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// ExportCatalog streams every catalog entry for backup or migration.
+// format=json (default) or yaml selects the encoding; without archive the
+// response is one combined document (a JSON array or multi-document YAML),
+// while archive=tar returns a tar.gz of individual files laid out like the
+// catalog repo's models directory.
+func (h *Handler) ExportCatalog(c *gin.Context) {
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "json")))
+	if format != "json" && format != "yaml" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or yaml"})
+		return
+	}
+	archive := strings.ToLower(strings.TrimSpace(c.Query("archive")))
+	if archive != "" && archive != "tar" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "archive must be tar"})
+		return
+	}
+	if err := h.ensureCatalogFresh(false); err != nil {
+		log.Printf("Failed to ensure catalog freshness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load model catalog"})
+		return
+	}
+
+	models := h.catalog.All()
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+	ext := "." + format
+	stamp := time.Now().UTC().Format("20060102-150405")
+
+	var err error
+	if archive == "tar" {
+		filename := fmt.Sprintf("catalog-%s.tar.gz", stamp)
+		c.Header("Content-Type", "application/gzip")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		c.Status(http.StatusOK)
+		err = h.writeCatalogTar(c.Writer, models, ext)
+	} else {
+		filename := fmt.Sprintf("catalog-%s%s", stamp, ext)
+		contentType := "application/json"
+		if format == "yaml" {
+			contentType = "application/yaml"
+		}
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		c.Status(http.StatusOK)
+		err = writeCatalogDocument(c.Writer, models, format)
+	}
+	if err != nil {
+		// Headers are already sent; the truncated body is all we can signal.
+		log.Printf("catalog export aborted: %v", err)
+		return
+	}
+
+	h.recordHistory(c.Request.Context(), "catalog_exported", "", map[string]interface{}{
+		"format":  format,
+		"archive": archive,
+		"models":  len(models),
+	})
+}
+
+// writeCatalogTar writes one file per model under the catalog models
+// directory, encoded like catalogwriter saves them.
+func (h *Handler) writeCatalogTar(w io.Writer, models []*catalog.Model, ext string) error {
+	modelsDir := h.opts.CatalogModelsDir
+	if modelsDir == "" {
+		modelsDir = "models"
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now().UTC()
+	for _, model := range models {
+		data, err := catalogwriter.Marshal(model, ext)
+		if err != nil {
+			return fmt.Errorf("%s: %w", model.ID, err)
+		}
+		hdr := &tar.Header{
+			Name:     path.Join(modelsDir, model.ID+ext),
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			ModTime:  now,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeCatalogDocument streams models as a JSON array or a multi-document YAML
+// stream, encoding one entry at a time.
+func writeCatalogDocument(w io.Writer, models []*catalog.Model, format string) error {
+	ext := "." + format
+	if format == "json" {
+		if _, err := io.WriteString(w, "[\n"); err != nil {
+			return err
+		}
+	}
+	for i, model := range models {
+		data, err := catalogwriter.Marshal(model, ext)
+		if err != nil {
+			return fmt.Errorf("%s: %w", model.ID, err)
+		}
+		var sep string
+		switch {
+		case format == "yaml":
+			sep = "---\n"
+		case i > 0:
+			sep = ",\n"
+		}
+		if format == "json" {
+			data = bytes.TrimRight(data, "\n")
+		}
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	if format == "json" {
+		if _, err := io.WriteString(w, "\n]\n"); err != nil {
+			return err
+		}
 	}
-
-	c.JSON(status, result)
+	return nil
 }
 
 // TestModel performs a dry-run activation (and optional readiness probe) for a model.
@@ -1126,7 +2733,12 @@ func (h *Handler) TestModel(c *gin.Context) {
 		return
 	}
 
-	dryRun, err := h.kserve.DryRun(model)
+	live, err := h.kserve.GetActive()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun, err := h.kserve.DryRun(model, h.modelProvenance(model))
 	if err != nil {
 		log.Printf("Dry-run failed for model %s: %v", req.ID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -1137,6 +2749,16 @@ func (h *Handler) TestModel(c *gin.Context) {
 		"status": "success",
 		"dryRun": dryRun,
 	}
+	if live != nil {
+		changes := kserve.DiffManifest(live, dryRun.Applied)
+		if changes == nil {
+			changes = []kserve.ManifestChange{}
+		}
+		response["diff"] = gin.H{
+			"liveModelId": annotationValue(live, "model-manager/model-id"),
+			"changes":     changes,
+		}
+	}
 
 	if req.ReadinessURL != "" {
 		readiness := h.checkReadiness(c.Request.Context(), req.ReadinessURL, req.TimeoutSeconds)
@@ -1149,17 +2771,59 @@ func (h *Handler) TestModel(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetRuntimeStatus returns the cached KServe/Knative runtime status.
+// GetRuntimeStatus returns the cached KServe/Knative runtime status of the
+// active InferenceService, of the "namespace/name" given by ?target=, or of
+// every watched target with ?all=true.
 func (h *Handler) GetRuntimeStatus(c *gin.Context) {
 	if h.runtime == nil {
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "runtime status unavailable"})
 		return
 	}
-	status := h.runtime.CurrentStatus()
-	if status.UpdatedAt.IsZero() {
-		status.UpdatedAt = time.Now().UTC()
+	statuses := []status.RuntimeStatus{h.runtime.CurrentStatus()}
+	if multi, ok := h.runtime.(multiRuntimeStatusProvider); ok {
+		if all := multi.Statuses(); len(all) > 0 {
+			statuses = all
+		}
+	}
+	if target := strings.TrimSpace(c.Query("target")); target != "" {
+		var matched []status.RuntimeStatus
+		for _, st := range statuses {
+			if st.Target == target || (!strings.Contains(target, "/") && strings.HasSuffix(st.Target, "/"+target)) {
+				matched = append(matched, st)
+			}
+		}
+		if len(matched) != 1 {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("status target %s is not watched", target)})
+			return
+		}
+		statuses = matched
+	}
+
+	deployment := c.Query("deployment")
+	pod := c.Query("pod")
+	summarize := parseBool(c, "summary")
+	render := func(st status.RuntimeStatus) interface{} {
+		if st.UpdatedAt.IsZero() {
+			st.UpdatedAt = time.Now().UTC()
+		}
+		st = st.Filter(deployment, pod)
+		if summarize {
+			summary := st.Summarize()
+			summary.Deployment = deployment
+			summary.Pod = pod
+			return summary
+		}
+		return st
+	}
+	if parseBool(c, "all") {
+		targets := make([]interface{}, 0, len(statuses))
+		for _, st := range statuses {
+			targets = append(targets, render(st))
+		}
+		c.JSON(http.StatusOK, gin.H{"targets": targets})
+		return
 	}
-	c.JSON(http.StatusOK, status)
+	c.JSON(http.StatusOK, render(statuses[0]))
 }
 
 // ListWeights returns cached weights stored on Venus.
@@ -1179,7 +2843,53 @@ func (h *Handler) ListWeights(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"weights": weights})
 }
 
-// GetWeightInfo returns information about a specific weight directory.
+// ListOrphanedWeights lists installed weights that neither a catalog entry's
+// storageUri nor the active InferenceService references, along with the
+// bytes deleting them would reclaim.
+func (h *Handler) ListOrphanedWeights(c *gin.Context) {
+	if h.weights == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "weight management is disabled"})
+		return
+	}
+	if h.catalog == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog not configured"})
+		return
+	}
+	// Without a loaded catalog every weight would look orphaned.
+	if err := h.ensureCatalogFresh(false); err != nil || h.catalogStatus == "syncing" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "catalog is not loaded yet; cannot determine which weights are referenced"})
+		return
+	}
+	installed, err := h.weights.List()
+	if err != nil {
+		log.Printf("Failed to list weights: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list weights"})
+		return
+	}
+	refs, err := h.weightReferences()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	orphaned := make([]weights.WeightInfo, 0)
+	var reclaimable int64
+	for _, info := range installed {
+		if len(refs[normalizeWeightName(info.Name)]) > 0 {
+			continue
+		}
+		orphaned = append(orphaned, info)
+		reclaimable += info.SizeBytes
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"weights":          orphaned,
+		"count":            len(orphaned),
+		"reclaimableBytes": reclaimable,
+		"reclaimableHuman": weights.FormatBytes(reclaimable),
+	})
+}
+
+// GetWeightInfo returns information about a specific weight directory. The
+// per-file manifest (path, size, SHA-256) is included with files=true.
 func (h *Handler) GetWeightInfo(c *gin.Context) {
 	if h.weights == nil {
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "weight management is disabled"})
@@ -1197,22 +2907,106 @@ func (h *Handler) GetWeightInfo(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if parseBool(c, "files") {
+		files, err := h.weights.Manifest(name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to read file manifest: %v", err)})
+			return
+		}
+		info.Files = files
+	}
 
 	c.JSON(http.StatusOK, info)
 }
 
-// DeleteWeights removes cached weights for a model.
+// VerifyWeights compares an installed weight directory with the file list and
+// sizes Hugging Face reports for the recorded model revision.
+func (h *Handler) VerifyWeights(c *gin.Context) {
+	if h.weights == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "weight management is disabled"})
+		return
+	}
+	if h.vllm == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "vLLM discovery is disabled"})
+		return
+	}
+
+	name := strings.Trim(c.Query("name"), "/")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	info, err := h.weights.Get(name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if info.HFModelID == "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "weights have no recorded Hugging Face model; reinstall to enable verification"})
+		return
+	}
+
+	hfModel, err := h.vllm.GetHuggingFaceModelRevision(info.HFModelID, info.Revision)
+	if err != nil {
+		if status, msg, ok := huggingFaceError(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	wanted := make(map[string]struct{})
+	for _, file := range vllm.CollectHuggingFaceFiles(hfModel) {
+		wanted[file] = struct{}{}
+	}
+	var expected []vllm.HFSibling
+	for _, sibling := range hfModel.Siblings {
+		if _, ok := wanted[sibling.RFileName]; ok {
+			expected = append(expected, sibling)
+		}
+	}
+
+	result, err := h.weights.Verify(name, expected)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteWeights removes cached weights for a model. When a prefix or match
+// query parameter is supplied it deletes every matching directory instead.
 func (h *Handler) DeleteWeights(c *gin.Context) {
 	if h.weights == nil {
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "weight management is disabled"})
 		return
 	}
+	if _, _, bulk := weightSelection(c); bulk {
+		h.BulkDeleteWeights(c)
+		return
+	}
 
 	var req deleteWeightsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	force := parseBool(c, "force")
+	allRefs, err := h.weightReferences()
+	if err != nil && !force {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error(), "hint": "pass force=true to delete anyway"})
+		return
+	}
+	if refs := allRefs[normalizeWeightName(req.Name)]; len(refs) > 0 && !force {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":        fmt.Sprintf("weights %s are in use", req.Name),
+			"name":         req.Name,
+			"referencedBy": refs,
+			"hint":         "pass force=true to delete anyway",
+		})
+		return
+	}
 
 	if err := h.weights.Delete(req.Name); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -1224,7 +3018,149 @@ func (h *Handler) DeleteWeights(c *gin.Context) {
 		"message": "Deleted weights for " + req.Name,
 	})
 
-	h.recordHistory("weight_deleted", req.Name, nil)
+	h.recordHistory(c.Request.Context(), "weight_deleted", req.Name, nil)
+}
+
+// BulkDeleteWeights deletes every installed weight directory whose name starts
+// with ?prefix= or matches the ?match= glob (e.g. Qwen/*). Each match goes
+// through weights.Delete so reserved-name and traversal guards still apply.
+// Pass dryRun=true to list matches without deleting.
+func (h *Handler) BulkDeleteWeights(c *gin.Context) {
+	if h.weights == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "weight management is disabled"})
+		return
+	}
+	prefix, pattern, _ := weightSelection(c)
+	if prefix == "" && pattern == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "prefix or match is required"})
+		return
+	}
+	if pattern != "" {
+		if _, err := path.Match(pattern, ""); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid match pattern: %v", err)})
+			return
+		}
+	}
+	installed, err := h.weights.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun := parseBool(c, "dryRun")
+	force := parseBool(c, "force")
+	refs, err := h.weightReferences()
+	if err != nil && !force {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error(), "hint": "pass force=true to delete anyway"})
+		return
+	}
+	results := make(map[string]string)
+	for _, info := range installed {
+		if !weightNameMatches(info.Name, prefix, pattern) {
+			continue
+		}
+		if inUse := refs[normalizeWeightName(info.Name)]; len(inUse) > 0 && !force {
+			results[info.Name] = weightInUseMessage(inUse)
+			continue
+		}
+		if dryRun {
+			results[info.Name] = "matched"
+			continue
+		}
+		if err := h.weights.Delete(info.Name); err != nil {
+			results[info.Name] = err.Error()
+			continue
+		}
+		results[info.Name] = "deleted"
+		h.recordHistory(c.Request.Context(), "weight_deleted", info.Name, map[string]interface{}{"prefix": prefix, "match": pattern})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"matched": len(results),
+		"dryRun":  dryRun,
+	})
+}
+
+// weightSelection reads the ?prefix= and ?match= selectors for bulk deletes.
+// bulk reports whether either parameter was supplied, even when empty.
+func weightSelection(c *gin.Context) (prefix, pattern string, bulk bool) {
+	rawPrefix, hasPrefix := c.GetQuery("prefix")
+	rawMatch, hasMatch := c.GetQuery("match")
+	prefix = strings.TrimLeft(strings.TrimSpace(rawPrefix), "/")
+	pattern = strings.Trim(strings.TrimSpace(rawMatch), "/")
+	return prefix, pattern, hasPrefix || hasMatch
+}
+
+func weightNameMatches(name, prefix, pattern string) bool {
+	if prefix != "" && !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if pattern != "" {
+		ok, err := path.Match(pattern, name)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// PruneWeights deletes installed weights not modified within ?olderThan=
+// (a duration such as 720h or a day count such as 30d), oldest first. Weights
+// still referenced by the catalog or the active runtime are skipped unless
+// force=true; dryRun=true lists what would go without deleting.
+func (h *Handler) PruneWeights(c *gin.Context) {
+	if h.weights == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "weight management is disabled"})
+		return
+	}
+	raw := strings.TrimSpace(c.Query("olderThan"))
+	if raw == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "olderThan is required"})
+		return
+	}
+	maxAge, err := weights.ParseAge(raw)
+	if err != nil || maxAge <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "olderThan must be a positive duration such as 720h or 30d"})
+		return
+	}
+	candidates, err := h.weights.PruneCandidates(maxAge)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	dryRun := parseBool(c, "dryRun")
+	force := parseBool(c, "force")
+	refs, err := h.weightReferences()
+	if err != nil && !force {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error(), "hint": "pass force=true to prune anyway"})
+		return
+	}
+	pruned := make([]weights.WeightInfo, 0, len(candidates))
+	skipped := make(map[string]string)
+	var freed int64
+	for _, info := range candidates {
+		if inUse := refs[normalizeWeightName(info.Name)]; len(inUse) > 0 && !force {
+			skipped[info.Name] = weightInUseMessage(inUse)
+			continue
+		}
+		if !dryRun {
+			if err := h.weights.Delete(info.Name); err != nil {
+				skipped[info.Name] = err.Error()
+				continue
+			}
+			h.recordHistory(c.Request.Context(), "weight_deleted", info.Name, map[string]interface{}{"olderThan": raw})
+		}
+		pruned = append(pruned, info)
+		freed += info.SizeBytes
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"weights":    pruned,
+		"count":      len(pruned),
+		"skipped":    skipped,
+		"freedBytes": freed,
+		"freedHuman": weights.FormatBytes(freed),
+		"cutoff":     time.Now().UTC().Add(-maxAge),
+		"dryRun":     dryRun,
+	})
 }
 
 // DeleteJobs clears job records (optionally filtered by status).
@@ -1238,7 +3174,7 @@ func (h *Handler) DeleteJobs(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	h.recordHistory("jobs_purged", "", map[string]interface{}{"status": status})
+	h.recordHistory(c.Request.Context(), "jobs_purged", "", map[string]interface{}{"status": status})
 	c.JSON(http.StatusOK, gin.H{"status": "deleted", "filteredStatus": status})
 }
 
@@ -1255,6 +3191,79 @@ func (h *Handler) ClearHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "cleared"})
 }
 
+// RetentionResult reports what a retention sweep removed.
+type RetentionResult struct {
+	JobsRemoved    int64      `json:"jobsRemoved"`
+	HistoryRemoved int64      `json:"historyRemoved"`
+	JobsBefore     *time.Time `json:"jobsBefore,omitempty"`
+	HistoryBefore  *time.Time `json:"historyBefore,omitempty"`
+}
+
+// CleanupRetention deletes finished (done, failed, cancelled) jobs last
+// updated more than jobMaxAge ago and history older than historyMaxAge. A zero
+// max age skips that table.
+func (h *Handler) CleanupRetention(jobMaxAge, historyMaxAge time.Duration) (*RetentionResult, error) {
+	if h.store == nil {
+		return nil, errors.New("persistent store not configured")
+	}
+	now := time.Now().UTC()
+	result := &RetentionResult{}
+	if jobMaxAge > 0 {
+		before := now.Add(-jobMaxAge)
+		removed, err := h.store.CleanupJobsBefore(before, store.JobDone, store.JobFailed, store.JobCancelled)
+		if err != nil {
+			return nil, fmt.Errorf("cleanup jobs: %w", err)
+		}
+		result.JobsRemoved = removed
+		result.JobsBefore = &before
+	}
+	if historyMaxAge > 0 {
+		before := now.Add(-historyMaxAge)
+		removed, err := h.store.CleanupHistoryBefore(before)
+		if err != nil {
+			return result, fmt.Errorf("cleanup history: %w", err)
+		}
+		result.HistoryRemoved = removed
+		result.HistoryBefore = &before
+	}
+	return result, nil
+}
+
+// AdminCleanup runs the job/history retention sweep on demand. The
+// jobMaxAge and historyMaxAge query parameters override the configured
+// retention for this run.
+func (h *Handler) AdminCleanup(c *gin.Context) {
+	if h.store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
+		return
+	}
+	jobMaxAge, historyMaxAge := h.opts.JobRetention, h.opts.HistoryRetention
+	for param, target := range map[string]*time.Duration{"jobMaxAge": &jobMaxAge, "historyMaxAge": &historyMaxAge} {
+		raw := strings.TrimSpace(c.Query(param))
+		if raw == "" {
+			continue
+		}
+		value, err := time.ParseDuration(raw)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be a non-negative duration such as 168h", param)})
+			return
+		}
+		*target = value
+	}
+	result, err := h.CleanupRetention(jobMaxAge, historyMaxAge)
+	if err != nil {
+		log.Printf("Retention cleanup failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("Retention cleanup removed %d jobs and %d history entries", result.JobsRemoved, result.HistoryRemoved)
+	h.recordHistory(c.Request.Context(), "retention_cleanup", "", map[string]interface{}{
+		"jobsRemoved":    result.JobsRemoved,
+		"historyRemoved": result.HistoryRemoved,
+	})
+	c.JSON(http.StatusOK, result)
+}
+
 // GetWeightUsage returns PVC usage statistics.
 func (h *Handler) GetWeightUsage(c *gin.Context) {
 	if h.weights == nil {
@@ -1279,11 +3288,15 @@ func (h *Handler) InstallWeights(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req.IdempotencyKey = strings.TrimSpace(c.GetHeader("Idempotency-Key"))
 
 	result, err := h.scheduleWeightInstall(c.Request.Context(), req)
 	if err != nil {
+		var violation *policy.Violation
 		var reqErr *requestError
-		if errors.As(err, &reqErr) {
+		if errors.As(err, &violation) {
+			c.JSON(http.StatusForbidden, gin.H{"error": violation.Error(), "policy": violation.Policy, "rule": violation.Rule})
+		} else if errors.As(err, &reqErr) {
 			c.JSON(reqErr.code, gin.H{"error": reqErr.message})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -1292,7 +3305,8 @@ func (h *Handler) InstallWeights(c *gin.Context) {
 	}
 
 	if result.Async {
-		c.JSON(http.StatusAccepted, gin.H{
+		code := http.StatusAccepted
+		response := gin.H{
 			"status":               "queued",
 			"job":                  result.Job,
 			"jobUrl":               fmt.Sprintf("/jobs/%s", result.Job.ID),
@@ -1300,7 +3314,13 @@ func (h *Handler) InstallWeights(c *gin.Context) {
 			"target":               result.Target,
 			"storageUri":           result.StorageURI,
 			"inferenceModelPath":   result.InferencePath,
-		})
+		}
+		if result.Replayed {
+			code = http.StatusOK
+			response["status"] = "existing"
+			c.Header("Idempotent-Replayed", "true")
+		}
+		c.JSON(code, response)
 		return
 	}
 
@@ -1316,17 +3336,73 @@ func (h *Handler) InstallWeights(c *gin.Context) {
 		response["catalogInstructions"] = fmt.Sprintf("Set storageUri to %s and keep MODEL_ID (or equivalent env) pointed at %s", result.StorageURI, req.HFModelID)
 	}
 
-	h.recordHistory("weight_install_completed", req.HFModelID, map[string]interface{}{
+	installMeta := map[string]interface{}{
 		"target":      info.Name,
 		"storageUri":  result.StorageURI,
 		"modelPath":   result.InferencePath,
 		"sizeBytes":   info.SizeBytes,
 		"installedAt": info.InstalledAt,
-	})
+	}
+	h.recordHistory(c.Request.Context(), "weight_install_completed", req.HFModelID, installMeta)
+	h.notify("weights.install.completed", req.HFModelID, fmt.Sprintf("Installed weights for %s (%s)", req.HFModelID, info.SizeHuman), installMeta)
 
 	c.JSON(http.StatusOK, response)
 }
 
+// ScheduleWeightInstallJob queues a weight install through the same path as
+// POST /weights/install and returns the created job. Unlike the REST endpoint
+// it never installs synchronously, so it requires the job manager.
+func (h *Handler) ScheduleWeightInstallJob(ctx context.Context, hfModelID, revision, target string, overwrite bool) (*store.Job, error) {
+	if h.jobs == nil {
+		return nil, newRequestError(http.StatusNotImplemented, "weight install jobs are not configured", nil)
+	}
+	result, err := h.scheduleWeightInstall(ctx, installWeightsRequest{
+		HFModelID: hfModelID,
+		Revision:  revision,
+		Target:    target,
+		Overwrite: overwrite,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Job, nil
+}
+
+// claimIdempotencyKey claims key for req before anything is queued, so
+// concurrent retries can't both create a job. It returns the job to replay
+// when an earlier request with the same body already used the key, or
+// owned=true when this request should go ahead and record its job.
+func (h *Handler) claimIdempotencyKey(key string, req installWeightsRequest) (*store.Job, bool, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, false, newRequestError(http.StatusInternalServerError, err.Error(), err)
+	}
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	rec, claimed, err := h.store.ClaimIdempotencyKey(key, hash, h.opts.IdempotencyKeyTTL)
+	if err != nil {
+		return nil, false, newRequestError(http.StatusInternalServerError, err.Error(), err)
+	}
+	if claimed {
+		return nil, true, nil
+	}
+	if rec.RequestHash != hash {
+		return nil, false, newRequestError(http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request body", nil)
+	}
+	if rec.JobID == "" {
+		return nil, false, newRequestError(http.StatusConflict, "a request with this Idempotency-Key is still being processed", nil)
+	}
+	job, err := h.store.GetJob(rec.JobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The job has since been deleted; queue a new one under the key.
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, newRequestError(http.StatusInternalServerError, err.Error(), err)
+	}
+	return job, false, nil
+}
+
 func (h *Handler) scheduleWeightInstall(ctx context.Context, req installWeightsRequest) (*installScheduleResult, error) {
 	if h.weights == nil || h.vllm == nil {
 		return nil, newRequestError(http.StatusNotImplemented, "weight installation is disabled", nil)
@@ -1338,10 +3414,58 @@ func (h *Handler) scheduleWeightInstall(ctx context.Context, req installWeightsR
 	}
 	req.Target = targetName
 
+	layout := h.storageLayout()
+	storageURI := layout.StorageURI(targetName)
+	inferencePath := layout.InferencePath(targetName)
+
+	// Idempotency keys only apply to queued installs, which have a job to
+	// hand back, and need the datastore to remember them. The key is claimed
+	// up front and released again if no job ends up recorded against it.
+	var recordJob func(jobID string)
+	if req.IdempotencyKey != "" && h.jobs != nil && h.store != nil {
+		key := "weights.install:" + req.IdempotencyKey
+		job, owned, err := h.claimIdempotencyKey(key, req)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return &installScheduleResult{
+				Async:         true,
+				Replayed:      true,
+				Job:           job,
+				Target:        targetName,
+				StorageURI:    storageURI,
+				InferencePath: inferencePath,
+			}, nil
+		}
+		recorded := false
+		defer func() {
+			if recorded {
+				return
+			}
+			if err := h.store.ReleaseIdempotencyKey(key); err != nil {
+				log.Printf("Failed to release idempotency key: %v", err)
+			}
+		}()
+		recordJob = func(jobID string) {
+			if err := h.store.SetIdempotencyJob(key, jobID); err != nil {
+				log.Printf("Failed to record idempotency key for job %s: %v", jobID, err)
+				return
+			}
+			recorded = true
+		}
+	}
+
 	hfModel, err := h.fetchAndValidateHFModel(req.HFModelID)
 	if err != nil {
+		if status, msg, ok := huggingFaceError(err); ok {
+			return nil, newRequestError(status, msg, err)
+		}
 		return nil, newRequestError(http.StatusBadRequest, err.Error(), err)
 	}
+	if err := h.checkInstallPolicies(req.HFModelID, hfModel); err != nil {
+		return nil, err
+	}
 
 	files := req.Files
 	if len(files) == 0 {
@@ -1350,25 +3474,28 @@ func (h *Handler) scheduleWeightInstall(ctx context.Context, req installWeightsR
 	if len(files) == 0 {
 		return nil, newRequestError(http.StatusBadRequest, "no downloadable files found for model", nil)
 	}
-
-	storageURI := ""
-	if h.opts.WeightsPVCName != "" {
-		storageURI = fmt.Sprintf("pvc://%s/%s", h.opts.WeightsPVCName, targetName)
+	if err := h.checkWeightCapacity(ctx, hfModel, files, targetName); err != nil {
+		return nil, err
 	}
-	inferencePath := path.Join(h.opts.InferenceModelRoot, targetName)
 
 	if h.jobs != nil {
 		payload := jobs.InstallRequest{
-			ModelID:   req.HFModelID,
-			Revision:  req.Revision,
-			Target:    req.Target,
-			Files:     files,
-			Overwrite: req.Overwrite,
+			ModelID:           req.HFModelID,
+			Revision:          req.Revision,
+			Target:            req.Target,
+			Files:             files,
+			Overwrite:         req.Overwrite,
+			RequestID:         logutil.RequestID(ctx),
+			Priority:          req.Priority,
+			FallbackRevisions: req.FallbackRevisions,
 		}
 		job, err := h.jobs.CreateJob(payload)
 		if err != nil {
 			return nil, newRequestError(http.StatusInternalServerError, err.Error(), err)
 		}
+		if recordJob != nil {
+			recordJob(job.ID)
+		}
 
 		runCtx := ctx
 		if runCtx == nil {
@@ -1405,34 +3532,48 @@ func (h *Handler) scheduleWeightInstall(ctx context.Context, req installWeightsR
 	runCtx, cancel := context.WithTimeout(runCtx, timeout)
 	defer cancel()
 
-	info, err := h.weights.InstallFromHuggingFace(runCtx, weights.InstallOptions{
-		ModelID:   req.HFModelID,
-		Revision:  req.Revision,
-		Target:    req.Target,
-		Files:     files,
-		Token:     h.opts.HuggingFaceToken,
-		Overwrite: req.Overwrite,
-	})
+	var info *weights.WeightInfo
+	revisions := weights.RevisionCandidates(req.Revision, req.FallbackRevisions)
+	for _, revision := range revisions {
+		info, err = h.weights.InstallFromHuggingFace(runCtx, weights.InstallOptions{
+			ModelID:           req.HFModelID,
+			Revision:          revision,
+			RequestedRevision: revisions[0],
+			Target:            req.Target,
+			Files:             files,
+			Token:             h.opts.HuggingFaceToken,
+			Overwrite:         req.Overwrite,
+		})
+		if err == nil || !weights.IsRevisionNotFound(err) {
+			break
+		}
+		log.Printf("Revision %s of %s is missing files: %v", revision, req.HFModelID, err)
+	}
+	metrics.ObserveInstall(err == nil)
 	if err != nil {
 		log.Printf("Failed to install weights for %s: %v", req.HFModelID, err)
 		return nil, newRequestError(http.StatusInternalServerError, err.Error(), err)
 	}
 
-	storageURI = ""
-	if h.opts.WeightsPVCName != "" {
-		storageURI = fmt.Sprintf("pvc://%s/%s", h.opts.WeightsPVCName, info.Name)
-	}
-	modelPath := path.Join(h.opts.InferenceModelRoot, info.Name)
-
 	return &installScheduleResult{
 		Async:         false,
 		Weight:        info,
 		Target:        info.Name,
-		StorageURI:    storageURI,
-		InferencePath: modelPath,
+		StorageURI:    layout.StorageURI(info.Name),
+		InferencePath: layout.InferencePath(info.Name),
 	}, nil
 }
 
+func (h *Handler) storageLayout() weights.StorageLayout {
+	return weights.StorageLayout{
+		Backend:   h.opts.StorageBackend,
+		PVCName:   h.opts.WeightsPVCName,
+		Bucket:    h.opts.StorageBucket,
+		Prefix:    h.opts.StoragePrefix,
+		ModelRoot: h.opts.InferenceModelRoot,
+	}
+}
+
 // ListSecrets returns metadata for managed secrets.
 func (h *Handler) ListSecrets(c *gin.Context) {
 	if !h.ensureSecretManager(c) {
@@ -1492,12 +3633,16 @@ func (h *Handler) ApplySecret(c *gin.Context) {
 		return
 	}
 	record, err := h.secrets.Upsert(c.Request.Context(), name, req.Data)
+	if errors.Is(err, secrets.ErrNoMasterKey) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error(), "hint": "set SECRETS_MASTER_KEY"})
+		return
+	}
 	if err != nil {
 		log.Printf("Failed to apply secret %s: %v", name, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save secret"})
 		return
 	}
-	h.recordHistory("secret_applied", name, map[string]interface{}{"keys": len(req.Data)})
+	h.recordHistory(c.Request.Context(), "secret_applied", name, map[string]interface{}{"keys": len(req.Data)})
 	c.JSON(http.StatusOK, record)
 }
 
@@ -1520,7 +3665,7 @@ func (h *Handler) DeleteSecret(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete secret"})
 		return
 	}
-	h.recordHistory("secret_deleted", name, nil)
+	h.recordHistory(c.Request.Context(), "secret_deleted", name, nil)
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
 
@@ -1576,7 +3721,7 @@ func (h *Handler) ApplyPlaybook(c *gin.Context) {
 	}
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read payload"})
+		respondBodyReadError(c, err, "failed to read payload")
 		return
 	}
 	payload, err := decodePlaybookPayload(body)
@@ -1606,7 +3751,7 @@ func (h *Handler) ApplyPlaybook(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save playbook"})
 		return
 	}
-	h.recordHistory("playbook_saved", name, map[string]interface{}{"tags": len(payload.Tags)})
+	h.recordHistory(c.Request.Context(), "playbook_saved", name, map[string]interface{}{"tags": len(payload.Tags)})
 	c.JSON(http.StatusOK, record)
 }
 
@@ -1630,7 +3775,7 @@ func (h *Handler) DeletePlaybook(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete playbook"})
 		return
 	}
-	h.recordHistory("playbook_deleted", name, nil)
+	h.recordHistory(c.Request.Context(), "playbook_deleted", name, nil)
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
 
@@ -1730,7 +3875,10 @@ func (h *Handler) RunPlaybook(c *gin.Context) {
 			step["status"] = "pending_install"
 			steps["activate"] = step
 		} else {
-			model, result, actErr := h.activateModelInternal(c.GetString("subject"), modelID)
+			model, result, actErr := h.activateModelInternal(c.Request.Context(), c.GetString("subject"), modelID, activationOptions{
+				strategy:       spec.Activate.Strategy,
+				trafficPercent: spec.Activate.TrafficPercent,
+			})
 			if actErr != nil {
 				h.respondActivationError(c, actErr)
 				return
@@ -1738,7 +3886,7 @@ func (h *Handler) RunPlaybook(c *gin.Context) {
 			step["status"] = "completed"
 			step["model"] = model
 			step["inferenceservice"] = result
-			steps["activate"] = step
+			steps["activate"] = addLifecycleWarning(step, model)
 		}
 	}
 
@@ -1747,13 +3895,10 @@ func (h *Handler) RunPlaybook(c *gin.Context) {
 		return
 	}
 
-	h.recordHistory("playbook_run", name, map[string]interface{}{
+	h.recordHistory(c.Request.Context(), "playbook_run", name, map[string]interface{}{
 		"steps": len(steps),
 	})
-	h.publishEvent("playbook.run", gin.H{
-		"name":  name,
-		"steps": steps,
-	})
+	h.publishEvent(c.Request.Context(), events.PlaybookRun{Name: name, Steps: steps})
 
 	response := gin.H{
 		"status":   "accepted",
@@ -1797,6 +3942,10 @@ func (h *Handler) ApplyNotification(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !notify.ValidType(req.Type) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported notification type %q (use slack, webhook, or msteams)", req.Type)})
+		return
+	}
 	record := &store.Notification{
 		Name:     name,
 		Type:     req.Type,
@@ -1808,7 +3957,7 @@ func (h *Handler) ApplyNotification(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save notification"})
 		return
 	}
-	h.recordHistory("notification_upserted", "", map[string]interface{}{"name": name, "type": req.Type})
+	h.recordHistory(c.Request.Context(), "notification_upserted", "", map[string]interface{}{"name": name, "type": req.Type})
 	c.JSON(http.StatusOK, record)
 }
 
@@ -1832,7 +3981,7 @@ func (h *Handler) DeleteNotification(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete notification"})
 		return
 	}
-	h.recordHistory("notification_deleted", "", map[string]interface{}{"name": name})
+	h.recordHistory(c.Request.Context(), "notification_deleted", "", map[string]interface{}{"name": name})
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
 
@@ -1909,7 +4058,7 @@ func (h *Handler) RotateNotification(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rotate notification"})
 		return
 	}
-	h.recordHistory("notification_rotated", "", map[string]interface{}{"name": name})
+	h.recordHistory(c.Request.Context(), "notification_rotated", "", map[string]interface{}{"name": name})
 	c.JSON(http.StatusOK, record)
 }
 
@@ -1921,30 +4070,44 @@ func (h *Handler) ensureSecretManager(c *gin.Context) bool {
 	return true
 }
 
-func postSlackMessage(webhook, message string) error {
-	if webhook == "" {
-		return fmt.Errorf("webhook empty")
+// NotificationDeliveries returns recent delivery attempts recorded for a channel.
+func (h *Handler) NotificationDeliveries(c *gin.Context) {
+	if h.store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
+		return
 	}
-	payload := map[string]string{"text": message}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return err
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
 	}
-	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewReader(body))
+	limit := parseLimit(c, "limit", 20, 200)
+	deliveries, err := h.store.ListNotificationDeliveries(name, limit)
 	if err != nil {
-		return err
+		log.Printf("Failed to list deliveries for %s: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load deliveries"})
+		return
 	}
-	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	if deliveries == nil {
+		deliveries = []store.NotificationDelivery{}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("slack webhook returned %s", resp.Status)
+	c.JSON(http.StatusOK, gin.H{"channel": name, "deliveries": deliveries})
+}
+
+// notify queues an event for every configured notification channel so slow
+// webhooks never hold up the request; it is dropped when the queue is full.
+func (h *Handler) notify(event, modelID, message string, meta map[string]interface{}) {
+	if h.notifications == nil {
+		return
 	}
-	return nil
+	evt := notify.Event{
+		Name:      event,
+		ModelID:   modelID,
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+		Metadata:  meta,
+	}
+	h.notifications.Broadcast(evt)
 }
 
 // ListTokens returns issued API tokens (metadata only).
@@ -2010,7 +4173,7 @@ func (h *Handler) IssueToken(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store token"})
 		return
 	}
-	h.recordHistory("api_token_issued", "", map[string]interface{}{"id": record.ID, "name": record.Name})
+	h.recordHistory(c.Request.Context(), "api_token_issued", "", map[string]interface{}{"id": record.ID, "name": record.Name})
 	c.JSON(http.StatusCreated, gin.H{
 		"token":     plain,
 		"tokenId":   record.ID,
@@ -2041,7 +4204,7 @@ func (h *Handler) DeleteToken(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete token"})
 		return
 	}
-	h.recordHistory("api_token_revoked", "", map[string]interface{}{"id": id})
+	h.recordHistory(c.Request.Context(), "api_token_revoked", "", map[string]interface{}{"id": id})
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
 
@@ -2065,16 +4228,6 @@ func normalizeScopes(scopes []string) []string {
 	return out
 }
 
-func parseSince(value string) (time.Time, error) {
-	if d, err := time.ParseDuration(value); err == nil {
-		return time.Now().Add(-d), nil
-	}
-	if ts, err := time.Parse(time.RFC3339, value); err == nil {
-		return ts, nil
-	}
-	return time.Time{}, fmt.Errorf("invalid since value")
-}
-
 // ListPolicies returns stored policy documents.
 func (h *Handler) ListPolicies(c *gin.Context) {
 	if h.store == nil {
@@ -2106,6 +4259,10 @@ func (h *Handler) ApplyPolicy(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if _, err := policy.Parse(name, req.Document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	policy := &store.Policy{
 		Name:      name,
 		Document:  req.Document,
@@ -2116,7 +4273,7 @@ func (h *Handler) ApplyPolicy(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save policy"})
 		return
 	}
-	h.recordHistory("policy_applied", "", map[string]interface{}{"name": name})
+	h.recordHistory(c.Request.Context(), "policy_applied", "", map[string]interface{}{"name": name})
 	c.JSON(http.StatusOK, policy)
 }
 
@@ -2163,15 +4320,15 @@ func (h *Handler) ListPolicyVersions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"versions": versions})
 }
 
-// LintPolicy validates that the supplied document is valid JSON.
+// LintPolicy validates the supplied document the way ApplyPolicy does.
 func (h *Handler) LintPolicy(c *gin.Context) {
 	var req policyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if !json.Valid([]byte(req.Document)) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "policy document must be valid JSON"})
+	if _, err := policy.Parse("document", req.Document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -2223,7 +4380,7 @@ func (h *Handler) RollbackPolicy(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	policy, err := h.store.RollbackPolicy(name, req.Version)
+	target, err := h.store.GetPolicyVersion(name, req.Version)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, sql.ErrNoRows) {
@@ -2232,7 +4389,19 @@ func (h *Handler) RollbackPolicy(c *gin.Context) {
 		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
-	h.recordHistory("policy_rolled_back", "", map[string]interface{}{"name": name, "version": req.Version})
+	// Old revisions may predate validation; restoring one that no longer
+	// parses would block every install and activation.
+	if _, err := policy.Parse(name, target.Document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("version %d cannot be restored: %v", target.Version, err)})
+		return
+	}
+	policy, err := h.store.RollbackPolicy(name, target.Version)
+	if err != nil {
+		log.Printf("Failed to roll back policy %s: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.recordHistory(c.Request.Context(), "policy_rolled_back", "", map[string]interface{}{"name": name, "version": target.Version})
 	c.JSON(http.StatusOK, policy)
 }
 
@@ -2256,7 +4425,7 @@ func (h *Handler) DeletePolicy(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete policy"})
 		return
 	}
-	h.recordHistory("policy_deleted", "", map[string]interface{}{"name": name})
+	h.recordHistory(c.Request.Context(), "policy_deleted", "", map[string]interface{}{"name": name})
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
 
@@ -2299,7 +4468,7 @@ func (h *Handler) RecordBackup(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record backup"})
 		return
 	}
-	h.recordHistory("backup_recorded", "", map[string]interface{}{"type": req.Type, "location": req.Location})
+	h.recordHistory(c.Request.Context(), "backup_recorded", "", map[string]interface{}{"type": req.Type, "location": req.Location})
 	c.JSON(http.StatusCreated, rec)
 }
 
@@ -2324,17 +4493,27 @@ func (h *Handler) CleanupWeights(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "names is required"})
 		return
 	}
+	force := req.Force || parseBool(c, "force")
+	refs, err := h.weightReferences()
+	if err != nil && !force {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error(), "hint": "pass force=true to delete anyway"})
+		return
+	}
 	results := make(map[string]string)
 	for _, name := range req.Names {
 		name = strings.TrimSpace(name)
 		if name == "" {
 			continue
 		}
+		if inUse := refs[normalizeWeightName(name)]; len(inUse) > 0 && !force {
+			results[name] = weightInUseMessage(inUse)
+			continue
+		}
 		if err := h.weights.Delete(name); err != nil {
 			results[name] = err.Error()
 		} else {
 			results[name] = "deleted"
-			h.recordHistory("weight_deleted", name, nil)
+			h.recordHistory(c.Request.Context(), "weight_deleted", name, nil)
 		}
 	}
 	c.JSON(http.StatusOK, gin.H{"results": results})
@@ -2362,11 +4541,12 @@ func (h *Handler) RestoreBackup(c *gin.Context) {
 	if req.Notes != "" {
 		meta["notes"] = req.Notes
 	}
-	h.recordHistory("backup_restore_requested", "", meta)
+	h.recordHistory(c.Request.Context(), "backup_restore_requested", "", meta)
 	c.JSON(http.StatusAccepted, gin.H{"status": "scheduled"})
 }
 
 type notificationRequest struct {
+	Channel string `json:"channel"`
 	Message string `json:"message"`
 }
 type notificationConfigRequest struct {
@@ -2399,6 +4579,7 @@ type backupRequest struct {
 
 type cleanupWeightsRequest struct {
 	Names []string `json:"names" binding:"required"`
+	Force bool     `json:"force,omitempty"`
 }
 
 type restoreBackupRequest struct {
@@ -2413,28 +4594,47 @@ type rollbackPolicyRequest struct {
 
 // TestNotification sends a one-off notification via the configured channel.
 func (h *Handler) TestNotification(c *gin.Context) {
-	if h.opts.SlackWebhookURL == "" {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "notification channel not configured"})
-		return
-	}
 	var req notificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	channel := strings.TrimSpace(req.Channel)
+	record := &store.Notification{Name: notify.DefaultChannel, Type: notify.TypeSlack, Target: h.opts.SlackWebhookURL}
+	if channel != "" {
+		if h.store == nil {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
+			return
+		}
+		stored, err := h.store.GetNotification(channel)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load notification"})
+			return
+		}
+		record = stored
+	} else {
+		channel = notify.DefaultChannel
+	}
+	if record.Target == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "notification channel not configured"})
+		return
+	}
 	message := strings.TrimSpace(req.Message)
 	if message == "" {
 		message = fmt.Sprintf("Model Manager notification triggered at %s", time.Now().UTC().Format(time.RFC3339))
 	}
-	if err := postSlackMessage(h.opts.SlackWebhookURL, message); err != nil {
+	evt := notify.Event{Name: "test", Message: message, Timestamp: time.Now().UTC()}
+	if err := h.notifier.Deliver(*record, evt); err != nil {
 		log.Printf("Failed to send notification: %v", err)
-		h.recordHistory("notification_failed", "", map[string]interface{}{"message": message, "error": err.Error()})
 		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to deliver notification"})
 		return
 	}
-	h.recordHistory("notification_delivery", "", map[string]interface{}{"message": message})
-	h.recordHistory("notification_test", "", map[string]interface{}{"message": message})
-	c.JSON(http.StatusOK, gin.H{"status": "sent"})
+	h.recordHistory(c.Request.Context(), "notification_test", "", map[string]interface{}{"name": channel, "message": message})
+	c.JSON(http.StatusOK, gin.H{"status": "sent", "channel": channel})
 }
 
 // ListVLLMArchitectures lists vLLM supported architectures.
@@ -2465,7 +4665,7 @@ func (h *Handler) GetVLLMArchitecture(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "architecture is required"})
 		return
 	}
-	detail, err := h.vllm.GetArchitectureDetail(name)
+	detail, err := h.vllm.GetArchitectureDetail(name, parseBool(c, "inline"))
 	if err != nil {
 		status := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "not found") {
@@ -2513,8 +4713,12 @@ func (h *Handler) DescribeVLLMModel(c *gin.Context) {
 		return
 	}
 
-	info, err := h.vllm.DescribeModel(req.HFModelID, req.AutoDetect)
+	info, err := h.describeModel(req.HFModelID, req.AutoDetect, req.IncludeCard)
 	if err != nil {
+		if status, msg, ok := huggingFaceError(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		log.Printf("Failed to describe model %s: %v", req.HFModelID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -2523,20 +4727,89 @@ func (h *Handler) DescribeVLLMModel(c *gin.Context) {
 	response := gin.H{"insight": info}
 
 	if h.advisor != nil && info.SuggestedCatalog != nil {
-		profiles := h.advisor.Profiles()
-		recs := make([]recommendations.Recommendation, 0, len(profiles))
-		compat := make([]recommendations.CompatibilityReport, 0, len(profiles))
-		for _, profile := range profiles {
-			recs = append(recs, h.advisor.RecommendForModel(info.SuggestedCatalog, profile.Name))
-			compat = append(compat, h.advisor.Compatibility(info.SuggestedCatalog, profile.Name))
+		var hfConfig map[string]interface{}
+		if info.HFModel != nil {
+			hfConfig = info.HFModel.Config
 		}
+		recs, compat, skipped := h.evaluateProfiles(c.Request.Context(), info.SuggestedCatalog, hfConfig)
 		response["recommendations"] = recs
 		response["compatibility"] = compat
+		if len(skipped) > 0 {
+			response["notes"] = []string{fmt.Sprintf("profile evaluation timed out; skipped %s", strings.Join(skipped, ", "))}
+			response["partial"] = true
+		}
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// evaluateProfiles runs recommendations/compatibility for every GPU profile with
+// bounded concurrency, returning whatever finished before the deadline. hfConfig
+// (the model's Hugging Face config, may be nil) sharpens the VRAM estimate.
+func (h *Handler) evaluateProfiles(ctx context.Context, model *catalog.Model, hfConfig map[string]interface{}) ([]recommendations.Recommendation, []recommendations.CompatibilityReport, []string) {
+	profiles := h.advisor.Profiles()
+	ctx, cancel := context.WithTimeout(ctx, h.opts.DescribeTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	recs := make([]*recommendations.Recommendation, len(profiles))
+	compat := make([]*recommendations.CompatibilityReport, len(profiles))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g, gctx := errgroup.WithContext(ctx)
+		g.SetLimit(h.opts.DescribeConcurrency)
+		for i, profile := range profiles {
+			if gctx.Err() != nil {
+				break
+			}
+			g.Go(func() error {
+				if gctx.Err() != nil {
+					return nil
+				}
+				rec := h.advisor.RecommendForModel(model, profile.Name)
+				report := h.advisor.CompatibilityWithConfig(model, profile.Name, hfConfig)
+				mu.Lock()
+				recs[i] = &rec
+				compat[i] = &report
+				mu.Unlock()
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	outRecs := make([]recommendations.Recommendation, 0, len(profiles))
+	outCompat := make([]recommendations.CompatibilityReport, 0, len(profiles))
+	var skipped []string
+	for i, profile := range profiles {
+		if recs[i] == nil || compat[i] == nil {
+			skipped = append(skipped, profile.Name)
+			continue
+		}
+		outRecs = append(outRecs, *recs[i])
+		outCompat = append(outCompat, *compat[i])
+	}
+	return outRecs, outCompat, skipped
+}
+
+// describeModel describes a Hugging Face model, fetching its model card only
+// when asked since that costs an extra Hub request.
+func (h *Handler) describeModel(id string, autoDetect, includeCard bool) (*vllm.ModelInsight, error) {
+	if includeCard {
+		return h.vllm.DescribeModelWithCard(id, autoDetect)
+	}
+	return h.vllm.DescribeModel(id, autoDetect)
+}
+
 // GetHuggingFaceModel exposes metadata via REST-friendly GET.
 func (h *Handler) GetHuggingFaceModel(c *gin.Context) {
 	if h.vllm == nil {
@@ -2546,8 +4819,12 @@ func (h *Handler) GetHuggingFaceModel(c *gin.Context) {
 	id := strings.TrimPrefix(c.Param("id"), "/")
 	autoDetect := c.Query("autoDetect") == "true"
 
-	info, err := h.vllm.DescribeModel(id, autoDetect)
+	info, err := h.describeModel(id, autoDetect, c.Query("includeCard") == "true")
 	if err != nil {
+		if status, msg, ok := huggingFaceError(err); ok {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
 		status := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "not found") {
 			status = http.StatusNotFound
@@ -2558,6 +4835,29 @@ func (h *Handler) GetHuggingFaceModel(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"insight": info})
 }
 
+// ListDiscoveredModels returns the draft catalog entries the sync service
+// generated for compatible Hugging Face discoveries.
+func (h *Handler) ListDiscoveredModels(c *gin.Context) {
+	if h.store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
+		return
+	}
+	models, updated, err := h.store.LoadDiscoveredModels()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusOK, gin.H{"models": []*catalog.Model{}, "count": 0})
+			return
+		}
+		log.Printf("Failed to load discovered models: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load discovered models"})
+		return
+	}
+	if models == nil {
+		models = []*catalog.Model{}
+	}
+	c.JSON(http.StatusOK, gin.H{"models": models, "count": len(models), "updatedAt": updated})
+}
+
 // SearchHuggingFace proxies HF search for discoverability.
 func (h *Handler) SearchHuggingFace(c *gin.Context) {
 	if h.vllm == nil {
@@ -2655,7 +4955,54 @@ func (h *Handler) GenerateCatalogEntry(c *gin.Context) {
 			response["status"] = "warning"
 		}
 	}
+	h.attachGPUFit(response, model)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PreviewCatalogPR shows the diff CreateCatalogPR would commit for a model
+// without writing to disk, so reviewers can tell a new entry from an edit to an
+// existing one.
+func (h *Handler) PreviewCatalogPR(c *gin.Context) {
+	if h.writer == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog contribution automation is disabled"})
+		return
+	}
+
+	var req catalogPRRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Model.ID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "model.id is required"})
+		return
+	}
+
+	model := req.Model
+	result, diff, err := h.writer.Diff(&model)
+	if err != nil {
+		log.Printf("Failed to diff catalog entry: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
+	action := "create"
+	switch {
+	case result.Exists && diff == "":
+		action = "unchanged"
+	case result.Exists:
+		action = "update"
+	}
+	response := gin.H{
+		"action": action,
+		"file":   result.RelativePath,
+		"exists": result.Exists,
+		"diff":   diff,
+	}
+	if req.Validate && h.checker != nil {
+		response["validation"] = h.checker.Validate(c.Request.Context(), nil, &model)
+	}
 	c.JSON(http.StatusOK, response)
 }
 
@@ -2691,7 +5038,103 @@ func (h *Handler) CreateCatalogPR(c *gin.Context) {
 		}
 	}
 
-	saveResult, err := h.writer.Save(&model)
+	response := gin.H{}
+	if validation != nil {
+		response["validation"] = validation
+	}
+	h.submitCatalogPR(c, &model, req, response)
+}
+
+// PatchCatalogModel applies a JSON merge patch (RFC 7386) from the request body
+// to the catalog entry on disk, re-validates it, and opens a pull request so
+// fields the caller did not mention are left untouched. PR options come from
+// the branch, base, title, body, and draft query parameters.
+func (h *Handler) PatchCatalogModel(c *gin.Context) {
+	if h.writer == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "catalog contribution automation is disabled"})
+		return
+	}
+
+	id := c.Param("id")
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respondBodyReadError(c, err, "failed to read request body")
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "body must be a JSON merge patch object"})
+		return
+	}
+
+	current, err := h.writer.Load(id)
+	if errors.Is(err, catalogwriter.ErrModelNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("model %s not found", id)})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to load catalog entry %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	patched, err := catalogwriter.ApplyMergePatch(current, patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if patched.ID != id {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "model id cannot be changed by a patch"})
+		return
+	}
+
+	saveResult, diff, err := h.writer.Diff(patched)
+	if err != nil {
+		log.Printf("Failed to diff catalog entry %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if diff == "" {
+		c.JSON(http.StatusOK, gin.H{"status": "unchanged", "file": saveResult.RelativePath})
+		return
+	}
+
+	response := gin.H{"diff": diff}
+	if h.checker != nil {
+		result := h.checker.Validate(c.Request.Context(), nil, patched)
+		if !result.Valid {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":      "model validation failed",
+				"validation": result,
+			})
+			return
+		}
+		response["validation"] = result
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, "`"+key+"`")
+	}
+	sort.Strings(keys)
+	draft, _ := strconv.ParseBool(c.Query("draft"))
+	req := catalogPRRequest{
+		Branch: c.Query("branch"),
+		Base:   c.Query("base"),
+		Title:  c.Query("title"),
+		Body:   c.Query("body"),
+		Draft:  draft,
+	}
+	if req.Body == "" {
+		req.Body = fmt.Sprintf("Patches %s on `%s`.", strings.Join(keys, ", "), modelDisplayName(patched))
+	}
+	h.submitCatalogPR(c, patched, req, response)
+}
+
+// submitCatalogPR saves model, commits it to a branch, and opens a pull request
+// when a GitHub token is configured. response carries any extra fields for the
+// reply.
+func (h *Handler) submitCatalogPR(c *gin.Context, model *catalog.Model, req catalogPRRequest, response gin.H) {
+	saveResult, err := h.writer.Save(model)
 	if err != nil {
 		log.Printf("Failed to save catalog entry: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -2705,12 +5148,15 @@ func (h *Handler) CreateCatalogPR(c *gin.Context) {
 
 	title := req.Title
 	if title == "" {
-		title = fmt.Sprintf("Add model %s", modelDisplayName(&model))
+		title = fmt.Sprintf("Add model %s", modelDisplayName(model))
+		if saveResult.Exists {
+			title = fmt.Sprintf("Update model %s", modelDisplayName(model))
+		}
 	}
 
 	body := req.Body
 	if body == "" {
-		body = fmt.Sprintf("Automated catalog entry for `%s`.", modelDisplayName(&model))
+		body = fmt.Sprintf("Automated catalog entry for `%s`.", modelDisplayName(model))
 	}
 
 	if err := h.writer.CommitAndPush(c.Request.Context(), branch, req.Base, title, saveResult.RelativePath); err != nil {
@@ -2719,14 +5165,9 @@ func (h *Handler) CreateCatalogPR(c *gin.Context) {
 		return
 	}
 
-	response := gin.H{
-		"status": "success",
-		"branch": branch,
-		"file":   saveResult.RelativePath,
-	}
-	if validation != nil {
-		response["validation"] = validation
-	}
+	response["status"] = "success"
+	response["branch"] = branch
+	response["file"] = saveResult.RelativePath
 
 	if h.opts.GitHubToken == "" {
 		response["message"] = "changes committed locally; set GITHUB_TOKEN to enable automatic PR creation"
@@ -2767,8 +5208,204 @@ func (h *Handler) GetModelManifest(c *gin.Context) {
 		return
 	}
 
-	manifest := h.kserve.RenderManifest(model)
-	c.JSON(http.StatusOK, gin.H{"manifest": manifest, "model": model})
+	manifest := h.kserve.RenderManifest(model, h.modelProvenance(model))
+	c.JSON(http.StatusOK, gin.H{"manifest": manifest, "model": model})
+}
+
+// GetModelPlan aggregates everything that would matter if the model were deployed:
+// rendered manifest, storage/weights status, GPU fit, policies and validation.
+func (h *Handler) GetModelPlan(c *gin.Context) {
+	if err := h.ensureCatalogFresh(false); err != nil {
+		log.Printf("Failed to ensure catalog freshness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load model catalog"})
+		return
+	}
+
+	modelID := c.Param("id")
+	model := h.catalog.Get(modelID)
+	if model == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "model not found"})
+		return
+	}
+
+	var warnings []string
+	plan := gin.H{
+		"modelId":     model.ID,
+		"displayName": modelDisplayName(model),
+		"catalogHash": catalog.ContentHash(model),
+		"generatedAt": time.Now().UTC(),
+	}
+
+	if h.kserve != nil {
+		plan["manifest"] = h.kserve.RenderManifest(model, h.modelProvenance(model))
+	} else {
+		warnings = append(warnings, "kserve client not configured; manifest not rendered")
+	}
+
+	storage := h.planStorage(model)
+	if installed, _ := storage["installed"].(bool); !installed {
+		if msg, ok := storage["message"].(string); ok && msg != "" {
+			warnings = append(warnings, msg)
+		}
+	}
+	plan["storage"] = storage
+
+	if h.advisor != nil {
+		report := h.advisor.Compatibility(model, c.Query("gpuType"))
+		plan["compatibility"] = report
+		plan["tensorParallel"] = planTensorParallel(model, report.EstimatedVRAMGB, h.advisor.Profiles())
+		if report.GPUType != "" && !report.Compatible {
+			warnings = append(warnings, report.Reason)
+		}
+	} else {
+		warnings = append(warnings, "recommendation service disabled; GPU fit not evaluated")
+	}
+
+	plan["policies"] = h.planPolicies(model)
+
+	if h.checker != nil {
+		result := h.checker.Validate(c.Request.Context(), nil, model)
+		plan["validation"] = result
+		for _, check := range result.Checks {
+			if check.Status != validator.StatusPass {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", check.Name, check.Message))
+			}
+		}
+		warnings = append(warnings, result.Errors...)
+	} else {
+		warnings = append(warnings, "catalog validation disabled")
+	}
+
+	plan["warnings"] = warnings
+	c.JSON(http.StatusOK, plan)
+}
+
+func (h *Handler) planStorage(model *catalog.Model) gin.H {
+	storage := gin.H{
+		"storageUri": model.StorageURI,
+		"installed":  false,
+	}
+	loc, ok := h.storageLayout().Resolve(model.StorageURI)
+	if !ok {
+		if model.StorageURI == "" {
+			storage["message"] = "model has no storageUri configured"
+		} else {
+			storage["message"] = "storageUri is not backed by the configured weights storage"
+		}
+		return storage
+	}
+	storage["backend"] = loc.Backend
+	if loc.Backend == weights.StorageBackendPVC {
+		storage["pvc"] = loc.Volume
+	} else {
+		storage["bucket"] = loc.Volume
+	}
+	storage["path"] = loc.Name
+	if h.weights == nil {
+		storage["message"] = "weight management is disabled"
+		return storage
+	}
+	if loc.Name == "" {
+		storage["message"] = "storageUri does not include a weights path"
+		return storage
+	}
+	info, err := h.weights.Get(loc.Name)
+	if err != nil || info == nil {
+		storage["message"] = fmt.Sprintf("weights for %s are not installed", loc.Name)
+		return storage
+	}
+	storage["installed"] = true
+	storage["weights"] = info
+	return storage
+}
+
+// modelProvenance looks up the installed weights behind a model's storageUri.
+// It returns nil when the model isn't served from the weights storage or the
+// weights aren't installed.
+func (h *Handler) modelProvenance(model *catalog.Model) *kserve.Provenance {
+	if h.weights == nil || model == nil {
+		return nil
+	}
+	name, ok := h.weightNameForURI(model.StorageURI)
+	if !ok {
+		return nil
+	}
+	info, err := h.weights.Get(name)
+	if err != nil || info == nil {
+		return nil
+	}
+	return &kserve.Provenance{
+		HFModelID:   info.HFModelID,
+		Revision:    info.Revision,
+		InstalledAt: info.InstalledAt,
+	}
+}
+
+// planPolicies reports whether activating model would pass the stored
+// policies.
+func (h *Handler) planPolicies(model *catalog.Model) gin.H {
+	if h.store == nil {
+		return gin.H{"evaluated": false, "message": "persistent store not configured"}
+	}
+	policies, err := h.loadPolicies()
+	if err != nil {
+		return gin.H{"evaluated": false, "message": err.Error()}
+	}
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	result := gin.H{"evaluated": true, "policies": names, "allowed": true}
+	if err := h.checkActivationPolicies(model); err != nil {
+		var violation *policy.Violation
+		if !errors.As(err, &violation) {
+			return gin.H{"evaluated": false, "policies": names, "message": err.Error()}
+		}
+		result["allowed"] = false
+		result["violation"] = violation
+	}
+	return result
+}
+
+// planTensorParallel sizes tensor parallelism per GPU profile the same way
+// recommendations do, marking profiles where even a full node falls short.
+func planTensorParallel(model *catalog.Model, requiredGB int, profiles []recommendations.GPUProfile) gin.H {
+	result := gin.H{}
+	if model != nil && model.VLLM != nil && model.VLLM.TensorParallelSize != nil {
+		result["configured"] = *model.VLLM.TensorParallelSize
+	}
+	options := make([]gin.H, 0, len(profiles))
+	for _, profile := range profiles {
+		if profile.MemoryGB <= 0 {
+			continue
+		}
+		maxGPUs := recommendations.MaxGPUsPerNode(profile)
+		option := gin.H{
+			"gpu":            profile.Name,
+			"memoryGB":       profile.MemoryGB,
+			"maxGpusPerNode": maxGPUs,
+		}
+		if tp, feasible := recommendations.TensorParallelSize(requiredGB, profile); feasible {
+			option["feasible"] = true
+			option["gpus"] = tp
+		} else {
+			option["feasible"] = false
+			option["reason"] = fmt.Sprintf("~%d GiB required but %d× %s provide only %d GiB", requiredGB, maxGPUs, profile.Name, maxGPUs*profile.MemoryGB)
+		}
+		options = append(options, option)
+	}
+	result["options"] = options
+	return result
+}
+
+// weightNameForURI resolves a storage URI of any supported scheme to the
+// installed weight directory it serves from.
+func (h *Handler) weightNameForURI(uri string) (string, bool) {
+	loc, ok := h.storageLayout().Resolve(uri)
+	if !ok || loc.Name == "" {
+		return "", false
+	}
+	return loc.Name, true
 }
 
 // PreviewCatalog validates an ad-hoc catalog entry and returns the manifest.
@@ -2788,28 +5425,137 @@ func (h *Handler) PreviewCatalog(c *gin.Context) {
 		}
 	}
 
-	result["manifest"] = h.kserve.RenderManifest(&model)
+	h.attachGPUFit(result, &model)
+
+	result["manifest"] = h.kserve.RenderManifest(&model, h.modelProvenance(&model))
 
 	c.JSON(http.StatusOK, result)
 }
 
+// attachGPUFit adds a per-profile compatibility array to response when the
+// model requests GPUs, flagging a warning if no known profile can host it.
+func (h *Handler) attachGPUFit(response gin.H, model *catalog.Model) {
+	reports, fits := h.gpuProfileFit(model)
+	if reports == nil {
+		return
+	}
+	response["compatibility"] = reports
+	if !fits {
+		response["status"] = "warning"
+		response["compatibilityWarning"] = "requested GPU resources do not fit any known GPU profile"
+	}
+}
+
+// gpuProfileFit checks the model against every GPU profile. The requested GPU
+// count is honoured, so a model split across two GPUs may use their combined
+// memory. It returns nil when the model requests no GPUs or no advisor is set.
+func (h *Handler) gpuProfileFit(model *catalog.Model) ([]recommendations.CompatibilityReport, bool) {
+	gpus := requestedGPUs(model)
+	if h.advisor == nil || gpus == 0 {
+		return nil, false
+	}
+	profiles := h.advisor.Profiles()
+	reports := make([]recommendations.CompatibilityReport, 0, len(profiles))
+	fits := false
+	for _, profile := range profiles {
+		report := h.advisor.Compatibility(model, profile.Name)
+		if gpus > 1 && !report.Compatible && profile.MemoryGB > 0 {
+			available := int(gpus) * profile.MemoryGB
+			if available >= report.EstimatedVRAMGB {
+				report.Compatible = true
+				report.Reason = fmt.Sprintf("fits across %d GPUs (%d GiB total)", gpus, available)
+			} else {
+				report.Reason = fmt.Sprintf("requires %d GiB, only %d GiB available across %d GPUs", report.EstimatedVRAMGB, available, gpus)
+			}
+		}
+		if report.Compatible {
+			fits = true
+		}
+		reports = append(reports, report)
+	}
+	return reports, fits
+}
+
+// requestedGPUs returns the GPU count from the model's limits, falling back to
+// requests.
+func requestedGPUs(model *catalog.Model) int64 {
+	if model == nil || model.Resources == nil {
+		return 0
+	}
+	for _, resources := range []map[string]string{model.Resources.Limits, model.Resources.Requests} {
+		for name, value := range resources {
+			if !strings.Contains(strings.ToLower(name), "gpu") {
+				continue
+			}
+			qty, err := resource.ParseQuantity(value)
+			if err != nil || qty.Value() <= 0 {
+				continue
+			}
+			return qty.Value()
+		}
+	}
+	return 0
+}
+
 // ListJobs returns recent asynchronous jobs.
 func (h *Handler) ListJobs(c *gin.Context) {
 	if h.store == nil {
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
 		return
 	}
-	limit := parseLimit(c, "limit", h.opts.HistoryLimit, 200)
-	jobs, err := h.store.ListJobs(limit)
+	opts := store.JobListOptions{
+		Status: store.JobStatus(c.Query("status")),
+		Type:   c.Query("type"),
+		Limit:  parseLimit(c, "limit", h.opts.HistoryLimit, 200),
+	}
+	if value := strings.TrimSpace(c.Query("since")); value != "" {
+		since, err := store.ParseSince(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be a duration (e.g. 24h) or RFC3339 timestamp"})
+			return
+		}
+		opts.Since = since
+	}
+	if value := strings.TrimSpace(c.Query("until")); value != "" {
+		until, err := store.ParseSince(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be a duration (e.g. 24h) or RFC3339 timestamp"})
+			return
+		}
+		opts.Until = until
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must not be before since"})
+		return
+	}
+	jobs, err := h.store.ListJobsFiltered(opts)
 	if err != nil {
 		log.Printf("Failed to list jobs: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	jobs = filterJobs(jobs, c.Query("status"), c.Query("type"), c.Query("modelId"))
+	// Model IDs live in the JSON payload, so that filter stays in memory.
+	jobs = filterJobs(jobs, "", "", c.Query("modelId"))
 	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
 }
 
+// ListDeadLetterJobs returns job messages the worker moved to the dead-letter
+// stream because they could never succeed.
+func (h *Handler) ListDeadLetterJobs(c *gin.Context) {
+	if h.queue == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "job queue not configured"})
+		return
+	}
+	limit := parseLimit(c, "limit", 50, 500)
+	entries, err := h.queue.ListDeadLetter(c.Request.Context(), int64(limit))
+	if err != nil {
+		log.Printf("Failed to list dead-letter jobs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"entries": entries, "count": len(entries)})
+}
+
 // GetJob returns a single job status.
 func (h *Handler) GetJob(c *gin.Context) {
 	if h.store == nil {
@@ -2824,7 +5570,8 @@ func (h *Handler) GetJob(c *gin.Context) {
 	c.JSON(http.StatusOK, job)
 }
 
-// CancelJob marks a pending/running job as cancelled.
+// CancelJob marks a pending/running job as cancelled and stops its download if
+// one is in progress.
 func (h *Handler) CancelJob(c *gin.Context) {
 	if h.store == nil {
 		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
@@ -2857,6 +5604,9 @@ func (h *Handler) CancelJob(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if h.jobs != nil {
+		h.jobs.CancelJob(c.Request.Context(), job.ID)
+	}
 	h.publishJobEvent(c.Request.Context(), job)
 	h.publishJobLog(c.Request.Context(), job.ID, entry)
 	c.JSON(http.StatusOK, gin.H{"status": "cancelled", "job": job})
@@ -2881,22 +5631,30 @@ func (h *Handler) RetryJob(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "max attempts reached"})
 		return
 	}
-	req, err := installRequestFromPayload(job.Payload)
-	if err != nil {
+	if _, err := jobs.InstallRequestFromPayload(job.Payload); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if h.queue == nil && h.jobs == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "job queue unavailable"})
+		return
+	}
+	// The backoff is persisted on the job: the worker re-enqueues it (or,
+	// without Redis, the job manager runs it) once NextAttemptAt passes.
+	delay := jobs.RetryBackoff(h.retryBackoffBase(), h.retryBackoffMax(), job.Attempt)
+	nextAttempt := time.Now().UTC().Add(delay)
 	job.Status = store.JobPending
 	job.Stage = "queued"
 	job.Progress = 0
-	job.Message = "Retry requested"
+	job.Message = fmt.Sprintf("Retry scheduled for %s", nextAttempt.Format(time.RFC3339))
 	job.Error = ""
 	job.CancelledAt = nil
+	job.NextAttemptAt = &nextAttempt
 	entry := store.JobLogEntry{
 		Timestamp: time.Now().UTC(),
 		Level:     "info",
 		Stage:     "queued",
-		Message:   fmt.Sprintf("Retry scheduled (%d/%d)", job.Attempt+1, job.MaxAttempts),
+		Message:   fmt.Sprintf("Retry scheduled (%d/%d) after %s backoff", job.Attempt+1, job.MaxAttempts, delay),
 	}
 	job.Logs = append(job.Logs, entry)
 	if err := h.store.UpdateJob(job); err != nil {
@@ -2906,23 +5664,21 @@ func (h *Handler) RetryJob(c *gin.Context) {
 	}
 	h.publishJobEvent(c.Request.Context(), job)
 	h.publishJobLog(c.Request.Context(), job.ID, entry)
-	queued := false
-	if h.queue != nil {
-		if err := h.queue.Enqueue(c.Request.Context(), job.ID, req); err == nil {
-			queued = true
-		} else {
-			log.Printf("Failed to enqueue retry job %s: %v", job.ID, err)
-		}
+	c.JSON(http.StatusAccepted, gin.H{"status": "queued", "job": job, "nextAttemptAt": nextAttempt})
+}
+
+func (h *Handler) retryBackoffBase() time.Duration {
+	if h.opts.RetryBackoffBase > 0 {
+		return h.opts.RetryBackoffBase
 	}
-	if !queued {
-		if h.jobs == nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "job queue unavailable"})
-			return
-		}
-		h.jobs.ExecuteJob(job, req)
+	return 30 * time.Second
+}
+
+func (h *Handler) retryBackoffMax() time.Duration {
+	if h.opts.RetryBackoffMax > 0 {
+		return h.opts.RetryBackoffMax
 	}
-	h.publishJobEvent(c.Request.Context(), job)
-	c.JSON(http.StatusAccepted, gin.H{"status": "queued", "job": job})
+	return 30 * time.Minute
 }
 
 // JobLogs returns the recorded job log entries.
@@ -2939,6 +5695,133 @@ func (h *Handler) JobLogs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"logs": job.Logs})
 }
 
+// StreamJobLogs streams a job's log entries via SSE. Recorded entries are
+// replayed first, then new entries are streamed live until the job reaches a
+// terminal state, at which point a final job.<status> event is sent and the
+// stream closes.
+func (h *Handler) StreamJobLogs(c *gin.Context) {
+	if h.store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
+		return
+	}
+	if h.events == nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "event streaming unavailable"})
+		return
+	}
+	jobID := c.Param("id")
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	// Subscribe before loading the job so entries appended in between are not
+	// lost; duplicates of replayed entries are skipped by event ID.
+	eventStream, unsubscribe, err := h.events.SubscribeFiltered(ctx, func(evt events.Event) bool {
+		if evt.Type == events.TypeJobLog {
+			var payload events.JobLog
+			return events.DecodeInto(evt, &payload) == nil && payload.JobID == jobID
+		}
+		return evt.ID == jobID && strings.HasPrefix(evt.Type, "job.")
+	})
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to subscribe"})
+		return
+	}
+	defer unsubscribe()
+
+	job, err := h.store.GetJob(jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	releaseGauge := metrics.TrackSSEConnection()
+	defer releaseGauge()
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+
+	sent := make(map[string]struct{}, len(job.Logs))
+	render := func(evt events.Event) {
+		metrics.ObserveSSEEvent(evt.Type)
+		c.Render(-1, sse.Event{
+			Id:    evt.ID,
+			Event: evt.Type,
+			Data:  evt,
+		})
+		c.Writer.Flush()
+	}
+	renderLogs := func(entries []store.JobLogEntry) {
+		for _, entry := range entries {
+			evt := events.NewJobLogEvent(jobID, entry)
+			if _, ok := sent[evt.ID]; ok {
+				continue
+			}
+			sent[evt.ID] = struct{}{}
+			render(evt)
+		}
+	}
+	finish := func(job *store.Job) {
+		renderLogs(job.Logs)
+		render(events.NewJobEvent(job))
+	}
+
+	if jobIsTerminal(job.Status) {
+		finish(job)
+		return
+	}
+	renderLogs(job.Logs)
+
+	// Status events can be dropped when a subscriber falls behind, so the store
+	// is also polled to make sure the stream always closes.
+	ticker := time.NewTicker(jobLogStreamPoll)
+	defer ticker.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case evt, ok := <-eventStream:
+			if !ok {
+				return false
+			}
+			if evt.Type == events.TypeJobLog {
+				if _, seen := sent[evt.ID]; seen {
+					return true
+				}
+				sent[evt.ID] = struct{}{}
+				render(evt)
+				return true
+			}
+			if !jobIsTerminal(store.JobStatus(strings.TrimPrefix(evt.Type, "job."))) {
+				return true
+			}
+		case <-ticker.C:
+			current, err := h.store.GetJob(jobID)
+			if err != nil || !jobIsTerminal(current.Status) {
+				return true
+			}
+		case <-ctx.Done():
+			return false
+		}
+		// The job finished; reload it so any entries whose events were missed
+		// are flushed before the final status.
+		if current, err := h.store.GetJob(jobID); err == nil {
+			finish(current)
+		}
+		return false
+	})
+}
+
+const jobLogStreamPoll = 5 * time.Second
+
+func jobIsTerminal(status store.JobStatus) bool {
+	switch status {
+	case store.JobDone, store.JobFailed, store.JobCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
 // ListHistory returns historical deployment/install events.
 func (h *Handler) ListHistory(c *gin.Context) {
 	if h.store == nil {
@@ -2952,18 +5835,11 @@ func (h *Handler) ListHistory(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	entries = filterHistory(entries, c.Query("event"), c.Query("modelId"))
+	var since time.Time
 	if sinceParam := strings.TrimSpace(c.Query("since")); sinceParam != "" {
-		if since, err := parseSince(sinceParam); err == nil {
-			filtered := entries[:0]
-			for _, entry := range entries {
-				if entry.CreatedAt.After(since) || entry.CreatedAt.Equal(since) {
-					filtered = append(filtered, entry)
-				}
-			}
-			entries = filtered
-		}
+		since, _ = store.ParseSince(sinceParam)
 	}
+	entries = store.FilterHistory(entries, c.Query("event"), c.Query("modelId"), since)
 	output := interface{}(entries)
 	jsonPath := strings.TrimSpace(c.Query("jsonpath"))
 	if jsonPath != "" {
@@ -2984,26 +5860,296 @@ func (h *Handler) ListHistory(c *gin.Context) {
 			output = result
 		}
 	}
-	if strings.EqualFold(c.Query("format"), "csv") {
-		writeHistoryCSV(c, entries)
-		return
+	if strings.EqualFold(c.Query("format"), "csv") {
+		writeHistoryCSV(c, entries)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"events": output})
+}
+
+// modelTimelineEntry is one item of the GET /models/:id/events feed.
+type modelTimelineEntry struct {
+	// Source is "history", "job", or "event".
+	Source string `json:"source"`
+	// Type is the history event, job type, or event type.
+	Type      string      `json:"type"`
+	ID        string      `json:"id,omitempty"`
+	Status    string      `json:"status,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// ModelEvents merges the model's history entries, the jobs installing its
+// weights, and the model.* events still in the event buffer into one
+// timeline, newest first.
+func (h *Handler) ModelEvents(c *gin.Context) {
+	if h.store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "persistent store not configured"})
+		return
+	}
+	modelID := c.Param("id")
+	var since time.Time
+	if value := strings.TrimSpace(c.Query("since")); value != "" {
+		parsed, err := store.ParseSince(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be a duration (e.g. 24h) or RFC3339 timestamp"})
+			return
+		}
+		since = parsed
+	}
+	limit := parseLimit(c, "limit", h.opts.HistoryLimit, 200)
+
+	// History and events carry catalog IDs (including retired aliases); jobs
+	// carry the Hugging Face ID.
+	modelIDs := []string{modelID}
+	hfModelIDs := []string{modelID}
+	if h.ensureCatalogFresh(false) == nil && h.catalog != nil {
+		if model := h.catalog.Get(modelID); model != nil {
+			modelIDs = append(append(modelIDs, model.ID), model.Aliases...)
+			if model.HFModelID != "" {
+				hfModelIDs = append(hfModelIDs, model.HFModelID)
+			}
+		}
+	}
+	matchesModel := func(id string) bool {
+		for _, candidate := range modelIDs {
+			if id != "" && strings.EqualFold(id, candidate) {
+				return true
+			}
+		}
+		return false
+	}
+
+	entries, err := h.store.ListHistory(modelTimelineScan)
+	if err != nil {
+		log.Printf("Failed to list history: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	jobList, err := h.store.ListJobsFiltered(store.JobListOptions{Since: since, Limit: modelTimelineScan})
+	if err != nil {
+		log.Printf("Failed to list jobs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	timeline := make([]modelTimelineEntry, 0)
+	for _, entry := range store.FilterHistory(entries, "", "", since) {
+		if !matchesModel(entry.ModelID) {
+			continue
+		}
+		timeline = append(timeline, modelTimelineEntry{
+			Source:    "history",
+			Type:      entry.Event,
+			ID:        entry.ID,
+			Timestamp: entry.CreatedAt,
+			Data:      entry.Metadata,
+		})
+	}
+	seenJobs := make(map[string]bool)
+	for _, hfModelID := range hfModelIDs {
+		for _, job := range filterJobs(jobList, "", "", hfModelID) {
+			if seenJobs[job.ID] {
+				continue
+			}
+			seenJobs[job.ID] = true
+			message := job.Message
+			if job.Error != "" {
+				message = job.Error
+			}
+			timeline = append(timeline, modelTimelineEntry{
+				Source:    "job",
+				Type:      job.Type,
+				ID:        job.ID,
+				Status:    string(job.Status),
+				Message:   message,
+				Timestamp: job.UpdatedAt,
+				Data:      job,
+			})
+		}
+	}
+	if h.events != nil {
+		for _, evt := range h.events.Recent() {
+			if !strings.HasPrefix(evt.Type, "model.") || evt.Timestamp.Before(since) || !matchesModel(eventModelID(evt)) {
+				continue
+			}
+			timeline = append(timeline, modelTimelineEntry{
+				Source:    "event",
+				Type:      evt.Type,
+				ID:        evt.ID,
+				Timestamp: evt.Timestamp,
+				Data:      evt.Data,
+			})
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Timestamp.After(timeline[j].Timestamp)
+	})
+	if len(timeline) > limit {
+		timeline = timeline[:limit]
+	}
+	c.JSON(http.StatusOK, gin.H{"modelId": modelID, "events": timeline})
+}
+
+// eventModelID returns the modelId field of an event payload, whether it is
+// a typed payload or a map relayed through Redis.
+func eventModelID(evt events.Event) string {
+	var fields map[string]interface{}
+	switch data := evt.Data.(type) {
+	case events.Payload:
+		fields = events.Fields(data)
+	case map[string]interface{}:
+		fields = data
+	}
+	id, _ := fields["modelId"].(string)
+	return id
+}
+
+// ListProfiles exposes GPU profiles for the frontend.
+func (h *Handler) ListProfiles(c *gin.Context) {
+	if h.advisor == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "recommendations disabled"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"profiles": h.advisor.Profiles()})
+}
+
+// GetGPUInventory lists per-node GPU capacity with totals per GPU type.
+func (h *Handler) GetGPUInventory(c *gin.Context) {
+	if h.opts.GPUInventory == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "GPU inventory is disabled"})
+		return
+	}
+	inv, err := h.opts.GPUInventory.Inventory(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": fmt.Sprintf("failed to collect GPU inventory: %v", err)})
+		return
+	}
+	c.JSON(http.StatusOK, inv)
+}
+
+// ModelCompatibility reports whether a catalog entry fits on the requested GPU.
+func (h *Handler) ModelCompatibility(c *gin.Context) {
+	if h.advisor == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "compatibility service is disabled"})
+		return
+	}
+
+	if err := h.ensureCatalogFresh(false); err != nil {
+		log.Printf("Failed to ensure catalog freshness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load model catalog"})
+		return
+	}
+
+	modelID := c.Param("id")
+	model := h.catalog.Get(modelID)
+	if model == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "model not found"})
+		return
+	}
+
+	gpuType := c.Query("gpuType")
+	report := h.advisor.Compatibility(model, gpuType)
+	h.addGPUAvailability(c.Request.Context(), model, &report)
+	c.JSON(http.StatusOK, report)
+}
+
+// addGPUAvailability cross-checks a compatibility report against the live GPU
+// inventory. Without an inventory, or when it can't be collected, the report
+// keeps its profile-only answer.
+func (h *Handler) addGPUAvailability(ctx context.Context, model *catalog.Model, report *recommendations.CompatibilityReport) {
+	if h.opts.GPUInventory == nil {
+		return
+	}
+	inv, err := h.opts.GPUInventory.Inventory(ctx)
+	if err != nil {
+		log.Printf("GPU inventory unavailable for compatibility of %s: %v", model.ID, err)
+		return
+	}
+	profiles := make(map[string]recommendations.GPUProfile)
+	for _, profile := range h.advisor.Profiles() {
+		profiles[strings.ToLower(profile.Name)] = profile
+	}
+	needed := requestedGPUs(model)
+	if needed < 1 {
+		needed = 1
+	}
+	availability := func(gpuType string) (*bool, *int) {
+		profile, ok := profiles[strings.ToLower(gpuType)]
+		if !ok {
+			return nil, nil
+		}
+		avail := inv.Available(inventory.ResourceForVendor(profile.Vendor), profile.Labels)
+		schedulable := avail.MaxFreeOnNode >= needed
+		free := int(avail.Free)
+		return &schedulable, &free
+	}
+
+	if report.GPUType != "" {
+		report.Schedulable, report.FreeGPUs = availability(report.GPUType)
+		if report.Compatible && report.Schedulable != nil && !*report.Schedulable {
+			report.Reason = fmt.Sprintf("fits %s, but no node currently has %d free %s GPU(s)", report.GPUType, needed, report.GPUType)
+		}
+	}
+	for i := range report.Candidates {
+		report.Candidates[i].Schedulable, report.Candidates[i].FreeGPUs = availability(report.Candidates[i].GPU)
 	}
-	c.JSON(http.StatusOK, gin.H{"events": output})
 }
 
-// ListProfiles exposes GPU profiles for the frontend.
-func (h *Handler) ListProfiles(c *gin.Context) {
+// BestGPURecommendation picks the cheapest GPU profile the model fits on and
+// lists the other fitting profiles as alternatives.
+func (h *Handler) BestGPURecommendation(c *gin.Context) {
 	if h.advisor == nil {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "recommendations disabled"})
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "compatibility service is disabled"})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"profiles": h.advisor.Profiles()})
+
+	if err := h.ensureCatalogFresh(false); err != nil {
+		log.Printf("Failed to ensure catalog freshness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load model catalog"})
+		return
+	}
+
+	model := h.catalog.Get(c.Param("id"))
+	if model == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "model not found"})
+		return
+	}
+
+	profiles := h.advisor.Profiles()
+	options := make([]recommendations.ProfileOption, 0, len(profiles))
+	estimated := 0
+	for _, profile := range profiles {
+		rec := h.advisor.RecommendForModel(model, profile.Name)
+		estimated = rec.EstimatedVRAMGB
+		options = append(options, recommendations.ProfileOption{Profile: profile, Recommendation: rec})
+	}
+	ranked := recommendations.RankFitting(options)
+
+	response := gin.H{
+		"modelId":         model.ID,
+		"estimatedVramGb": estimated,
+		"best":            nil,
+		"alternatives":    []recommendations.ProfileOption{},
+	}
+	if len(ranked) == 0 {
+		response["reason"] = fmt.Sprintf("no GPU profile fits %s (%d profiles checked)", model.ID, len(profiles))
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	response["best"] = ranked[0]
+	response["alternatives"] = ranked[1:]
+	c.JSON(http.StatusOK, response)
 }
 
-// ModelCompatibility reports whether a catalog entry fits on the requested GPU.
-func (h *Handler) ModelCompatibility(c *gin.Context) {
-	if h.advisor == nil {
-		c.JSON(http.StatusNotImplemented, gin.H{"error": "compatibility service is disabled"})
+// CompareModels returns a field-by-field diff between two catalog entries.
+func (h *Handler) CompareModels(c *gin.Context) {
+	idA := strings.TrimSpace(c.Query("a"))
+	idB := strings.TrimSpace(c.Query("b"))
+	if idA == "" || idB == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameters a and b are required"})
 		return
 	}
 
@@ -3013,16 +6159,24 @@ func (h *Handler) ModelCompatibility(c *gin.Context) {
 		return
 	}
 
-	modelID := c.Param("id")
-	model := h.catalog.Get(modelID)
-	if model == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "model not found"})
+	modelA := h.catalog.Get(idA)
+	if modelA == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("model %s not found", idA)})
+		return
+	}
+	modelB := h.catalog.Get(idB)
+	if modelB == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("model %s not found", idB)})
 		return
 	}
 
-	gpuType := c.Query("gpuType")
-	report := h.advisor.Compatibility(model, gpuType)
-	c.JSON(http.StatusOK, report)
+	changes := catalog.Diff(modelA, modelB)
+	c.JSON(http.StatusOK, gin.H{
+		"a":         idA,
+		"b":         idB,
+		"identical": len(changes) == 0,
+		"changes":   changes,
+	})
 }
 
 // GPURecommendations returns vLLM flag suggestions for a GPU type.
@@ -3033,20 +6187,95 @@ func (h *Handler) GPURecommendations(c *gin.Context) {
 	}
 
 	gpuType := c.Param("gpuType")
-	rec := h.advisor.Recommend(gpuType)
-	c.JSON(http.StatusOK, rec)
+	modelID := strings.TrimSpace(c.Query("modelId"))
+	if modelID == "" {
+		c.JSON(http.StatusOK, h.advisor.Recommend(gpuType))
+		return
+	}
+
+	if err := h.ensureCatalogFresh(false); err != nil {
+		log.Printf("Failed to ensure catalog freshness: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load model catalog"})
+		return
+	}
+	model := h.catalog.Get(modelID)
+	if model == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "model not found"})
+		return
+	}
+	c.JSON(http.StatusOK, h.advisor.RecommendForModel(model, gpuType))
+}
+
+// WatchCatalog reloads the catalog whenever the models directory changes so
+// git-sync updates show up without waiting for CatalogTTL. If the watch can't
+// be established the handler keeps polling on the TTL.
+func (h *Handler) WatchCatalog(ctx context.Context) {
+	if h.catalog == nil {
+		return
+	}
+
+	err := h.catalog.Watch(ctx, func() {
+		if err := h.ensureCatalogFresh(true); err != nil {
+			log.Printf("catalog watch reload failed: %v", err)
+		}
+	})
+	if err != nil {
+		log.Printf("Catalog watch unavailable, polling every %s: %v", h.opts.CatalogTTL, err)
+		return
+	}
+
+	h.catalogWatching.Store(true)
+	log.Printf("Watching catalog models directory for changes")
+
+	go func() {
+		<-ctx.Done()
+		h.catalogWatching.Store(false)
+	}()
+}
+
+// KeepCatalogFresh loads the catalog and then re-checks it every CatalogTTL
+// until ctx is done, so readiness reflects a recent load even when no request
+// touches the catalog.
+func (h *Handler) KeepCatalogFresh(ctx context.Context) {
+	if h.catalog == nil {
+		return
+	}
+	refresh := func() {
+		if err := h.ensureCatalogFresh(false); err != nil {
+			log.Printf("Catalog refresh failed: %v", err)
+		}
+	}
+	refresh()
+	go func() {
+		ticker := time.NewTicker(h.opts.CatalogTTL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
 }
 
 func (h *Handler) ensureCatalogFresh(force bool) error {
 	h.catalogMu.Lock()
 	defer h.catalogMu.Unlock()
 
-	refresh := force || h.lastCatalogRefresh.IsZero() || time.Since(h.lastCatalogRefresh) > h.opts.CatalogTTL || h.catalogStatus == "syncing"
+	// While a watch is active a live catalog only reloads on change; catalogs
+	// hydrated from the datastore keep polling until the directory appears.
+	expired := time.Since(h.lastCatalogRefresh) > h.opts.CatalogTTL && (!h.catalogWatching.Load() || h.catalogStatus != "live")
+	refresh := force || h.lastCatalogRefresh.IsZero() || expired || h.catalogStatus == "syncing"
 	if !refresh {
 		return nil
 	}
 
-	if err := h.catalog.Reload(); err != nil {
+	err := h.catalog.Reload()
+	h.catalogLoaded = true
+	h.catalogLoadErr = nil
+	if err != nil {
 		if errors.Is(err, catalog.ErrModelsDirMissing) {
 			log.Printf("Catalog directory not ready yet: %v", err)
 			h.catalogStatus = "syncing"
@@ -3054,6 +6283,9 @@ func (h *Handler) ensureCatalogFresh(force bool) error {
 			if h.store != nil {
 				if models, updatedAt, err := h.store.LoadCatalogSnapshot(); err == nil && len(models) > 0 {
 					h.catalog.Restore(models)
+					if h.catalogVersion, err = store.CatalogHash(models); err != nil {
+						log.Printf("Failed to hash catalog: %v", err)
+					}
 					h.lastCatalogRefresh = updatedAt
 					h.catalogCacheTime = updatedAt
 					h.catalogStatus = "cache"
@@ -3064,6 +6296,7 @@ func (h *Handler) ensureCatalogFresh(force bool) error {
 			}
 			return nil
 		}
+		h.catalogLoadErr = err
 		return err
 	}
 
@@ -3072,30 +6305,87 @@ func (h *Handler) ensureCatalogFresh(force bool) error {
 	h.catalogStatus = "live"
 	h.catalogCacheTime = now
 
+	models := h.catalog.All()
+	if h.catalogVersion, err = store.CatalogHash(models); err != nil {
+		log.Printf("Failed to hash catalog: %v", err)
+	}
 	if h.store != nil {
-		if err := h.store.SaveCatalogSnapshot(h.catalog.All()); err != nil {
-			log.Printf("Failed to persist catalog snapshot: %v", err)
-		}
+		h.persistCatalogSnapshot(models, h.catalogVersion)
 	}
 
 	return nil
 }
 
+// currentCatalogVersion returns the content hash of the loaded catalog.
+func (h *Handler) currentCatalogVersion() string {
+	h.catalogMu.Lock()
+	defer h.catalogMu.Unlock()
+	return h.catalogVersion
+}
+
+// persistCatalogSnapshot writes the catalog to the store unless hash matches
+// the last persisted snapshot. Callers must hold catalogMu.
+func (h *Handler) persistCatalogSnapshot(models []*catalog.Model, hash string) {
+	if h.catalogHash == "" {
+		if stored, err := h.store.CatalogSnapshotHash(); err == nil {
+			h.catalogHash = stored
+		}
+	}
+	if hash != "" && hash == h.catalogHash {
+		return
+	}
+	if err := h.store.SaveCatalogSnapshot(models); err != nil {
+		log.Printf("Failed to persist catalog snapshot: %v", err)
+		return
+	}
+	h.catalogHash = hash
+}
+
+// readinessPollInterval is the delay between readiness probes.
+const readinessPollInterval = 2 * time.Second
+
 func (h *Handler) checkReadiness(ctx context.Context, url string, timeoutSeconds int) gin.H {
 	timeout := time.Duration(timeoutSeconds) * time.Second
 	if timeout <= 0 {
 		timeout = 10 * time.Second
 	}
 
-	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	// Freshly activated models spend a while loading weights, so keep probing
+	// until the URL answers below 400 or the timeout runs out.
+	start := time.Now()
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+	attempts := 0
+	var result gin.H
+poll:
+	for {
+		attempts++
+		result = probeReadiness(pollCtx, url)
+		if result["status"] == "ok" {
+			break
+		}
+		select {
+		case <-pollCtx.Done():
+			break poll
+		case <-ticker.C:
+		}
+	}
+
+	result["url"] = url
+	result["attempts"] = attempts
+	result["duration"] = time.Since(start).String()
+	return result
+}
+
+// probeReadiness performs a single readiness GET.
+func probeReadiness(ctx context.Context, url string) gin.H {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return gin.H{"status": "error", "message": err.Error()}
 	}
-
-	start := time.Now()
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return gin.H{"status": "error", "message": err.Error()}
@@ -3108,13 +6398,10 @@ func (h *Handler) checkReadiness(ctx context.Context, url string, timeoutSeconds
 	if resp.StatusCode >= 400 {
 		status = "fail"
 	}
-
 	return gin.H{
-		"status":   status,
-		"code":     resp.StatusCode,
-		"duration": time.Since(start).String(),
-		"url":      url,
-		"preview":  string(body),
+		"status":  status,
+		"code":    resp.StatusCode,
+		"preview": string(body),
 	}
 }
 
@@ -3179,13 +6466,18 @@ func writeHistoryCSV(c *gin.Context, entries []store.HistoryEntry) {
 	}
 }
 
-func (h *Handler) recordHistory(event, modelID string, meta map[string]interface{}) {
+// recordHistory appends a history entry, stamping the originating request ID
+// from ctx into its metadata.
+func (h *Handler) recordHistory(ctx context.Context, event, modelID string, meta map[string]interface{}) {
 	if h.store == nil {
 		return
 	}
 	if meta == nil {
 		meta = map[string]interface{}{}
 	}
+	if requestID := logutil.RequestID(ctx); requestID != "" {
+		meta["requestId"] = requestID
+	}
 	entry := &store.HistoryEntry{
 		Event:    event,
 		ModelID:  modelID,
@@ -3196,18 +6488,16 @@ func (h *Handler) recordHistory(event, modelID string, meta map[string]interface
 	}
 }
 
-func (h *Handler) publishEvent(eventType string, payload interface{}) {
-	if h.events == nil || eventType == "" {
+func (h *Handler) publishEvent(ctx context.Context, payload events.Payload) {
+	if h.events == nil {
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	publishCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
-	if err := h.events.Publish(ctx, events.Event{
-		Type:      eventType,
-		Timestamp: time.Now().UTC(),
-		Data:      payload,
-	}); err != nil {
-		log.Printf("Failed to publish event %s: %v", eventType, err)
+	evt := events.New(payload)
+	evt.RequestID = logutil.RequestID(ctx)
+	if err := h.events.Publish(publishCtx, evt); err != nil {
+		log.Printf("Failed to publish event %s: %v", evt.Type, err)
 	}
 }
 
@@ -3359,16 +6649,9 @@ func (h *Handler) publishJobEvent(ctx context.Context, job *store.Job) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	timestamp := job.UpdatedAt
-	if timestamp.IsZero() {
-		timestamp = time.Now().UTC()
-	}
-	if err := h.events.Publish(ctx, events.Event{
-		ID:        job.ID,
-		Type:      fmt.Sprintf("job.%s", job.Status),
-		Timestamp: timestamp,
-		Data:      job,
-	}); err != nil {
+	evt := events.NewJobEvent(job)
+	evt.RequestID = logutil.RequestID(ctx)
+	if err := h.events.Publish(ctx, evt); err != nil {
 		log.Printf("Failed to publish job event: %v", err)
 	}
 }
@@ -3380,15 +6663,7 @@ func (h *Handler) publishJobLog(ctx context.Context, jobID string, entry store.J
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	if err := h.events.Publish(ctx, events.Event{
-		ID:        fmt.Sprintf("%s-log-%d", jobID, entry.Timestamp.UnixNano()),
-		Type:      "job.log",
-		Timestamp: entry.Timestamp,
-		Data: gin.H{
-			"jobId": jobID,
-			"log":   entry,
-		},
-	}); err != nil {
+	if err := h.events.Publish(ctx, events.NewJobLogEvent(jobID, entry)); err != nil {
 		log.Printf("Failed to publish job log event: %v", err)
 	}
 }
@@ -3407,41 +6682,6 @@ func (h *Handler) appendJobLog(ctx context.Context, jobID string, entry store.Jo
 	h.publishJobLog(ctx, jobID, entry)
 }
 
-func installRequestFromPayload(data map[string]interface{}) (jobs.InstallRequest, error) {
-	if data == nil {
-		return jobs.InstallRequest{}, fmt.Errorf("job payload missing")
-	}
-	modelID, _ := data["hfModelId"].(string)
-	if modelID == "" {
-		return jobs.InstallRequest{}, fmt.Errorf("payload missing hfModelId")
-	}
-	req := jobs.InstallRequest{
-		ModelID: modelID,
-	}
-	if rev, ok := data["revision"].(string); ok {
-		req.Revision = rev
-	}
-	if target, ok := data["target"].(string); ok {
-		req.Target = target
-	}
-	if overwrite, ok := data["overwrite"].(bool); ok {
-		req.Overwrite = overwrite
-	}
-	if rawFiles, ok := data["files"]; ok {
-		switch v := rawFiles.(type) {
-		case []interface{}:
-			for _, entry := range v {
-				if s, ok := entry.(string); ok {
-					req.Files = append(req.Files, s)
-				}
-			}
-		case []string:
-			req.Files = append(req.Files, v...)
-		}
-	}
-	return req, nil
-}
-
 func (h *Handler) collectAlerts(stats *weights.StorageStats) []gin.H {
 	var alerts []gin.H
 	triggered := false
@@ -3461,38 +6701,30 @@ func (h *Handler) collectAlerts(stats *weights.StorageStats) []gin.H {
 	return alerts
 }
 
+// EvaluateStorageAlerts checks PVC usage against PVCAlertThreshold and notifies
+// channels when the alert starts or clears. It is safe to call on every tick;
+// repeated evaluations while over threshold do not re-notify.
+func (h *Handler) EvaluateStorageAlerts(stats *weights.StorageStats) {
+	h.collectAlerts(stats)
+}
+
 func (h *Handler) maybeEmitStorageAlert(triggered bool, usage float64) {
+	h.alertMu.Lock()
+	defer h.alertMu.Unlock()
 	if triggered && !h.pvcAlertActive {
-		meta := gin.H{"kind": "storage", "usagePercent": usage * 100}
-		h.publishEvent("alert.triggered", meta)
-		h.recordHistory("alert_triggered", "", map[string]interface{}{"kind": "storage", "usagePercent": usage * 100})
+		alert := events.AlertTriggered{Kind: "storage", UsagePercent: usage * 100}
+		h.publishEvent(context.Background(), alert)
+		h.recordHistory(context.Background(), "alert_triggered", "", events.Fields(alert))
+		h.notify(alert.EventType(), "", fmt.Sprintf("Weights PVC usage %.1f%% exceeds threshold", usage*100), events.Fields(alert))
 	} else if !triggered && h.pvcAlertActive {
-		meta := gin.H{"kind": "storage", "usagePercent": usage * 100}
-		h.publishEvent("alert.resolved", meta)
-		h.recordHistory("alert_resolved", "", map[string]interface{}{"kind": "storage", "usagePercent": usage * 100})
+		alert := events.AlertResolved{Kind: "storage", UsagePercent: usage * 100}
+		h.publishEvent(context.Background(), alert)
+		h.recordHistory(context.Background(), "alert_resolved", "", events.Fields(alert))
+		h.notify(alert.EventType(), "", fmt.Sprintf("Weights PVC usage back to %.1f%%", usage*100), events.Fields(alert))
 	}
 	h.pvcAlertActive = triggered
 }
 
-func filterHistory(entries []store.HistoryEntry, event, modelID string) []store.HistoryEntry {
-	event = strings.TrimSpace(strings.ToLower(event))
-	modelID = strings.TrimSpace(strings.ToLower(modelID))
-	if event == "" && modelID == "" {
-		return entries
-	}
-	result := make([]store.HistoryEntry, 0, len(entries))
-	for _, entry := range entries {
-		if event != "" && strings.ToLower(entry.Event) != event {
-			continue
-		}
-		if modelID != "" && strings.ToLower(entry.ModelID) != modelID {
-			continue
-		}
-		result = append(result, entry)
-	}
-	return result
-}
-
 func filterCachedHFModels(models []vllm.HuggingFaceModel, opts vllm.SearchOptions) []vllm.HuggingFaceModel {
 	query := strings.ToLower(strings.TrimSpace(opts.Query))
 	filtered := make([]vllm.HuggingFaceModel, 0, len(models))
@@ -3649,15 +6881,267 @@ const docsHTML = `<!doctype html>
   </body>
 </html>`
 
-var hfModelIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*/[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+// installSpaceHeadroom is the extra fraction of the estimated download size
+// that must be free before an install is scheduled (temp files, metadata).
+const installSpaceHeadroom = 0.1
+
+// checkWeightCapacity rejects installs whose estimated size would not fit on the
+// weights volume. It is skipped when the Hub reports no sizes or the volume's
+// free space cannot be determined.
+func (h *Handler) checkWeightCapacity(ctx context.Context, model *vllm.HuggingFaceModel, files []string, target string) error {
+	estimate, known := vllm.EstimateDownloadBytes(model, files)
+	if !known || h.weights == nil {
+		return nil
+	}
+	stats, err := h.weights.GetStats()
+	if err != nil || stats == nil || stats.TotalBytes == 0 {
+		return nil
+	}
+	required := estimate + int64(float64(estimate)*installSpaceHeadroom)
+	if h.opts.WeightEviction {
+		if shortfall := required + h.opts.WeightEvictionMinFree - stats.AvailableBytes; shortfall > 0 {
+			if h.evictWeights(ctx, shortfall, model.ModelID, target) {
+				return nil
+			}
+		}
+	}
+	if required <= stats.AvailableBytes {
+		return nil
+	}
+	volume := h.opts.WeightsPVCName
+	if volume == "" {
+		volume = "the weights volume"
+	}
+	msg := fmt.Sprintf("insufficient storage: %s needs about %s (including %.0f%% headroom) but only %s is free on %s; short by %s",
+		model.ModelID, weights.FormatBytes(required), installSpaceHeadroom*100, weights.FormatBytes(stats.AvailableBytes), volume, weights.FormatBytes(required-stats.AvailableBytes))
+	return newRequestError(http.StatusInsufficientStorage, msg, nil)
+}
+
+// evictWeights frees at least bytes by evicting cached weights for an install
+// of modelID into target, recording each eviction in history. Weights the
+// active InferenceService or any catalog entry references are never evicted,
+// and nothing is evicted when the active runtime can't be looked up. It
+// reports whether enough space was freed.
+func (h *Handler) evictWeights(ctx context.Context, bytes int64, modelID, target string) bool {
+	refs, err := h.weightReferences()
+	if err != nil {
+		log.Printf("Weight eviction for %s skipped: %v", modelID, err)
+		return false
+	}
+	protected := make([]string, 0, len(refs)+1)
+	for name := range refs {
+		protected = append(protected, name)
+	}
+	protected = append(protected, target)
+	evicted, err := h.weights.EvictToFree(bytes, protected)
+	for _, info := range evicted {
+		meta := map[string]interface{}{
+			"sizeBytes":    info.SizeBytes,
+			"modifiedTime": info.ModifiedTime,
+			"reason":       fmt.Sprintf("free space for %s", modelID),
+		}
+		h.recordHistory(ctx, "weight_evicted", info.Name, meta)
+	}
+	if err != nil {
+		log.Printf("Weight eviction for %s could not free %s: %v", modelID, weights.FormatBytes(bytes), err)
+		return false
+	}
+	return true
+}
+
+// activeWeightNames returns the weights the active InferenceService is served
+// from: its storageUri and the storageUri of the catalog entry it was
+// activated from. It fails when the InferenceService can't be read, so callers
+// don't mistake a lookup error for "nothing is active".
+func (h *Handler) activeWeightNames() ([]string, error) {
+	if h.kserve == nil {
+		return nil, nil
+	}
+	isvc, err := h.kserve.GetActive()
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine which weights the active InferenceService uses: %w", err)
+	}
+	if isvc == nil {
+		return nil, nil
+	}
+	var names []string
+	uri, _, _ := unstructured.NestedString(isvc, "spec", "predictor", "model", "storageUri")
+	if name, ok := h.weightNameForURI(uri); ok {
+		names = append(names, name)
+	}
+	if h.catalog != nil {
+		if model := h.catalog.Get(annotationValue(isvc, "model-manager/model-id")); model != nil {
+			if name, ok := h.weightNameForURI(model.StorageURI); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// weightReferences maps installed weight names to what serves from them: the
+// active InferenceService and any catalog entry whose storageUri points
+// at them. Deletes consult it so in-use weights aren't removed by accident;
+// it fails closed when the active InferenceService can't be read.
+func (h *Handler) weightReferences() (map[string][]string, error) {
+	active, err := h.activeWeightNames()
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string][]string)
+	for _, name := range active {
+		if name = normalizeWeightName(name); len(refs[name]) == 0 {
+			refs[name] = []string{"active InferenceService"}
+		}
+	}
+	if h.catalog != nil {
+		for _, model := range h.catalog.All() {
+			if name, ok := h.weightNameForURI(model.StorageURI); ok {
+				refs[name] = append(refs[name], "catalog model "+model.ID)
+			}
+		}
+	}
+	return refs, nil
+}
+
+func normalizeWeightName(name string) string {
+	return strings.Trim(strings.TrimSpace(name), "/")
+}
+
+func weightInUseMessage(refs []string) string {
+	return "in use by " + strings.Join(refs, ", ") + "; pass force=true to delete"
+}
+
+// errHFOrgNotAllowed marks models whose organization is excluded by
+// HF_ALLOWED_ORGS / HF_DENIED_ORGS.
+var errHFOrgNotAllowed = errors.New("hugging face organization not allowed")
+
+// checkHFOrg enforces the organization allow/deny lists. Organizations are
+// compared case-insensitively.
+func (h *Handler) checkHFOrg(id string) error {
+	org := vllm.HuggingFaceOrg(id)
+	for _, denied := range h.opts.HFDeniedOrgs {
+		if strings.EqualFold(org, denied) {
+			return fmt.Errorf("%w: %s is on the denylist", errHFOrgNotAllowed, org)
+		}
+	}
+	if len(h.opts.HFAllowedOrgs) == 0 {
+		return nil
+	}
+	for _, allowed := range h.opts.HFAllowedOrgs {
+		if strings.EqualFold(org, allowed) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not on the allowlist", errHFOrgNotAllowed, org)
+}
+
+// loadPolicies parses every stored policy document. A document that no longer
+// parses (e.g. saved before policies were validated) is an error: skipping it
+// would silently drop the rules it was meant to enforce.
+func (h *Handler) loadPolicies() ([]*policy.Policy, error) {
+	if h.store == nil {
+		return nil, nil
+	}
+	stored, err := h.store.ListPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("load policies: %w", err)
+	}
+	policies := make([]*policy.Policy, 0, len(stored))
+	for _, doc := range stored {
+		parsed, err := policy.Parse(doc.Name, doc.Document)
+		if err != nil {
+			return nil, fmt.Errorf("stored policy is invalid; fix or delete it: %w", err)
+		}
+		policies = append(policies, parsed)
+	}
+	return policies, nil
+}
+
+// UpgradeLegacyPolicies makes policies stored before documents were validated
+// loadable, since loadPolicies fails closed on any document that doesn't
+// parse. Unknown fields are dropped (those documents were never enforced, so
+// enforcing their remaining rules only tightens things); documents that still
+// don't parse are archived into their version history and removed. Run it
+// once at startup.
+func (h *Handler) UpgradeLegacyPolicies(ctx context.Context) error {
+	if h.store == nil {
+		return nil
+	}
+	stored, err := h.store.ListPolicies()
+	if err != nil {
+		return fmt.Errorf("load policies: %w", err)
+	}
+	for _, doc := range stored {
+		if _, err := policy.Parse(doc.Name, doc.Document); err == nil {
+			continue
+		}
+		upgraded, dropped, parseErr := policy.Upgrade(doc.Name, doc.Document)
+		if parseErr != nil {
+			log.Printf("Archiving stored policy %s that cannot be enforced: %v", doc.Name, parseErr)
+			if err := h.store.ArchivePolicy(doc.Name); err != nil {
+				return fmt.Errorf("archive policy %s: %w", doc.Name, err)
+			}
+			h.recordHistory(ctx, "policy_archived", "", map[string]interface{}{"name": doc.Name, "error": parseErr.Error()})
+			continue
+		}
+		log.Printf("Upgrading stored policy %s; dropping unknown fields %s", doc.Name, strings.Join(dropped, ", "))
+		if err := h.store.UpsertPolicy(&store.Policy{Name: doc.Name, Document: upgraded, UpdatedAt: time.Now().UTC()}); err != nil {
+			return fmt.Errorf("upgrade policy %s: %w", doc.Name, err)
+		}
+		h.recordHistory(ctx, "policy_upgraded", "", map[string]interface{}{"name": doc.Name, "droppedFields": dropped})
+	}
+	return nil
+}
+
+// checkInstallPolicies evaluates stored policies before a Hub model is
+// downloaded.
+func (h *Handler) checkInstallPolicies(id string, hfModel *vllm.HuggingFaceModel) error {
+	policies, err := h.loadPolicies()
+	if err != nil {
+		return newRequestError(http.StatusInternalServerError, err.Error(), err)
+	}
+	if v := policy.Evaluate(policies, policy.FromHuggingFace(id, hfModel), policy.ActionInstall); v != nil {
+		return newRequestError(http.StatusForbidden, v.Error(), v)
+	}
+	return nil
+}
+
+// checkActivationPolicies evaluates stored policies before a catalog model is
+// activated. The Hugging Face metadata is only fetched when a policy checks
+// licenses; entries without an hfModelId count as declaring no license.
+func (h *Handler) checkActivationPolicies(model *catalog.Model) error {
+	policies, err := h.loadPolicies()
+	if err != nil {
+		return newRequestError(http.StatusInternalServerError, err.Error(), err)
+	}
+	var hfModel *vllm.HuggingFaceModel
+	for _, p := range policies {
+		if !p.NeedsLicenses(policy.ActionActivate) || model.HFModelID == "" || h.vllm == nil {
+			continue
+		}
+		hfModel, err = h.vllm.GetHuggingFaceModel(model.HFModelID)
+		if err != nil {
+			return newRequestError(http.StatusBadGateway, fmt.Sprintf("cannot verify license of %s: %v", model.ID, err), err)
+		}
+		break
+	}
+	if v := policy.Evaluate(policies, policy.FromCatalog(model, hfModel), policy.ActionActivate); v != nil {
+		return newRequestError(http.StatusForbidden, v.Error(), v)
+	}
+	return nil
+}
 
 func (h *Handler) fetchAndValidateHFModel(id string) (*vllm.HuggingFaceModel, error) {
 	if h.vllm == nil {
 		return nil, fmt.Errorf("vLLM discovery client not configured")
 	}
-	if !hfModelIDPattern.MatchString(id) {
+	if !vllm.ValidHuggingFaceModelID(id) {
 		return nil, fmt.Errorf("invalid Hugging Face model id: %s", id)
 	}
+	if err := h.checkHFOrg(id); err != nil {
+		return nil, err
+	}
 
 	model, err := h.vllm.GetHuggingFaceModel(id)
 	if err != nil {
@@ -3667,6 +7151,20 @@ func (h *Handler) fetchAndValidateHFModel(id string) (*vllm.HuggingFaceModel, er
 	return model, nil
 }
 
+// huggingFaceError maps gated and missing Hub models to distinct HTTP statuses
+// with a message the caller can act on.
+func huggingFaceError(err error) (int, string, bool) {
+	switch {
+	case errors.Is(err, vllm.ErrGatedModel):
+		return http.StatusForbidden, fmt.Sprintf("%v. Accept the model's license on huggingface.co and set HUGGINGFACE_API_TOKEN to a token with access.", err), true
+	case errors.Is(err, vllm.ErrModelNotFound):
+		return http.StatusNotFound, err.Error(), true
+	case errors.Is(err, errHFOrgNotAllowed):
+		return http.StatusForbidden, err.Error(), true
+	}
+	return 0, "", false
+}
+
 func normalizeSearchTypes(values []string) map[string]bool {
 	types := make(map[string]bool)
 	if len(values) == 0 {
@@ -1,28 +1,50 @@
 package handlers
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
 	"github.com/oremus-labs/ol-model-manager/internal/catalogwriter"
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/inventory"
+	"github.com/oremus-labs/ol-model-manager/internal/jobs"
+	"github.com/oremus-labs/ol-model-manager/internal/kserve"
+	"github.com/oremus-labs/ol-model-manager/internal/queue"
 	"github.com/oremus-labs/ol-model-manager/internal/recommendations"
 	"github.com/oremus-labs/ol-model-manager/internal/status"
 	"github.com/oremus-labs/ol-model-manager/internal/store"
+	"github.com/oremus-labs/ol-model-manager/internal/validator"
 	"github.com/oremus-labs/ol-model-manager/internal/vllm"
 	"github.com/oremus-labs/ol-model-manager/internal/weights"
+	"golang.org/x/net/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func init() {
@@ -164,6 +186,103 @@ func TestDeleteJobsEndpoint(t *testing.T) {
 	}
 }
 
+func TestStreamJobLogsReplaysAndClosesOnCompletion(t *testing.T) {
+	t.Parallel()
+
+	stateStore := openTestStore(t)
+	bus := events.NewBus(events.Options{})
+	handler := New(nil, nil, nil, nil, nil, nil, nil, stateStore, nil, bus, nil, nil, nil, nil, Options{})
+
+	job := &store.Job{
+		ID:     "job-stream",
+		Type:   "weight_install",
+		Status: store.JobRunning,
+		Logs:   []store.JobLogEntry{{Timestamp: time.Now().UTC(), Stage: "download", Message: "seeded"}},
+	}
+	if err := stateStore.CreateJob(job); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	engine := gin.New()
+	engine.GET("/jobs/:id/logs/stream", handler.StreamJobLogs)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/jobs/job-stream/logs/stream")
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	type received struct {
+		event string
+		data  string
+	}
+	lines := make(chan received, 16)
+	go func() {
+		defer close(lines)
+		var current received
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event:"):
+				current.event = strings.TrimPrefix(line, "event:")
+			case strings.HasPrefix(line, "data:"):
+				current.data = strings.TrimPrefix(line, "data:")
+			case line == "" && current.event != "":
+				lines <- current
+				current = received{}
+			}
+		}
+	}()
+	next := func() received {
+		t.Helper()
+		select {
+		case evt, ok := <-lines:
+			if !ok {
+				t.Fatalf("stream closed early")
+			}
+			return evt
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event")
+		}
+		return received{}
+	}
+
+	if evt := next(); evt.event != "job.log" || !strings.Contains(evt.data, "seeded") {
+		t.Fatalf("expected seeded log replay, got %+v", evt)
+	}
+
+	handler.appendJobLog(context.Background(), job.ID, store.JobLogEntry{Stage: "download", Message: "live entry"})
+	if evt := next(); evt.event != "job.log" || !strings.Contains(evt.data, "live entry") {
+		t.Fatalf("expected live log entry, got %+v", evt)
+	}
+
+	bus.Publish(context.Background(), events.Event{ID: "other-job-log", Type: "job.log", Data: gin.H{"jobId": "other", "log": store.JobLogEntry{Message: "ignored"}}})
+	current, err := stateStore.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	current.Status = store.JobDone
+	if err := stateStore.UpdateJob(current); err != nil {
+		t.Fatalf("UpdateJob: %v", err)
+	}
+	handler.publishJobEvent(context.Background(), current)
+
+	if evt := next(); evt.event != "job.completed" {
+		t.Fatalf("expected job.completed, got %+v", evt)
+	}
+	select {
+	case evt, ok := <-lines:
+		if ok {
+			t.Fatalf("expected stream to close, got %+v", evt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stream did not close after completion")
+	}
+}
+
 func TestClearHistoryEndpoint(t *testing.T) {
 	t.Parallel()
 
@@ -327,6 +446,94 @@ func TestDescribeVLLMModel(t *testing.T) {
 	}
 }
 
+// slowAdvisor blocks recommendations for the profiles in stuck until release
+// is closed and records how many profiles were evaluated at once.
+type slowAdvisor struct {
+	fakeAdvisor
+	profiles []recommendations.GPUProfile
+	stuck    map[string]bool
+	release  chan struct{}
+
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (a *slowAdvisor) RecommendForModel(model *catalog.Model, gpuType string) recommendations.Recommendation {
+	a.mu.Lock()
+	a.running++
+	if a.running > a.peak {
+		a.peak = a.running
+	}
+	a.mu.Unlock()
+	if a.stuck[gpuType] {
+		<-a.release
+	} else {
+		time.Sleep(5 * time.Millisecond)
+	}
+	a.mu.Lock()
+	a.running--
+	a.mu.Unlock()
+	return recommendations.Recommendation{GPUType: gpuType}
+}
+
+func (a *slowAdvisor) Profiles() []recommendations.GPUProfile {
+	return a.profiles
+}
+
+func TestDescribeVLLMModelReturnsPartialResultsOnTimeout(t *testing.T) {
+	t.Parallel()
+
+	advisor := &slowAdvisor{
+		profiles: []recommendations.GPUProfile{{Name: "l4"}, {Name: "stuck"}, {Name: "a100"}, {Name: "h100"}, {Name: "mi300x"}},
+		stuck:    map[string]bool{"stuck": true},
+		release:  make(chan struct{}),
+	}
+	t.Cleanup(func() { close(advisor.release) })
+	discovery := &fakeDiscovery{
+		modelInfo: &vllm.ModelInsight{Compatible: true, SuggestedCatalog: &catalog.Model{ID: "foo"}},
+	}
+	handler := New(nil, nil, nil, discovery, nil, nil, advisor, nil, nil, nil, nil, nil, nil, nil, Options{
+		DescribeConcurrency: 2,
+		DescribeTimeout:     200 * time.Millisecond,
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/vllm/model-info", strings.NewReader(`{"hfModelId":"foo/bar"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler.DescribeVLLMModel(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Recommendations []recommendations.Recommendation      `json:"recommendations"`
+		Compatibility   []recommendations.CompatibilityReport `json:"compatibility"`
+		Partial         bool                                  `json:"partial"`
+		Notes           []string                              `json:"notes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	var got []string
+	for _, rec := range resp.Recommendations {
+		got = append(got, rec.GPUType)
+	}
+	if strings.Join(got, ",") != "l4,a100,h100,mi300x" || len(resp.Compatibility) != 4 {
+		t.Fatalf("expected every profile but the stuck one in order, got %v (%d reports)", got, len(resp.Compatibility))
+	}
+	if !resp.Partial || len(resp.Notes) != 1 || !strings.Contains(resp.Notes[0], "skipped stuck") {
+		t.Fatalf("expected a partial response naming the skipped profile, got partial=%v notes=%v", resp.Partial, resp.Notes)
+	}
+	advisor.mu.Lock()
+	peak := advisor.peak
+	advisor.mu.Unlock()
+	if peak > 2 {
+		t.Fatalf("expected at most 2 profiles evaluated at once, saw %d", peak)
+	}
+}
+
 func TestGetHuggingFaceModel(t *testing.T) {
 	t.Parallel()
 
@@ -347,6 +554,26 @@ func TestGetHuggingFaceModel(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Fatalf("unexpected status: %d body=%s", w.Code, w.Body.String())
 	}
+	if strings.Contains(w.Body.String(), "cardSummary") {
+		t.Fatalf("expected no model card without includeCard: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = []gin.Param{{Key: "id", Value: "foo/bar"}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/huggingface/models/foo/bar?includeCard=true", nil)
+
+	handler.GetHuggingFaceModel(c)
+
+	var resp struct {
+		Insight vllm.ModelInsight `json:"insight"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if w.Code != http.StatusOK || resp.Insight.CardSummary != "# foo/bar" {
+		t.Fatalf("expected model card summary, got %d %s", w.Code, w.Body.String())
+	}
 }
 
 func TestGetVLLMArchitecture(t *testing.T) {
@@ -370,6 +597,22 @@ func TestGetVLLMArchitecture(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Fatalf("unexpected status: %d body=%s", w.Code, w.Body.String())
 	}
+	var detail vllm.ArchitectureDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil || detail.Name != "qwen" || detail.Source != "class Qwen: pass" {
+		t.Fatalf("unexpected detail %s", w.Body.String())
+	}
+	if discovery.lastInline {
+		t.Fatalf("expected the source not to be requested inline by default")
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = []gin.Param{{Key: "architecture", Value: "qwen"}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/vllm/model/qwen?inline=true", nil)
+	handler.GetVLLMArchitecture(c)
+	if w.Code != http.StatusOK || !discovery.lastInline {
+		t.Fatalf("expected ?inline=true to be passed through, got %d (inline=%v)", w.Code, discovery.lastInline)
+	}
 }
 
 func TestSystemInfo(t *testing.T) {
@@ -472,6 +715,31 @@ func TestListJobsFilters(t *testing.T) {
 	if len(payload.Jobs) != 1 || payload.Jobs[0].ID != "job-1" {
 		t.Fatalf("unexpected jobs payload: %+v", payload)
 	}
+
+	for query, want := range map[string]int{
+		"/jobs?since=1h":                   http.StatusOK,
+		"/jobs?until=2000-01-01T00:00:00Z": http.StatusOK,
+		"/jobs?since=yesterday":            http.StatusBadRequest,
+		"/jobs?since=1h&until=2h":          http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, query, nil)
+		h.ListJobs(c)
+		if w.Code != want {
+			t.Fatalf("%s: expected %d got %d body=%s", query, want, w.Code, w.Body.String())
+		}
+		if want != http.StatusOK {
+			continue
+		}
+		payload.Jobs = nil
+		if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+			t.Fatalf("%s: decode: %v", query, err)
+		}
+		if expected := map[string]int{"/jobs?since=1h": 2}[query]; len(payload.Jobs) != expected {
+			t.Fatalf("%s: expected %d jobs got %+v", query, expected, payload.Jobs)
+		}
+	}
 }
 
 func TestListHistoryFilters(t *testing.T) {
@@ -504,6 +772,37 @@ func TestListHistoryFilters(t *testing.T) {
 	}
 }
 
+func TestListDiscoveredModels(t *testing.T) {
+	t.Parallel()
+
+	st := newTempStore(t)
+	h := New(nil, nil, nil, nil, nil, nil, nil, st, nil, nil, nil, nil, nil, nil, Options{})
+
+	list := func() (int, []catalog.Model) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/huggingface/discovered", nil)
+		h.ListDiscoveredModels(c)
+		var resp struct {
+			Models []catalog.Model `json:"models"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return w.Code, resp.Models
+	}
+
+	if code, models := list(); code != http.StatusOK || len(models) != 0 {
+		t.Fatalf("expected empty list before the first sync, got %d %+v", code, models)
+	}
+	if err := st.SaveDiscoveredModels([]*catalog.Model{{ID: "qwen2-5-0-5b", HFModelID: "Qwen/Qwen2.5-0.5B"}}); err != nil {
+		t.Fatalf("SaveDiscoveredModels: %v", err)
+	}
+	if code, models := list(); code != http.StatusOK || len(models) != 1 || models[0].HFModelID != "Qwen/Qwen2.5-0.5B" {
+		t.Fatalf("unexpected discovered models %d %+v", code, models)
+	}
+}
+
 func TestOpenAPISpecEndpoint(t *testing.T) {
 	t.Parallel()
 
@@ -767,11 +1066,21 @@ func TestMetricsSummaryEndpoint(t *testing.T) {
 type fakeWeightStore struct {
 	listResp        []weights.WeightInfo
 	getResp         *weights.WeightInfo
+	manifestResp    []weights.FileInfo
 	statsResp       *weights.StorageStats
 	installResp     *weights.WeightInfo
 	installErr      error
 	installCalled   bool
 	lastInstallOpts weights.InstallOptions
+	deleted         []string
+	verifyResp      *weights.VerifyResult
+	verifyExpected  []vllm.HFSibling
+	evictResp       []weights.WeightInfo
+	evictErr        error
+	evictRequested  int64
+	evictProtected  []string
+	pruneResp       []weights.WeightInfo
+	pruneAge        time.Duration
 }
 
 func (f *fakeWeightStore) List() ([]weights.WeightInfo, error) {
@@ -779,10 +1088,30 @@ func (f *fakeWeightStore) List() ([]weights.WeightInfo, error) {
 }
 
 func (f *fakeWeightStore) Get(name string) (*weights.WeightInfo, error) {
-	return f.getResp, nil
+	if f.getResp == nil {
+		return nil, nil
+	}
+	info := *f.getResp
+	return &info, nil
+}
+
+func (f *fakeWeightStore) Manifest(name string) ([]weights.FileInfo, error) {
+	if f.manifestResp == nil {
+		return nil, fs.ErrNotExist
+	}
+	return f.manifestResp, nil
+}
+
+func (f *fakeWeightStore) Verify(name string, expected []vllm.HFSibling) (*weights.VerifyResult, error) {
+	f.verifyExpected = expected
+	if f.verifyResp != nil {
+		return f.verifyResp, nil
+	}
+	return &weights.VerifyResult{Name: name, OK: true}, nil
 }
 
 func (f *fakeWeightStore) Delete(name string) error {
+	f.deleted = append(f.deleted, name)
 	return nil
 }
 
@@ -790,6 +1119,20 @@ func (f *fakeWeightStore) GetStats() (*weights.StorageStats, error) {
 	return f.statsResp, nil
 }
 
+func (f *fakeWeightStore) EvictToFree(bytes int64, protected []string) ([]weights.WeightInfo, error) {
+	f.evictRequested = bytes
+	f.evictProtected = protected
+	if f.evictErr != nil {
+		return nil, f.evictErr
+	}
+	return f.evictResp, nil
+}
+
+func (f *fakeWeightStore) PruneCandidates(maxAge time.Duration) ([]weights.WeightInfo, error) {
+	f.pruneAge = maxAge
+	return f.pruneResp, nil
+}
+
 func (f *fakeWeightStore) InstallFromHuggingFace(ctx context.Context, opts weights.InstallOptions) (*weights.WeightInfo, error) {
 	f.installCalled = true
 	f.lastInstallOpts = opts
@@ -801,7 +1144,9 @@ type fakeDiscovery struct {
 	modelResp  *catalog.Model
 	modelInfo  *vllm.ModelInsight
 	archDetail *vllm.ArchitectureDetail
+	lastInline bool
 	lastSearch vllm.SearchOptions
+	hfErr      error
 }
 
 func (f *fakeDiscovery) ListSupportedArchitectures() ([]vllm.ModelArchitecture, error) {
@@ -827,12 +1172,19 @@ func (f *fakeDiscovery) GenerateModelConfig(req vllm.GenerateRequest) (*catalog.
 }
 
 func (f *fakeDiscovery) GetHuggingFaceModel(modelID string) (*vllm.HuggingFaceModel, error) {
+	if f.hfErr != nil {
+		return nil, f.hfErr
+	}
 	model := *f.hfModel
 	model.ID = modelID
 	model.ModelID = modelID
 	return &model, nil
 }
 
+func (f *fakeDiscovery) GetHuggingFaceModelRevision(modelID, revision string) (*vllm.HuggingFaceModel, error) {
+	return f.GetHuggingFaceModel(modelID)
+}
+
 func (f *fakeDiscovery) DescribeModel(id string, auto bool) (*vllm.ModelInsight, error) {
 	if f.modelInfo == nil {
 		return nil, fmt.Errorf("not found")
@@ -841,6 +1193,15 @@ func (f *fakeDiscovery) DescribeModel(id string, auto bool) (*vllm.ModelInsight,
 	return &info, nil
 }
 
+func (f *fakeDiscovery) DescribeModelWithCard(id string, auto bool) (*vllm.ModelInsight, error) {
+	info, err := f.DescribeModel(id, auto)
+	if err != nil {
+		return nil, err
+	}
+	info.CardSummary = "# " + id
+	return info, nil
+}
+
 func (f *fakeDiscovery) SearchModels(opts vllm.SearchOptions) ([]*vllm.ModelInsight, error) {
 	f.lastSearch = opts
 	if f.modelInfo == nil {
@@ -850,10 +1211,11 @@ func (f *fakeDiscovery) SearchModels(opts vllm.SearchOptions) ([]*vllm.ModelInsi
 	return []*vllm.ModelInsight{&info}, nil
 }
 
-func (f *fakeDiscovery) GetArchitectureDetail(name string) (*vllm.ArchitectureDetail, error) {
+func (f *fakeDiscovery) GetArchitectureDetail(name string, inline bool) (*vllm.ArchitectureDetail, error) {
 	if f.archDetail == nil {
 		return nil, fmt.Errorf("not found")
 	}
+	f.lastInline = inline
 	detail := *f.archDetail
 	return &detail, nil
 }
@@ -879,26 +1241,83 @@ type fakeCatalogWriter struct {
 	prErr        error
 	commitCalled bool
 	lastBranch   string
+	lastBase     string
 	lastMessage  string
 	lastPaths    []string
+	lastStatus   catalogwriter.ActiveStatus
+	lastPR       *catalogwriter.PullRequestOptions
+}
+
+func (f *fakeCatalogWriter) Load(id string) (*catalog.Model, error) {
+	return nil, catalogwriter.ErrModelNotFound
 }
 
 func (f *fakeCatalogWriter) Save(model *catalog.Model) (*catalogwriter.SaveResult, error) {
 	return f.saveResult, f.saveErr
 }
 
+func (f *fakeCatalogWriter) Diff(model *catalog.Model) (*catalogwriter.SaveResult, string, error) {
+	return f.saveResult, "", f.saveErr
+}
+
+func (f *fakeCatalogWriter) SaveStatus(status catalogwriter.ActiveStatus) (*catalogwriter.SaveResult, error) {
+	f.lastStatus = status
+	return f.saveResult, f.saveErr
+}
+
 func (f *fakeCatalogWriter) CommitAndPush(ctx context.Context, branch, base, message string, paths ...string) error {
 	f.commitCalled = true
 	f.lastBranch = branch
+	f.lastBase = base
 	f.lastMessage = message
 	f.lastPaths = paths
 	return f.commitErr
 }
 
 func (f *fakeCatalogWriter) CreatePullRequest(ctx context.Context, opts catalogwriter.PullRequestOptions) (*catalogwriter.PullRequest, error) {
+	f.lastPR = &opts
 	return f.pr, f.prErr
 }
 
+func TestActiveStatusRecordsInstalledWeightRevision(t *testing.T) {
+	t.Parallel()
+
+	model := &catalog.Model{ID: "demo", HFModelID: "org/demo", StorageURI: "pvc://venus-model-storage/org/demo"}
+	wm := &fakeWeightStore{getResp: &weights.WeightInfo{Name: "org/demo", HFModelID: "org/demo", Revision: "abc123"}}
+	handler := New(nil, nil, wm, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{WeightsPVCName: "venus-model-storage"})
+
+	status := handler.activeStatus("alice", model)
+	if status.Revision != "abc123" || status.CatalogHash != catalog.ContentHash(model) || status.ActivatedBy != "alice" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestPublishActiveStatusOpensPullRequestUnlessBranchConfigured(t *testing.T) {
+	t.Parallel()
+
+	status := catalogwriter.ActiveStatus{ModelID: "demo", ActivatedAt: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)}
+
+	writer := &fakeCatalogWriter{
+		saveResult: &catalogwriter.SaveResult{RelativePath: "status/active.yaml"},
+		pr:         &catalogwriter.PullRequest{HTMLURL: "https://github.com/org/catalog/pull/7"},
+	}
+	handler := New(nil, nil, nil, nil, nil, writer, nil, nil, nil, nil, nil, nil, nil, nil, Options{GitHubToken: "token"})
+	handler.publishActiveStatus(status)
+	if writer.lastBranch != "status/active-20261018-093000" || writer.lastBase != "" {
+		t.Fatalf("expected a fresh status branch off the base branch, got %q from %q", writer.lastBranch, writer.lastBase)
+	}
+	if writer.lastPR == nil || writer.lastPR.Branch != writer.lastBranch || writer.lastPR.Token != "token" {
+		t.Fatalf("expected a pull request for the status branch, got %+v", writer.lastPR)
+	}
+
+	direct := &fakeCatalogWriter{saveResult: &catalogwriter.SaveResult{RelativePath: "status/active.yaml"}}
+	handler = New(nil, nil, nil, nil, nil, direct, nil, nil, nil, nil, nil, nil, nil, nil, Options{CatalogStatusBranch: "status"})
+	handler.publishActiveStatus(status)
+	if direct.lastBranch != "status" || direct.lastBase != "status" || direct.lastPR != nil {
+		t.Fatalf("expected a direct push to the configured branch, got %q from %q (pr %+v)", direct.lastBranch, direct.lastBase, direct.lastPR)
+	}
+}
+
 type fakeAdvisor struct{}
 
 func (f *fakeAdvisor) Compatibility(model *catalog.Model, gpuType string) recommendations.CompatibilityReport {
@@ -910,6 +1329,10 @@ func (f *fakeAdvisor) Compatibility(model *catalog.Model, gpuType string) recomm
 	}
 }
 
+func (f *fakeAdvisor) CompatibilityWithConfig(model *catalog.Model, gpuType string, cfg map[string]interface{}) recommendations.CompatibilityReport {
+	return f.Compatibility(model, gpuType)
+}
+
 func (f *fakeAdvisor) Recommend(gpuType string) recommendations.Recommendation {
 	return recommendations.Recommendation{GPUType: gpuType}
 }
@@ -932,6 +1355,18 @@ func (f *fakeRuntimeStatus) CurrentStatus() status.RuntimeStatus {
 	return f.status
 }
 
+type fakeMultiRuntimeStatus struct {
+	statuses []status.RuntimeStatus
+}
+
+func (f *fakeMultiRuntimeStatus) CurrentStatus() status.RuntimeStatus {
+	return f.statuses[0]
+}
+
+func (f *fakeMultiRuntimeStatus) Statuses() []status.RuntimeStatus {
+	return f.statuses
+}
+
 func newTempStore(t *testing.T) *store.Store {
 	t.Helper()
 	dir := t.TempDir()
@@ -945,3 +1380,3057 @@ func newTempStore(t *testing.T) *store.Store {
 	})
 	return s
 }
+
+func TestActivateModelRejectsStaleCatalogHash(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelsDir, "demo.json"), []byte(`{"id":"demo","hfModelId":"org/demo"}`), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	cat := catalog.New(root, "models")
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+	body := bytes.NewBufferString(`{"id":"demo","catalogHash":"\"deadbeef\""}`)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/models/activate", body)
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.ActivateModel(c)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp["catalogHash"] != catalog.ContentHash(cat.Get("demo")) {
+		t.Fatalf("expected current catalog hash in response, got %v", resp["catalogHash"])
+	}
+}
+
+func TestGetModelPlan(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "demo", HFModelID: "org/demo", StorageURI: "pvc://venus-model-storage/demo"},
+	})
+	wm := &fakeWeightStore{getResp: &weights.WeightInfo{Name: "demo", Path: "/models/demo"}}
+	handler := New(cat, nil, wm, nil, nil, nil, &fakeAdvisor{}, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "demo"}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/models/demo/plan", nil)
+
+	handler.GetModelPlan(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Storage struct {
+			Installed bool   `json:"installed"`
+			PVC       string `json:"pvc"`
+		} `json:"storage"`
+		TensorParallel struct {
+			Options []struct {
+				GPU  string `json:"gpu"`
+				GPUs int    `json:"gpus"`
+			} `json:"options"`
+		} `json:"tensorParallel"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !resp.Storage.Installed || resp.Storage.PVC != "venus-model-storage" {
+		t.Fatalf("unexpected storage section: %+v", resp.Storage)
+	}
+	if len(resp.TensorParallel.Options) != 1 || resp.TensorParallel.Options[0].GPUs != 1 {
+		t.Fatalf("unexpected tensor parallel options: %+v", resp.TensorParallel.Options)
+	}
+}
+
+func TestGetModelPlanResolvesBucketStorage(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "demo", HFModelID: "org/demo", StorageURI: "s3://models/cache/org/demo"},
+	})
+	wm := &fakeWeightStore{getResp: &weights.WeightInfo{Name: "org/demo", Path: "/models/org/demo"}}
+	handler := New(cat, nil, wm, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{
+		StorageBackend: "s3",
+		StorageBucket:  "models",
+		StoragePrefix:  "cache",
+	})
+
+	storage := handler.planStorage(cat.Get("demo"))
+	if storage["installed"] != true || storage["backend"] != "s3" || storage["bucket"] != "models" || storage["path"] != "org/demo" {
+		t.Fatalf("unexpected storage section: %+v", storage)
+	}
+
+	other := &catalog.Model{ID: "other", StorageURI: "s3://archive/org/demo"}
+	if storage := handler.planStorage(other); storage["installed"] != false || storage["message"] == nil {
+		t.Fatalf("expected bucket outside the layout to be reported, got %+v", storage)
+	}
+}
+
+func TestPlanTensorParallelHonoursNodeLimits(t *testing.T) {
+	t.Parallel()
+
+	profiles := []recommendations.GPUProfile{
+		{Name: "a100-40gb", MemoryGB: 40, MaxGPUsPerNode: 8},
+		{Name: "l4", MemoryGB: 24, MaxGPUsPerNode: 2},
+		{Name: "t4", MemoryGB: 16},
+	}
+	result := planTensorParallel(nil, 100, profiles)
+	options, _ := result["options"].([]gin.H)
+	if len(options) != 3 {
+		t.Fatalf("expected one option per profile, got %+v", result)
+	}
+	// 100 GiB needs three 40 GiB GPUs, rounded up to a power of two.
+	if options[0]["feasible"] != true || options[0]["gpus"] != 4 {
+		t.Fatalf("expected a100 to shard across 4 GPUs, got %+v", options[0])
+	}
+	for _, option := range options[1:] {
+		if option["feasible"] != false || option["gpus"] != nil || option["reason"] == nil {
+			t.Fatalf("expected %v to be infeasible within one node, got %+v", option["gpu"], option)
+		}
+	}
+}
+
+func TestGetRuntimeStatusFiltersAndSummarizes(t *testing.T) {
+	t.Parallel()
+
+	runtime := &fakeRuntimeStatus{status: status.RuntimeStatus{
+		InferenceService: &status.InferenceServiceStatus{Name: "active-llm", Ready: "True"},
+		Deployments: []status.DeploymentStatus{
+			{Name: "active-llm-predictor", Replicas: 1, ReadyReplicas: 1},
+			{Name: "other", Replicas: 1},
+		},
+		Pods: []status.PodStatus{
+			{Name: "active-llm-predictor-abc-1", Phase: "Running", ReadyContainers: 1, TotalContainers: 1},
+			{Name: "active-llm-predictor-abc-2", Phase: "Pending", TotalContainers: 1},
+			{Name: "other-xyz-1", Phase: "Running", ReadyContainers: 1, TotalContainers: 1},
+		},
+		UpdatedAt: time.Now().UTC(),
+	}}
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, runtime, nil, Options{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/models/status?deployment=active-llm-predictor&summary=true", nil)
+
+	handler.GetRuntimeStatus(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var summary status.Summary
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if summary.Deployments != 1 || summary.ReadyDeployments != 1 {
+		t.Fatalf("unexpected deployment counts: %+v", summary)
+	}
+	if summary.Pods != 2 || summary.ReadyPods != 1 {
+		t.Fatalf("unexpected pod counts: %+v", summary)
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/models/status?pod=other-xyz-1", nil)
+
+	handler.GetRuntimeStatus(c)
+
+	var filtered status.RuntimeStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &filtered); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(filtered.Pods) != 1 || filtered.Pods[0].Name != "other-xyz-1" {
+		t.Fatalf("unexpected pods: %+v", filtered.Pods)
+	}
+}
+
+func TestGetRuntimeStatusSelectsTargets(t *testing.T) {
+	t.Parallel()
+
+	runtime := &fakeMultiRuntimeStatus{statuses: []status.RuntimeStatus{
+		{Namespace: "ai", Target: "ai/active-llm", InferenceService: &status.InferenceServiceStatus{Name: "active-llm", Ready: "True"}, UpdatedAt: time.Now().UTC()},
+		{Namespace: "batch", Target: "batch/embedder", InferenceService: &status.InferenceServiceStatus{Name: "embedder", Ready: "False"},
+			Pods: []status.PodStatus{{Name: "embedder-predictor-1", GPURequests: map[string]string{"nvidia.com/gpu": "2"}}}, GPUAllocations: map[string]string{"nvidia.com/gpu": "2"}, UpdatedAt: time.Now().UTC()},
+	}}
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, runtime, nil, Options{})
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/models/status"+query, nil)
+		handler.GetRuntimeStatus(c)
+		return w
+	}
+
+	var st status.RuntimeStatus
+	if err := json.Unmarshal(get("").Body.Bytes(), &st); err != nil || st.Target != "ai/active-llm" {
+		t.Fatalf("expected the active runtime by default, got %+v (%v)", st, err)
+	}
+	for _, target := range []string{"batch/embedder", "embedder"} {
+		w := get("?target=" + target)
+		st = status.RuntimeStatus{}
+		if err := json.Unmarshal(w.Body.Bytes(), &st); err != nil || st.Target != "batch/embedder" || st.GPUAllocations["nvidia.com/gpu"] != "2" {
+			t.Fatalf("target %s: unexpected status %d %s", target, w.Code, w.Body.String())
+		}
+	}
+	if w := get("?target=ai/missing"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unwatched target, got %d", w.Code)
+	}
+
+	var all struct {
+		Targets []status.Summary `json:"targets"`
+	}
+	if err := json.Unmarshal(get("?all=true&summary=true").Body.Bytes(), &all); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(all.Targets) != 2 || all.Targets[0].Target != "ai/active-llm" || all.Targets[1].Ready != "False" || all.Targets[1].Pods != 1 {
+		t.Fatalf("unexpected per-target summaries: %+v", all.Targets)
+	}
+}
+
+type fakeGPUInventory struct {
+	inv *inventory.Inventory
+}
+
+func (f *fakeGPUInventory) Inventory(context.Context) (*inventory.Inventory, error) {
+	return f.inv, nil
+}
+
+func TestModelEventsMergesTimeline(t *testing.T) {
+	t.Parallel()
+
+	stateStore := newTempStore(t)
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{{ID: "qwen", HFModelID: "Qwen/Qwen2.5-0.5B", Aliases: []string{"qwen-old"}}})
+	bus := events.NewBus(events.Options{})
+	handler := New(cat, nil, nil, nil, nil, nil, nil, stateStore, nil, bus, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+
+	step := func() { time.Sleep(5 * time.Millisecond) }
+	if err := stateStore.CreateJob(&store.Job{ID: "job-1", Type: "weight_install", Status: store.JobDone, Payload: map[string]interface{}{"hfModelId": "Qwen/Qwen2.5-0.5B"}}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if err := stateStore.CreateJob(&store.Job{ID: "job-other", Type: "weight_install", Payload: map[string]interface{}{"hfModelId": "other/model"}}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	step()
+	for _, entry := range []store.HistoryEntry{{Event: "catalog_update", ModelID: "qwen-old"}, {Event: "activate", ModelID: "other"}} {
+		entry := entry
+		if err := stateStore.AppendHistory(&entry); err != nil {
+			t.Fatalf("AppendHistory: %v", err)
+		}
+	}
+	step()
+	if err := bus.Publish(context.Background(), events.New(events.ActivationCompleted{Action: "created", ModelID: "qwen"})); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	_ = bus.Publish(context.Background(), events.New(events.ActivationCompleted{ModelID: "other"}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = []gin.Param{{Key: "id", Value: "qwen"}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/models/qwen/events", nil)
+	handler.ModelEvents(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Events []modelTimelineEntry `json:"events"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	got := make([]string, 0, len(resp.Events))
+	for _, entry := range resp.Events {
+		got = append(got, entry.Source+":"+entry.Type)
+	}
+	want := []string{"event:" + events.TypeActivationCompleted, "history:catalog_update", "job:weight_install"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected timeline %v, got %v", want, got)
+	}
+}
+
+func TestGetGPUInventoryAndSummaryAggregate(t *testing.T) {
+	t.Parallel()
+
+	disabled := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/gpu/inventory", nil)
+	disabled.GetGPUInventory(c)
+	if w.Code != http.StatusNotImplemented {
+		t.Fatalf("expected 501 without an inventory, got %d", w.Code)
+	}
+
+	inv := &inventory.Inventory{
+		Nodes: []inventory.Node{{Name: "gpu-1", Ready: true, GPUs: []inventory.NodeGPUs{{Resource: inventory.ResourceNVIDIA, Product: "NVIDIA-A100-SXM4-80GB", Total: 4, Used: 3, Free: 1}}}},
+		Types: []inventory.TypeTotal{{Resource: inventory.ResourceNVIDIA, Product: "NVIDIA-A100-SXM4-80GB", Nodes: 1, Total: 4, Used: 3, Free: 1}},
+		Total: 4, Used: 3, Free: 1,
+	}
+	handler := New(catalog.New(t.TempDir(), ""), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{GPUInventory: &fakeGPUInventory{inv: inv}})
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/gpu/inventory", nil)
+	handler.GetGPUInventory(c)
+	var got inventory.Inventory
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+	if len(got.Nodes) != 1 || got.Nodes[0].GPUs[0].Free != 1 {
+		t.Fatalf("unexpected inventory: %+v", got)
+	}
+
+	summary, _ := handler.buildSystemSummary(context.Background())
+	gpus, ok := summary["gpus"].(gin.H)
+	if !ok || gpus["total"] != int64(4) || gpus["free"] != int64(1) || gpus["nodes"] != 1 {
+		t.Fatalf("unexpected GPU aggregate in summary: %+v", summary["gpus"])
+	}
+}
+
+func TestTestNotificationRecordsFailedDelivery(t *testing.T) {
+	t.Parallel()
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer webhook.Close()
+
+	stateStore := openTestStore(t)
+	if err := stateStore.UpsertNotification(&store.Notification{Name: "ops", Type: "slack", Target: webhook.URL}); err != nil {
+		t.Fatalf("UpsertNotification: %v", err)
+	}
+	handler := New(nil, nil, nil, nil, nil, nil, nil, stateStore, nil, nil, nil, nil, nil, nil, Options{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/notifications/test", strings.NewReader(`{"channel":"ops","message":"hello"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.TestNotification(c)
+
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected status 502 got %d body=%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "name", Value: "ops"}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/notifications/ops/deliveries", nil)
+
+	handler.NotificationDeliveries(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Deliveries []store.NotificationDelivery `json:"deliveries"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(body.Deliveries) != 1 {
+		t.Fatalf("expected 1 delivery got %+v", body.Deliveries)
+	}
+	got := body.Deliveries[0]
+	if got.Status != store.DeliveryFailed || got.HTTPCode != http.StatusInternalServerError || got.Error == "" || got.Payload != "hello" {
+		t.Fatalf("unexpected delivery record: %+v", got)
+	}
+}
+
+func TestInstallWeightsRejectsWhenStorageIsShort(t *testing.T) {
+	t.Parallel()
+
+	weightStore := &fakeWeightStore{
+		statsResp: &weights.StorageStats{
+			TotalBytes:     100 << 30,
+			AvailableBytes: 5 << 30,
+		},
+	}
+	discovery := &fakeDiscovery{
+		hfModel: &vllm.HuggingFaceModel{
+			Siblings: []vllm.HFSibling{
+				{RFileName: "config.json", Size: 1 << 10},
+				{RFileName: "model.safetensors", Size: 8 << 30},
+			},
+		},
+	}
+	handler := New(nil, nil, weightStore, discovery, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{
+		WeightsPVCName: "venus-model-storage",
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(`{"hfModelId":"Qwen/Qwen2.5-7B"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.InstallWeights(c)
+
+	if w.Code != http.StatusInsufficientStorage {
+		t.Fatalf("expected status 507 got %d body=%s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "short by") {
+		t.Fatalf("expected shortfall in error message: %s", w.Body.String())
+	}
+	if weightStore.installCalled {
+		t.Fatalf("install must not run when storage is insufficient")
+	}
+}
+
+func TestDeleteWeightsByPrefixAndGlob(t *testing.T) {
+	t.Parallel()
+
+	installed := []weights.WeightInfo{
+		{Name: "Qwen/Qwen2.5-0.5B"},
+		{Name: "Qwen/Qwen2.5-7B"},
+		{Name: "QwenLab/other"},
+		{Name: "meta-llama/Llama-3-8B"},
+	}
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{query: "prefix=Qwen/", want: []string{"Qwen/Qwen2.5-0.5B", "Qwen/Qwen2.5-7B"}},
+		{query: "match=Qwen/*-7B", want: []string{"Qwen/Qwen2.5-7B"}},
+	}
+	for _, tc := range cases {
+		weightStore := &fakeWeightStore{listResp: installed}
+		handler := New(nil, nil, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/weights?"+tc.query, nil)
+
+		handler.DeleteWeights(c)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200 got %d body=%s", tc.query, w.Code, w.Body.String())
+		}
+		sort.Strings(weightStore.deleted)
+		if !reflect.DeepEqual(weightStore.deleted, tc.want) {
+			t.Fatalf("%s: deleted %v want %v", tc.query, weightStore.deleted, tc.want)
+		}
+		var body struct {
+			Results map[string]string `json:"results"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		for _, name := range tc.want {
+			if body.Results[name] != "deleted" {
+				t.Fatalf("%s: expected %s deleted in results %v", tc.query, name, body.Results)
+			}
+		}
+	}
+}
+
+func TestRetryJobAppliesBackoff(t *testing.T) {
+	t.Parallel()
+
+	stateStore := openTestStore(t)
+	job := &store.Job{
+		ID:          "job-retry",
+		Type:        "weight_install",
+		Status:      store.JobFailed,
+		Attempt:     2,
+		MaxAttempts: 5,
+		Payload:     map[string]interface{}{"hfModelId": "Qwen/Qwen2.5-0.5B"},
+	}
+	if err := stateStore.CreateJob(job); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	jobQueue := queue.NewMemory()
+	handler := New(nil, nil, nil, nil, nil, nil, nil, stateStore, nil, nil, jobQueue, nil, nil, nil, Options{
+		RetryBackoffBase: time.Minute,
+		RetryBackoffMax:  10 * time.Minute,
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/jobs/job-retry/retry", nil)
+	c.Params = []gin.Param{{Key: "id", Value: "job-retry"}}
+
+	before := time.Now().UTC()
+	handler.RetryJob(c)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		NextAttemptAt time.Time `json:"nextAttemptAt"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// base * 2^2 = 4m
+	if delay := resp.NextAttemptAt.Sub(before); delay < 4*time.Minute || delay > 4*time.Minute+5*time.Second {
+		t.Fatalf("expected ~4m backoff got %s", delay)
+	}
+
+	stored, err := stateStore.GetJob("job-retry")
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if stored.Status != store.JobPending || stored.NextAttemptAt == nil {
+		t.Fatalf("expected pending job with next attempt, got status=%s next=%v", stored.Status, stored.NextAttemptAt)
+	}
+	if depth, _ := jobQueue.Length(context.Background()); depth != 0 {
+		t.Fatalf("expected the job to wait out its backoff before being enqueued, queue depth %d", depth)
+	}
+}
+
+func TestListDeadLetterJobsReturnsNewestFirst(t *testing.T) {
+	t.Parallel()
+
+	jobQueue := queue.NewMemory()
+	ctx := context.Background()
+	if err := jobQueue.DeadLetter(ctx, "1-0", nil, `{"jobId":`, "malformed job message"); err != nil {
+		t.Fatalf("DeadLetter: %v", err)
+	}
+	poison := &queue.WeightInstallMessage{JobID: "job-poison"}
+	if err := jobQueue.DeadLetter(ctx, "2-0", poison, "", "invalid payload: missing hfModelId"); err != nil {
+		t.Fatalf("DeadLetter: %v", err)
+	}
+
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, nil, Options{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/jobs/deadletter", nil)
+	handler.ListDeadLetterJobs(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Entries []queue.DeadLetterEntry `json:"entries"`
+		Count   int                     `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Count != 2 || len(resp.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", resp)
+	}
+	if resp.Entries[0].JobID != "job-poison" || resp.Entries[0].Data == "" {
+		t.Fatalf("expected newest entry for job-poison with payload, got %+v", resp.Entries[0])
+	}
+	if resp.Entries[1].Data != `{"jobId":` {
+		t.Fatalf("expected raw payload preserved for malformed message, got %q", resp.Entries[1].Data)
+	}
+}
+
+func TestListDeadLetterJobsRequiresQueue(t *testing.T) {
+	t.Parallel()
+
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/jobs/deadletter", nil)
+	handler.ListDeadLetterJobs(c)
+
+	if w.Code != http.StatusNotImplemented {
+		t.Fatalf("expected 501 got %d", w.Code)
+	}
+}
+
+func TestVerifyWeightsUsesRecordedRevision(t *testing.T) {
+	t.Parallel()
+
+	weightStore := &fakeWeightStore{
+		getResp: &weights.WeightInfo{Name: "Qwen/Qwen2.5-0.5B", HFModelID: "Qwen/Qwen2.5-0.5B", Revision: "main"},
+		verifyResp: &weights.VerifyResult{
+			Name:    "Qwen/Qwen2.5-0.5B",
+			Missing: []string{"model.safetensors"},
+		},
+	}
+	discovery := &fakeDiscovery{hfModel: &vllm.HuggingFaceModel{
+		Siblings: []vllm.HFSibling{
+			{RFileName: "config.json", Size: 2},
+			{RFileName: "model.safetensors", Size: 1024},
+		},
+	}}
+	handler := New(nil, nil, weightStore, discovery, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/weights/verify?name=Qwen/Qwen2.5-0.5B", nil)
+
+	handler.VerifyWeights(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	if len(weightStore.verifyExpected) != 2 || weightStore.verifyExpected[1].Size != 1024 {
+		t.Fatalf("expected Hub siblings with sizes, got %+v", weightStore.verifyExpected)
+	}
+	var result weights.VerifyResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.OK || len(result.Missing) != 1 {
+		t.Fatalf("unexpected verify result: %+v", result)
+	}
+}
+
+func TestInstallWeightsReportsGatedModel(t *testing.T) {
+	t.Parallel()
+
+	discovery := &fakeDiscovery{hfErr: fmt.Errorf("%w: meta-llama/Llama-3-8B", vllm.ErrGatedModel)}
+	handler := New(nil, nil, &fakeWeightStore{}, discovery, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(`{"hfModelId":"meta-llama/Llama-3-8B"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	handler.InstallWeights(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 got %d body=%s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "HUGGINGFACE_API_TOKEN") {
+		t.Fatalf("expected token guidance in error, got %s", w.Body.String())
+	}
+}
+
+func TestListModelsPaginates(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{{ID: "c"}, {ID: "a"}, {ID: "b"}})
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	type page struct {
+		Models     []catalog.Model `json:"models"`
+		Total      int             `json:"total"`
+		NextOffset *int            `json:"nextOffset"`
+	}
+	get := func(query string) page {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/models"+query, nil)
+		handler.ListModels(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200 got %d body=%s", query, w.Code, w.Body.String())
+		}
+		var resp page
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp
+	}
+
+	first := get("?limit=2")
+	if first.Total != 3 || len(first.Models) != 2 || first.Models[0].ID != "a" || first.Models[1].ID != "b" {
+		t.Fatalf("unexpected first page %+v", first)
+	}
+	if first.NextOffset == nil || *first.NextOffset != 2 {
+		t.Fatalf("expected nextOffset 2 got %v", first.NextOffset)
+	}
+	second := get("?limit=2&offset=2")
+	if len(second.Models) != 1 || second.Models[0].ID != "c" || second.NextOffset != nil {
+		t.Fatalf("unexpected second page %+v", second)
+	}
+	if all := get(""); len(all.Models) != 3 || all.NextOffset != nil {
+		t.Fatalf("expected full listing without limit, got %+v", all)
+	}
+}
+
+func TestListModelsFilters(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "llama-chat", DisplayName: "Llama Chat", Runtime: "vllm-runtime", Tags: []string{"chat", "Llama"}},
+		{ID: "qwen-coder", HFModelID: "Qwen/Qwen2.5-Coder", Runtime: "vllm-runtime", Tags: []string{"code"}},
+		{ID: "bert-embed", Runtime: "tei", Tags: []string{"embedding"}},
+	})
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	ids := func(query string) ([]string, int) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/models"+query, nil)
+		handler.ListModels(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200 got %d body=%s", query, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Models []catalog.Model `json:"models"`
+			Total  int             `json:"total"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		out := make([]string, 0, len(resp.Models))
+		for _, m := range resp.Models {
+			out = append(out, m.ID)
+		}
+		return out, resp.Total
+	}
+
+	cases := map[string][]string{
+		"?q=qwen2.5":                     {"qwen-coder"},
+		"?q=CHAT":                        {"llama-chat"},
+		"?runtime=VLLM-runtime":          {"llama-chat", "qwen-coder"},
+		"?tag=llama&tag=chat":            {"llama-chat"},
+		"?tag=chat&tag=code":             {},
+		"?runtime=vllm-runtime&tag=code": {"qwen-coder"},
+		"?runtime=tei&q=bert&limit=1":    {"bert-embed"},
+	}
+	for query, want := range cases {
+		got, total := ids(query)
+		if len(got) != len(want) || total != len(want) {
+			t.Fatalf("%s: expected %v got %v (total %d)", query, want, got, total)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: expected %v got %v", query, want, got)
+			}
+		}
+	}
+}
+
+func TestGPURecommendationsSizesTensorParallel(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "qwen-7b", HFModelID: "Qwen/Qwen2-7B"},
+		{ID: "llama-70b", HFModelID: "meta-llama/Llama-3-70B"},
+		{ID: "llama-405b", HFModelID: "meta-llama/Llama-3.1-405B"},
+	})
+	advisor := recommendations.New(map[string]recommendations.GPUProfile{
+		"a100": {Name: "a100", MemoryGB: 40, MaxGPUsPerNode: 4},
+	})
+	handler := New(cat, nil, nil, nil, nil, nil, advisor, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	recommend := func(modelID string) (int, recommendations.Recommendation) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "gpuType", Value: "a100"}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/recommendations/a100?modelId="+modelID, nil)
+		handler.GPURecommendations(c)
+		var rec recommendations.Recommendation
+		_ = json.Unmarshal(w.Body.Bytes(), &rec)
+		return w.Code, rec
+	}
+
+	if code, rec := recommend("qwen-7b"); code != http.StatusOK || !rec.Feasible || rec.TensorParallelSize != 1 {
+		t.Fatalf("expected 7B to fit on one GPU, got %d %+v", code, rec)
+	}
+	code, rec := recommend("llama-70b")
+	if code != http.StatusOK || !rec.Feasible || rec.TensorParallelSize != 4 {
+		t.Fatalf("expected 70B to need TP 4, got %d %+v", code, rec)
+	}
+	if !strings.Contains(strings.Join(rec.Flags, " "), "--tensor-parallel-size 4") {
+		t.Fatalf("expected tensor parallel flag, got %v", rec.Flags)
+	}
+	if code, rec := recommend("llama-405b"); code != http.StatusOK || rec.Feasible || rec.TensorParallelSize != 0 {
+		t.Fatalf("expected 405B to be infeasible, got %d %+v", code, rec)
+	}
+	if code, _ := recommend("missing"); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown model, got %d", code)
+	}
+}
+
+func TestModelCompatibilityReportsLiveGPUAvailability(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{{ID: "qwen-7b", HFModelID: "Qwen/Qwen2-7B", Resources: &catalog.Resources{Limits: map[string]string{"nvidia.com/gpu": "2"}}}})
+	advisor := recommendations.New(map[string]recommendations.GPUProfile{
+		"a100": {Name: "a100", Vendor: "nvidia", MemoryGB: 80, Labels: map[string]string{"nvidia.com/gpu.product": "A100"}},
+		"l4":   {Name: "l4", Vendor: "nvidia", MemoryGB: 24, Labels: map[string]string{"nvidia.com/gpu.product": "L4"}},
+	})
+	inv := &inventory.Inventory{Nodes: []inventory.Node{
+		{Name: "a100-1", Ready: true, Labels: map[string]string{"nvidia.com/gpu.product": "A100"}, GPUs: []inventory.NodeGPUs{{Resource: inventory.ResourceNVIDIA, Total: 4, Used: 3, Free: 1}}},
+		{Name: "a100-2", Ready: true, Labels: map[string]string{"nvidia.com/gpu.product": "A100"}, GPUs: []inventory.NodeGPUs{{Resource: inventory.ResourceNVIDIA, Total: 2, Used: 1, Free: 1}}},
+		{Name: "l4-1", Ready: true, Labels: map[string]string{"nvidia.com/gpu.product": "L4"}, GPUs: []inventory.NodeGPUs{{Resource: inventory.ResourceNVIDIA, Total: 4, Free: 4}}},
+	}}
+
+	compatibility := func(handler *Handler, query string) recommendations.CompatibilityReport {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "id", Value: "qwen-7b"}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/models/qwen-7b/compatibility"+query, nil)
+		handler.ModelCompatibility(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+		}
+		var report recommendations.CompatibilityReport
+		_ = json.Unmarshal(w.Body.Bytes(), &report)
+		return report
+	}
+
+	profileOnly := New(cat, nil, nil, nil, nil, nil, advisor, nil, nil, nil, nil, nil, nil, nil, Options{})
+	profileOnly.lastCatalogRefresh = time.Now()
+	if report := compatibility(profileOnly, "?gpuType=a100"); !report.Compatible || report.Schedulable != nil || report.FreeGPUs != nil {
+		t.Fatalf("expected a profile-only answer without inventory, got %+v", report)
+	}
+
+	handler := New(cat, nil, nil, nil, nil, nil, advisor, nil, nil, nil, nil, nil, nil, nil, Options{GPUInventory: &fakeGPUInventory{inv: inv}})
+	handler.lastCatalogRefresh = time.Now()
+	// Two A100s are free, but on different nodes; the model needs two on one.
+	report := compatibility(handler, "?gpuType=a100")
+	if !report.Compatible || report.Schedulable == nil || *report.Schedulable || report.FreeGPUs == nil || *report.FreeGPUs != 2 {
+		t.Fatalf("expected a compatible but unschedulable A100 report, got %+v", report)
+	}
+	if !strings.Contains(report.Reason, "no node currently has 2 free") {
+		t.Fatalf("expected the reason to explain the missing capacity, got %q", report.Reason)
+	}
+	for _, candidate := range compatibility(handler, "").Candidates {
+		if candidate.GPU == "l4" && (candidate.Schedulable == nil || !*candidate.Schedulable || *candidate.FreeGPUs != 4) {
+			t.Fatalf("expected the L4 candidate to be schedulable, got %+v", candidate)
+		}
+	}
+}
+
+func TestBestGPURecommendationPicksCheapestFit(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{{ID: "qwen-14b"}})
+	// qwen-14b needs ~34 GiB: two L4s shard it for less than one A100, a
+	// single 24 GiB card never fits, and the uncosted profile ranks last
+	// even though it has the least memory.
+	advisor := recommendations.New(map[string]recommendations.GPUProfile{
+		"mi300x":  {Name: "mi300x", MemoryGB: 192, CostPerHour: 4},
+		"a100-80": {Name: "a100-80", MemoryGB: 80, CostPerHour: 3},
+		"h100":    {Name: "h100", MemoryGB: 80, CostPerHour: 5},
+		"l4":      {Name: "l4", MemoryGB: 24, CostPerHour: 1, MaxGPUsPerNode: 2},
+		"a10":     {Name: "a10", MemoryGB: 24, CostPerHour: 0.5},
+		"a6000":   {Name: "a6000", MemoryGB: 48},
+	})
+	handler := New(cat, nil, nil, nil, nil, nil, advisor, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	best := func(id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/models/"+id+"/recommendation/best", nil)
+		handler.BestGPURecommendation(c)
+		return w
+	}
+
+	w := best("qwen-14b")
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		EstimatedVRAMGB int                             `json:"estimatedVramGb"`
+		Best            *recommendations.ProfileOption  `json:"best"`
+		Alternatives    []recommendations.ProfileOption `json:"alternatives"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Best == nil || resp.Best.Profile.Name != "l4" || resp.Best.Recommendation.TensorParallelSize != 2 || resp.EstimatedVRAMGB != 34 {
+		t.Fatalf("expected two l4s as best fit, got %s", w.Body.String())
+	}
+	var alternatives []string
+	for _, option := range resp.Alternatives {
+		alternatives = append(alternatives, option.Profile.Name)
+	}
+	if strings.Join(alternatives, ",") != "a100-80,mi300x,h100,a6000" {
+		t.Fatalf("expected fitting runner-ups by cost, got %v", alternatives)
+	}
+
+	if w := best("missing"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown model, got %d", w.Code)
+	}
+}
+
+func TestCompareModels(t *testing.T) {
+	t.Parallel()
+
+	short, long := 4096, 32768
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{
+			ID:        "qwen-small",
+			Runtime:   "vllm-runtime",
+			Env:       []catalog.EnvVar{{Name: "HF_HOME", Value: "/cache"}},
+			Resources: &catalog.Resources{Limits: map[string]string{"nvidia.com/gpu": "1"}},
+			VLLM:      &catalog.VLLMConfig{MaxModelLen: &short},
+		},
+		{
+			ID:        "qwen-large",
+			Runtime:   "vllm-runtime-v2",
+			Env:       []catalog.EnvVar{{Name: "HF_HOME", Value: "/cache"}, {Name: "HF_TOKEN", ValueFrom: &catalog.EnvVarSource{SecretKeyRef: &catalog.SecretKeySelector{Name: "hf", Key: "token"}}}},
+			Resources: &catalog.Resources{Limits: map[string]string{"nvidia.com/gpu": "2"}},
+			VLLM:      &catalog.VLLMConfig{MaxModelLen: &long},
+		},
+	})
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	compare := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/models/compare"+query, nil)
+		handler.CompareModels(c)
+		return w
+	}
+
+	w := compare("?a=qwen-small&b=qwen-large")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Identical bool                  `json:"identical"`
+		Changes   []catalog.FieldChange `json:"changes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := map[string][2]interface{}{
+		"env.HF_TOKEN":                    {nil, "secretKeyRef:hf/token"},
+		"resources.limits.nvidia.com/gpu": {"1", "2"},
+		"runtime":                         {"vllm-runtime", "vllm-runtime-v2"},
+		"vllm.maxModelLen":                {float64(4096), float64(32768)},
+	}
+	if resp.Identical || len(resp.Changes) != len(want) {
+		t.Fatalf("unexpected changes %+v", resp.Changes)
+	}
+	for _, change := range resp.Changes {
+		expected, ok := want[change.Field]
+		if !ok || change.Old != expected[0] || change.New != expected[1] {
+			t.Fatalf("unexpected change %+v", change)
+		}
+	}
+
+	w = compare("?a=qwen-small&b=qwen-small")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"identical":true`) {
+		t.Fatalf("expected identical comparison, got %d body=%s", w.Code, w.Body.String())
+	}
+	if code := compare("?a=qwen-small").Code; code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for missing b, got %d", code)
+	}
+	if code := compare("?a=qwen-small&b=missing").Code; code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown model, got %d", code)
+	}
+}
+
+func TestApplyNotificationRejectsUnknownType(t *testing.T) {
+	t.Parallel()
+
+	handler := New(nil, nil, nil, nil, nil, nil, nil, openTestStore(t), nil, nil, nil, nil, nil, nil, Options{})
+
+	apply := func(body string) int {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "name", Value: "ops"}}
+		c.Request = httptest.NewRequest(http.MethodPut, "/notifications/ops", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.ApplyNotification(c)
+		return w.Code
+	}
+
+	if code := apply(`{"type":"pagerduty","target":"https://example.invalid"}`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown type, got %d", code)
+	}
+	if code := apply(`{"type":"msteams","target":"https://example.invalid"}`); code != http.StatusOK {
+		t.Fatalf("expected 200 for msteams, got %d", code)
+	}
+}
+
+func TestEvaluateStorageAlertsNotifiesOncePerCrossing(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var received []map[string]interface{}
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		received = append(received, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer webhook.Close()
+
+	stateStore := openTestStore(t)
+	if err := stateStore.UpsertNotification(&store.Notification{Name: "ops", Type: "webhook", Target: webhook.URL}); err != nil {
+		t.Fatalf("UpsertNotification: %v", err)
+	}
+	handler := New(nil, nil, nil, nil, nil, nil, nil, stateStore, nil, nil, nil, nil, nil, nil, Options{PVCAlertThreshold: 0.8})
+
+	full := &weights.StorageStats{TotalBytes: 100, UsedBytes: 90}
+	waitForEvents := func(n int) []map[string]interface{} {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			mu.Lock()
+			got := append([]map[string]interface{}(nil), received...)
+			mu.Unlock()
+			if len(got) >= n {
+				return got
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for %d notifications", n)
+		return nil
+	}
+
+	handler.EvaluateStorageAlerts(full)
+	handler.EvaluateStorageAlerts(full)
+	got := waitForEvents(1)
+	if got[0]["event"] != "alert.triggered" {
+		t.Fatalf("expected alert.triggered, got %+v", got[0])
+	}
+
+	handler.EvaluateStorageAlerts(&weights.StorageStats{TotalBytes: 100, UsedBytes: 10})
+	got = waitForEvents(2)
+	if len(got) != 2 || got[1]["event"] != "alert.resolved" {
+		t.Fatalf("expected a single trigger followed by resolve, got %+v", got)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		health, err := stateStore.NotificationHealth()
+		if err != nil {
+			t.Fatalf("NotificationHealth: %v", err)
+		}
+		if health.Delivered == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 recorded deliveries, got %+v", health)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPreviewCatalogPRShowsDiff(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writer, err := catalogwriter.New(catalogwriter.Options{Root: root})
+	if err != nil {
+		t.Fatalf("catalogwriter.New: %v", err)
+	}
+	handler := New(nil, nil, nil, nil, nil, writer, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+	type preview struct {
+		Action string `json:"action"`
+		File   string `json:"file"`
+		Exists bool   `json:"exists"`
+		Diff   string `json:"diff"`
+	}
+	run := func(body string) preview {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/catalog/pr/preview", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.PreviewCatalogPR(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+		}
+		var resp preview
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp
+	}
+
+	created := run(`{"model":{"id":"demo","displayName":"Demo","runtime":"vllm-runtime"}}`)
+	if created.Action != "create" || created.Exists || !strings.HasPrefix(created.Diff, "--- /dev/null\n+++ b/models/demo.json\n") {
+		t.Fatalf("unexpected create preview %+v", created)
+	}
+	if _, err := os.Stat(filepath.Join(root, "models", "demo.json")); !os.IsNotExist(err) {
+		t.Fatalf("preview must not write the catalog file, stat err=%v", err)
+	}
+
+	if _, err := writer.Save(&catalog.Model{ID: "demo", DisplayName: "Demo", Runtime: "vllm-runtime"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if unchanged := run(`{"model":{"id":"demo","displayName":"Demo","runtime":"vllm-runtime"}}`); unchanged.Action != "unchanged" || unchanged.Diff != "" {
+		t.Fatalf("unexpected unchanged preview %+v", unchanged)
+	}
+
+	updated := run(`{"model":{"id":"demo","displayName":"Demo v2","runtime":"vllm-runtime"}}`)
+	if updated.Action != "update" || !updated.Exists {
+		t.Fatalf("unexpected update preview %+v", updated)
+	}
+	if !strings.Contains(updated.Diff, "-  \"displayName\": \"Demo\",\n+  \"displayName\": \"Demo v2\",\n") {
+		t.Fatalf("expected displayName change in diff, got:\n%s", updated.Diff)
+	}
+	if !strings.HasPrefix(updated.Diff, "--- a/models/demo.json\n+++ b/models/demo.json\n@@ -1,") {
+		t.Fatalf("unexpected diff header:\n%s", updated.Diff)
+	}
+}
+
+// runtimeValidator fails models without a runtime.
+type runtimeValidator struct{}
+
+func (runtimeValidator) Validate(ctx context.Context, raw []byte, model *catalog.Model) validator.Result {
+	if model.Runtime == "" {
+		return validator.Result{Errors: []string{"runtime is required"}}
+	}
+	return validator.Result{Valid: true}
+}
+
+func TestValidateCatalogBulkCollectsEveryFailure(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "good", Runtime: "vllm-runtime"},
+		{ID: "bad", Runtime: ""},
+	})
+	handler := New(cat, nil, nil, nil, runtimeValidator{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+
+	type bulkResponse struct {
+		Valid   bool                        `json:"valid"`
+		Source  string                      `json:"source"`
+		Total   int                         `json:"total"`
+		Failed  int                         `json:"failed"`
+		Results map[string]validator.Result `json:"results"`
+	}
+	run := func(body string) (int, bulkResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/catalog/validate/bulk", strings.NewReader(body))
+		handler.ValidateCatalogBulk(c)
+		var resp bulkResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v body=%s", err, w.Body.String())
+		}
+		return w.Code, resp
+	}
+
+	code, resp := run("")
+	if code != http.StatusBadRequest || resp.Valid || resp.Source != "catalog" || resp.Total != 2 || resp.Failed != 1 {
+		t.Fatalf("unexpected catalog validation %d %+v", code, resp)
+	}
+	if !resp.Results["good"].Valid || resp.Results["bad"].Valid {
+		t.Fatalf("unexpected per-model results %+v", resp.Results)
+	}
+
+	code, resp = run(`{"models":[{"id":"a","runtime":"x"},{"runtime":"x"},{"id":"b"},{"id":"a","runtime":"x"},"nope"]}`)
+	if code != http.StatusBadRequest || resp.Source != "request" || resp.Total != 5 || resp.Failed != 4 {
+		t.Fatalf("unexpected request validation %d %+v", code, resp)
+	}
+	for _, key := range []string{"models[1]", "b", "a (models[3])", "models[4]"} {
+		if result, ok := resp.Results[key]; !ok || result.Valid || len(result.Errors) == 0 {
+			t.Fatalf("expected failure for %s, got %+v", key, resp.Results)
+		}
+	}
+
+	if code, resp = run(`{"models":[{"id":"a","runtime":"x"}]}`); code != http.StatusOK || !resp.Valid {
+		t.Fatalf("expected passing validation, got %d %+v", code, resp)
+	}
+}
+
+// localCatalogWriter writes catalog files for real but records commits instead
+// of shelling out to git.
+type localCatalogWriter struct {
+	*catalogwriter.Writer
+	committed []string
+	message   string
+}
+
+func (w *localCatalogWriter) CommitAndPush(ctx context.Context, branch, base, message string, paths ...string) error {
+	w.committed = append(w.committed, paths...)
+	w.message = message
+	return nil
+}
+
+func TestPatchCatalogModelMergesFields(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	base, err := catalogwriter.New(catalogwriter.Options{Root: root})
+	if err != nil {
+		t.Fatalf("catalogwriter.New: %v", err)
+	}
+	writer := &localCatalogWriter{Writer: base}
+	maxLen := 4096
+	if _, err := base.Save(&catalog.Model{
+		ID:          "demo",
+		DisplayName: "Demo",
+		Runtime:     "vllm-runtime",
+		Env:         []catalog.EnvVar{{Name: "HF_HOME", Value: "/cache"}},
+		VLLM:        &catalog.VLLMConfig{MaxModelLen: &maxLen, Dtype: "bfloat16"},
+	}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	handler := New(nil, nil, nil, nil, nil, writer, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+	patch := func(id, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodPatch, "/catalog/models/"+id, strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+		handler.PatchCatalogModel(c)
+		return w
+	}
+
+	w := patch("demo", `{"vllm":{"maxModelLen":32768}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"status":"success"`) || len(writer.committed) != 1 || writer.message != "Update model Demo" {
+		t.Fatalf("expected commit of patched entry, got body=%s committed=%v message=%q", w.Body.String(), writer.committed, writer.message)
+	}
+	saved, err := base.Load("demo")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if saved.VLLM == nil || saved.VLLM.MaxModelLen == nil || *saved.VLLM.MaxModelLen != 32768 || saved.VLLM.Dtype != "bfloat16" {
+		t.Fatalf("expected maxModelLen bumped and dtype kept, got %+v", saved.VLLM)
+	}
+	if len(saved.Env) != 1 || saved.Runtime != "vllm-runtime" {
+		t.Fatalf("expected untouched fields preserved, got %+v", saved)
+	}
+
+	if w := patch("demo", `{"vllm":{"maxModelLen":32768}}`); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"unchanged"`) || len(writer.committed) != 1 {
+		t.Fatalf("expected unchanged no-op, got %d body=%s", w.Code, w.Body.String())
+	}
+	if w := patch("demo", `{"vllm":{"dtype":null}}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 removing dtype, got %d body=%s", w.Code, w.Body.String())
+	}
+	if saved, _ := base.Load("demo"); saved.VLLM.Dtype != "" {
+		t.Fatalf("expected dtype removed, got %+v", saved.VLLM)
+	}
+	if code := patch("missing", `{"runtime":"x"}`).Code; code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown model, got %d", code)
+	}
+	if code := patch("demo", `{"id":"other"}`).Code; code != http.StatusBadRequest {
+		t.Fatalf("expected 400 when changing id, got %d", code)
+	}
+	if code := patch("demo", `[1,2]`).Code; code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for non-object patch, got %d", code)
+	}
+	for _, body := range []string{`{"runtme":"x"}`, `{"vllm":{"maxModelLength":8192}}`} {
+		if w := patch("demo", body); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "unknown field") {
+			t.Fatalf("expected 400 for unknown field in %s, got %d body=%s", body, w.Code, w.Body.String())
+		}
+	}
+	if len(writer.committed) != 2 {
+		t.Fatalf("expected rejected patches not to be committed, got %v", writer.committed)
+	}
+}
+
+type sizedAdvisor struct {
+	fakeAdvisor
+	vramGB   int
+	profiles []recommendations.GPUProfile
+}
+
+func (a *sizedAdvisor) Compatibility(model *catalog.Model, gpuType string) recommendations.CompatibilityReport {
+	report := recommendations.CompatibilityReport{ModelID: model.ID, GPUType: gpuType, EstimatedVRAMGB: a.vramGB}
+	for _, profile := range a.profiles {
+		if profile.Name == gpuType {
+			report.Compatible = profile.MemoryGB >= a.vramGB
+		}
+	}
+	return report
+}
+
+func (a *sizedAdvisor) CompatibilityWithConfig(model *catalog.Model, gpuType string, cfg map[string]interface{}) recommendations.CompatibilityReport {
+	return a.Compatibility(model, gpuType)
+}
+
+func (a *sizedAdvisor) Profiles() []recommendations.GPUProfile {
+	return a.profiles
+}
+
+func TestGenerateCatalogEntryReportsGPUFit(t *testing.T) {
+	t.Parallel()
+
+	profiles := []recommendations.GPUProfile{{Name: "rtx-4090", MemoryGB: 24}, {Name: "a100-40gb", MemoryGB: 40}}
+	generate := func(vram int, gpus string) map[string]interface{} {
+		t.Helper()
+		discovery := &fakeDiscovery{modelResp: &catalog.Model{ID: "draft-model"}}
+		handler := New(nil, nil, nil, discovery, nil, nil, &sizedAdvisor{vramGB: vram, profiles: profiles}, nil, nil, nil, nil, nil, nil, nil, Options{})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"hfModelId":"foo/bar"}`
+		if gpus != "" {
+			body = fmt.Sprintf(`{"hfModelId":"foo/bar","resources":{"limits":{"nvidia.com/gpu":%q}}}`, gpus)
+		}
+		c.Request = httptest.NewRequest(http.MethodPost, "/catalog/generate", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.GenerateCatalogEntry(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d body=%s", w.Code, w.Body.String())
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp
+	}
+
+	if resp := generate(30, ""); resp["compatibility"] != nil {
+		t.Fatalf("expected no compatibility check without a GPU request, got %+v", resp["compatibility"])
+	}
+
+	fits := generate(30, "1")
+	if reports, _ := fits["compatibility"].([]interface{}); len(reports) != 2 {
+		t.Fatalf("expected a report per profile, got %+v", fits["compatibility"])
+	}
+	if fits["status"] == "warning" {
+		t.Fatalf("expected no warning when a profile fits, got %+v", fits)
+	}
+
+	tooBig := generate(90, "1")
+	if tooBig["status"] != "warning" || tooBig["compatibilityWarning"] == nil {
+		t.Fatalf("expected warning when no profile fits, got %+v", tooBig)
+	}
+
+	split := generate(90, "4")
+	if split["status"] == "warning" {
+		t.Fatalf("expected 4 GPUs to fit across profiles, got %+v", split)
+	}
+}
+
+func newActivationTestHandler(t *testing.T, runtime runtimeStatusProvider, dataStore *store.Store) (*Handler, *kserve.Client) {
+	t.Helper()
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for _, id := range []string{"stable", "broken"} {
+		body := fmt.Sprintf(`{"id":%q,"hfModelId":"org/%s"}`, id, id)
+		if err := os.WriteFile(filepath.Join(modelsDir, id+".json"), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", id, err)
+		}
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		kserve.InferenceServiceGVR(): "InferenceServiceList",
+	})
+	ks := kserve.NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+	handler := New(catalog.New(root, "models"), ks, nil, nil, nil, nil, nil, dataStore, nil, nil, nil, nil, runtime, nil, Options{
+		ActivationReadyTimeout: 50 * time.Millisecond,
+	})
+	return handler, ks
+}
+
+func TestActivateWaitForReadyRollsBackToPreviousModel(t *testing.T) {
+	handler, _ := newActivationTestHandler(t, &fakeRuntimeStatus{status: status.RuntimeStatus{
+		InferenceService: &status.InferenceServiceStatus{Name: "active-llm", Ready: "False"},
+		UpdatedAt:        time.Now().Add(time.Hour),
+	}}, nil)
+	if _, _, err := handler.activateModelInternal(context.Background(), "tester", "stable", activationOptions{}); err != nil {
+		t.Fatalf("activate stable: %v", err)
+	}
+
+	engine := gin.New()
+	engine.POST("/models/activate", handler.ActivateModel)
+	req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(`{"id":"broken","waitForReady":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["rolledBack"] != true || body["previousId"] != "stable" {
+		t.Fatalf("expected rollback to stable, got %+v", body)
+	}
+	current, err := handler.currentRuntimeModelID()
+	if err != nil || current != "stable" {
+		t.Fatalf("expected stable to be active after rollback, got %q (%v)", current, err)
+	}
+}
+
+func TestRuntimeReadyFallbackWaitsForObservedGeneration(t *testing.T) {
+	handler, _ := newActivationTestHandler(t, nil, nil)
+	isvc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1beta1",
+		"kind":       "InferenceService",
+		"metadata":   map[string]interface{}{"name": "active-llm", "namespace": "ai", "generation": int64(2)},
+		"status": map[string]interface{}{
+			"observedGeneration": int64(1),
+			"conditions":         []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+		},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		kserve.InferenceServiceGVR(): "InferenceServiceList",
+	}, isvc)
+	handler.kserve = kserve.NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+
+	if handler.runtimeReady(time.Now()) {
+		t.Fatalf("expected a Ready condition from the previous generation not to count")
+	}
+	if err := unstructured.SetNestedField(isvc.Object, int64(2), "status", "observedGeneration"); err != nil {
+		t.Fatalf("set observedGeneration: %v", err)
+	}
+	if _, err := dyn.Resource(kserve.InferenceServiceGVR()).Namespace("ai").Update(context.Background(), isvc, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !handler.runtimeReady(time.Now()) {
+		t.Fatalf("expected the runtime to be ready once the latest generation is observed")
+	}
+}
+
+func TestActivateWaitForReadySucceedsWhenReady(t *testing.T) {
+	handler, _ := newActivationTestHandler(t, &fakeRuntimeStatus{status: status.RuntimeStatus{
+		InferenceService: &status.InferenceServiceStatus{Name: "active-llm", Ready: "True"},
+		UpdatedAt:        time.Now().Add(time.Hour),
+	}}, nil)
+	if _, _, err := handler.activateModelInternal(context.Background(), "tester", "stable", activationOptions{}); err != nil {
+		t.Fatalf("activate stable: %v", err)
+	}
+	if _, _, err := handler.activateModelInternal(context.Background(), "tester", "broken", activationOptions{waitForReady: true}); err != nil {
+		t.Fatalf("expected ready activation to succeed, got %v", err)
+	}
+	current, _ := handler.currentRuntimeModelID()
+	if current != "broken" {
+		t.Fatalf("expected broken to stay active, got %q", current)
+	}
+}
+
+func TestRuntimeRollbackReactivatesPreviousModel(t *testing.T) {
+	handler, _ := newActivationTestHandler(t, nil, newTempStore(t))
+	ctx := context.Background()
+	if _, _, err := handler.activateModelInternal(ctx, "tester", "stable", activationOptions{}); err != nil {
+		t.Fatalf("activate stable: %v", err)
+	}
+
+	engine := gin.New()
+	engine.POST("/runtime/rollback", handler.RuntimeRollback)
+	rollback := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/runtime/rollback", nil))
+		return rec
+	}
+
+	if rec := rollback(); rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 without a previous model, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if _, _, err := handler.activateModelInternal(ctx, "tester", "broken", activationOptions{}); err != nil {
+		t.Fatalf("activate broken: %v", err)
+	}
+	rec := rollback()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["rolledBackFrom"] != "broken" {
+		t.Fatalf("expected rolledBackFrom broken, got %+v", body)
+	}
+	if current, _ := handler.currentRuntimeModelID(); current != "stable" {
+		t.Fatalf("expected stable to be active, got %q", current)
+	}
+}
+
+func TestRollbackAndCanaryPromoteTakeActivationLock(t *testing.T) {
+	dataStore := newTempStore(t)
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	ctx := context.Background()
+	if _, _, err := handler.activateModelInternal(ctx, "tester", "stable", activationOptions{}); err != nil {
+		t.Fatalf("activate stable: %v", err)
+	}
+	if _, _, err := handler.activateModelInternal(ctx, "tester", "broken", activationOptions{strategy: kserve.StrategyCanary, trafficPercent: 10}); err != nil {
+		t.Fatalf("activate canary: %v", err)
+	}
+
+	engine := gin.New()
+	engine.POST("/runtime/rollback", handler.RuntimeRollback)
+	engine.POST("/runtime/promote", handler.RuntimePromote)
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+	promote := `{"candidateId":"broken","force":true}`
+
+	if ok, err := dataStore.AcquireLock(activationLockName, "other-replica", time.Minute); err != nil || !ok {
+		t.Fatalf("acquire lock: %v (%v)", ok, err)
+	}
+	if rec := post("/runtime/rollback", ""); rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "activation in progress") {
+		t.Fatalf("expected rollback to wait for the lock, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := post("/runtime/promote", promote); rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "activation in progress") {
+		t.Fatalf("expected canary promotion to wait for the lock, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if percent, splitting, err := handler.kserve.CanaryTrafficPercent(); err != nil || !splitting || percent != 10 {
+		t.Fatalf("expected the canary split untouched, got %d %v (%v)", percent, splitting, err)
+	}
+	if err := dataStore.ReleaseLock(activationLockName, "other-replica"); err != nil {
+		t.Fatalf("release lock: %v", err)
+	}
+
+	if rec := post("/runtime/promote", promote); rec.Code != http.StatusOK {
+		t.Fatalf("expected promotion once the lock is free, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := post("/runtime/rollback", ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected rollback once the lock is free, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ok, err := dataStore.AcquireLock(activationLockName, "other-replica", time.Minute); err != nil || !ok {
+		t.Fatalf("expected promote and rollback to release the lease, got %v (%v)", ok, err)
+	}
+}
+
+func TestCheckReadinessPollsUntilReady(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n == 1 {
+			http.Error(w, "loading weights", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ready")
+	}))
+	defer server.Close()
+
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	result := handler.checkReadiness(context.Background(), server.URL, 10)
+	if result["status"] != "ok" || result["attempts"] != 2 {
+		t.Fatalf("expected ok after 2 attempts, got %+v", result)
+	}
+	if result["preview"] != "ready" || result["code"] != http.StatusOK {
+		t.Fatalf("expected preview from the final attempt, got %+v", result)
+	}
+}
+
+func catalogImportRequest(t *testing.T, files map[string]string, fields map[string]string) *http.Request {
+	t.Helper()
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("tar write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", "models.tar.gz")
+	if err != nil {
+		t.Fatalf("form file: %v", err)
+	}
+	part.Write(archive.Bytes())
+	for key, value := range fields {
+		mw.WriteField(key, value)
+	}
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/catalog/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestImportCatalogSkipsInvalidEntriesAndCommitsTheRest(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"models/good.json":     `{"id":"good","runtime":"vllm-runtime","hfModelId":"org/good"}`,
+		"models/also-good.yml": "id: also-good\nruntime: vllm-runtime\nhfModelId: org/also-good\n",
+		"models/bad.yaml":      "id: bad\nhfModelId: org/bad\n",
+		"README.md":            "ignored",
+	}
+	writer := &fakeCatalogWriter{saveResult: &catalogwriter.SaveResult{RelativePath: "models/imported.json"}}
+	handler := New(nil, nil, nil, nil, runtimeValidator{}, writer, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.POST("/catalog/import", handler.ImportCatalog)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, catalogImportRequest(t, files, map[string]string{"strict": "true", "commit": "true"}))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected strict import to be rejected, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if writer.commitCalled {
+		t.Fatalf("strict rejection must not commit anything")
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, catalogImportRequest(t, files, map[string]string{"commit": "true"}))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Total    int                   `json:"total"`
+		Imported int                   `json:"imported"`
+		Skipped  int                   `json:"skipped"`
+		Results  []catalogImportResult `json:"results"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 3 || resp.Imported != 2 || resp.Skipped != 1 {
+		t.Fatalf("unexpected counts: %+v", resp)
+	}
+	for _, result := range resp.Results {
+		if result.ModelID == "bad" && result.Valid {
+			t.Fatalf("expected bad entry to be reported invalid: %+v", result)
+		}
+	}
+	if !writer.commitCalled || len(writer.lastPaths) != 2 {
+		t.Fatalf("expected one commit with 2 files, got called=%v paths=%v", writer.commitCalled, writer.lastPaths)
+	}
+}
+
+func TestImportCatalogRejectsArchiveThatExpandsPastTotalLimit(t *testing.T) {
+	t.Parallel()
+
+	// Each entry is within the per-file limit and compresses to almost
+	// nothing, but together they exceed what the import keeps in memory.
+	padding := strings.Repeat(" ", maxImportEntryBytes-64)
+	files := make(map[string]string)
+	for i := 0; i <= maxImportTotalBytes/maxImportEntryBytes; i++ {
+		files[fmt.Sprintf("models/m%03d.json", i)] = fmt.Sprintf(`{"id":"m%03d"}`, i) + padding
+	}
+	handler := New(nil, nil, nil, nil, runtimeValidator{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.POST("/catalog/import", handler.ImportCatalog)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, catalogImportRequest(t, files, nil))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "extracted model files exceed") {
+		t.Fatalf("expected 400 for an archive over the total limit, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestExportCatalogStreamsDocumentAndArchive(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "zeta", HFModelID: "org/zeta", Runtime: "vllm-runtime"},
+		{ID: "alpha", HFModelID: "org/alpha", Runtime: "vllm-runtime"},
+	})
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{CatalogModelsDir: "models"})
+	handler.lastCatalogRefresh = time.Now()
+	handler.catalogStatus = "test"
+	engine := gin.New()
+	engine.GET("/catalog/export", handler.ExportCatalog)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/catalog/export", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, ".json") {
+		t.Fatalf("unexpected Content-Disposition %q", cd)
+	}
+	var models []catalog.Model
+	if err := json.Unmarshal(rec.Body.Bytes(), &models); err != nil {
+		t.Fatalf("decode combined export: %v\n%s", err, rec.Body.String())
+	}
+	if len(models) != 2 || models[0].ID != "alpha" || models[1].ID != "zeta" {
+		t.Fatalf("unexpected export: %+v", models)
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/catalog/export?format=yaml&archive=tar", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, ".tar.gz") {
+		t.Fatalf("unexpected Content-Disposition %q", cd)
+	}
+	files, err := extractCatalogArchive(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	if len(files) != 2 || files[0].Name != "models/alpha.yaml" || files[1].Name != "models/zeta.yaml" {
+		t.Fatalf("unexpected archive entries: %+v", files)
+	}
+	model, _, err := decodeCatalogEntry(files[1].Data)
+	if err != nil || model.ID != "zeta" || model.Runtime != "vllm-runtime" {
+		t.Fatalf("unexpected archived entry %+v (%v)", model, err)
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/catalog/export?format=xml", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d", rec.Code)
+	}
+}
+
+func TestActivationHonorsModelLifecycle(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	entries := map[string]string{
+		"current": `{"id":"current","hfModelId":"org/current"}`,
+		"old":     `{"id":"old","hfModelId":"org/old","lifecycle":"deprecated","deprecationMessage":"use current"}`,
+		"gone":    `{"id":"gone","hfModelId":"org/gone","lifecycle":"retired","deprecationMessage":"removed upstream"}`,
+	}
+	for id, body := range entries {
+		if err := os.WriteFile(filepath.Join(modelsDir, id+".json"), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", id, err)
+		}
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		kserve.InferenceServiceGVR(): "InferenceServiceList",
+	})
+	ks := kserve.NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+	handler := New(catalog.New(root, "models"), ks, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/models", handler.ListModels)
+	engine.POST("/models/activate", handler.ActivateModel)
+
+	activate := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(fmt.Sprintf(`{"id":%q}`, id)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := activate("gone")
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "removed upstream") {
+		t.Fatalf("expected 409 for retired model, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if isvc, err := ks.GetActive(); err != nil || isvc != nil {
+		t.Fatalf("retired model must not be applied, got %v (%v)", isvc, err)
+	}
+
+	rec = activate("old")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected deprecated model to activate, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Warning string `json:"warning"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Warning != "model old is deprecated: use current" {
+		t.Fatalf("unexpected warning %q", resp.Warning)
+	}
+
+	rec = activate("current")
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), `"warning"`) {
+		t.Fatalf("expected plain activation, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/models?lifecycle=active", nil))
+	var list struct {
+		Models []catalog.Model `json:"models"`
+		Total  int             `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if list.Total != 1 || list.Models[0].ID != "current" {
+		t.Fatalf("expected only the active model, got %+v", list)
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/models?lifecycle=archived", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown lifecycle, got %d", rec.Code)
+	}
+}
+
+func TestActivateAndRenderThroughAlias(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := `{"id":"new-name","aliases":["old-name"],"hfModelId":"org/model"}`
+	if err := os.WriteFile(filepath.Join(modelsDir, "new-name.json"), []byte(body), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		kserve.InferenceServiceGVR(): "InferenceServiceList",
+	})
+	ks := kserve.NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+	handler := New(catalog.New(root, "models"), ks, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.POST("/models/activate", handler.ActivateModel)
+	engine.GET("/models/:id/manifest", handler.GetModelManifest)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/models/old-name/manifest", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"new-name"`) {
+		t.Fatalf("expected manifest for canonical model, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(`{"id":"old-name"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected activation through alias, got %d: %s", rec.Code, rec.Body.String())
+	}
+	isvc, err := ks.GetActive()
+	if err != nil || isvc == nil {
+		t.Fatalf("expected InferenceService, got %v (%v)", isvc, err)
+	}
+	if got := annotationValue(isvc, "model-manager/model-id"); got != "new-name" {
+		t.Fatalf("expected canonical model id annotation, got %q", got)
+	}
+}
+
+func TestActivationRejectsConcurrentActivation(t *testing.T) {
+	dataStore := newTempStore(t)
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	engine := gin.New()
+	engine.POST("/models/activate", handler.ActivateModel)
+	activate := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(`{"id":"stable"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	handler.activationMu.Lock()
+	rec := activate()
+	handler.activationMu.Unlock()
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "activation in progress") {
+		t.Fatalf("expected 409 while activating locally, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if ok, err := dataStore.AcquireLock(activationLockName, "other-replica", time.Minute); err != nil || !ok {
+		t.Fatalf("acquire lock: %v (%v)", ok, err)
+	}
+	if rec := activate(); rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 while another replica holds the lock, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := dataStore.ReleaseLock(activationLockName, "other-replica"); err != nil {
+		t.Fatalf("release lock: %v", err)
+	}
+
+	if rec := activate(); rec.Code != http.StatusOK {
+		t.Fatalf("expected activation once the lock is free, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ok, err := dataStore.AcquireLock(activationLockName, "other-replica", time.Minute); err != nil || !ok {
+		t.Fatalf("expected activation to release its lease, got %v (%v)", ok, err)
+	}
+}
+
+type fakeJobManager struct {
+	store    *store.Store
+	created  int
+	executed int
+}
+
+func (f *fakeJobManager) EnqueueWeightInstall(req jobs.InstallRequest) (*store.Job, error) {
+	return f.CreateJob(req)
+}
+
+func (f *fakeJobManager) CreateJob(req jobs.InstallRequest) (*store.Job, error) {
+	f.created++
+	job := &store.Job{ID: fmt.Sprintf("job-%d", f.created), Type: "weight_install", Status: store.JobPending}
+	if err := f.store.CreateJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (f *fakeJobManager) ExecuteJob(*store.Job, jobs.InstallRequest) {
+	f.executed++
+}
+
+func (f *fakeJobManager) CancelJob(context.Context, string) bool {
+	return false
+}
+
+func TestInstallWeightsHonorsIdempotencyKey(t *testing.T) {
+	dataStore := newTempStore(t)
+	jobMgr := &fakeJobManager{store: dataStore}
+	discovery := &fakeDiscovery{
+		hfModel: &vllm.HuggingFaceModel{
+			ID:       "Qwen/Qwen2.5-0.5B",
+			Siblings: []vllm.HFSibling{{RFileName: "config.json"}},
+		},
+	}
+	handler := New(nil, nil, &fakeWeightStore{}, discovery, nil, nil, nil, dataStore, jobMgr, nil, nil, nil, nil, nil, Options{
+		WeightsPVCName:     "venus-model-storage",
+		InferenceModelRoot: "/mnt/models",
+	})
+	engine := gin.New()
+	engine.POST("/weights/install", handler.InstallWeights)
+	installBody := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+	install := func(key string) *httptest.ResponseRecorder {
+		return installBody(key, `{"hfModelId":"Qwen/Qwen2.5-0.5B"}`)
+	}
+	jobID := func(rec *httptest.ResponseRecorder) string {
+		var resp struct {
+			Job store.Job `json:"job"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp.Job.ID
+	}
+
+	first := install("retry-me")
+	if first.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", first.Code, first.Body.String())
+	}
+	replay := install("retry-me")
+	if replay.Code != http.StatusOK || replay.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected replayed 200, got %d: %s", replay.Code, replay.Body.String())
+	}
+	if jobID(replay) != jobID(first) {
+		t.Fatalf("expected the same job, got %s and %s", jobID(first), jobID(replay))
+	}
+	if jobMgr.created != 1 || jobMgr.executed != 1 {
+		t.Fatalf("expected a single job, created=%d executed=%d", jobMgr.created, jobMgr.executed)
+	}
+	if rec := installBody("retry-me", `{"hfModelId":"Qwen/Qwen2.5-0.5B","revision":"v2"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 when a key is reused with a different body, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if jobMgr.created != 1 {
+		t.Fatalf("expected a mismatched body not to queue a job, created=%d", jobMgr.created)
+	}
+
+	if rec := install("another-key"); rec.Code != http.StatusAccepted || jobID(rec) == jobID(first) {
+		t.Fatalf("expected a new job for a new key, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := install(""); rec.Code != http.StatusAccepted {
+		t.Fatalf("expected a new job without a key, got %d", rec.Code)
+	}
+	if jobMgr.created != 3 {
+		t.Fatalf("expected 3 jobs, got %d", jobMgr.created)
+	}
+}
+
+func TestInstallWeightsEnforcesOrgLists(t *testing.T) {
+	discovery := &fakeDiscovery{
+		hfModel: &vllm.HuggingFaceModel{
+			ID:       "Qwen/Qwen2.5-0.5B",
+			Siblings: []vllm.HFSibling{{RFileName: "config.json"}},
+		},
+	}
+	jobMgr := &fakeJobManager{store: newTempStore(t)}
+	handler := New(nil, nil, &fakeWeightStore{}, discovery, nil, nil, nil, nil, jobMgr, nil, nil, nil, nil, nil, Options{
+		WeightsPVCName: "venus-model-storage",
+		HFAllowedOrgs:  []string{"qwen", "meta-llama"},
+		HFDeniedOrgs:   []string{"meta-llama"},
+	})
+	engine := gin.New()
+	engine.POST("/weights/install", handler.InstallWeights)
+	install := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(`{"hfModelId":"`+id+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, id := range []string{"mistralai/Mistral-7B-v0.1", "meta-llama/Llama-3.1-8B"} {
+		if rec := install(id); rec.Code != http.StatusForbidden {
+			t.Fatalf("expected 403 for %s, got %d: %s", id, rec.Code, rec.Body.String())
+		}
+	}
+	if jobMgr.created != 0 {
+		t.Fatalf("expected no jobs for rejected orgs, got %d", jobMgr.created)
+	}
+	if rec := install("Qwen/Qwen2.5-0.5B"); rec.Code != http.StatusAccepted {
+		t.Fatalf("expected allowed org to be queued, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLicensePolicyBlocksInstallAndActivation(t *testing.T) {
+	dataStore := newTempStore(t)
+	discovery := &fakeDiscovery{
+		hfModel: &vllm.HuggingFaceModel{
+			ID:       "org/stable",
+			Tags:     []string{"license:cc-by-nc-4.0"},
+			Siblings: []vllm.HFSibling{{RFileName: "config.json"}},
+		},
+	}
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	handler.vllm = discovery
+	handler.weights = &fakeWeightStore{}
+	handler.jobs = &fakeJobManager{store: dataStore}
+
+	engine := gin.New()
+	engine.PUT("/policies/:name", handler.ApplyPolicy)
+	engine.POST("/weights/install", handler.InstallWeights)
+	engine.POST("/models/activate", handler.ActivateModel)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPut, "/policies/license", `{"document":"{\"allowedLicenses\":[]}"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected empty license policy to be rejected, got %d", rec.Code)
+	}
+	if rec := do(http.MethodPut, "/policies/license", `{"document":"{\"allowedLicenses\":[\"apache-2.0\",\"mit\"]}"}`); rec.Code != http.StatusOK {
+		t.Fatalf("apply policy: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec := do(http.MethodPost, "/weights/install", `{"hfModelId":"org/stable"}`)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "cc-by-nc-4.0") {
+		t.Fatalf("expected 403 naming the license for install, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodPost, "/models/activate", `{"id":"stable"}`)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "cc-by-nc-4.0") {
+		t.Fatalf("expected 403 naming the license for activation, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	discovery.hfModel.Tags = []string{"license:apache-2.0"}
+	if rec := do(http.MethodPost, "/weights/install", `{"hfModelId":"org/stable"}`); rec.Code != http.StatusAccepted {
+		t.Fatalf("expected allowed license to install, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPost, "/models/activate", `{"id":"stable"}`); rec.Code != http.StatusOK {
+		t.Fatalf("expected allowed license to activate, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestInvalidStoredPolicyFailsActivationClosed(t *testing.T) {
+	dataStore := newTempStore(t)
+	// Stored directly, as if saved before documents were validated.
+	if err := dataStore.UpsertPolicy(&store.Policy{Name: "prod", Document: `{"requiredTag":["approved"]}`}); err != nil {
+		t.Fatalf("upsert policy: %v", err)
+	}
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	engine := gin.New()
+	engine.POST("/models/activate", handler.ActivateModel)
+	engine.PUT("/policies/:name", handler.ApplyPolicy)
+
+	req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(`{"id":"stable"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "policy prod") {
+		t.Fatalf("expected 500 naming the invalid policy, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/policies/prod", strings.NewReader(`{"document":"{\"requiredTag\":[\"approved\"]}"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "requiredTag") {
+		t.Fatalf("expected 400 for the misspelled rule, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRollbackPolicyRejectsRevisionThatNoLongerParses(t *testing.T) {
+	dataStore := newTempStore(t)
+	// The first revision predates validation.
+	for _, doc := range []string{`{"requiredTag":["approved"]}`, `{"requiredTags":["approved"]}`, `{"requiredTags":["reviewed"]}`} {
+		if err := dataStore.UpsertPolicy(&store.Policy{Name: "prod", Document: doc}); err != nil {
+			t.Fatalf("upsert policy: %v", err)
+		}
+	}
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	engine := gin.New()
+	engine.POST("/policies/:name/rollback", handler.RollbackPolicy)
+	rollback := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/policies/prod/rollback", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := rollback(`{"version":1}`); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "requiredTag") {
+		t.Fatalf("expected 400 for the invalid revision, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if current, err := dataStore.GetPolicy("prod"); err != nil || current.Document != `{"requiredTags":["reviewed"]}` {
+		t.Fatalf("expected the current policy to be kept, got %+v (%v)", current, err)
+	}
+	if rec := rollback(`{"version":2}`); rec.Code != http.StatusOK {
+		t.Fatalf("expected the valid revision to be restored, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := rollback(`{"version":9}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a missing revision, got %d", rec.Code)
+	}
+}
+
+func TestUpgradeLegacyPoliciesUnblocksActivation(t *testing.T) {
+	dataStore := newTempStore(t)
+	for name, doc := range map[string]string{
+		"tagged": `{"requiredTags":["approved"],"owner":"ops"}`,
+		"notes":  `allow everything from the platform team`,
+		"valid":  `{"actions":["install"]}`,
+	} {
+		if err := dataStore.UpsertPolicy(&store.Policy{Name: name, Document: doc}); err != nil {
+			t.Fatalf("upsert policy: %v", err)
+		}
+	}
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	if err := handler.UpgradeLegacyPolicies(context.Background()); err != nil {
+		t.Fatalf("UpgradeLegacyPolicies: %v", err)
+	}
+
+	policies, err := handler.loadPolicies()
+	if err != nil {
+		t.Fatalf("expected stored policies to load after the upgrade: %v", err)
+	}
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	if strings.Join(names, ",") != "tagged,valid" {
+		t.Fatalf("expected the unparseable policy to be archived, got %v", names)
+	}
+	if versions, err := dataStore.ListPolicyVersions("notes", 10); err != nil || len(versions) != 1 {
+		t.Fatalf("expected the archived document in the version history, got %+v (%v)", versions, err)
+	}
+	if tagged, _ := dataStore.GetPolicy("tagged"); strings.Contains(tagged.Document, "owner") {
+		t.Fatalf("expected unknown fields to be dropped, got %s", tagged.Document)
+	}
+
+	engine := gin.New()
+	engine.POST("/models/activate", handler.ActivateModel)
+	req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(`{"id":"stable"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "requiredTags") {
+		t.Fatalf("expected the upgraded policy to be enforced, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestActivationPolicyViolationNamesRule(t *testing.T) {
+	dataStore := newTempStore(t)
+	if err := dataStore.UpsertPolicy(&store.Policy{Name: "prod", Document: `{"actions":["activate"],"requiredTags":["approved"]}`}); err != nil {
+		t.Fatalf("upsert policy: %v", err)
+	}
+	handler, _ := newActivationTestHandler(t, nil, dataStore)
+	engine := gin.New()
+	engine.POST("/models/activate", handler.ActivateModel)
+	req := httptest.NewRequest(http.MethodPost, "/models/activate", strings.NewReader(`{"id":"stable"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body["policy"] != "prod" || body["rule"] != "requiredTags" {
+		t.Fatalf("expected prod/requiredTags violation, got %v", body)
+	}
+}
+
+func TestAdminCleanupRemovesFinishedJobsAndHistory(t *testing.T) {
+	dataStore := newTempStore(t)
+	done := &store.Job{ID: "done-job", Type: "weight_install", Status: store.JobDone}
+	running := &store.Job{ID: "running-job", Type: "weight_install", Status: store.JobRunning}
+	for _, job := range []*store.Job{done, running} {
+		if err := dataStore.CreateJob(job); err != nil {
+			t.Fatalf("create job: %v", err)
+		}
+	}
+	if err := dataStore.AppendHistory(&store.HistoryEntry{Event: "model_activated", ModelID: "stable"}); err != nil {
+		t.Fatalf("append history: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	handler := New(nil, nil, nil, nil, nil, nil, nil, dataStore, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.POST("/admin/cleanup", handler.AdminCleanup)
+	cleanup := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/cleanup"+query, nil))
+		return rec
+	}
+
+	if rec := cleanup("?jobMaxAge=soon"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid duration, got %d", rec.Code)
+	}
+	rec := cleanup("?jobMaxAge=5ms&historyMaxAge=5ms")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result RetentionResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if result.JobsRemoved != 1 || result.HistoryRemoved != 1 {
+		t.Fatalf("expected 1 job and 1 history entry removed, got %+v", result)
+	}
+	if _, err := dataStore.GetJob("done-job"); err == nil {
+		t.Fatalf("expected finished job to be deleted")
+	}
+	if _, err := dataStore.GetJob("running-job"); err != nil {
+		t.Fatalf("expected running job to be kept: %v", err)
+	}
+}
+
+func TestInstallWeightsEvictionProtectsReferencedWeightsAndFailsClosed(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := `{"id":"keep","storageUri":"pvc://venus-model-storage/org/keep"}`
+	if err := os.WriteFile(filepath.Join(modelsDir, "keep.json"), []byte(body), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	cat := catalog.New(root, "models")
+	if err := cat.Load(); err != nil {
+		t.Fatalf("load catalog: %v", err)
+	}
+
+	install := func(lookupErr error) (*httptest.ResponseRecorder, *fakeWeightStore) {
+		dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+			kserve.InferenceServiceGVR(): "InferenceServiceList",
+		})
+		if lookupErr != nil {
+			dyn.PrependReactor("get", "inferenceservices", func(k8stesting.Action) (bool, k8sruntime.Object, error) {
+				return true, nil, lookupErr
+			})
+		}
+		ks := kserve.NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+		dataStore := newTempStore(t)
+		weightStore := &fakeWeightStore{
+			statsResp: &weights.StorageStats{TotalBytes: 100 << 30, AvailableBytes: 5 << 30},
+			evictResp: []weights.WeightInfo{{Name: "org/stale", SizeBytes: 6 << 30}},
+		}
+		discovery := &fakeDiscovery{hfModel: &vllm.HuggingFaceModel{
+			ModelID:  "Qwen/Qwen2.5-7B",
+			Siblings: []vllm.HFSibling{{RFileName: "model.safetensors", Size: 8 << 30}},
+		}}
+		handler := New(cat, ks, weightStore, discovery, nil, nil, nil, dataStore, &fakeJobManager{store: dataStore}, nil, nil, nil, nil, nil, Options{
+			WeightsPVCName: "venus-model-storage",
+			WeightEviction: true,
+		})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(`{"hfModelId":"Qwen/Qwen2.5-7B"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		handler.InstallWeights(c)
+		return w, weightStore
+	}
+
+	w, weightStore := install(nil)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected install to be queued after eviction, got %d: %s", w.Code, w.Body.String())
+	}
+	protected := append([]string{}, weightStore.evictProtected...)
+	sort.Strings(protected)
+	if want := []string{"Qwen/Qwen2.5-7B", "org/keep"}; !reflect.DeepEqual(protected, want) {
+		t.Fatalf("expected catalog-referenced weights and the target to be protected, got %v", protected)
+	}
+
+	w, weightStore = install(fmt.Errorf("apiserver unavailable"))
+	if w.Code != http.StatusInsufficientStorage {
+		t.Fatalf("expected 507 when the active runtime can't be read, got %d: %s", w.Code, w.Body.String())
+	}
+	if weightStore.evictRequested != 0 {
+		t.Fatalf("expected no eviction when the active runtime can't be read, requested %d bytes", weightStore.evictRequested)
+	}
+}
+
+func TestInstallWeightsEvictsWhenStorageIsShort(t *testing.T) {
+	dataStore := newTempStore(t)
+	weightStore := &fakeWeightStore{
+		statsResp: &weights.StorageStats{
+			TotalBytes:     100 << 30,
+			AvailableBytes: 5 << 30,
+		},
+		evictResp: []weights.WeightInfo{{Name: "org/stale", SizeBytes: 6 << 30}},
+	}
+	discovery := &fakeDiscovery{
+		hfModel: &vllm.HuggingFaceModel{
+			ModelID:  "Qwen/Qwen2.5-7B",
+			Siblings: []vllm.HFSibling{{RFileName: "model.safetensors", Size: 8 << 30}},
+		},
+	}
+	handler := New(nil, nil, weightStore, discovery, nil, nil, nil, dataStore, &fakeJobManager{store: dataStore}, nil, nil, nil, nil, nil, Options{
+		WeightsPVCName:        "venus-model-storage",
+		WeightEviction:        true,
+		WeightEvictionMinFree: 1 << 30,
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(`{"hfModelId":"Qwen/Qwen2.5-7B"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler.InstallWeights(c)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected install to be queued after eviction, got %d: %s", w.Code, w.Body.String())
+	}
+	// 8GiB + 10% headroom + 1GiB margin - 5GiB free.
+	estimate := int64(8 << 30)
+	required := estimate + int64(float64(estimate)*installSpaceHeadroom)
+	if want := required + 1<<30 - 5<<30; weightStore.evictRequested != want {
+		t.Fatalf("expected eviction of %d bytes, got %d", want, weightStore.evictRequested)
+	}
+	if !reflect.DeepEqual(weightStore.evictProtected, []string{"Qwen/Qwen2.5-7B"}) {
+		t.Fatalf("expected install target to be protected, got %v", weightStore.evictProtected)
+	}
+	history, err := dataStore.ListHistory(10)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	found := false
+	for _, entry := range history {
+		if entry.Event == "weight_evicted" && entry.ModelID == "org/stale" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected weight_evicted history entry, got %+v", history)
+	}
+
+	weightStore.evictErr = weights.ErrCannotFreeSpace
+	weightStore.evictResp = nil
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/weights/install", strings.NewReader(`{"hfModelId":"Qwen/Qwen2.5-7B"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler.InstallWeights(c)
+	if w.Code != http.StatusInsufficientStorage {
+		t.Fatalf("expected 507 when eviction can't free enough, got %d", w.Code)
+	}
+}
+
+func TestDeleteWeightsRefusesInUseWeights(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := `{"id":"served","storageUri":"pvc://venus-model-storage/org/used"}`
+	if err := os.WriteFile(filepath.Join(modelsDir, "served.json"), []byte(body), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	cat := catalog.New(root, "models")
+	if err := cat.Load(); err != nil {
+		t.Fatalf("load catalog: %v", err)
+	}
+	weightStore := &fakeWeightStore{}
+	handler := New(cat, nil, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.DELETE("/weights", handler.DeleteWeights)
+	engine.POST("/cleanup/weights", handler.CleanupWeights)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodDelete, "/weights", `{"name":"org/used"}`)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "catalog model served") {
+		t.Fatalf("expected 409 naming the catalog reference, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodPost, "/cleanup/weights", `{"names":["org/used","org/unused"]}`)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "in use by catalog model served") {
+		t.Fatalf("expected cleanup to skip in-use weights, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !reflect.DeepEqual(weightStore.deleted, []string{"org/unused"}) {
+		t.Fatalf("expected only unused weights deleted, got %v", weightStore.deleted)
+	}
+	if rec := do(http.MethodDelete, "/weights?force=true", `{"name":"org/used"}`); rec.Code != http.StatusOK {
+		t.Fatalf("expected forced delete to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDeleteWeightsFailsClosedWhenActiveRuntimeUnknown(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(k8sruntime.NewScheme(), map[schema.GroupVersionResource]string{
+		kserve.InferenceServiceGVR(): "InferenceServiceList",
+	})
+	dyn.PrependReactor("get", "inferenceservices", func(k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, nil, fmt.Errorf("apiserver unavailable")
+	})
+	ks := kserve.NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+	weightStore := &fakeWeightStore{}
+	handler := New(nil, ks, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.DELETE("/weights", handler.DeleteWeights)
+	do := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, path, strings.NewReader(`{"name":"org/model"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do("/weights"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when the active runtime can't be read, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(weightStore.deleted) != 0 {
+		t.Fatalf("expected nothing deleted, got %v", weightStore.deleted)
+	}
+	if rec := do("/weights?force=true"); rec.Code != http.StatusOK {
+		t.Fatalf("expected forced delete to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestPruneWeightsSkipsInUseAndHonorsDryRun(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := `{"id":"served","storageUri":"pvc://venus-model-storage/org/used"}`
+	if err := os.WriteFile(filepath.Join(modelsDir, "served.json"), []byte(body), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	cat := catalog.New(root, "models")
+	if err := cat.Load(); err != nil {
+		t.Fatalf("load catalog: %v", err)
+	}
+	weightStore := &fakeWeightStore{pruneResp: []weights.WeightInfo{
+		{Name: "org/used", SizeBytes: 10},
+		{Name: "org/stale", SizeBytes: 20},
+		{Name: "other/stale", SizeBytes: 30},
+	}}
+	handler := New(cat, nil, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.POST("/weights/prune", handler.PruneWeights)
+	prune := func(query string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/weights/prune"+query, nil))
+		var resp map[string]interface{}
+		_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, resp
+	}
+
+	for _, query := range []string{"", "?olderThan=soon", "?olderThan=0d"} {
+		if rec, _ := prune(query); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%q: expected 400, got %d: %s", query, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec, resp := prune("?olderThan=30d&dryRun=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if weightStore.pruneAge != 30*24*time.Hour {
+		t.Fatalf("expected 30 days, got %s", weightStore.pruneAge)
+	}
+	if len(weightStore.deleted) != 0 {
+		t.Fatalf("dry run must not delete, deleted %v", weightStore.deleted)
+	}
+	if resp["count"] != float64(2) || resp["freedBytes"] != float64(50) {
+		t.Fatalf("expected two candidates freeing 50 bytes, got %v", resp)
+	}
+	if skipped, _ := resp["skipped"].(map[string]interface{}); !strings.Contains(fmt.Sprint(skipped["org/used"]), "catalog model served") {
+		t.Fatalf("expected in-use weights skipped, got %v", resp["skipped"])
+	}
+
+	if rec, _ := prune("?olderThan=720h"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !reflect.DeepEqual(weightStore.deleted, []string{"org/stale", "other/stale"}) {
+		t.Fatalf("expected only unused stale weights deleted, got %v", weightStore.deleted)
+	}
+}
+
+func TestListOrphanedWeightsSkipsReferencedWeights(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	body := `{"id":"served","storageUri":"pvc://venus-model-storage/org/used"}`
+	if err := os.WriteFile(filepath.Join(modelsDir, "served.json"), []byte(body), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	weightStore := &fakeWeightStore{listResp: []weights.WeightInfo{
+		{Name: "org/used", SizeBytes: 10},
+		{Name: "org/old", SizeBytes: 20},
+		{Name: "other/older", SizeBytes: 30},
+	}}
+	handler := New(catalog.New(root, "models"), nil, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/weights/orphaned", handler.ListOrphanedWeights)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weights/orphaned", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Weights          []weights.WeightInfo `json:"weights"`
+		ReclaimableBytes int64                `json:"reclaimableBytes"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Weights) != 2 || resp.Weights[0].Name != "org/old" || resp.Weights[1].Name != "other/older" {
+		t.Fatalf("expected unreferenced weights only, got %+v", resp.Weights)
+	}
+	if resp.ReclaimableBytes != 50 {
+		t.Fatalf("expected 50 reclaimable bytes, got %d", resp.ReclaimableBytes)
+	}
+}
+
+func TestListOrphanedWeightsResolvesBucketStorageURIs(t *testing.T) {
+	cases := []struct {
+		name string
+		opts Options
+		used string
+		// other points at org/old outside the layout, so it stays orphaned.
+		other string
+	}{
+		{
+			name:  "s3",
+			opts:  Options{StorageBackend: "s3", StorageBucket: "models", StoragePrefix: "cache"},
+			used:  "s3://models/cache/org/used",
+			other: "s3://archive/cache/org/old",
+		},
+		{
+			name:  "gcs",
+			opts:  Options{StorageBackend: "gcs", StorageBucket: "models"},
+			used:  "gs://models/org/used",
+			other: "s3://models/org/old",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			modelsDir := filepath.Join(root, "models")
+			if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			for id, uri := range map[string]string{"served": tc.used, "stale": tc.other} {
+				body := fmt.Sprintf(`{"id":%q,"storageUri":%q}`, id, uri)
+				if err := os.WriteFile(filepath.Join(modelsDir, id+".json"), []byte(body), 0o644); err != nil {
+					t.Fatalf("write model: %v", err)
+				}
+			}
+			weightStore := &fakeWeightStore{listResp: []weights.WeightInfo{
+				{Name: "org/used", SizeBytes: 10},
+				{Name: "org/old", SizeBytes: 20},
+			}}
+			handler := New(catalog.New(root, "models"), nil, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, tc.opts)
+			engine := gin.New()
+			engine.GET("/weights/orphaned", handler.ListOrphanedWeights)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weights/orphaned", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var resp struct {
+				Weights []weights.WeightInfo `json:"weights"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(resp.Weights) != 1 || resp.Weights[0].Name != "org/old" {
+				t.Fatalf("expected only org/old to be orphaned, got %+v", resp.Weights)
+			}
+		})
+	}
+}
+
+func TestWeightReferencesResolveEveryScheme(t *testing.T) {
+	t.Parallel()
+
+	cat := catalog.New("", "")
+	cat.Restore([]*catalog.Model{
+		{ID: "on-pvc", StorageURI: "pvc://venus-model-storage/org/legacy"},
+		{ID: "on-s3", StorageURI: "s3://models/cache/org/current"},
+		{ID: "on-gcs", StorageURI: "gs://models/org/elsewhere"},
+		{ID: "on-hf", StorageURI: "hf://org/remote"},
+	})
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{
+		WeightsPVCName: "venus-model-storage",
+		StorageBackend: "s3",
+		StorageBucket:  "models",
+		StoragePrefix:  "cache",
+	})
+
+	refs, err := handler.weightReferences()
+	if err != nil {
+		t.Fatalf("weightReferences: %v", err)
+	}
+	want := map[string][]string{
+		"org/legacy":  {"catalog model on-pvc"},
+		"org/current": {"catalog model on-s3"},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("unexpected references: %+v", refs)
+	}
+}
+
+func TestCatalogRefreshSkipsUnchangedSnapshot(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeModel := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(modelsDir, "served.json"), []byte(body), 0o644); err != nil {
+			t.Fatalf("write model: %v", err)
+		}
+	}
+	writeModel(`{"id":"served","hfModelId":"org/served"}`)
+	dataStore := newTempStore(t)
+	handler := New(catalog.New(root, "models"), nil, nil, nil, nil, nil, nil, dataStore, nil, nil, nil, nil, nil, nil, Options{})
+
+	if err := handler.ensureCatalogFresh(true); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	_, first, err := dataStore.LoadCatalogSnapshot()
+	if err != nil {
+		t.Fatalf("LoadCatalogSnapshot: %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if err := handler.ensureCatalogFresh(true); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	if _, second, _ := dataStore.LoadCatalogSnapshot(); !second.Equal(first) {
+		t.Fatalf("expected unchanged catalog to skip the snapshot write (%s != %s)", second, first)
+	}
+
+	writeModel(`{"id":"served","hfModelId":"org/served-v2"}`)
+	time.Sleep(10 * time.Millisecond)
+	if err := handler.ensureCatalogFresh(true); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	models, third, _ := dataStore.LoadCatalogSnapshot()
+	if !third.After(first) || len(models) != 1 || models[0].HFModelID != "org/served-v2" {
+		t.Fatalf("expected changed catalog to be persisted, got %+v at %s", models, third)
+	}
+}
+
+func TestReadyReportsDegradedDependencies(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	dataStore := newTempStore(t)
+	handler := New(catalog.New(root, "models"), nil, nil, nil, nil, nil, nil, dataStore, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/readyz", handler.Ready)
+
+	ready := func() (int, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return rec.Code, body["checks"].(map[string]interface{})
+	}
+
+	catalogError := func(checks map[string]interface{}) interface{} {
+		return checks["catalog"].(map[string]interface{})["error"]
+	}
+
+	code, checks := ready()
+	if code != http.StatusServiceUnavailable || catalogError(checks) != "catalog has not been loaded yet" {
+		t.Fatalf("expected 503 before the first catalog load, got %d: %+v", code, checks)
+	}
+	if err := handler.ensureCatalogFresh(false); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	code, checks = ready()
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 for empty catalog, got %d", code)
+	}
+	if checks["catalog"].(map[string]interface{})["status"] != "error" || checks["datastore"].(map[string]interface{})["status"] != "ok" {
+		t.Fatalf("unexpected checks: %+v", checks)
+	}
+	if checks["redis"].(map[string]interface{})["status"] != "disabled" {
+		t.Fatalf("expected redis to be reported disabled, got %+v", checks["redis"])
+	}
+
+	// Probes report the cached load state; they never reload the catalog.
+	if err := os.WriteFile(filepath.Join(modelsDir, "served.json"), []byte(`{"id":"served"}`), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	handler.lastCatalogRefresh = time.Time{}
+	if code, checks = ready(); code != http.StatusServiceUnavailable || handler.catalog.Count() != 0 {
+		t.Fatalf("expected the probe not to reload the catalog, got %d with %d models", code, handler.catalog.Count())
+	}
+	if err := handler.ensureCatalogFresh(true); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	if code, checks = ready(); code != http.StatusOK {
+		t.Fatalf("expected 200 once the catalog loads, got %d: %+v", code, checks)
+	}
+
+	_ = dataStore.Close()
+	if code, checks = ready(); code != http.StatusServiceUnavailable || checks["datastore"].(map[string]interface{})["status"] != "error" {
+		t.Fatalf("expected closed datastore to degrade readiness, got %d: %+v", code, checks)
+	}
+}
+
+func TestSystemRuntimeReportsMemoryStats(t *testing.T) {
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/system/runtime", handler.SystemRuntime)
+	runtime.GC()
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/system/runtime", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp struct {
+		Goroutines int `json:"goroutines"`
+		Heap       struct {
+			AllocBytes uint64 `json:"allocBytes"`
+		} `json:"heap"`
+		GC struct {
+			Count        uint32   `json:"count"`
+			RecentPauses []string `json:"recentPauses"`
+		} `json:"gc"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Goroutines == 0 || resp.Heap.AllocBytes == 0 {
+		t.Fatalf("expected goroutine and heap stats, got %s", rec.Body.String())
+	}
+	if resp.GC.Count == 0 || len(resp.GC.RecentPauses) == 0 {
+		t.Fatalf("expected GC pauses after a forced collection, got %s", rec.Body.String())
+	}
+}
+
+func TestCatalogVersionIsStableAcrossReloads(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		body := fmt.Sprintf(`{"id":"model-%d","hfModelId":"org/model-%d"}`, i, i)
+		if err := os.WriteFile(filepath.Join(modelsDir, fmt.Sprintf("model-%d.json", i)), []byte(body), 0o644); err != nil {
+			t.Fatalf("write model: %v", err)
+		}
+	}
+	cat := catalog.New(root, "models")
+	handler := New(cat, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	if err := handler.ensureCatalogFresh(true); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	want := handler.currentCatalogVersion()
+	if expected, _ := store.CatalogHash(cat.All()); want == "" || want != expected {
+		t.Fatalf("expected the catalog version to be store.CatalogHash, got %q want %q", want, expected)
+	}
+	for i := 0; i < 10; i++ {
+		if err := handler.ensureCatalogFresh(true); err != nil {
+			t.Fatalf("ensureCatalogFresh: %v", err)
+		}
+		if got := handler.currentCatalogVersion(); got != want {
+			t.Fatalf("reload %d: catalog version changed from %s to %s without a content change", i, want, got)
+		}
+	}
+}
+
+func TestCatalogHandlersHonorIfNoneMatch(t *testing.T) {
+	root := t.TempDir()
+	modelsDir := filepath.Join(root, "models")
+	if err := os.MkdirAll(modelsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeModel := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(modelsDir, "served.json"), []byte(body), 0o644); err != nil {
+			t.Fatalf("write model: %v", err)
+		}
+	}
+	writeModel(`{"id":"served","hfModelId":"org/served"}`)
+	handler := New(catalog.New(root, "models"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/models", handler.ListModels)
+	engine.GET("/models/:id", handler.GetModel)
+
+	get := func(path, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/models", "/models/served"} {
+		first := get(path, "")
+		etag := first.Header().Get("ETag")
+		if first.Code != http.StatusOK || etag == "" {
+			t.Fatalf("%s: expected 200 with an ETag, got %d %q", path, first.Code, etag)
+		}
+		if rec := get(path, etag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Fatalf("%s: expected empty 304 for matching ETag, got %d", path, rec.Code)
+		}
+	}
+
+	listTag := get("/models", "").Header().Get("ETag")
+	if get("/models?q=served", "").Header().Get("ETag") == listTag {
+		t.Fatalf("expected the list ETag to vary with the query")
+	}
+
+	writeModel(`{"id":"served","hfModelId":"org/served-v2"}`)
+	if err := handler.ensureCatalogFresh(true); err != nil {
+		t.Fatalf("ensureCatalogFresh: %v", err)
+	}
+	if rec := get("/models", listTag); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 after the catalog changed, got %d", rec.Code)
+	}
+}
+
+func TestStreamEventsWebSocketSeedsAndForwardsEvents(t *testing.T) {
+	dataStore := newTempStore(t)
+	if err := dataStore.CreateJob(&store.Job{ID: "job-1", Type: "weight_install", Status: store.JobPending}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	bus := events.NewBus(events.Options{})
+	handler := New(nil, nil, nil, nil, nil, nil, nil, dataStore, nil, bus, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/events/ws", handler.StreamEventsWebSocket)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/events/ws", "", srv.URL)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	receive := func() events.Event {
+		t.Helper()
+		var evt events.Event
+		if err := websocket.JSON.Receive(conn, &evt); err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		return evt
+	}
+	for _, want := range []string{"stream.seed.start", "job.pending", "stream.seed.complete"} {
+		if evt := receive(); evt.Type != want {
+			t.Fatalf("expected %s, got %s", want, evt.Type)
+		}
+	}
+
+	if err := bus.Publish(context.Background(), events.Event{Type: "model.activated"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if evt := receive(); evt.Type != "model.activated" {
+		t.Fatalf("expected live event, got %s", evt.Type)
+	}
+}
+
+func TestStreamEventsReplaysAfterLastEventID(t *testing.T) {
+	bus := events.NewBus(events.Options{ReplaySize: 2})
+	var ids []string
+	for _, typ := range []string{"job.created", "job.running", "job.completed"} {
+		if err := bus.Publish(context.Background(), events.Event{Type: typ}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		recent := bus.Recent()
+		ids = append(ids, recent[len(recent)-1].StreamID)
+	}
+	handler := New(nil, nil, nil, nil, nil, nil, nil, nil, nil, bus, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/events", handler.StreamEvents)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	open := func(lastEventID string) func() (string, string) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events", nil)
+		req.Header.Set("Last-Event-ID", lastEventID)
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("GET /events: %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		scanner := bufio.NewScanner(resp.Body)
+		return func() (string, string) {
+			t.Helper()
+			var id, typ string
+			for scanner.Scan() {
+				line := scanner.Text()
+				switch {
+				case strings.HasPrefix(line, "id:"):
+					id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+				case strings.HasPrefix(line, "event:"):
+					typ = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+				case line == "" && typ != "":
+					return id, typ
+				}
+			}
+			t.Fatalf("stream ended: %v", scanner.Err())
+			return "", ""
+		}
+	}
+
+	// Event 2 was seen; event 3 is still buffered.
+	next := open(ids[1])
+	if id, typ := next(); id != ids[2] || typ != "job.completed" {
+		t.Fatalf("expected replayed %s/job.completed, got %s/%s", ids[2], id, typ)
+	}
+	if err := bus.Publish(context.Background(), events.Event{Type: "model.activated"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	recent := bus.Recent()
+	if id, typ := next(); id != recent[len(recent)-1].StreamID || typ != "model.activated" {
+		t.Fatalf("expected the live event, got %s/%s", id, typ)
+	}
+
+	// Event 1 has fallen out of the two-event buffer, so the client is told
+	// to resync instead of silently missing events.
+	if _, typ := open(ids[0])(); typ != "stream.reset" {
+		t.Fatalf("expected stream.reset for an evicted Last-Event-ID, got %s", typ)
+	}
+}
+
+func TestEventStreamFiltersByTypePrefix(t *testing.T) {
+	dataStore := newTempStore(t)
+	for _, job := range []*store.Job{
+		{ID: "job-1", Type: "weight_install", Status: store.JobPending},
+		{ID: "job-2", Type: "weight_install", Status: store.JobFailed},
+	} {
+		if err := dataStore.CreateJob(job); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+	}
+	bus := events.NewBus(events.Options{})
+	handler := New(nil, nil, nil, nil, nil, nil, nil, dataStore, nil, bus, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/events/ws", handler.StreamEventsWebSocket)
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/events/ws?types=job.failed,model.", "", srv.URL)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	receive := func() events.Event {
+		t.Helper()
+		var evt events.Event
+		if err := websocket.JSON.Receive(conn, &evt); err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		return evt
+	}
+	for _, want := range []string{"stream.seed.start", "job.failed", "stream.seed.complete"} {
+		if evt := receive(); evt.Type != want {
+			t.Fatalf("expected %s, got %s", want, evt.Type)
+		}
+	}
+
+	for _, typ := range []string{"catalog.refreshed", "job.pending", "model.activated"} {
+		if err := bus.Publish(context.Background(), events.Event{Type: typ}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	if evt := receive(); evt.Type != "model.activated" {
+		t.Fatalf("expected only model.activated to pass the filter, got %s", evt.Type)
+	}
+}
+
+func TestGetWeightInfoIncludesFileManifestOnRequest(t *testing.T) {
+	weightStore := &fakeWeightStore{
+		getResp:      &weights.WeightInfo{Name: "org/model"},
+		manifestResp: []weights.FileInfo{{Path: "config.json", SizeBytes: 2, SHA256: "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"}},
+	}
+	handler := New(nil, nil, weightStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, Options{})
+	engine := gin.New()
+	engine.GET("/weights/info", handler.GetWeightInfo)
+	get := func(query string) weights.WeightInfo {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weights/info"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d: %s", query, rec.Code, rec.Body.String())
+		}
+		var info weights.WeightInfo
+		if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return info
+	}
+
+	if info := get("?name=org/model"); len(info.Files) != 0 {
+		t.Fatalf("expected no files without files=true, got %v", info.Files)
+	}
+	info := get("?name=org/model&files=true")
+	if len(info.Files) != 1 || info.Files[0].Path != "config.json" || info.Files[0].SHA256 == "" {
+		t.Fatalf("expected the file manifest, got %v", info.Files)
+	}
+
+	// Weights installed before manifests were recorded have none to show.
+	weightStore.manifestResp = nil
+	if info := get("?name=org/model&files=true"); len(info.Files) != 0 {
+		t.Fatalf("expected no files without a recorded manifest, got %v", info.Files)
+	}
+}
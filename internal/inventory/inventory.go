@@ -0,0 +1,321 @@
+// Package inventory reports the GPU capacity of cluster nodes: what each node
+// can allocate and what running pods already request.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GPU resource names counted by the inventory.
+const (
+	ResourceNVIDIA = "nvidia.com/gpu"
+	ResourceAMD    = "amd.com/gpu"
+)
+
+var gpuResources = []string{ResourceNVIDIA, ResourceAMD}
+
+// productLabels are the node labels the vendor device plugins use for the GPU
+// model.
+var productLabels = map[string]string{
+	ResourceNVIDIA: "nvidia.com/gpu.product",
+	ResourceAMD:    "amd.com/gpu.product",
+}
+
+// NodeGPUs is one GPU type on one node.
+type NodeGPUs struct {
+	Resource string `json:"resource"`
+	Product  string `json:"product,omitempty"`
+	Total    int64  `json:"total"`
+	Used     int64  `json:"used"`
+	Free     int64  `json:"free"`
+}
+
+// Node lists the GPUs of a node. Labels are kept for matching GPU profiles
+// to nodes but not serialized.
+type Node struct {
+	Name          string            `json:"name"`
+	Ready         bool              `json:"ready"`
+	Unschedulable bool              `json:"unschedulable,omitempty"`
+	GPUs          []NodeGPUs        `json:"gpus"`
+	Labels        map[string]string `json:"-"`
+}
+
+// TypeTotal aggregates one GPU type across nodes.
+type TypeTotal struct {
+	Resource string `json:"resource"`
+	Product  string `json:"product,omitempty"`
+	Nodes    int    `json:"nodes"`
+	Total    int64  `json:"total"`
+	Used     int64  `json:"used"`
+	Free     int64  `json:"free"`
+}
+
+// Inventory is a point-in-time view of cluster GPUs. Only nodes exposing a
+// GPU resource are listed.
+type Inventory struct {
+	Nodes     []Node      `json:"nodes"`
+	Types     []TypeTotal `json:"types"`
+	Total     int64       `json:"total"`
+	Used      int64       `json:"used"`
+	Free      int64       `json:"free"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+// Options configures a Collector.
+type Options struct {
+	// TTL is how long a collected inventory is reused (default 30s).
+	TTL time.Duration
+}
+
+// Collector lists nodes and pods to build the inventory, caching the result
+// for TTL so dashboards and recommendations don't hammer the API server.
+type Collector struct {
+	client kubernetes.Interface
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	cached *Inventory
+}
+
+// New returns a collector backed by client.
+func New(client kubernetes.Interface, opts Options) *Collector {
+	if opts.TTL <= 0 {
+		opts.TTL = 30 * time.Second
+	}
+	return &Collector{client: client, ttl: opts.TTL}
+}
+
+// Inventory returns the cached inventory or collects a fresh one.
+func (c *Collector) Inventory(ctx context.Context) (*Inventory, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cached != nil && time.Since(c.cached.UpdatedAt) < c.ttl {
+		return c.cached, nil
+	}
+	inv, err := c.collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.cached = inv
+	return inv, nil
+}
+
+func (c *Collector) collect(ctx context.Context) (*Inventory, error) {
+	nodes, err := c.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+	pods, err := c.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+	return Build(nodes.Items, pods.Items, time.Now().UTC()), nil
+}
+
+// Build computes the inventory from node and pod objects. Pods that are not
+// scheduled or have finished don't count towards a node's used GPUs.
+func Build(nodes []corev1.Node, pods []corev1.Pod, now time.Time) *Inventory {
+	used := make(map[string]map[string]int64)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for resourceName, count := range podGPURequests(pod) {
+			if used[pod.Spec.NodeName] == nil {
+				used[pod.Spec.NodeName] = make(map[string]int64)
+			}
+			used[pod.Spec.NodeName][resourceName] += count
+		}
+	}
+
+	inv := &Inventory{Nodes: []Node{}, Types: []TypeTotal{}, UpdatedAt: now}
+	totals := make(map[string]*TypeTotal)
+	for i := range nodes {
+		node := &nodes[i]
+		entry := Node{
+			Name:          node.Name,
+			Ready:         nodeReady(node),
+			Unschedulable: node.Spec.Unschedulable,
+			Labels:        node.Labels,
+		}
+		for _, resourceName := range gpuResources {
+			allocatable, ok := node.Status.Allocatable[corev1.ResourceName(resourceName)]
+			if !ok || allocatable.Value() <= 0 {
+				continue
+			}
+			gpus := NodeGPUs{
+				Resource: resourceName,
+				Product:  node.Labels[productLabels[resourceName]],
+				Total:    allocatable.Value(),
+				Used:     used[node.Name][resourceName],
+			}
+			gpus.Free = gpus.Total - gpus.Used
+			if gpus.Free < 0 {
+				gpus.Free = 0
+			}
+			entry.GPUs = append(entry.GPUs, gpus)
+
+			key := gpus.Resource + "|" + gpus.Product
+			total := totals[key]
+			if total == nil {
+				total = &TypeTotal{Resource: gpus.Resource, Product: gpus.Product}
+				totals[key] = total
+			}
+			total.Nodes++
+			total.Total += gpus.Total
+			total.Used += gpus.Used
+			if entry.schedulable() {
+				total.Free += gpus.Free
+			}
+		}
+		if len(entry.GPUs) == 0 {
+			continue
+		}
+		inv.Nodes = append(inv.Nodes, entry)
+	}
+	sort.Slice(inv.Nodes, func(i, j int) bool { return inv.Nodes[i].Name < inv.Nodes[j].Name })
+	for _, total := range totals {
+		inv.Types = append(inv.Types, *total)
+		inv.Total += total.Total
+		inv.Used += total.Used
+		inv.Free += total.Free
+	}
+	sort.Slice(inv.Types, func(i, j int) bool {
+		if inv.Types[i].Resource != inv.Types[j].Resource {
+			return inv.Types[i].Resource < inv.Types[j].Resource
+		}
+		return inv.Types[i].Product < inv.Types[j].Product
+	})
+	return inv
+}
+
+// schedulable reports whether new pods can land on the node; free GPUs on
+// other nodes are listed but not counted in the aggregate free totals.
+func (n Node) schedulable() bool {
+	return n.Ready && !n.Unschedulable
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podGPURequests returns the GPUs a pod holds: the larger of the summed app
+// container requests and the biggest init container request, as the
+// scheduler computes it. Extended resources fall back to limits when no
+// request is set.
+func podGPURequests(pod *corev1.Pod) map[string]int64 {
+	out := make(map[string]int64)
+	for _, resourceName := range gpuResources {
+		var app, init int64
+		for _, ctr := range pod.Spec.Containers {
+			app += containerGPUs(ctr, resourceName)
+		}
+		for _, ctr := range pod.Spec.InitContainers {
+			if n := containerGPUs(ctr, resourceName); n > init {
+				init = n
+			}
+		}
+		if init > app {
+			app = init
+		}
+		if app > 0 {
+			out[resourceName] = app
+		}
+	}
+	return out
+}
+
+func containerGPUs(ctr corev1.Container, resourceName string) int64 {
+	name := corev1.ResourceName(resourceName)
+	if qty, ok := ctr.Resources.Requests[name]; ok {
+		return qty.Value()
+	}
+	if qty, ok := ctr.Resources.Limits[name]; ok {
+		return qty.Value()
+	}
+	return 0
+}
+
+// ResourceForVendor maps a GPU profile vendor ("nvidia", "amd") to its
+// resource name, or "" when unknown.
+func ResourceForVendor(vendor string) string {
+	switch strings.ToLower(strings.TrimSpace(vendor)) {
+	case "nvidia":
+		return ResourceNVIDIA
+	case "amd":
+		return ResourceAMD
+	}
+	return ""
+}
+
+// Availability is the free capacity of the nodes matching a GPU selector.
+type Availability struct {
+	// Nodes counts matching nodes, schedulable or not.
+	Nodes int
+	// Free sums the free GPUs on matching nodes that accept new pods.
+	Free int64
+	// MaxFreeOnNode is the most free GPUs on one such node; a pod needing
+	// several GPUs must find them on a single node.
+	MaxFreeOnNode int64
+}
+
+// Available reports the free GPUs of resourceName ("" matches any GPU
+// resource) on nodes carrying all of labels.
+func (inv *Inventory) Available(resourceName string, labels map[string]string) Availability {
+	var avail Availability
+	if inv == nil {
+		return avail
+	}
+	for _, node := range inv.Nodes {
+		if !hasLabels(node.Labels, labels) {
+			continue
+		}
+		var free int64
+		matched := false
+		for _, gpus := range node.GPUs {
+			if resourceName != "" && gpus.Resource != resourceName {
+				continue
+			}
+			matched = true
+			free += gpus.Free
+		}
+		if !matched {
+			continue
+		}
+		avail.Nodes++
+		if !node.schedulable() {
+			continue
+		}
+		avail.Free += free
+		if free > avail.MaxFreeOnNode {
+			avail.MaxFreeOnNode = free
+		}
+	}
+	return avail
+}
+
+func hasLabels(nodeLabels, want map[string]string) bool {
+	for key, value := range want {
+		if nodeLabels[key] != value {
+			return false
+		}
+	}
+	return true
+}
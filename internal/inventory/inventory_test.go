@@ -0,0 +1,84 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func gpuNode(name, resourceName, product string, count int64, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{productLabels[resourceName]: product}},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceName(resourceName): *resource.NewQuantity(count, resource.DecimalSI)},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func gpuPod(name, node, resourceName string, count int64, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ai"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name:      "main",
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceName(resourceName): *resource.NewQuantity(count, resource.DecimalSI)}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestCollectorCountsUsedAndFreeGPUs(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		gpuNode("a100-1", ResourceNVIDIA, "NVIDIA-A100-SXM4-80GB", 4, true),
+		gpuNode("a100-2", ResourceNVIDIA, "NVIDIA-A100-SXM4-80GB", 2, false),
+		gpuNode("venus", ResourceAMD, "MI210", 1, true),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-only"}},
+		gpuPod("llm", "a100-1", ResourceNVIDIA, 3, corev1.PodRunning),
+		gpuPod("done", "a100-1", ResourceNVIDIA, 1, corev1.PodSucceeded),
+		gpuPod("pending", "", ResourceNVIDIA, 1, corev1.PodPending),
+		gpuPod("embed", "venus", ResourceAMD, 1, corev1.PodRunning),
+	)
+	inv, err := New(client, Options{TTL: time.Minute}).Inventory(context.Background())
+	if err != nil {
+		t.Fatalf("Inventory: %v", err)
+	}
+
+	if len(inv.Nodes) != 3 || inv.Nodes[0].Name != "a100-1" {
+		t.Fatalf("expected the three GPU nodes sorted by name, got %+v", inv.Nodes)
+	}
+	if gpus := inv.Nodes[0].GPUs[0]; gpus.Total != 4 || gpus.Used != 3 || gpus.Free != 1 {
+		t.Fatalf("unexpected a100-1 GPUs: %+v", gpus)
+	}
+	if len(inv.Types) != 2 {
+		t.Fatalf("expected two GPU types, got %+v", inv.Types)
+	}
+	// The not-ready node's GPUs count towards the total but aren't free.
+	a100 := inv.Types[1]
+	if a100.Resource != ResourceNVIDIA || a100.Nodes != 2 || a100.Total != 6 || a100.Used != 3 || a100.Free != 1 {
+		t.Fatalf("unexpected A100 totals: %+v", a100)
+	}
+	if inv.Total != 7 || inv.Used != 4 || inv.Free != 1 {
+		t.Fatalf("unexpected cluster totals: total=%d used=%d free=%d", inv.Total, inv.Used, inv.Free)
+	}
+
+	// Only the ready A100 node contributes free capacity.
+	avail := inv.Available(ResourceForVendor("nvidia"), map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-80GB"})
+	if avail.Nodes != 2 || avail.Free != 1 || avail.MaxFreeOnNode != 1 {
+		t.Fatalf("unexpected A100 availability: %+v", avail)
+	}
+	if avail := inv.Available(ResourceAMD, nil); avail.Nodes != 1 || avail.Free != 0 {
+		t.Fatalf("unexpected AMD availability: %+v", avail)
+	}
+}
@@ -2,28 +2,45 @@ package jobs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"path"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/oremus-labs/ol-model-manager/internal/events"
 	"github.com/oremus-labs/ol-model-manager/internal/logutil"
 	"github.com/oremus-labs/ol-model-manager/internal/metrics"
+	"github.com/oremus-labs/ol-model-manager/internal/notify"
 	"github.com/oremus-labs/ol-model-manager/internal/store"
 	"github.com/oremus-labs/ol-model-manager/internal/weights"
 )
 
+// ErrJobInterrupted reports that a job stopped because its context was
+// cancelled, typically by a worker draining for shutdown.
+var ErrJobInterrupted = errors.New("job interrupted")
+
+// ErrJobCancelled reports that an operator cancelled the job while it was
+// running. The partial download is discarded and the job stays cancelled.
+var ErrJobCancelled = errors.New("job cancelled")
+
+// CancelEventType is published when a job is cancelled so the replica that is
+// running it can stop the download.
+const CancelEventType = events.TypeJobCancel
+
 // Manager coordinates asynchronous background work (e.g., weight installs).
 type Manager struct {
 	store       *store.Store
 	weights     weightStore
 	hfToken     string
-	pvcName     string
-	modelRoot   string
+	layout      weights.StorageLayout
 	events      eventPublisher
+	notifier    notifier
 	maxAttempts int
+
+	runMu   sync.Mutex
+	running map[string]context.CancelCauseFunc
 }
 
 type weightStore interface {
@@ -34,6 +51,14 @@ type eventPublisher interface {
 	Publish(context.Context, events.Event) error
 }
 
+type eventSubscriber interface {
+	SubscribeFiltered(context.Context, func(events.Event) bool) (<-chan events.Event, func(), error)
+}
+
+type notifier interface {
+	Broadcast(notify.Event)
+}
+
 // Options configures the job manager.
 type Options struct {
 	Store              *store.Store
@@ -41,8 +66,15 @@ type Options struct {
 	HuggingFaceToken   string
 	WeightsPVCName     string
 	InferenceModelRoot string
+	StorageBackend     string
+	StorageBucket      string
+	StoragePrefix      string
 	EventPublisher     eventPublisher
-	MaxJobAttempts     int
+	// Notifier receives job notifications. Anything other than a
+	// notify.Queue is wrapped in one so a slow webhook never stalls the job
+	// reporting it.
+	Notifier       notifier
+	MaxJobAttempts int
 }
 
 // New creates a job manager.
@@ -50,15 +82,28 @@ func New(opts Options) *Manager {
 	if opts.MaxJobAttempts <= 0 {
 		opts.MaxJobAttempts = 3
 	}
-	return &Manager{
-		store:       opts.Store,
-		weights:     opts.Weights,
-		hfToken:     opts.HuggingFaceToken,
-		pvcName:     opts.WeightsPVCName,
-		modelRoot:   opts.InferenceModelRoot,
+	if opts.Notifier != nil {
+		if _, queued := opts.Notifier.(*notify.Queue); !queued {
+			opts.Notifier = notify.NewQueue(opts.Notifier, notify.DefaultQueueSize)
+		}
+	}
+	m := &Manager{
+		store:   opts.Store,
+		weights: opts.Weights,
+		hfToken: opts.HuggingFaceToken,
+		layout: weights.StorageLayout{
+			Backend:   opts.StorageBackend,
+			PVCName:   opts.WeightsPVCName,
+			Bucket:    opts.StorageBucket,
+			Prefix:    opts.StoragePrefix,
+			ModelRoot: opts.InferenceModelRoot,
+		},
 		events:      opts.EventPublisher,
+		notifier:    opts.Notifier,
 		maxAttempts: opts.MaxJobAttempts,
+		running:     make(map[string]context.CancelCauseFunc),
 	}
+	return m
 }
 
 // InstallRequest describes a weight installation job.
@@ -68,6 +113,86 @@ type InstallRequest struct {
 	Target    string   `json:"target"`
 	Files     []string `json:"files,omitempty"`
 	Overwrite bool     `json:"overwrite"`
+	// FallbackRevisions are tried in order when Revision (or the previous
+	// fallback) is missing the requested files.
+	FallbackRevisions []string `json:"fallbackRevisions,omitempty"`
+	// RequestID is the X-Request-ID of the API call that queued the install.
+	RequestID string `json:"requestId,omitempty"`
+	// Priority orders the job against other pending installs; higher runs
+	// first and 0 is normal.
+	Priority int `json:"priority,omitempty"`
+}
+
+// InstallRequestFromPayload rebuilds an InstallRequest from a persisted job payload.
+func InstallRequestFromPayload(data map[string]interface{}) (InstallRequest, error) {
+	if data == nil {
+		return InstallRequest{}, fmt.Errorf("job payload missing")
+	}
+	modelID, _ := data["hfModelId"].(string)
+	if modelID == "" {
+		return InstallRequest{}, fmt.Errorf("payload missing hfModelId")
+	}
+	req := InstallRequest{
+		ModelID: modelID,
+	}
+	if rev, ok := data["revision"].(string); ok {
+		req.Revision = rev
+	}
+	if target, ok := data["target"].(string); ok {
+		req.Target = target
+	}
+	if overwrite, ok := data["overwrite"].(bool); ok {
+		req.Overwrite = overwrite
+	}
+	if requestID, ok := data["requestId"].(string); ok {
+		req.RequestID = requestID
+	}
+	switch priority := data["priority"].(type) {
+	case float64:
+		req.Priority = int(priority)
+	case int:
+		req.Priority = priority
+	}
+	req.Files = payloadStrings(data["files"])
+	req.FallbackRevisions = payloadStrings(data["fallbackRevisions"])
+	return req, nil
+}
+
+func payloadStrings(raw interface{}) []string {
+	var out []string
+	switch v := raw.(type) {
+	case []interface{}:
+		for _, entry := range v {
+			if s, ok := entry.(string); ok {
+				out = append(out, s)
+			}
+		}
+	case []string:
+		out = append(out, v...)
+	}
+	return out
+}
+
+// RetryBackoff returns how long a retry should wait after the given number of
+// attempts: base * 2^attempt, capped at max.
+func RetryBackoff(base, max time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if attempt < 0 {
+		attempt = 0
+	}
+	delay := base
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if max > 0 && delay >= max {
+			return max
+		}
+	}
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
 }
 
 // EnqueueWeightInstall schedules a weight install job asynchronously.
@@ -94,12 +219,22 @@ func (m *Manager) CreateJob(req InstallRequest) (*store.Job, error) {
 	if len(req.Files) > 0 {
 		payload["files"] = req.Files
 	}
+	if len(req.FallbackRevisions) > 0 {
+		payload["fallbackRevisions"] = req.FallbackRevisions
+	}
+	if req.RequestID != "" {
+		payload["requestId"] = req.RequestID
+	}
+	if req.Priority != 0 {
+		payload["priority"] = req.Priority
+	}
 	job := &store.Job{
 		ID:          uuid.NewString(),
 		Type:        "weight_install",
 		Payload:     payload,
 		Status:      store.JobPending,
 		MaxAttempts: m.maxAttempts,
+		Priority:    req.Priority,
 	}
 	if err := m.store.CreateJob(job); err != nil {
 		return nil, err
@@ -107,14 +242,65 @@ func (m *Manager) CreateJob(req InstallRequest) (*store.Job, error) {
 	return job, nil
 }
 
-// ExecuteJob kicks off the job asynchronously.
+// ExecuteJob claims the pending job and runs it asynchronously. Without Redis
+// the worker's claim loop polls the same rows, so the job only runs here when
+// the claim succeeds; otherwise a worker already owns it.
 func (m *Manager) ExecuteJob(job *store.Job, req InstallRequest) {
-	go m.processJob(job, req)
+	claimed, err := m.store.ClaimJob(context.Background(), job.ID, "api")
+	if err != nil {
+		log.Printf("jobs: failed to claim job %s: %v", job.ID, err)
+		return
+	}
+	if claimed == nil {
+		log.Printf("jobs: job %s already claimed; not running it inline", job.ID)
+		return
+	}
+	go m.processJob(context.Background(), claimed, req)
+}
+
+// RunDueRetries runs retried jobs inline once their persisted backoff
+// (NextAttemptAt) elapses, checking every interval until ctx is done. The API
+// server runs it when there is no Redis queue; because the backoff lives in
+// the datastore, retries scheduled before a restart still run, and
+// ExecuteJob's claim keeps a worker and the server from both running one.
+func (m *Manager) RunDueRetries(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		due, err := m.store.DueRetryJobs(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("jobs: failed to list due retries: %v", err)
+			}
+			continue
+		}
+		for _, job := range due {
+			req, err := InstallRequestFromPayload(job.Payload)
+			if err != nil {
+				log.Printf("jobs: retry %s has an invalid payload: %v", job.ID, err)
+				continue
+			}
+			m.ExecuteJob(job, req)
+		}
+	}
 }
 
 // ProcessJob executes the job synchronously (used by workers).
 func (m *Manager) ProcessJob(job *store.Job, req InstallRequest) {
-	m.processJob(job, req)
+	_ = m.processJob(context.Background(), job, req)
+}
+
+// ProcessJobContext executes the job synchronously and stops early when ctx is
+// cancelled, returning ErrJobInterrupted. An interrupted job is left running so
+// the caller can release it; partially downloaded files stay on disk and the
+// next attempt resumes from them.
+func (m *Manager) ProcessJobContext(ctx context.Context, job *store.Job, req InstallRequest) error {
+	return m.processJob(ctx, job, req)
 }
 
 // GetJob loads a job by ID.
@@ -125,8 +311,119 @@ func (m *Manager) GetJob(id string) (*store.Job, error) {
 	return m.store.GetJob(id)
 }
 
-func (m *Manager) processJob(job *store.Job, req InstallRequest) {
-	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Hour)
+// ReleaseJob returns an in-flight job to the pending state so another worker
+// can pick it up (used when a worker shuts down mid-job).
+func (m *Manager) ReleaseJob(id, reason string) (*store.Job, error) {
+	if m.store == nil {
+		return nil, fmt.Errorf("job manager not configured")
+	}
+	job, err := m.store.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != store.JobRunning && job.Status != store.JobPending {
+		return job, nil
+	}
+	if reason == "" {
+		reason = "Job released back to the queue"
+	}
+	// The interrupted attempt should not count against the retry budget.
+	if job.Status == store.JobRunning && job.Attempt > 0 {
+		job.Attempt--
+	}
+	m.logJob(job, "warn", "handoff", reason)
+	m.updateJob(job, store.JobPending, 0, "queued", reason)
+	return job, nil
+}
+
+// DeadLetterJob marks a job that can never succeed as failed with the
+// dead_letter stage so it stops being retried.
+func (m *Manager) DeadLetterJob(id, reason string) (*store.Job, error) {
+	if m.store == nil {
+		return nil, fmt.Errorf("job manager not configured")
+	}
+	job, err := m.store.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		reason = "Job moved to the dead-letter queue"
+	}
+	job.Error = reason
+	m.logJob(job, "error", "dead_letter", reason)
+	m.updateJob(job, store.JobFailed, job.Progress, "dead_letter", reason)
+	modelID, _ := job.Payload["hfModelId"].(string)
+	m.appendHistory(job.ID, "job_dead_lettered", modelID, map[string]interface{}{
+		"jobId":  job.ID,
+		"reason": reason,
+	})
+	return job, nil
+}
+
+// CancelJob stops the job's download if it is running on this replica and
+// publishes a job.cancel event so whichever replica owns it does the same. It
+// reports whether the job was running locally.
+func (m *Manager) CancelJob(ctx context.Context, id string) bool {
+	if m.cancelRunning(id) {
+		return true
+	}
+	if m.events != nil {
+		if err := m.events.Publish(ctx, events.New(events.JobCancel{JobID: id})); err != nil {
+			log.Printf("jobs: failed to publish cancel event for job %s: %v", id, err)
+		}
+	}
+	return false
+}
+
+// WatchCancellations cancels local jobs named by job.cancel events until ctx is
+// done. Workers run it so a cancel issued through any API replica reaches the
+// download wherever it runs.
+func (m *Manager) WatchCancellations(ctx context.Context, bus eventSubscriber) error {
+	ch, cancel, err := bus.SubscribeFiltered(ctx, func(evt events.Event) bool {
+		return evt.Type == CancelEventType
+	})
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	for evt := range ch {
+		var payload events.JobCancel
+		if err := events.DecodeInto(evt, &payload); err != nil {
+			continue
+		}
+		if payload.JobID != "" && m.cancelRunning(payload.JobID) {
+			log.Printf("jobs: cancelled running job %s", payload.JobID)
+		}
+	}
+	return ctx.Err()
+}
+
+func (m *Manager) cancelRunning(id string) bool {
+	m.runMu.Lock()
+	cancel, ok := m.running[id]
+	m.runMu.Unlock()
+	if ok {
+		cancel(weights.ErrInstallCancelled)
+	}
+	return ok
+}
+
+func (m *Manager) track(id string, cancel context.CancelCauseFunc) func() {
+	m.runMu.Lock()
+	m.running[id] = cancel
+	m.runMu.Unlock()
+	return func() {
+		m.runMu.Lock()
+		delete(m.running, id)
+		m.runMu.Unlock()
+		cancel(nil)
+	}
+}
+
+func (m *Manager) processJob(parent context.Context, job *store.Job, req InstallRequest) error {
+	runCtx, cancelRun := context.WithCancelCause(parent)
+	defer m.track(job.ID, cancelRun)()
+	ctx, cancel := context.WithTimeout(runCtx, 6*time.Hour)
 	defer cancel()
 	start := time.Now()
 	finalStatus := "failed"
@@ -135,42 +432,118 @@ func (m *Manager) processJob(job *store.Job, req InstallRequest) {
 	}()
 
 	job.Attempt++
+	job.NextAttemptAt = nil
 	m.logJob(job, "info", "queued", fmt.Sprintf("Attempt %d/%d scheduled", job.Attempt, job.MaxAttempts))
 	m.updateJob(job, store.JobRunning, 5, "queued", fmt.Sprintf("Attempt %d/%d queued", job.Attempt, job.MaxAttempts))
 	m.logJob(job, "info", "preparing", "Preparing cache directory")
 	m.updateJob(job, store.JobRunning, 15, "preparing", "Preparing cache directory")
 
-	m.updateJob(job, store.JobRunning, 25, "downloading", "Downloading weights via Hugging Face CLI (this may take a while)")
-	info, err := m.weights.InstallFromHuggingFace(ctx, weights.InstallOptions{
-		ModelID:   req.ModelID,
-		Revision:  req.Revision,
-		Target:    req.Target,
-		Files:     req.Files,
-		Token:     m.hfToken,
-		Overwrite: req.Overwrite,
-	})
+	m.updateJob(job, store.JobRunning, downloadProgressStart, "downloading", "Downloading weights via Hugging Face CLI (this may take a while)")
+	progress := &downloadProgress{manager: m, job: job, lastPct: downloadProgressStart, lastStage: "downloading"}
+	revisions := weights.RevisionCandidates(req.Revision, req.FallbackRevisions)
+	var (
+		info            *weights.WeightInfo
+		err             error
+		revision        string
+		failedRevisions []string
+	)
+	for i, candidate := range revisions {
+		revision = candidate
+		if i > 0 {
+			message := fmt.Sprintf("Revision %s is missing files; trying fallback revision %s", revisions[i-1], revision)
+			m.logJob(job, "warn", "downloading", message)
+			m.updateJob(job, store.JobRunning, job.Progress, "downloading", message)
+		}
+		info, err = m.weights.InstallFromHuggingFace(ctx, weights.InstallOptions{
+			ModelID:           req.ModelID,
+			Revision:          revision,
+			RequestedRevision: revisions[0],
+			Target:            req.Target,
+			Files:             req.Files,
+			Token:             m.hfToken,
+			Overwrite:         req.Overwrite,
+			Progress:          progress.files,
+			ProgressBytes:     progress.bytes,
+			HashProgress:      progress.hashing,
+		})
+		if err == nil || !weights.IsRevisionNotFound(err) {
+			break
+		}
+		failedRevisions = append(failedRevisions, revision)
+	}
 
+	if err != nil && errors.Is(context.Cause(runCtx), weights.ErrInstallCancelled) {
+		finalStatus = "cancelled"
+		now := time.Now().UTC()
+		if job.CancelledAt == nil {
+			job.CancelledAt = &now
+		}
+		job.Error = "cancelled"
+		m.logJob(job, "warn", "cancelled", "Download stopped; partial files removed")
+		m.updateJob(job, store.JobCancelled, job.Progress, "cancelled", "Cancelled by operator")
+		logutil.Info("weights_install_cancelled", withRequestID(map[string]interface{}{
+			"jobId":   job.ID,
+			"modelId": req.ModelID,
+			"target":  req.Target,
+		}, req.RequestID))
+		return ErrJobCancelled
+	}
+	if err != nil && parent.Err() != nil {
+		finalStatus = "interrupted"
+		m.logJob(job, "warn", "interrupted", "Install interrupted; partial download kept for the next attempt")
+		logutil.Info("weights_install_interrupted", withRequestID(map[string]interface{}{
+			"jobId":   job.ID,
+			"modelId": req.ModelID,
+			"target":  req.Target,
+		}, req.RequestID))
+		return ErrJobInterrupted
+	}
 	if err != nil {
+		metrics.ObserveInstall(false)
 		job.Error = err.Error()
 		m.updateJob(job, store.JobFailed, job.Progress, "failed", err.Error())
-		m.appendHistory(job.ID, "weight_install_failed", req.ModelID, map[string]interface{}{
+		failure := map[string]interface{}{
 			"error": err.Error(),
-		})
+		}
+		if len(revisions) > 1 {
+			failure["failedRevisions"] = failedRevisions
+		}
+		m.appendHistory(job.ID, "weight_install_failed", req.ModelID, withRequestID(failure, req.RequestID))
 		m.logJob(job, "error", "failed", err.Error())
-		logutil.Error("weights_install_failed", err, map[string]interface{}{
+		m.notify("weights.install.failed", req.ModelID, fmt.Sprintf("Weight install for %s failed: %v", req.ModelID, err), map[string]interface{}{
+			"jobId": job.ID,
+			"error": err.Error(),
+		})
+		logutil.Error("weights_install_failed", err, withRequestID(map[string]interface{}{
 			"jobId":   job.ID,
 			"modelId": req.ModelID,
 			"target":  req.Target,
-		})
-		return
+		}, req.RequestID))
+		return err
 	}
 	finalStatus = "success"
+	metrics.ObserveInstall(true)
 
 	job.Error = ""
+	installedAt := info.InstalledAt
+	if installedAt.IsZero() {
+		installedAt = time.Now().UTC()
+	}
 	result := map[string]interface{}{
-		"path":      info.Path,
-		"name":      info.Name,
-		"sizeBytes": info.SizeBytes,
+		"path":        info.Path,
+		"name":        info.Name,
+		"sizeBytes":   info.SizeBytes,
+		"installedAt": installedAt.Format(time.RFC3339),
+	}
+	if info.HFModelID != "" {
+		result["hfModelId"] = info.HFModelID
+	}
+	// The revision that actually installed, which differs from the request
+	// when a fallback was used.
+	result["revision"] = revision
+	if len(failedRevisions) > 0 {
+		result["requestedRevision"] = revisions[0]
+		result["failedRevisions"] = failedRevisions
 	}
 	if storageURI := m.storageURI(info.Name); storageURI != "" {
 		result["storageUri"] = storageURI
@@ -182,13 +555,128 @@ func (m *Manager) processJob(job *store.Job, req InstallRequest) {
 	m.updateJob(job, store.JobDone, 100, "completed", "Weights ready")
 	m.logJob(job, "info", "completed", "Weights ready")
 
-	m.appendHistory(job.ID, "weight_install_completed", req.ModelID, job.Result)
-	logutil.Info("weights_install_completed", map[string]interface{}{
+	m.appendHistory(job.ID, "weight_install_completed", req.ModelID, withRequestID(copyMeta(job.Result), req.RequestID))
+	m.notify("weights.install.completed", req.ModelID, fmt.Sprintf("Installed weights for %s (%s)", req.ModelID, info.SizeHuman), job.Result)
+	logutil.Info("weights_install_completed", withRequestID(map[string]interface{}{
 		"jobId":    job.ID,
 		"modelId":  req.ModelID,
 		"target":   req.Target,
 		"duration": time.Since(start).String(),
-	})
+	}, req.RequestID))
+	return nil
+}
+
+const (
+	downloadProgressStart = 25
+	downloadProgressEnd   = 95
+	// progressFlushInterval bounds how often in-file progress is persisted.
+	progressFlushInterval = time.Second
+)
+
+// downloadProgress maps downloader callbacks onto the job's 25-95% range,
+// records a per-file stage, and throttles writes to the store.
+type downloadProgress struct {
+	manager *Manager
+	job     *store.Job
+
+	mu        sync.Mutex
+	lastFlush time.Time
+	lastPct   int
+	lastStage string
+	seen      map[string]bool
+	fileBytes map[string]fileBytes
+}
+
+// fileBytes is the latest byte count reported for one file.
+type fileBytes struct {
+	downloaded int64
+	total      int64
+}
+
+func (p *downloadProgress) files(file string, completed, total int) {
+	if total <= 0 {
+		return
+	}
+	p.report(file, float64(completed)/float64(total), fmt.Sprintf("Downloaded %d/%d files", completed, total))
+}
+
+func (p *downloadProgress) bytes(file string, index, totalFiles int, downloaded, totalBytes int64) {
+	if totalFiles <= 0 || totalBytes <= 0 || index <= 0 {
+		return
+	}
+	// The CLI downloads several files in parallel and indexes them in the
+	// order they first appear, so progress is the share of bytes fetched
+	// across every file reported so far rather than a position in the list.
+	p.mu.Lock()
+	if p.fileBytes == nil {
+		p.fileBytes = make(map[string]fileBytes)
+	}
+	p.fileBytes[file] = fileBytes{downloaded: downloaded, total: totalBytes}
+	var sumDownloaded, sumTotal int64
+	for _, fb := range p.fileBytes {
+		sumDownloaded += fb.downloaded
+		sumTotal += fb.total
+	}
+	p.mu.Unlock()
+	fraction := float64(sumDownloaded) / float64(sumTotal)
+	p.report(file, fraction, fmt.Sprintf("Downloading %s (%d/%d)", file, index, totalFiles))
+}
+
+// hashing reports the checksum manifest recorded after the download. The
+// percentage stays where the download left it; only the stage and message
+// move, throttled like download progress.
+func (p *downloadProgress) hashing(file string, hashed, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lastStage == "hashing" && hashed < total && time.Since(p.lastFlush) < progressFlushInterval {
+		return
+	}
+	p.lastFlush = time.Now()
+	p.lastStage = "hashing"
+	p.manager.updateJob(p.job, store.JobRunning, p.lastPct, "hashing", fmt.Sprintf("Recording checksums (%d/%d files)", hashed, total))
+}
+
+func (p *downloadProgress) report(file string, fraction float64, message string) {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	pct := downloadProgressStart + int(fraction*float64(downloadProgressEnd-downloadProgressStart))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pct < p.lastPct {
+		pct = p.lastPct
+	}
+	stage := p.lastStage
+	firstSeen := false
+	if file != "" {
+		stage = "downloading:" + file
+		if p.seen == nil {
+			p.seen = make(map[string]bool)
+		}
+		firstSeen = !p.seen[file]
+		p.seen[file] = true
+	}
+	// The CLI downloads several files in parallel, so only a newly started
+	// file or a throttled percentage change is worth a store write.
+	if !firstSeen {
+		if pct == p.lastPct {
+			return
+		}
+		if time.Since(p.lastFlush) < progressFlushInterval && pct < downloadProgressEnd {
+			return
+		}
+	}
+	p.lastFlush = time.Now()
+	p.lastPct = pct
+	p.lastStage = stage
+	if firstSeen {
+		p.manager.logJob(p.job, "info", stage, fmt.Sprintf("Downloading %s", file))
+	}
+	p.manager.updateJob(p.job, store.JobRunning, pct, stage, message)
 }
 
 func (m *Manager) updateJob(job *store.Job, status store.JobStatus, progress int, stage, message string) {
@@ -226,37 +714,65 @@ func (m *Manager) appendHistory(id, event, modelID string, meta map[string]inter
 	})
 }
 
-func (m *Manager) storageURI(name string) string {
-	if m.pvcName == "" || name == "" {
-		return ""
+// withRequestID adds requestId to fields when the job came from an API call.
+func withRequestID(fields map[string]interface{}, requestID string) map[string]interface{} {
+	if requestID == "" {
+		return fields
+	}
+	if fields == nil {
+		fields = map[string]interface{}{}
 	}
-	return fmt.Sprintf("pvc://%s/%s", m.pvcName, name)
+	fields["requestId"] = requestID
+	return fields
 }
 
-func (m *Manager) inferencePath(name string) string {
-	if m.modelRoot == "" || name == "" {
+func copyMeta(meta map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(meta)+1)
+	for k, v := range meta {
+		out[k] = v
+	}
+	return out
+}
+
+func jobRequestID(job *store.Job) string {
+	if job == nil {
 		return ""
 	}
-	return path.Join(m.modelRoot, name)
+	id, _ := job.Payload["requestId"].(string)
+	return id
+}
+
+func (m *Manager) notify(event, modelID, message string, meta map[string]interface{}) {
+	if m.notifier == nil {
+		return
+	}
+	evt := notify.Event{
+		Name:      event,
+		ModelID:   modelID,
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+		Metadata:  meta,
+	}
+	m.notifier.Broadcast(evt)
+}
+
+func (m *Manager) storageURI(name string) string {
+	return m.layout.StorageURI(name)
+}
+
+func (m *Manager) inferencePath(name string) string {
+	return m.layout.InferencePath(name)
 }
 
 func (m *Manager) emitJobEvent(job *store.Job) {
 	if m.events == nil || job == nil {
 		return
 	}
-	payload := *job
-	timestamp := job.UpdatedAt
-	if timestamp.IsZero() {
-		timestamp = time.Now().UTC()
-	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
-	if err := m.events.Publish(ctx, events.Event{
-		ID:        job.ID,
-		Type:      fmt.Sprintf("job.%s", job.Status),
-		Timestamp: timestamp,
-		Data:      payload,
-	}); err != nil {
+	evt := events.NewJobEvent(job)
+	evt.RequestID = jobRequestID(job)
+	if err := m.events.Publish(ctx, evt); err != nil {
 		log.Printf("jobs: failed to publish event for job %s: %v", job.ID, err)
 	}
 }
@@ -284,15 +800,7 @@ func (m *Manager) emitJobLogEvent(jobID string, entry store.JobLogEntry) {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
-	if err := m.events.Publish(ctx, events.Event{
-		ID:        fmt.Sprintf("%s-log-%d", jobID, entry.Timestamp.UnixNano()),
-		Type:      "job.log",
-		Timestamp: entry.Timestamp,
-		Data: map[string]interface{}{
-			"jobId": jobID,
-			"log":   entry,
-		},
-	}); err != nil {
+	if err := m.events.Publish(ctx, events.NewJobLogEvent(jobID, entry)); err != nil {
 		log.Printf("jobs: failed to publish log event for job %s: %v", jobID, err)
 	}
 }
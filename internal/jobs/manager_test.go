@@ -3,20 +3,29 @@ package jobs
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/notify"
 	"github.com/oremus-labs/ol-model-manager/internal/store"
 	"github.com/oremus-labs/ol-model-manager/internal/weights"
 )
 
 type fakeInstaller struct {
-	info *weights.WeightInfo
-	err  error
+	info     *weights.WeightInfo
+	err      error
+	progress func(opts weights.InstallOptions)
 }
 
 func (f *fakeInstaller) InstallFromHuggingFace(ctx context.Context, opts weights.InstallOptions) (*weights.WeightInfo, error) {
+	if f.progress != nil {
+		f.progress(opts)
+	}
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -51,6 +60,25 @@ func TestManagerEnqueueWeightInstallSuccess(t *testing.T) {
 
 	waitForJobStatus(t, s, job.ID, store.JobDone)
 
+	stored, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if got := stored.Result["storageUri"]; got != "pvc://venus-model-storage/qwen2.5-0.5b" {
+		t.Fatalf("expected persisted storageUri, got %v", got)
+	}
+	if got := stored.Result["inferenceModelPath"]; got != "/mnt/models/qwen2.5-0.5b" {
+		t.Fatalf("expected persisted inferenceModelPath, got %v", got)
+	}
+	if got, ok := stored.Result["sizeBytes"].(float64); !ok || got != 123 {
+		t.Fatalf("expected persisted sizeBytes 123, got %v", stored.Result["sizeBytes"])
+	}
+	if got, _ := stored.Result["installedAt"].(string); got == "" {
+		t.Fatalf("expected persisted installedAt, got %v", stored.Result["installedAt"])
+	} else if _, err := time.Parse(time.RFC3339, got); err != nil {
+		t.Fatalf("installedAt not RFC3339: %v", err)
+	}
+
 	waitForHistoryEvent(t, s, "weight_install_completed")
 }
 
@@ -81,6 +109,450 @@ func TestManagerEnqueueWeightInstallFailure(t *testing.T) {
 	waitForHistoryEvent(t, s, "weight_install_failed")
 }
 
+// blockingNotifier holds every broadcast until release is closed.
+type blockingNotifier struct {
+	release   chan struct{}
+	delivered chan notify.Event
+}
+
+func (n *blockingNotifier) Broadcast(evt notify.Event) {
+	<-n.release
+	n.delivered <- evt
+}
+
+func TestManagerNotifyDoesNotWaitForDelivery(t *testing.T) {
+	t.Parallel()
+
+	notifier := &blockingNotifier{release: make(chan struct{}), delivered: make(chan notify.Event, notify.DefaultQueueSize+2)}
+	m := New(Options{Notifier: notifier})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// One event is held by the stalled delivery, a queue's worth waits
+		// and the rest are dropped.
+		for i := 0; i < notify.DefaultQueueSize+5; i++ {
+			m.notify("weights.install.completed", fmt.Sprintf("model-%d", i), "done", nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("notify blocked on a stalled notifier")
+	}
+
+	close(notifier.release)
+	first := <-notifier.delivered
+	if first.ModelID != "model-0" || first.Name != "weights.install.completed" {
+		t.Fatalf("unexpected first notification %+v", first)
+	}
+	deadline := time.After(2 * time.Second)
+	for delivered := 1; delivered < notify.DefaultQueueSize; delivered++ {
+		select {
+		case <-notifier.delivered:
+		case <-deadline:
+			t.Fatalf("only %d queued notifications were delivered", delivered)
+		}
+	}
+}
+
+// revisionInstaller fails with ErrRevisionNotFound for the listed revisions.
+type revisionInstaller struct {
+	missing map[string]bool
+
+	mu    sync.Mutex
+	tried []string
+}
+
+func (r *revisionInstaller) InstallFromHuggingFace(ctx context.Context, opts weights.InstallOptions) (*weights.WeightInfo, error) {
+	r.mu.Lock()
+	r.tried = append(r.tried, opts.Revision)
+	r.mu.Unlock()
+	if r.missing[opts.Revision] {
+		return nil, fmt.Errorf("hf download failed: %w", weights.ErrRevisionNotFound)
+	}
+	return &weights.WeightInfo{Name: "qwen2.5-0.5b", Revision: opts.Revision}, nil
+}
+
+func TestManagerWeightInstallFallsBackToNextRevision(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	installer := &revisionInstaller{missing: map[string]bool{"main": true, "v2.0": true}}
+	m := New(Options{Store: s, Weights: installer})
+
+	job, err := m.CreateJob(InstallRequest{
+		ModelID:           "Qwen/Qwen2.5-0.5B",
+		FallbackRevisions: []string{"v2.0", "v1.0", "v0.9"},
+	})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	req, err := InstallRequestFromPayload(job.Payload)
+	if err != nil {
+		t.Fatalf("InstallRequestFromPayload: %v", err)
+	}
+	if err := m.ProcessJobContext(context.Background(), job, req); err != nil {
+		t.Fatalf("ProcessJobContext: %v", err)
+	}
+
+	if want := []string{"main", "v2.0", "v1.0"}; !reflect.DeepEqual(installer.tried, want) {
+		t.Fatalf("expected revisions %v to be tried, got %v", want, installer.tried)
+	}
+	stored, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if stored.Result["revision"] != "v1.0" || stored.Result["requestedRevision"] != "main" {
+		t.Fatalf("expected effective revision v1.0 requested as main, got %v", stored.Result)
+	}
+	if got, _ := stored.Result["failedRevisions"].([]interface{}); len(got) != 2 {
+		t.Fatalf("expected two failed revisions, got %v", stored.Result["failedRevisions"])
+	}
+}
+
+func TestManagerExecuteJobSkipsJobClaimedByWorker(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	installer := &revisionInstaller{}
+	m := New(Options{Store: s, Weights: installer})
+
+	job, err := m.CreateJob(InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	if claimed, err := s.ClaimNextPendingJob(context.Background(), "worker-a"); err != nil || claimed == nil {
+		t.Fatalf("worker claim: %+v, %v", claimed, err)
+	}
+	m.ExecuteJob(job, InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"})
+	time.Sleep(50 * time.Millisecond)
+
+	installer.mu.Lock()
+	defer installer.mu.Unlock()
+	if len(installer.tried) != 0 {
+		t.Fatalf("expected the inline run to skip a job a worker claimed, installer ran %v", installer.tried)
+	}
+}
+
+func TestManagerRunDueRetriesRunsJobsOnceBackoffElapses(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	installer := &revisionInstaller{}
+	m := New(Options{Store: s, Weights: installer})
+
+	job, err := m.CreateJob(InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	next := time.Now().UTC().Add(150 * time.Millisecond)
+	job.NextAttemptAt = &next
+	if err := s.UpdateJob(job); err != nil {
+		t.Fatalf("UpdateJob: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.RunDueRetries(ctx, 20*time.Millisecond)
+
+	time.Sleep(80 * time.Millisecond)
+	installer.mu.Lock()
+	early := len(installer.tried)
+	installer.mu.Unlock()
+	if early != 0 {
+		t.Fatalf("expected the retry to wait out its backoff, installer ran %d times", early)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stored, err := s.GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if stored.Status == store.JobDone {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the retry to run once due, job is %s", stored.Status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	installer.mu.Lock()
+	defer installer.mu.Unlock()
+	if len(installer.tried) != 1 {
+		t.Fatalf("expected the retry to run exactly once, installer ran %v", installer.tried)
+	}
+}
+
+func TestManagerReleaseJobReturnsRunningJobToPending(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	m := New(Options{Store: s, Weights: &fakeInstaller{}})
+
+	job, err := m.CreateJob(InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	job.Status = store.JobRunning
+	job.Attempt = 1
+	if err := s.UpdateJob(job); err != nil {
+		t.Fatalf("UpdateJob: %v", err)
+	}
+
+	released, err := m.ReleaseJob(job.ID, "")
+	if err != nil {
+		t.Fatalf("ReleaseJob: %v", err)
+	}
+	if released.Status != store.JobPending {
+		t.Fatalf("expected pending status, got %s", released.Status)
+	}
+	if released.Attempt != 0 {
+		t.Fatalf("expected interrupted attempt to be refunded, got %d", released.Attempt)
+	}
+}
+
+func TestManagerDeadLetterJobMarksFailed(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	m := New(Options{Store: s, Weights: &fakeInstaller{}})
+
+	job, err := m.CreateJob(InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	if _, err := m.DeadLetterJob(job.ID, "exceeded max attempts (1/1)"); err != nil {
+		t.Fatalf("DeadLetterJob: %v", err)
+	}
+	stored, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if stored.Status != store.JobFailed || stored.Stage != "dead_letter" {
+		t.Fatalf("expected failed dead_letter job, got status=%s stage=%s", stored.Status, stored.Stage)
+	}
+	if stored.Error != "exceeded max attempts (1/1)" {
+		t.Fatalf("expected reason recorded as error, got %q", stored.Error)
+	}
+	waitForHistoryEvent(t, s, "job_dead_lettered")
+}
+
+type blockingInstaller struct {
+	started chan struct{}
+}
+
+func (b *blockingInstaller) InstallFromHuggingFace(ctx context.Context, opts weights.InstallOptions) (*weights.WeightInfo, error) {
+	close(b.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestManagerProcessJobContextInterruptLeavesJobForHandoff(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	installer := &blockingInstaller{started: make(chan struct{})}
+	m := New(Options{Store: s, Weights: installer})
+
+	req := InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"}
+	job, err := m.CreateJob(req)
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		result <- m.ProcessJobContext(ctx, job, req)
+	}()
+
+	<-installer.started
+	cancel()
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrJobInterrupted) {
+			t.Fatalf("expected ErrJobInterrupted, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job did not stop after cancellation")
+	}
+
+	stored, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if stored.Status != store.JobRunning {
+		t.Fatalf("expected interrupted job to stay running until released, got %s", stored.Status)
+	}
+
+	released, err := m.ReleaseJob(job.ID, "")
+	if err != nil {
+		t.Fatalf("ReleaseJob: %v", err)
+	}
+	if released.Status != store.JobPending || released.Attempt != 0 {
+		t.Fatalf("expected pending job with refunded attempt, got %s attempt=%d", released.Status, released.Attempt)
+	}
+}
+
+type recordingBus struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (b *recordingBus) Publish(ctx context.Context, evt events.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, evt)
+	return nil
+}
+
+func TestManagerCancelJobStopsRunningDownload(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	installer := &blockingInstaller{started: make(chan struct{})}
+	bus := &recordingBus{}
+	m := New(Options{Store: s, Weights: installer, EventPublisher: bus})
+
+	req := InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"}
+	job, err := m.CreateJob(req)
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- m.ProcessJobContext(context.Background(), job, req)
+	}()
+
+	<-installer.started
+	if !m.CancelJob(context.Background(), job.ID) {
+		t.Fatalf("expected job to be cancelled locally")
+	}
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrJobCancelled) {
+			t.Fatalf("expected ErrJobCancelled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job did not stop after CancelJob")
+	}
+
+	stored, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if stored.Status != store.JobCancelled || stored.CancelledAt == nil {
+		t.Fatalf("expected cancelled job, got %s (cancelledAt=%v)", stored.Status, stored.CancelledAt)
+	}
+
+	// Once the job is no longer running here, cancelling falls back to the event.
+	if m.CancelJob(context.Background(), job.ID) {
+		t.Fatalf("expected finished job not to be cancelled locally")
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	last := bus.events[len(bus.events)-1]
+	var payload events.JobCancel
+	if last.Type != CancelEventType || events.DecodeInto(last, &payload) != nil || payload.JobID != job.ID {
+		t.Fatalf("expected job.cancel event for %s, got %+v", job.ID, last)
+	}
+}
+
+func TestManagerPersistsDownloadProgress(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	var midway, hashing *store.Job
+	var jobID string
+	installer := &fakeInstaller{
+		info: &weights.WeightInfo{Name: "qwen2.5-0.5b"},
+		progress: func(opts weights.InstallOptions) {
+			opts.ProgressBytes("config.json", 1, 2, 500, 1000)
+			opts.ProgressBytes("model.safetensors", 2, 2, 1000, 1000)
+			midway, _ = s.GetJob(jobID)
+			opts.HashProgress("config.json", 1, 2)
+			hashing, _ = s.GetJob(jobID)
+		},
+	}
+	m := New(Options{Store: s, Weights: installer})
+
+	job, err := m.CreateJob(InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B", Files: []string{"config.json", "model.safetensors"}})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	jobID = job.ID
+	m.ProcessJob(job, InstallRequest{ModelID: "Qwen/Qwen2.5-0.5B"})
+
+	if midway == nil {
+		t.Fatalf("expected intermediate job snapshot")
+	}
+	if midway.Stage != "downloading:model.safetensors" {
+		t.Fatalf("expected per-file stage, got %q", midway.Stage)
+	}
+	// 1500 of 2000 bytes maps to 25 + 0.75*70.
+	if midway.Progress != 77 {
+		t.Fatalf("expected progress 77, got %d", midway.Progress)
+	}
+	if hashing == nil || hashing.Stage != "hashing" || hashing.Progress != 77 || hashing.Message != "Recording checksums (1/2 files)" {
+		t.Fatalf("expected a hashing stage that keeps the download progress, got %+v", hashing)
+	}
+	final, err := s.GetJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if final.Status != store.JobDone || final.Progress != 100 {
+		t.Fatalf("expected completed job at 100%%, got %s %d", final.Status, final.Progress)
+	}
+}
+
+func TestDownloadProgressSumsInterleavedFiles(t *testing.T) {
+	t.Parallel()
+
+	s := openTestStore(t)
+	m := New(Options{Store: s, Weights: &fakeInstaller{}})
+	job, err := m.CreateJob(InstallRequest{ModelID: "Qwen/Qwen2.5-7B"})
+	if err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	p := &downloadProgress{manager: m, job: job, lastPct: downloadProgressStart}
+
+	// Three shards downloaded in parallel; the CLI reports them interleaved,
+	// so the last shard to appear gets index 3 while barely started.
+	steps := []struct {
+		file       string
+		index      int
+		downloaded int64
+		want       int
+	}{
+		{"model-00002.safetensors", 1, 100, 32},
+		{"model-00001.safetensors", 2, 100, 32},
+		{"model-00003.safetensors", 3, 100, 32},
+		{"model-00002.safetensors", 1, 500, 41},
+		{"model-00003.safetensors", 3, 500, 50},
+		{"model-00001.safetensors", 2, 1000, 71},
+		{"model-00002.safetensors", 1, 1000, 83},
+		{"model-00003.safetensors", 3, 1000, 95},
+	}
+	for _, step := range steps {
+		// Bypass the store-write throttle so every update is observable.
+		p.mu.Lock()
+		p.lastFlush = time.Time{}
+		p.mu.Unlock()
+		p.bytes(step.file, step.index, 3, step.downloaded, 1000)
+		got, err := s.GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if got.Progress != step.want {
+			t.Fatalf("after %s at %d bytes: expected progress %d, got %d", step.file, step.downloaded, step.want, got.Progress)
+		}
+	}
+}
+
 func openTestStore(t *testing.T) *store.Store {
 	t.Helper()
 	dir := t.TempDir()
@@ -138,3 +610,20 @@ func waitForHistoryEvent(t *testing.T, s *store.Store, event string) {
 		}
 	}
 }
+
+func TestRetryBackoff(t *testing.T) {
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: 30 * time.Second},
+		{attempt: 1, want: time.Minute},
+		{attempt: 3, want: 4 * time.Minute},
+		{attempt: 10, want: 10 * time.Minute},
+	}
+	for _, tc := range cases {
+		if got := RetryBackoff(30*time.Second, 10*time.Minute, tc.attempt); got != tc.want {
+			t.Fatalf("attempt %d: expected %s got %s", tc.attempt, tc.want, got)
+		}
+	}
+}
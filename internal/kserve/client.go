@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
 	"github.com/oremus-labs/ol-model-manager/internal/kube"
@@ -15,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 )
@@ -34,16 +37,51 @@ type Client struct {
 	gvr                schema.GroupVersionResource
 }
 
+// Activation strategies accepted by Activate.
+const (
+	StrategyDirect = "direct"
+	StrategyCanary = "canary"
+)
+
+// DefaultCanaryTrafficPercent is used for canary activations that don't name
+// a traffic split.
+const DefaultCanaryTrafficPercent = 10
+
+// ActivateOptions controls how traffic moves to the newly activated model.
+type ActivateOptions struct {
+	// Strategy is StrategyDirect (replace the predictor outright, the default)
+	// or StrategyCanary.
+	Strategy string
+	// TrafficPercent is the share of traffic the canary revision receives
+	// (1-99). KServe keeps routing the rest to the last ready revision.
+	TrafficPercent int
+	// Provenance, when set, is stamped on the InferenceService annotations.
+	Provenance *Provenance
+}
+
+// Provenance describes where the served weights came from so a running pod
+// can be traced back to an exact Hugging Face revision.
+type Provenance struct {
+	HFModelID   string
+	Revision    string
+	InstalledAt time.Time
+}
+
 // Result represents an operation result.
 type Result struct {
-	Action string `json:"action"`
-	Name   string `json:"name"`
+	Action         string `json:"action"`
+	Name           string `json:"name"`
+	Strategy       string `json:"strategy,omitempty"`
+	TrafficPercent int    `json:"trafficPercent,omitempty"`
 }
 
 // DryRunResult captures the outcome of a dry-run activation.
 type DryRunResult struct {
 	Action   string                 `json:"action"`
 	Manifest map[string]interface{} `json:"manifest"`
+	// Applied is the object the API server returned for the dry-run, with
+	// admission defaults filled in. It is the right side for DiffManifest.
+	Applied map[string]interface{} `json:"-"`
 }
 
 // NewClient creates a new KServe client.
@@ -62,24 +100,43 @@ func NewClientWithConfig(config *rest.Config, namespace, isvcName, inferenceMode
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
+	return NewClientWithDynamic(dynClient, namespace, isvcName, inferenceModelRoot), nil
+}
+
+// NewClientWithDynamic creates a KServe client on top of an existing dynamic
+// client, which lets tests substitute a fake cluster.
+func NewClientWithDynamic(client dynamic.Interface, namespace, isvcName, inferenceModelRoot string) *Client {
 	return &Client{
-		client:             dynClient,
+		client:             client,
 		namespace:          namespace,
 		isvcName:           isvcName,
 		inferenceModelRoot: inferenceModelRoot,
-		gvr: schema.GroupVersionResource{
-			Group:    kserveGroup,
-			Version:  kserveVersion,
-			Resource: isvcResource,
-		},
-	}, nil
+		gvr:                InferenceServiceGVR(),
+	}
 }
 
-// Activate creates or updates an InferenceService for the given model.
-func (c *Client) Activate(model *catalog.Model) (*Result, error) {
-	log.Printf("Activating model: %s", model.ID)
+// InferenceServiceGVR returns the group/version/resource for KServe InferenceServices.
+func InferenceServiceGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    kserveGroup,
+		Version:  kserveVersion,
+		Resource: isvcResource,
+	}
+}
+
+// Activate creates or updates an InferenceService for the given model. With
+// the canary strategy an existing InferenceService keeps serving its current
+// revision while canaryTrafficPercent of requests go to the new one; promote
+// it later with SetTrafficPercent(100). A canary on a missing
+// InferenceService is simply created, since there is nothing to split with.
+func (c *Client) Activate(model *catalog.Model, opts ActivateOptions) (*Result, error) {
+	opts, err := NormalizeActivateOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Activating model: %s (strategy %s)", model.ID, opts.Strategy)
 
-	isvc := buildInferenceService(c.namespace, c.isvcName, model, c.inferenceModelRoot)
+	isvc := buildInferenceService(c.namespace, c.isvcName, model, c.inferenceModelRoot, opts.Provenance)
 
 	ctx := context.Background()
 
@@ -88,12 +145,23 @@ func (c *Client) Activate(model *catalog.Model) (*Result, error) {
 	if err == nil {
 		// Update existing
 		log.Printf("Updating existing InferenceService: %s", c.isvcName)
+		if opts.Strategy == StrategyCanary {
+			patch, err := canaryPatch(existing, isvc, opts.TrafficPercent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build canary patch: %w", err)
+			}
+			if _, err := c.client.Resource(c.gvr).Namespace(c.namespace).Patch(ctx, c.isvcName, types.JSONPatchType, patch, metav1.PatchOptions{}); err != nil {
+				return nil, fmt.Errorf("failed to patch InferenceService: %w", err)
+			}
+			return &Result{Action: "updated", Name: c.isvcName, Strategy: StrategyCanary, TrafficPercent: opts.TrafficPercent}, nil
+		}
+		result := &Result{Action: "updated", Name: c.isvcName, Strategy: StrategyDirect}
 		isvc.SetResourceVersion(existing.GetResourceVersion())
 		_, err = c.client.Resource(c.gvr).Namespace(c.namespace).Update(ctx, isvc, metav1.UpdateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("failed to update InferenceService: %w", err)
 		}
-		return &Result{Action: "updated", Name: c.isvcName}, nil
+		return result, nil
 	}
 	if !apierrors.IsNotFound(err) {
 		return nil, fmt.Errorf("failed to get InferenceService: %w", err)
@@ -106,18 +174,202 @@ func (c *Client) Activate(model *catalog.Model) (*Result, error) {
 		return nil, fmt.Errorf("failed to create InferenceService: %w", err)
 	}
 
-	return &Result{Action: "created", Name: c.isvcName}, nil
+	return &Result{Action: "created", Name: c.isvcName, Strategy: StrategyDirect}, nil
+}
+
+// managedPredictorFields are the predictor fields buildInferenceService sets.
+// A canary patch replaces or removes only these, so anything else on the
+// predictor (and the rest of the spec) is left as the cluster has it.
+var managedPredictorFields = []string{"minReplicas", "model", "nodeSelector", "tolerations", "resources", "volumes"}
+
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// canaryPatch builds a JSON patch that points existing at desired's model
+// with percent of traffic on the new revision. It only touches the managed
+// predictor fields and the model manager's annotations, and fails if the
+// InferenceService changed since existing was read.
+func canaryPatch(existing, desired *unstructured.Unstructured, percent int) ([]byte, error) {
+	var ops []jsonPatchOp
+	if version := existing.GetResourceVersion(); version != "" {
+		ops = append(ops, jsonPatchOp{Op: "test", Path: "/metadata/resourceVersion", Value: version})
+	}
+
+	current := existing.GetAnnotations()
+	wanted := desired.GetAnnotations()
+	if current == nil {
+		ops = append(ops, jsonPatchOp{Op: "add", Path: "/metadata/annotations", Value: wanted})
+	} else {
+		for _, key := range sortedKeys(wanted) {
+			ops = append(ops, jsonPatchOp{Op: "add", Path: "/metadata/annotations/" + escapePointer(key), Value: wanted[key]})
+		}
+		for _, key := range sortedKeys(current) {
+			if _, keep := wanted[key]; keep || !managedAnnotation(key) {
+				continue
+			}
+			ops = append(ops, jsonPatchOp{Op: "remove", Path: "/metadata/annotations/" + escapePointer(key)})
+		}
+	}
+
+	predictor, _, _ := unstructured.NestedMap(desired.Object, "spec", "predictor")
+	existingPredictor, found, err := unstructured.NestedMap(existing.Object, "spec", "predictor")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		predictor["canaryTrafficPercent"] = int64(percent)
+		ops = append(ops, jsonPatchOp{Op: "add", Path: "/spec/predictor", Value: predictor})
+		return json.Marshal(ops)
+	}
+	for _, field := range managedPredictorFields {
+		if value, ok := predictor[field]; ok {
+			ops = append(ops, jsonPatchOp{Op: "add", Path: "/spec/predictor/" + field, Value: value})
+		} else if _, ok := existingPredictor[field]; ok {
+			ops = append(ops, jsonPatchOp{Op: "remove", Path: "/spec/predictor/" + field})
+		}
+	}
+	ops = append(ops, jsonPatchOp{Op: "add", Path: "/spec/predictor/canaryTrafficPercent", Value: percent})
+	return json.Marshal(ops)
+}
+
+// managedAnnotation reports whether buildInferenceService owns the annotation,
+// so a stale value may be removed.
+func managedAnnotation(key string) bool {
+	return strings.HasPrefix(key, "model-manager/") || key == "storage.kserve.io/readonly"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a key for use in a JSON pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// NormalizeActivateOptions validates opts, defaulting the strategy to direct
+// and the canary split to DefaultCanaryTrafficPercent.
+func NormalizeActivateOptions(opts ActivateOptions) (ActivateOptions, error) {
+	opts.Strategy = strings.ToLower(strings.TrimSpace(opts.Strategy))
+	switch opts.Strategy {
+	case "", StrategyDirect:
+		opts.Strategy = StrategyDirect
+		opts.TrafficPercent = 0
+	case StrategyCanary:
+		if opts.TrafficPercent == 0 {
+			opts.TrafficPercent = DefaultCanaryTrafficPercent
+		}
+		if opts.TrafficPercent < 1 || opts.TrafficPercent > 99 {
+			return opts, fmt.Errorf("canary trafficPercent must be between 1 and 99")
+		}
+	default:
+		return opts, fmt.Errorf("unknown activation strategy %q (expected %s or %s)", opts.Strategy, StrategyDirect, StrategyCanary)
+	}
+	return opts, nil
+}
+
+// CanaryTrafficPercent reads the predictor's canaryTrafficPercent. ok is false
+// when the InferenceService doesn't exist or isn't splitting traffic.
+func (c *Client) CanaryTrafficPercent() (percent int, ok bool, err error) {
+	existing, err := c.client.Resource(c.gvr).Namespace(c.namespace).Get(context.Background(), c.isvcName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get InferenceService: %w", err)
+	}
+	value, found, err := unstructured.NestedFieldNoCopy(existing.Object, "spec", "predictor", "canaryTrafficPercent")
+	if err != nil || !found {
+		return 0, false, err
+	}
+	switch v := value.(type) {
+	case int64:
+		return int(v), true, nil
+	case int:
+		return v, true, nil
+	case float64:
+		return int(v), true, nil
+	}
+	return 0, false, nil
+}
+
+// SetTrafficPercent patches only the predictor's canaryTrafficPercent, leaving
+// the rest of the spec untouched. 100 promotes the canary revision.
+func (c *Client) SetTrafficPercent(percent int) (*Result, error) {
+	if percent < 0 || percent > 100 {
+		return nil, fmt.Errorf("trafficPercent must be between 0 and 100")
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"predictor": map[string]interface{}{
+				"canaryTrafficPercent": percent,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Setting canaryTrafficPercent=%d on InferenceService %s", percent, c.isvcName)
+	_, err = c.client.Resource(c.gvr).Namespace(c.namespace).Patch(context.Background(), c.isvcName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch InferenceService traffic: %w", err)
+	}
+	action := "traffic_updated"
+	if percent == 100 {
+		action = "promoted"
+	}
+	return &Result{Action: action, Name: c.isvcName, Strategy: StrategyCanary, TrafficPercent: percent}, nil
+}
+
+// IsReady reports whether an InferenceService object has a Ready=True
+// condition.
+func IsReady(isvc map[string]interface{}) bool {
+	conditions, _, _ := unstructured.NestedSlice(isvc, "status", "conditions")
+	for _, raw := range conditions {
+		cond, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == "Ready" {
+			return cond["status"] == "True"
+		}
+	}
+	return false
+}
+
+// IsReadyAtLatestGeneration reports whether the InferenceService is Ready and
+// the controller has observed its current spec. Right after an update the
+// Ready condition still describes the previous generation until
+// status.observedGeneration catches up with metadata.generation.
+func IsReadyAtLatestGeneration(isvc map[string]interface{}) bool {
+	if !IsReady(isvc) {
+		return false
+	}
+	generation, _, _ := unstructured.NestedInt64(isvc, "metadata", "generation")
+	if generation == 0 {
+		return true
+	}
+	observed, found, _ := unstructured.NestedInt64(isvc, "status", "observedGeneration")
+	return found && observed >= generation
 }
 
 // DryRun renders the InferenceService and performs a server-side dry-run.
-func (c *Client) DryRun(model *catalog.Model) (*DryRunResult, error) {
-	isvc := buildInferenceService(c.namespace, c.isvcName, model, c.inferenceModelRoot)
+func (c *Client) DryRun(model *catalog.Model, prov *Provenance) (*DryRunResult, error) {
+	isvc := buildInferenceService(c.namespace, c.isvcName, model, c.inferenceModelRoot, prov)
 	manifest := deepCopyMap(isvc.Object)
 
 	ctx := context.Background()
 	action := "create"
 
-	_, err := c.client.Resource(c.gvr).Namespace(c.namespace).Create(ctx, isvc.DeepCopy(), metav1.CreateOptions{
+	applied, err := c.client.Resource(c.gvr).Namespace(c.namespace).Create(ctx, isvc.DeepCopy(), metav1.CreateOptions{
 		DryRun: []string{metav1.DryRunAll},
 	})
 	if err != nil {
@@ -128,7 +380,7 @@ func (c *Client) DryRun(model *catalog.Model) (*DryRunResult, error) {
 				return nil, fmt.Errorf("failed to fetch existing InferenceService: %w", getErr)
 			}
 			isvc.SetResourceVersion(existing.GetResourceVersion())
-			_, err = c.client.Resource(c.gvr).Namespace(c.namespace).Update(ctx, isvc.DeepCopy(), metav1.UpdateOptions{
+			applied, err = c.client.Resource(c.gvr).Namespace(c.namespace).Update(ctx, isvc.DeepCopy(), metav1.UpdateOptions{
 				DryRun: []string{metav1.DryRunAll},
 			})
 		}
@@ -137,10 +389,15 @@ func (c *Client) DryRun(model *catalog.Model) (*DryRunResult, error) {
 		}
 	}
 
-	return &DryRunResult{
+	result := &DryRunResult{
 		Action:   action,
 		Manifest: manifest,
-	}, nil
+		Applied:  manifest,
+	}
+	if applied != nil {
+		result.Applied = applied.UnstructuredContent()
+	}
+	return result, nil
 }
 
 // Deactivate deletes the active InferenceService.
@@ -176,7 +433,7 @@ func (c *Client) GetActive() (map[string]interface{}, error) {
 	return result.UnstructuredContent(), nil
 }
 
-func buildInferenceService(namespace, name string, model *catalog.Model, inferenceModelRoot string) *unstructured.Unstructured {
+func buildInferenceService(namespace, name string, model *catalog.Model, inferenceModelRoot string, prov *Provenance) *unstructured.Unstructured {
 	// Determine storage URI
 	storageURI := model.StorageURI
 	if storageURI == "" && model.HFModelID != "" {
@@ -261,6 +518,21 @@ func buildInferenceService(namespace, name string, model *catalog.Model, inferen
 	if pvcStorage {
 		annotations["storage.kserve.io/readonly"] = "false"
 	}
+	hfModelID := model.HFModelID
+	if prov != nil {
+		if prov.HFModelID != "" {
+			hfModelID = prov.HFModelID
+		}
+		if prov.Revision != "" {
+			annotations["model-manager/revision"] = prov.Revision
+		}
+		if !prov.InstalledAt.IsZero() {
+			annotations["model-manager/installed-at"] = prov.InstalledAt.UTC().Format(time.RFC3339)
+		}
+	}
+	if hfModelID != "" {
+		annotations["model-manager/hf-model-id"] = hfModelID
+	}
 
 	spec := map[string]interface{}{
 		"predictor": predictor,
@@ -283,8 +555,9 @@ func buildInferenceService(namespace, name string, model *catalog.Model, inferen
 }
 
 // RenderManifest returns the raw InferenceService manifest without applying it.
-func (c *Client) RenderManifest(model *catalog.Model) map[string]interface{} {
-	isvc := buildInferenceService(c.namespace, c.isvcName, model, c.inferenceModelRoot)
+// prov may be nil when the weight metadata is unknown.
+func (c *Client) RenderManifest(model *catalog.Model, prov *Provenance) map[string]interface{} {
+	isvc := buildInferenceService(c.namespace, c.isvcName, model, c.inferenceModelRoot, prov)
 	return deepCopyMap(isvc.Object)
 }
 
@@ -316,6 +589,10 @@ func buildVLLMArgs(model *catalog.Model) []string {
 		if vllm.TrustRemoteCode != nil && *vllm.TrustRemoteCode {
 			args = append(args, "--trust-remote-code")
 		}
+
+		if vllm.Quantization != "" {
+			args = append(args, "--quantization", vllm.Quantization)
+		}
 	}
 
 	var servedName string
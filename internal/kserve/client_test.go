@@ -3,9 +3,15 @@ package kserve
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 )
 
 func TestBuildVLLMArgsIncludesExtraAndServedName(t *testing.T) {
@@ -23,6 +29,7 @@ func TestBuildVLLMArgsIncludesExtraAndServedName(t *testing.T) {
 			GPUMemoryUtilization: &gpuUtil,
 			MaxModelLen:          &maxLen,
 			TrustRemoteCode:      &trust,
+			Quantization:         "awq",
 			ExtraArgs: []string{
 				"--speculative-decoding",
 				"eagle",
@@ -40,6 +47,7 @@ func TestBuildVLLMArgsIncludesExtraAndServedName(t *testing.T) {
 		"--gpu-memory-utilization", fmt.Sprintf("%f", gpuUtil),
 		"--max-model-len", "2048",
 		"--trust-remote-code",
+		"--quantization", "awq",
 		"--served-model-name", "Repo/Model",
 		"--speculative-decoding",
 		"eagle",
@@ -66,3 +74,230 @@ func TestBuildVLLMArgsFallsBackToHFID(t *testing.T) {
 		t.Fatalf("expected fallback served name.\nwant: %#v\n got: %#v", want, got)
 	}
 }
+
+func TestActivateCanarySplitsTrafficAndPromotes(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		InferenceServiceGVR(): "InferenceServiceList",
+	})
+	client := NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+
+	stable := &catalog.Model{ID: "stable", HFModelID: "org/stable"}
+	result, err := client.Activate(stable, ActivateOptions{Strategy: StrategyCanary, TrafficPercent: 20})
+	if err != nil {
+		t.Fatalf("Activate stable: %v", err)
+	}
+	if result.Action != "created" || result.Strategy != StrategyDirect {
+		t.Fatalf("expected first activation to create directly, got %+v", result)
+	}
+
+	candidate := &catalog.Model{ID: "candidate", HFModelID: "org/candidate"}
+	result, err = client.Activate(candidate, ActivateOptions{Strategy: StrategyCanary, TrafficPercent: 20})
+	if err != nil {
+		t.Fatalf("Activate candidate: %v", err)
+	}
+	if result.Strategy != StrategyCanary || result.TrafficPercent != 20 {
+		t.Fatalf("expected canary result at 20%%, got %+v", result)
+	}
+	percent, ok, err := client.CanaryTrafficPercent()
+	if err != nil || !ok || percent != 20 {
+		t.Fatalf("expected canaryTrafficPercent 20, got %d ok=%v err=%v", percent, ok, err)
+	}
+
+	if _, err := client.SetTrafficPercent(100); err != nil {
+		t.Fatalf("SetTrafficPercent: %v", err)
+	}
+	percent, ok, err = client.CanaryTrafficPercent()
+	if err != nil || !ok || percent != 100 {
+		t.Fatalf("expected canaryTrafficPercent 100 after promote, got %d ok=%v err=%v", percent, ok, err)
+	}
+	active, err := client.GetActive()
+	if err != nil {
+		t.Fatalf("GetActive: %v", err)
+	}
+	if got := active["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})["model-manager/model-id"]; got != "candidate" {
+		t.Fatalf("expected patch to keep the candidate spec, got model-id %v", got)
+	}
+
+	if _, err := client.Activate(stable, ActivateOptions{}); err != nil {
+		t.Fatalf("Activate direct: %v", err)
+	}
+	if _, ok, _ := client.CanaryTrafficPercent(); ok {
+		t.Fatalf("expected direct activation to clear the canary split")
+	}
+}
+
+func TestNormalizeActivateOptionsRejectsInvalidSplit(t *testing.T) {
+	if _, err := NormalizeActivateOptions(ActivateOptions{Strategy: StrategyCanary, TrafficPercent: 100}); err == nil {
+		t.Fatalf("expected error for 100%% canary")
+	}
+	if _, err := NormalizeActivateOptions(ActivateOptions{Strategy: "bluegreen"}); err == nil {
+		t.Fatalf("expected error for unknown strategy")
+	}
+	opts, err := NormalizeActivateOptions(ActivateOptions{Strategy: "Canary"})
+	if err != nil || opts.TrafficPercent != DefaultCanaryTrafficPercent {
+		t.Fatalf("expected default canary split, got %+v err=%v", opts, err)
+	}
+}
+
+func TestIsReadyAtLatestGenerationRequiresObservedSpec(t *testing.T) {
+	isvc := func(generation, observed int64, ready string) map[string]interface{} {
+		obj := map[string]interface{}{
+			"metadata": map[string]interface{}{},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": ready}},
+			},
+		}
+		if generation > 0 {
+			obj["metadata"].(map[string]interface{})["generation"] = generation
+		}
+		if observed > 0 {
+			obj["status"].(map[string]interface{})["observedGeneration"] = observed
+		}
+		return obj
+	}
+	cases := []struct {
+		name string
+		isvc map[string]interface{}
+		want bool
+	}{
+		{"current and ready", isvc(3, 3, "True"), true},
+		{"ready condition from previous generation", isvc(3, 2, "True"), false},
+		{"observed generation missing", isvc(3, 0, "True"), false},
+		{"current but not ready", isvc(3, 3, "False"), false},
+		{"no generation tracking", isvc(0, 0, "True"), true},
+	}
+	for _, tc := range cases {
+		if got := IsReadyAtLatestGeneration(tc.isvc); got != tc.want {
+			t.Errorf("%s: got %v want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRenderManifestStampsProvenance(t *testing.T) {
+	client := &Client{namespace: "ai", isvcName: "active-llm", inferenceModelRoot: "/mnt/models"}
+	model := &catalog.Model{ID: "qwen", HFModelID: "Qwen/Qwen2.5-0.5B", StorageURI: "pvc://weights/Qwen/Qwen2.5-0.5B"}
+	installedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	manifest := client.RenderManifest(model, &Provenance{Revision: "abc123", InstalledAt: installedAt})
+	annotations := manifest["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+	want := map[string]string{
+		"model-manager/model-id":     "qwen",
+		"model-manager/hf-model-id":  "Qwen/Qwen2.5-0.5B",
+		"model-manager/revision":     "abc123",
+		"model-manager/installed-at": "2025-03-01T12:00:00Z",
+	}
+	for key, value := range want {
+		if annotations[key] != value {
+			t.Fatalf("annotation %s = %v, want %s", key, annotations[key], value)
+		}
+	}
+
+	manifest = client.RenderManifest(model, nil)
+	annotations = manifest["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+	if _, ok := annotations["model-manager/revision"]; ok {
+		t.Fatalf("expected no revision annotation without provenance")
+	}
+	if annotations["model-manager/hf-model-id"] != "Qwen/Qwen2.5-0.5B" {
+		t.Fatalf("expected hf-model-id from the catalog entry, got %v", annotations["model-manager/hf-model-id"])
+	}
+}
+
+func TestRenderManifestEmitsSecretKeyRefEnv(t *testing.T) {
+	client := &Client{namespace: "ai", isvcName: "active-llm", inferenceModelRoot: "/mnt/models"}
+	model := &catalog.Model{
+		ID:        "qwen",
+		HFModelID: "Qwen/Qwen2.5-0.5B",
+		Env: []catalog.EnvVar{
+			{Name: "HF_HUB_OFFLINE", Value: "1"},
+			{
+				Name: "HF_TOKEN",
+				ValueFrom: &catalog.EnvVarSource{
+					SecretKeyRef: &catalog.SecretKeySelector{Name: "hf-token", Key: "token"},
+				},
+			},
+		},
+	}
+
+	manifest := client.RenderManifest(model, nil)
+	env := manifest["spec"].(map[string]interface{})["predictor"].(map[string]interface{})["model"].(map[string]interface{})["env"].([]interface{})
+	want := []interface{}{
+		map[string]interface{}{"name": "HF_HUB_OFFLINE", "value": "1"},
+		map[string]interface{}{
+			"name": "HF_TOKEN",
+			"valueFrom": map[string]interface{}{
+				"secretKeyRef": map[string]interface{}{"name": "hf-token", "key": "token"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Fatalf("unexpected env.\nwant: %#v\n got: %#v", want, env)
+	}
+}
+
+func TestActivateCanaryPatchesOnlyManagedFields(t *testing.T) {
+	existing := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1beta1",
+		"kind":       "InferenceService",
+		"metadata": map[string]interface{}{
+			"name":      "active-llm",
+			"namespace": "ai",
+			"annotations": map[string]interface{}{
+				"model-manager/model-id": "stable",
+				"model-manager/revision": "abc123",
+				"team.example.com/owner": "ml-platform",
+			},
+		},
+		"spec": map[string]interface{}{
+			"predictor": map[string]interface{}{
+				"minReplicas":        int64(1),
+				"serviceAccountName": "inference",
+				"nodeSelector":       map[string]interface{}{"gpu": "a100"},
+				"model": map[string]interface{}{
+					"runtime":    "vllm-runtime",
+					"storageUri": "hf://org/stable",
+					"args":       []interface{}{"--served-model-name=stable"},
+				},
+			},
+			"transformer": map[string]interface{}{"minReplicas": int64(1)},
+		},
+	}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		InferenceServiceGVR(): "InferenceServiceList",
+	}, existing)
+	client := NewClientWithDynamic(dyn, "ai", "active-llm", "/mnt/models")
+
+	candidate := &catalog.Model{ID: "candidate", HFModelID: "org/candidate"}
+	if _, err := client.Activate(candidate, ActivateOptions{Strategy: StrategyCanary, TrafficPercent: 25}); err != nil {
+		t.Fatalf("Activate candidate: %v", err)
+	}
+	active, err := client.GetActive()
+	if err != nil {
+		t.Fatalf("GetActive: %v", err)
+	}
+	obj := &unstructured.Unstructured{Object: active}
+	annotations := obj.GetAnnotations()
+	if annotations["model-manager/model-id"] != "candidate" || annotations["team.example.com/owner"] != "ml-platform" {
+		t.Fatalf("expected model annotations updated and others kept, got %v", annotations)
+	}
+	if _, ok := annotations["model-manager/revision"]; ok {
+		t.Fatalf("expected the stale revision annotation to be removed, got %v", annotations)
+	}
+	if account, _, _ := unstructured.NestedString(active, "spec", "predictor", "serviceAccountName"); account != "inference" {
+		t.Fatalf("expected unmanaged predictor fields kept, got %q", account)
+	}
+	if _, found, _ := unstructured.NestedMap(active, "spec", "transformer"); !found {
+		t.Fatalf("expected the transformer to be left alone")
+	}
+	if _, found, _ := unstructured.NestedMap(active, "spec", "predictor", "nodeSelector"); found {
+		t.Fatalf("expected the old model's nodeSelector to be removed")
+	}
+	if uri, _, _ := unstructured.NestedString(active, "spec", "predictor", "model", "storageUri"); uri != "hf://org/candidate" {
+		t.Fatalf("expected the candidate model, got storageUri %q", uri)
+	}
+	if args, _, _ := unstructured.NestedSlice(active, "spec", "predictor", "model", "args"); len(args) == 0 || !strings.Contains(fmt.Sprint(args), "candidate") {
+		t.Fatalf("expected the candidate's args, got %v", args)
+	}
+	if percent, ok, err := client.CanaryTrafficPercent(); err != nil || !ok || percent != 25 {
+		t.Fatalf("expected canaryTrafficPercent 25, got %d ok=%v err=%v", percent, ok, err)
+	}
+}
@@ -0,0 +1,140 @@
+package kserve
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change operations reported by DiffManifest.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// ManifestChange is a single field activation would mutate on the live
+// InferenceService.
+type ManifestChange struct {
+	Path   string      `json:"path"`
+	Op     string      `json:"op"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// diffRoots are the parts of an InferenceService the manager owns. Status and
+// server-populated metadata (resourceVersion, managedFields, ...) are ignored.
+var diffRoots = [][]string{
+	{"metadata", "labels"},
+	{"metadata", "annotations"},
+	{"spec"},
+}
+
+// DiffManifest compares the live InferenceService with the desired manifest
+// and returns the changed paths, sorted. Lists of objects keyed by "name"
+// (env, volumes, volumeMounts) are matched by name so reordering is not a
+// change; other lists are compared as a whole. A nil live object yields no
+// changes since activation would create rather than update.
+func DiffManifest(live, desired map[string]interface{}) []ManifestChange {
+	if live == nil || desired == nil {
+		return nil
+	}
+	live = ensureJSONObject(live)
+	desired = ensureJSONObject(desired)
+
+	var changes []ManifestChange
+	for _, root := range diffRoots {
+		before, _ := nestedValue(live, root)
+		after, _ := nestedValue(desired, root)
+		diffValue(strings.Join(root, "."), before, after, &changes)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func nestedValue(obj map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = obj
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func diffValue(path string, before, after interface{}, changes *[]ManifestChange) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		*changes = append(*changes, ManifestChange{Path: path, Op: ChangeAdded, After: after})
+		return
+	case after == nil:
+		*changes = append(*changes, ManifestChange{Path: path, Op: ChangeRemoved, Before: before})
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := make(map[string]struct{}, len(beforeMap)+len(afterMap))
+		for k := range beforeMap {
+			keys[k] = struct{}{}
+		}
+		for k := range afterMap {
+			keys[k] = struct{}{}
+		}
+		for k := range keys {
+			diffValue(path+"."+k, beforeMap[k], afterMap[k], changes)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList {
+		beforeByName, okBefore := namedItems(beforeList)
+		afterByName, okAfter := namedItems(afterList)
+		if okBefore && okAfter {
+			for name := range beforeByName {
+				if _, ok := afterByName[name]; !ok {
+					afterByName[name] = nil
+				}
+			}
+			for name, item := range afterByName {
+				diffValue(fmt.Sprintf("%s[%s]", path, name), beforeByName[name], item, changes)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, ManifestChange{Path: path, Op: ChangeModified, Before: before, After: after})
+	}
+}
+
+// namedItems indexes a list of objects by their "name" field. It reports false
+// if any element is not an object with a unique, non-empty name.
+func namedItems(list []interface{}) (map[string]interface{}, bool) {
+	items := make(map[string]interface{}, len(list))
+	for _, raw := range list {
+		item, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, _ := item["name"].(string)
+		if name == "" {
+			return nil, false
+		}
+		if _, dup := items[name]; dup {
+			return nil, false
+		}
+		items[name] = item
+	}
+	return items, true
+}
@@ -0,0 +1,54 @@
+package kserve
+
+import (
+	"testing"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+)
+
+func TestDiffManifestReportsChangedPaths(t *testing.T) {
+	client := &Client{namespace: "ai", isvcName: "active-llm", inferenceModelRoot: "/mnt/models"}
+	live := client.RenderManifest(&catalog.Model{
+		ID:         "old",
+		HFModelID:  "org/old",
+		StorageURI: "hf://org/old",
+		Env: []catalog.EnvVar{
+			{Name: "A", Value: "1"},
+			{Name: "B", Value: "2"},
+		},
+	}, nil)
+	live["status"] = map[string]interface{}{"url": "http://active-llm"}
+	live["metadata"].(map[string]interface{})["resourceVersion"] = "42"
+
+	desired := client.RenderManifest(&catalog.Model{
+		ID:         "new",
+		HFModelID:  "org/old",
+		StorageURI: "hf://org/new",
+		Env: []catalog.EnvVar{
+			{Name: "B", Value: "3"},
+			{Name: "A", Value: "1"},
+		},
+	}, nil)
+
+	got := map[string]string{}
+	for _, change := range DiffManifest(live, desired) {
+		got[change.Path] = change.Op
+	}
+	want := map[string]string{
+		"metadata.annotations.model-manager/model-id": ChangeModified,
+		"spec.predictor.model.storageUri":             ChangeModified,
+		"spec.predictor.model.env[B].value":           ChangeModified,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), got)
+	}
+	for path, op := range want {
+		if got[path] != op {
+			t.Fatalf("expected %s %s, got %v", path, op, got)
+		}
+	}
+
+	if changes := DiffManifest(nil, desired); changes != nil {
+		t.Fatalf("expected no diff without a live object, got %v", changes)
+	}
+}
@@ -1,6 +1,7 @@
 package logutil
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"time"
@@ -41,3 +42,23 @@ func logJSON(level, msg string, fields map[string]interface{}) {
 	}
 	log.Printf("%s", payload)
 }
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID of the HTTP call
+// that started the work.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID stored by WithRequestID, or "".
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -40,6 +40,11 @@ var (
 		Help: "Current active SSE connections",
 	})
 
+	wsConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "model_manager_websocket_connections",
+		Help: "Current active WebSocket event stream connections",
+	})
+
 	sseEventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "model_manager_sse_events_total",
 		Help: "Total SSE events streamed grouped by type",
@@ -49,6 +54,37 @@ var (
 		Name: "model_manager_job_queue_depth",
 		Help: "Approximate pending depth of the job queue",
 	})
+
+	installsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "model_manager_installs_total",
+		Help: "Weight installs that reached a terminal state grouped by result",
+	}, []string{"result"})
+
+	activationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "model_manager_activations_total",
+		Help: "Model activations grouped by result",
+	}, []string{"result"})
+
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "model_manager_queue_depth",
+		Help: "Length of the job stream as sampled by the worker",
+	})
+
+	queuePending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "model_manager_queue_pending",
+		Help: "Job stream entries delivered to the worker group but not yet acknowledged",
+	})
+
+	notificationsDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "model_manager_notifications_dropped_total",
+		Help: "Notifications dropped because the delivery queue was full or closed",
+	})
+
+	jobProcessing = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "model_manager_job_processing_seconds",
+		Help:    "Wall-clock time the worker spent executing each job",
+		Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600},
+	}, []string{"type", "outcome"})
 )
 
 // ObserveJobCompletion records the duration and status of a completed job.
@@ -84,6 +120,15 @@ func TrackSSEConnection() func() {
 	}
 }
 
+// TrackWebSocketConnection increments the WebSocket connection gauge and
+// returns a cleanup function.
+func TrackWebSocketConnection() func() {
+	wsConnections.Inc()
+	return func() {
+		wsConnections.Dec()
+	}
+}
+
 // ObserveSSEEvent increments the SSE event counter for the provided type.
 func ObserveSSEEvent(eventType string) {
 	if eventType == "" {
@@ -99,3 +144,53 @@ func SetJobQueueDepth(depth int64) {
 	}
 	jobQueueDepth.Set(float64(depth))
 }
+
+// SetQueueDepth records the job stream length sampled by the worker.
+func SetQueueDepth(depth int64) {
+	if depth < 0 {
+		return
+	}
+	queueDepth.Set(float64(depth))
+}
+
+// SetQueuePending records the worker group's pending-entries count.
+func SetQueuePending(pending int64) {
+	if pending < 0 {
+		return
+	}
+	queuePending.Set(float64(pending))
+}
+
+// ObserveJobProcessing records how long the worker spent executing a job.
+func ObserveJobProcessing(jobType, outcome string, duration time.Duration) {
+	if jobType == "" {
+		jobType = "unknown"
+	}
+	if outcome == "" {
+		outcome = "unknown"
+	}
+	jobProcessing.WithLabelValues(jobType, outcome).Observe(duration.Seconds())
+}
+
+// ObserveNotificationDropped counts a notification that was never delivered
+// because the queue was full or shut down.
+func ObserveNotificationDropped() {
+	notificationsDropped.Inc()
+}
+
+// ObserveInstall counts a weight install that finished successfully or failed.
+func ObserveInstall(success bool) {
+	installsTotal.WithLabelValues(resultLabel(success)).Inc()
+}
+
+// ObserveActivation counts a model activation attempt by result.
+func ObserveActivation(success bool) {
+	activationsTotal.WithLabelValues(resultLabel(success)).Inc()
+}
+
+func resultLabel(success bool) string {
+	if success {
+		return "success"
+	}
+	return "failure"
+}
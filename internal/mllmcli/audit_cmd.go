@@ -57,7 +57,7 @@ var auditListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.Events) == 0 {
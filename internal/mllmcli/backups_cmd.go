@@ -32,7 +32,7 @@ var backupsListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.Backups) == 0 {
@@ -84,7 +84,7 @@ var backupsRecordCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Recorded backup %s -> %s\n", record.Type, record.Location)
@@ -0,0 +1,185 @@
+package mllmcli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/validator"
+	"github.com/spf13/cobra"
+)
+
+var catalogLintSchema string
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Work with local catalog files",
+}
+
+type catalogLintResult struct {
+	File    string                  `json:"file"`
+	ID      string                  `json:"id,omitempty"`
+	Valid   bool                    `json:"valid"`
+	Errors  []string                `json:"errors,omitempty"`
+	Checks  []validator.CheckResult `json:"checks,omitempty"`
+	Skipped []string                `json:"skipped,omitempty"`
+}
+
+var catalogLintCmd = &cobra.Command{
+	Use:   "lint [path]",
+	Short: "Validate local catalog model files before pushing",
+	Long: `Runs the server's catalog validation against model JSON files: the JSON
+schema (--schema), lifecycle, resource quantities, and env var shape. Checks
+that need the cluster (PVC, cached weights, secrets, configmaps, GPU capacity)
+are reported as skipped. path is a model file or a directory of *.json files
+(default: the current directory, or its models/ subdirectory). Exits non-zero
+when any file fails, for CI.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) == 1 {
+			path = args[0]
+		}
+		files, err := catalogFiles(path)
+		if err != nil {
+			exitWithError(cmd, err)
+			return err
+		}
+		v, err := validator.New(validator.Options{SchemaPath: catalogLintSchema, Offline: true})
+		if err != nil {
+			exitWithError(cmd, err)
+			return err
+		}
+
+		results := make([]catalogLintResult, 0, len(files))
+		seen := make(map[string]string)
+		failed := 0
+		for _, file := range files {
+			result := lintCatalogFile(cmd, v, file)
+			if result.ID != "" {
+				if other, ok := seen[result.ID]; ok {
+					result.Valid = false
+					result.Errors = append(result.Errors, fmt.Sprintf("id %q is also used by %s", result.ID, other))
+				} else {
+					seen[result.ID] = file
+				}
+			}
+			if !result.Valid {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		if machineOutput() {
+			if err := writeOutput(cmd, results); err != nil {
+				exitWithError(cmd, err)
+				return err
+			}
+		} else {
+			tw := newTable()
+			fmt.Fprintf(tw, "FILE\tID\tRESULT\tSKIPPED\tISSUES\n")
+			for _, result := range results {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+					result.File,
+					valueOrDash(result.ID),
+					lintStatus(result),
+					valueOrDash(strings.Join(result.Skipped, ",")),
+					valueOrDash(strings.Join(lintIssues(result), "; ")))
+			}
+			flushTable(tw)
+			fmt.Fprintf(cmd.OutOrStdout(), "\n%d file(s) checked, %d failed.\n", len(results), failed)
+		}
+		if failed > 0 {
+			return errors.New("catalog lint failed")
+		}
+		return nil
+	},
+}
+
+// catalogFiles resolves the lint path to model files, preferring a models/
+// subdirectory when the directory itself holds no JSON.
+func catalogFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		files, err = filepath.Glob(filepath.Join(path, "models", "*.json"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no model files (*.json) found in %s", path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func lintCatalogFile(cmd *cobra.Command, v *validator.Validator, file string) catalogLintResult {
+	result := catalogLintResult{File: file}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	var model catalog.Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("invalid JSON: %v", err))
+		return result
+	}
+	result.ID = model.ID
+	if model.ID == "" {
+		result.Errors = append(result.Errors, "model config missing 'id' field")
+		return result
+	}
+	validation := v.Validate(cmd.Context(), data, &model)
+	result.Valid = validation.Valid
+	result.Errors = validation.Errors
+	result.Checks = validation.Checks
+	for _, check := range validation.Checks {
+		if check.Status == validator.StatusSkip {
+			result.Skipped = append(result.Skipped, check.Name)
+		}
+	}
+	return result
+}
+
+func lintStatus(result catalogLintResult) string {
+	if !result.Valid {
+		return string(validator.StatusFail)
+	}
+	for _, check := range result.Checks {
+		if check.Status == validator.StatusWarn {
+			return string(validator.StatusWarn)
+		}
+	}
+	return string(validator.StatusPass)
+}
+
+func lintIssues(result catalogLintResult) []string {
+	issues := append([]string{}, result.Errors...)
+	for _, check := range result.Checks {
+		if check.Status == validator.StatusFail || check.Status == validator.StatusWarn {
+			issues = append(issues, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+	return issues
+}
+
+func init() {
+	catalogLintCmd.Flags().StringVar(&catalogLintSchema, "schema", "", "JSON schema the model files must satisfy (the server's MODEL_CATALOG_SCHEMA_PATH)")
+	catalogCmd.AddCommand(catalogLintCmd)
+}
@@ -40,7 +40,7 @@ var cleanupWeightsCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -168,8 +168,14 @@ func (c *Client) Delete(path string) error {
 
 // StreamEvents opens the SSE feed and invokes handler for each event. Returning false stops the stream.
 func (c *Client) StreamEvents(ctx context.Context, handler func(EventEnvelope) bool) error {
+	return c.StreamSSE(ctx, "/events", handler)
+}
+
+// StreamSSE opens an SSE endpoint and invokes handler for each event. It returns
+// nil when the server closes the stream or handler returns false.
+func (c *Client) StreamSSE(ctx context.Context, path string, handler func(EventEnvelope) bool) error {
 	base := strings.TrimRight(c.BaseURL, "/")
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/events", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
 	if err != nil {
 		return err
 	}
@@ -184,7 +190,7 @@ func (c *Client) StreamEvents(ctx context.Context, handler func(EventEnvelope) b
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 300 {
-		return fmt.Errorf("GET %s failed: %s", "/events", resp.Status)
+		return fmt.Errorf("GET %s failed: %s", path, resp.Status)
 	}
 
 	reader := bufio.NewReader(resp.Body)
@@ -0,0 +1,138 @@
+package mllmcli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Prints a completion script for the given shell. Model IDs, weight names, and
+context names complete dynamically from the current context's server.
+
+  bash:  source <(mllm completion bash)
+         mllm completion bash > /etc/bash_completion.d/mllm
+  zsh:   mllm completion zsh > "${fpath[1]}/_mllm"
+  fish:  mllm completion fish > ~/.config/fish/completions/mllm.fish
+  powershell:
+         mllm completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(out)
+		case "fish":
+			return rootCmd.GenFishCompletion(out, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(out)
+		}
+		return fmt.Errorf("unsupported shell %q", args[0])
+	},
+}
+
+// completionClient returns a client for dynamic completions. Completion runs
+// skip the root pre-run hook, so the config file is loaded here, and a short
+// timeout keeps an unreachable server from hanging the shell.
+func completionClient() (*Client, bool) {
+	if appConfig == nil {
+		cfg, err := LoadConfig(cfgFile)
+		if err != nil {
+			return nil, false
+		}
+		appConfig = cfg
+	}
+	client, _, err := mustClient()
+	if err != nil {
+		return nil, false
+	}
+	client.Timeout = 3 * time.Second
+	return client, true
+}
+
+// completeModelIDs completes catalog model IDs, with display names as
+// descriptions, for the first n positional arguments.
+func completeModelIDs(n int) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		client, ok := completionClient()
+		if !ok {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var resp struct {
+			Models []ModelSummary `json:"models"`
+		}
+		if err := client.GetJSON("/models", &resp); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		completions := make([]string, 0, len(resp.Models))
+		for _, model := range resp.Models {
+			if model.DisplayName != "" && model.DisplayName != model.ID {
+				completions = append(completions, model.ID+"\t"+model.DisplayName)
+				continue
+			}
+			completions = append(completions, model.ID)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeWeightNames completes installed weight directory names.
+func completeWeightNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var resp struct {
+		Weights []WeightRecord `json:"weights"`
+	}
+	if err := client.GetJSON("/weights", &resp); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(resp.Weights))
+	for _, w := range resp.Weights {
+		completions = append(completions, w.Name+"\t"+w.SizeHuman)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContextNames completes context names from the local config file.
+func completeContextNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := LoadConfig(cfgFile)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(cfg.Contexts))
+	for name, ctx := range cfg.Contexts {
+		names = append(names, name+"\t"+ctx.Server)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+
+	for _, cmd := range []*cobra.Command{modelsGetCmd, recommendCompatCmd, recommendBestCmd, runtimeActivateCmd, runtimeSwitchCmd} {
+		cmd.ValidArgsFunction = completeModelIDs(1)
+	}
+	modelsCompareCmd.ValidArgsFunction = completeModelIDs(2)
+	weightsInfoCmd.ValidArgsFunction = completeWeightNames
+	weightsDeleteCmd.ValidArgsFunction = completeWeightNames
+	configUseContextCmd.ValidArgsFunction = completeContextNames
+}
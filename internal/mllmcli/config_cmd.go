@@ -1,14 +1,20 @@
 package mllmcli
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
+	serverconfig "github.com/oremus-labs/ol-model-manager/config"
 	"github.com/spf13/cobra"
 )
 
+var configValidateSkipNetwork bool
+
 var configCmd = &cobra.Command{
 	Use:   "config",
-	Short: "Manage CLI configuration",
+	Short: "Manage CLI configuration and check server settings",
 }
 
 var configSetContextCmd = &cobra.Command{
@@ -95,8 +101,8 @@ var configViewCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			if err := printJSON(cfg); err != nil {
+		if machineOutput() {
+			if err := writeOutput(cmd, cfg); err != nil {
 				exitWithError(cmd, err)
 			}
 			return
@@ -112,7 +118,80 @@ var configViewCmd = &cobra.Command{
 	},
 }
 
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the server configuration resolved from the environment, secrets redacted",
+	Long: `Loads the model manager server configuration from the current environment
+the same way the server does and prints the resolved values. Run it inside the
+server pod (kubectl exec) to see what a deployment actually uses.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := serverconfig.Load().Redacted()
+		if machineOutput() {
+			if err := writeOutput(cmd, cfg); err != nil {
+				exitWithError(cmd, err)
+			}
+			return
+		}
+		tw := newTable()
+		fmt.Fprintln(tw, "SETTING\tVALUE")
+		value := reflect.ValueOf(cfg)
+		for i := 0; i < value.NumField(); i++ {
+			text := fmt.Sprint(value.Field(i).Interface())
+			if list, ok := value.Field(i).Interface().([]string); ok {
+				text = strings.Join(list, ",")
+			}
+			if text == "" {
+				text = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", value.Type().Field(i).Name, text)
+		}
+		flushTable(tw)
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the server configuration in the environment for broken settings",
+	Long: `Loads the server configuration from the current environment and reports
+unsupported values, missing paths, and an unreachable datastore or Redis. Paths
+and connectivity are checked from where the command runs, so run it inside the
+server pod. Exits non-zero when any error is found.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issues := serverconfig.Load().Validate(serverconfig.ValidateOptions{CheckNetwork: !configValidateSkipNetwork})
+		errorCount := 0
+		for _, issue := range issues {
+			if issue.Severity == serverconfig.SeverityError {
+				errorCount++
+			}
+		}
+		if machineOutput() {
+			if issues == nil {
+				issues = []serverconfig.Issue{}
+			}
+			if err := writeOutput(cmd, map[string]interface{}{"valid": errorCount == 0, "issues": issues}); err != nil {
+				exitWithError(cmd, err)
+				return err
+			}
+		} else if len(issues) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "Configuration OK.")
+		} else {
+			tw := newTable()
+			fmt.Fprintln(tw, "SEVERITY\tSETTING\tMESSAGE")
+			for _, issue := range issues {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", issue.Severity, issue.Setting, issue.Message)
+			}
+			flushTable(tw)
+			fmt.Fprintf(cmd.OutOrStdout(), "\n%d error(s), %d warning(s).\n", errorCount, len(issues)-errorCount)
+		}
+		if errorCount > 0 {
+			return errors.New("configuration is invalid")
+		}
+		return nil
+	},
+}
+
 func init() {
+	configValidateCmd.Flags().BoolVar(&configValidateSkipNetwork, "skip-network", false, "Don't dial the datastore or Redis")
 	configSetContextCmd.Flags().String("server", "", "API server URL")
 	configSetContextCmd.Flags().String("token", "", "API token")
 	configSetContextCmd.Flags().String("namespace", "ai", "Default namespace")
@@ -121,4 +200,6 @@ func init() {
 	configCmd.AddCommand(configUseContextCmd)
 	configCmd.AddCommand(configCurrentContextCmd)
 	configCmd.AddCommand(configViewCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
 }
@@ -23,6 +23,8 @@ var (
 	jobsStatus  string
 	jobsType    string
 	jobsModelID string
+	jobsSince   string
+	jobsUntil   string
 )
 
 var jobsListCmd = &cobra.Command{
@@ -47,6 +49,12 @@ var jobsListCmd = &cobra.Command{
 		if jobsModelID != "" {
 			query.Set("modelId", jobsModelID)
 		}
+		if jobsSince != "" {
+			query.Set("since", jobsSince)
+		}
+		if jobsUntil != "" {
+			query.Set("until", jobsUntil)
+		}
 		path := "/jobs"
 		if len(query) > 0 {
 			path += "?" + query.Encode()
@@ -62,8 +70,7 @@ var jobsListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(resp.Jobs)
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -99,8 +106,7 @@ var jobsGetCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(job)
+		if machineOutput() {
 			return
 		}
 		printJobDetails(cmd, job)
@@ -192,6 +198,14 @@ var jobsLogsCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
+		if jobLogsFollow {
+			// The stream replays recorded entries before following new ones.
+			err := streamJobLogs(cmd.Context(), client, args[0], cmd.OutOrStdout())
+			if err != nil && cmd.Context().Err() == nil {
+				exitWithError(cmd, err)
+			}
+			return
+		}
 		var resp struct {
 			Logs []JobLogEntry `json:"logs"`
 		}
@@ -200,13 +214,6 @@ var jobsLogsCmd = &cobra.Command{
 			return
 		}
 		printJobLogs(cmd, resp.Logs)
-		if jobLogsFollow {
-			fmt.Fprintln(cmd.OutOrStdout(), "--- streaming new log entries ---")
-			err := streamJobLogs(cmd.Context(), client, args[0], cmd.OutOrStdout())
-			if err != nil && cmd.Context().Err() == nil {
-				exitWithError(cmd, err)
-			}
-		}
 	},
 }
 
@@ -215,6 +222,8 @@ func init() {
 	jobsListCmd.Flags().StringVar(&jobsStatus, "status", "", "Filter by status (pending|running|completed|failed)")
 	jobsListCmd.Flags().StringVar(&jobsType, "type", "", "Filter by job type")
 	jobsListCmd.Flags().StringVar(&jobsModelID, "model-id", "", "Filter by model ID")
+	jobsListCmd.Flags().StringVar(&jobsSince, "since", "", "Only jobs created at or after this time (duration like 24h or RFC3339)")
+	jobsListCmd.Flags().StringVar(&jobsUntil, "until", "", "Only jobs created at or before this time (duration like 1h or RFC3339)")
 	jobsWatchCmd.Flags().DurationVar(&jobWatchTimeout, "timeout", 0, "Stop watching after the specified duration (0 = wait forever)")
 	jobsRetryCmd.Flags().BoolVar(&jobRetryWatch, "watch", false, "Watch the job after retrying")
 	jobsLogsCmd.Flags().BoolVar(&jobLogsFollow, "follow", false, "Stream new log entries")
@@ -292,6 +301,13 @@ func shortID(id string) string {
 }
 
 func watchJob(ctx context.Context, cmd *cobra.Command, client *Client, jobID string) error {
+	_, err := followJob(ctx, cmd, client, jobID)
+	return err
+}
+
+// followJob prints progress updates for jobID until it reaches a terminal
+// status and returns the final job.
+func followJob(ctx context.Context, cmd *cobra.Command, client *Client, jobID string) (*Job, error) {
 	fmt.Fprintf(cmd.OutOrStdout(), "Watching job %s...\n", jobID)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -401,7 +417,7 @@ func watchJob(ctx context.Context, cmd *cobra.Command, client *Client, jobID str
 	if finalJob == nil {
 		job, err := fetchJob(client, jobID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		finalJob = job
 	}
@@ -410,7 +426,7 @@ func watchJob(ctx context.Context, cmd *cobra.Command, client *Client, jobID str
 	if finalJob.Error != "" {
 		fmt.Fprintf(cmd.OutOrStdout(), "Error: %s\n", finalJob.Error)
 	}
-	return nil
+	return finalJob, nil
 }
 
 func isTerminalStatus(status string) bool {
@@ -446,6 +462,9 @@ func printJobLogEntry(cmd *cobra.Command, entry JobLogEntry) {
 func streamJobLogs(ctx context.Context, client *Client, jobID string, out io.Writer) error {
 	handler := func(ev EventEnvelope) bool {
 		if ev.Type != "job.log" {
+			if status, ok := strings.CutPrefix(ev.Type, "job."); ok && isTerminalStatus(status) {
+				fmt.Fprintf(out, "--- job %s ---\n", status)
+			}
 			return true
 		}
 		var payload struct {
@@ -464,5 +483,5 @@ func streamJobLogs(ctx context.Context, client *Client, jobID string, out io.Wri
 			payload.Log.Message)
 		return true
 	}
-	return client.StreamEvents(ctx, handler)
+	return client.StreamSSE(ctx, fmt.Sprintf("/jobs/%s/logs/stream", url.PathEscape(jobID)), handler)
 }
@@ -29,7 +29,7 @@ var metricsTopCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		printMetricsSummary(cmd, resp)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -29,17 +30,19 @@ var modelsListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		var models []ModelSummary
-		if err := client.GetJSON("/models", &models); err != nil {
+		var resp struct {
+			Models []ModelSummary `json:"models"`
+		}
+		if err := client.GetJSON("/models", &resp); err != nil {
 			exitWithError(cmd, err)
 			return
 		}
+		models := resp.Models
 		if err := writeOutput(cmd, models); err != nil {
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(models)
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -75,8 +78,7 @@ var modelsGetCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(model)
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -161,8 +163,7 @@ var modelsValidateCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(result)
+		if machineOutput() {
 			return
 		}
 		printValidationSummary(result, cmd)
@@ -199,7 +200,7 @@ var modelsApplyCmd = &cobra.Command{
 			exitWithError(cmd, fmt.Errorf("validation failed"))
 			return
 		}
-		if outputFormat != "json" {
+		if !machineOutput() {
 			printValidationSummary(result, cmd)
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Model %s validated successfully.\n", model.ID)
@@ -256,6 +257,64 @@ var modelsDiffCmd = &cobra.Command{
 	},
 }
 
+var modelsCompareCmd = &cobra.Command{
+	Use:   "compare <model-a> <model-b>",
+	Short: "Compare the runtime and resource configuration of two catalog models",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _, err := mustClient()
+		if err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		var resp struct {
+			Identical bool `json:"identical"`
+			Changes   []struct {
+				Field string      `json:"field"`
+				Old   interface{} `json:"old"`
+				New   interface{} `json:"new"`
+			} `json:"changes"`
+		}
+		path := fmt.Sprintf("/models/compare?a=%s&b=%s", url.QueryEscape(args[0]), url.QueryEscape(args[1]))
+		if err := client.GetJSON(path, &resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if err := writeOutput(cmd, resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if machineOutput() {
+			return
+		}
+		if resp.Identical {
+			fmt.Fprintln(cmd.OutOrStdout(), "No differences detected.")
+			return
+		}
+		tw := newTable()
+		fmt.Fprintf(tw, "FIELD\t%s\t%s\n", strings.ToUpper(args[0]), strings.ToUpper(args[1]))
+		for _, change := range resp.Changes {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", change.Field, compareValue(change.Old), compareValue(change.New))
+		}
+		flushTable(tw)
+	},
+}
+
+func compareValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return val
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
 var modelsDeactivateCmd = &cobra.Command{
 	Use:   "deactivate",
 	Short: "Deactivate the currently active model",
@@ -286,6 +345,7 @@ func init() {
 	modelsCmd.AddCommand(modelsValidateCmd)
 	modelsCmd.AddCommand(modelsApplyCmd)
 	modelsCmd.AddCommand(modelsDiffCmd)
+	modelsCmd.AddCommand(modelsCompareCmd)
 	modelsCmd.AddCommand(modelsDeactivateCmd)
 }
 
@@ -33,7 +33,7 @@ var notifyListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.Notifications) == 0 {
@@ -90,7 +90,7 @@ var notifyAddCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Channel %s -> %s configured.\n", record.Name, record.Target)
@@ -126,7 +126,7 @@ var notifyRotateCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Channel %s rotated.\n", args[0])
@@ -208,7 +208,7 @@ var notifyHistoryCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.History) == 0 {
@@ -231,7 +231,7 @@ var notifyHistoryCmd = &cobra.Command{
 }
 
 func init() {
-	notifyAddCmd.Flags().StringVar(&notifyAddType, "type", "slack-webhook", "Channel type (slack-webhook, webhook, etc.)")
+	notifyAddCmd.Flags().StringVar(&notifyAddType, "type", "slack-webhook", "Channel type (slack-webhook, webhook, msteams)")
 	notifyAddCmd.Flags().StringVar(&notifyAddTarget, "target", "", "Channel target URL or identifier")
 	notifyAddCmd.Flags().StringSliceVar(&notifyAddMeta, "meta", nil, "key=value metadata pairs (repeatable)")
 	notifyRotateCmd.Flags().StringVar(&notifyRotateTarget, "target", "", "New target URL or identifier")
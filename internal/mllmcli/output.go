@@ -9,14 +9,72 @@ import (
 	"strings"
 	"text/tabwriter"
 	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats accepted by the global --output flag.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
 )
 
+func selectedOutput() string {
+	format := strings.ToLower(strings.TrimSpace(outputFormat))
+	if format == "" {
+		return outputTable
+	}
+	return format
+}
+
+// checkOutputFormat rejects an unknown --output value before any request is
+// made.
+func checkOutputFormat() error {
+	switch selectedOutput() {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q (expected table, json, or yaml)", outputFormat)
+}
+
+// machineOutput reports whether --output asks for JSON or YAML, in which case
+// writeOutput has already printed the response and callers skip their table.
+func machineOutput() bool {
+	format := selectedOutput()
+	return format == outputJSON || format == outputYAML
+}
+
+// writeOutput renders data as JSON or YAML when --output asks for it, using
+// the struct's json tags for both. Table output is left to the caller.
+func writeOutput(cmd *cobra.Command, data interface{}) error {
+	switch selectedOutput() {
+	case outputJSON:
+		return printJSON(data)
+	case outputYAML:
+		return printYAML(data)
+	case outputTable:
+		return nil
+	}
+	return checkOutputFormat()
+}
+
 func printJSON(data interface{}) error {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	return enc.Encode(data)
 }
 
+func printYAML(data interface{}) error {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
 func newTable() *tabwriter.Writer {
 	return tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 }
@@ -82,3 +140,10 @@ func confirmPrompt(prompt string, in io.Reader, out io.Writer) (bool, error) {
 	input = strings.TrimSpace(strings.ToLower(input))
 	return input == "y" || input == "yes", nil
 }
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
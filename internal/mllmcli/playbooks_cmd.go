@@ -32,8 +32,11 @@ var playbooksListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(resp.Playbooks)
+		if err := writeOutput(cmd, resp.Playbooks); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -74,8 +77,9 @@ var playbooksGetCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(record)
+		if !machineOutput() {
+			// Playbook definitions are free-form, so the table view is YAML.
+			_ = printYAML(record)
 		}
 	},
 }
@@ -186,9 +190,11 @@ var playbooksRunCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(resp)
-		} else {
+		if err := writeOutput(cmd, resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if !machineOutput() {
 			renderPlaybookRun(cmd, &resp)
 		}
 		if playbooksRunWatch && resp.Steps.Install != nil && resp.Steps.Install.Job != nil {
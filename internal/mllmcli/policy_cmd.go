@@ -34,7 +34,7 @@ var policyListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.Policies) == 0 {
@@ -82,7 +82,7 @@ var policyApplyCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Policy %s updated.\n", policy.Name)
@@ -139,7 +139,7 @@ var policyGetCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "%s (updated %s)\n%s\n", policy.Name, formatTimestamp(policy.UpdatedAt), policy.Document)
@@ -168,7 +168,7 @@ var policyVersionsCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.Versions) == 0 {
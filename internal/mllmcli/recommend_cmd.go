@@ -2,6 +2,7 @@ package mllmcli
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -32,8 +33,7 @@ var recommendProfilesCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(resp.Profiles)
+		if machineOutput() {
 			return
 		}
 		if len(resp.Profiles) == 0 {
@@ -59,8 +59,12 @@ var recommendGPUCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
+		path := "/recommendations/" + args[0]
+		if recommendGPUModel != "" {
+			path += "?modelId=" + url.QueryEscape(recommendGPUModel)
+		}
 		var rec Recommendation
-		if err := client.GetJSON("/recommendations/"+args[0], &rec); err != nil {
+		if err := client.GetJSON(path, &rec); err != nil {
 			exitWithError(cmd, err)
 			return
 		}
@@ -68,11 +72,17 @@ var recommendGPUCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(rec)
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "GPU: %s (%d GiB)\n", rec.GPUType, rec.MemoryGB)
+		if rec.EstimatedVRAMGB > 0 {
+			if rec.Feasible {
+				fmt.Fprintf(cmd.OutOrStdout(), "Fits: ~%d GiB with tensor parallel size %d\n", rec.EstimatedVRAMGB, rec.TensorParallelSize)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Fits: no (~%d GiB required)\n", rec.EstimatedVRAMGB)
+			}
+		}
 		if len(rec.Flags) > 0 {
 			fmt.Fprintf(cmd.OutOrStdout(), "Flags: %s\n", strings.Join(rec.Flags, " "))
 		}
@@ -85,7 +95,10 @@ var recommendGPUCmd = &cobra.Command{
 	},
 }
 
-var recommendCompatGPU string
+var (
+	recommendGPUModel  string
+	recommendCompatGPU string
+)
 
 var recommendCompatCmd = &cobra.Command{
 	Use:   "compatibility <model-id>",
@@ -110,8 +123,7 @@ var recommendCompatCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(report)
+		if machineOutput() {
 			return
 		}
 		if recommendCompatGPU != "" {
@@ -145,11 +157,63 @@ var recommendCompatCmd = &cobra.Command{
 	},
 }
 
+var recommendBestCmd = &cobra.Command{
+	Use:   "best <model-id>",
+	Short: "Pick the cheapest GPU profile a catalog model fits on",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _, err := mustClient()
+		if err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		var resp struct {
+			ModelID         string          `json:"modelId"`
+			EstimatedVRAMGB int             `json:"estimatedVramGb"`
+			Best            *ProfileOption  `json:"best"`
+			Alternatives    []ProfileOption `json:"alternatives"`
+			Reason          string          `json:"reason"`
+		}
+		if err := client.GetJSON(fmt.Sprintf("/models/%s/recommendation/best", url.PathEscape(args[0])), &resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if err := writeOutput(cmd, resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if machineOutput() {
+			return
+		}
+		if resp.Best == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", resp.ModelID, resp.Reason)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s needs ~%d GiB; best fit: %s\n", resp.ModelID, resp.EstimatedVRAMGB, resp.Best.Profile.Name)
+		tw := newTable()
+		fmt.Fprintf(tw, "GPU TYPE\tGPUS\tMEMORY\tCOST/HR\n")
+		for _, option := range append([]ProfileOption{*resp.Best}, resp.Alternatives...) {
+			gpus := option.Recommendation.TensorParallelSize
+			if gpus < 1 {
+				gpus = 1
+			}
+			cost := "-"
+			if option.Profile.CostPerHour > 0 {
+				cost = fmt.Sprintf("%.2f", option.Profile.CostPerHour*float64(gpus))
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d GiB\t%s\n", option.Profile.Name, gpus, option.Profile.MemoryGB*gpus, cost)
+		}
+		flushTable(tw)
+	},
+}
+
 func init() {
+	recommendGPUCmd.Flags().StringVar(&recommendGPUModel, "model", "", "Catalog model ID to size tensor parallelism for")
 	recommendCompatCmd.Flags().StringVar(&recommendCompatGPU, "gpu", "", "Specific GPU type to evaluate")
 	recommendCmd.AddCommand(recommendProfilesCmd)
 	recommendCmd.AddCommand(recommendGPUCmd)
 	recommendCmd.AddCommand(recommendCompatCmd)
+	recommendCmd.AddCommand(recommendBestCmd)
 }
 
 type GPUProfile struct {
@@ -158,13 +222,22 @@ type GPUProfile struct {
 	MemoryGB    int      `json:"memoryGB"`
 	Vendor      string   `json:"vendor"`
 	Features    []string `json:"features"`
+	CostPerHour float64  `json:"costPerHour"`
+}
+
+type ProfileOption struct {
+	Profile        GPUProfile     `json:"profile"`
+	Recommendation Recommendation `json:"recommendation"`
 }
 
 type Recommendation struct {
-	GPUType  string   `json:"gpuType"`
-	MemoryGB int      `json:"memoryGB"`
-	Flags    []string `json:"flags"`
-	Notes    []string `json:"notes"`
+	GPUType            string   `json:"gpuType"`
+	MemoryGB           int      `json:"memoryGB"`
+	Flags              []string `json:"flags"`
+	Notes              []string `json:"notes"`
+	EstimatedVRAMGB    int      `json:"estimatedVramGb"`
+	TensorParallelSize int      `json:"tensorParallelSize"`
+	Feasible           bool     `json:"feasible"`
 }
 
 type CompatibilityReport struct {
@@ -33,6 +33,10 @@ var rootCmd = &cobra.Command{
 	Long: `mllm is the official CLI for the Oremus Labs Model Manager control plane.
 Most commands require a configured context (see 'mllm config set-context').`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(); err != nil {
+			exitWithError(cmd, err)
+			return err
+		}
 		// Config commands load/save the file manually.
 		if strings.HasPrefix(cmd.CommandPath(), "mllm config") {
 			return nil
@@ -54,11 +58,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&overrideURL, "server", "", "Override API server URL")
 	rootCmd.PersistentFlags().StringVar(&overrideToken, "token", "", "Override API token")
 	rootCmd.PersistentFlags().StringVar(&overrideNS, "namespace", "", "Override namespace for commands")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json|yaml")
+	_ = rootCmd.RegisterFlagCompletionFunc("context", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeContextNames(cmd, nil, toComplete)
+	})
 
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(modelsCmd)
 	rootCmd.AddCommand(weightsCmd)
+	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(jobsCmd)
 	rootCmd.AddCommand(runtimeCmd)
 	rootCmd.AddCommand(recommendCmd)
@@ -74,6 +82,7 @@ func init() {
 	rootCmd.AddCommand(metricsCmd)
 	rootCmd.AddCommand(supportCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(catalogCmd)
 }
 
 // resolvedContext merges config state with flag overrides.
@@ -120,18 +129,6 @@ func mustClient() (*Client, *Context, error) {
 	return client, ctx, nil
 }
 
-func writeOutput(cmd *cobra.Command, data interface{}) error {
-	switch strings.ToLower(outputFormat) {
-	case "json":
-		return printJSON(data)
-	case "table", "":
-		// Table is handled by the caller.
-		return nil
-	default:
-		return fmt.Errorf("unsupported output format %q", outputFormat)
-	}
-}
-
 func exitWithError(cmd *cobra.Command, err error) {
 	cmd.SilenceUsage = true
 	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -48,6 +48,15 @@ type PodStatus struct {
 	Containers      []ContainerStatusSummary `json:"containers"`
 	GPURequests     map[string]string        `json:"gpuRequests"`
 	GPULimits       map[string]string        `json:"gpuLimits"`
+	GPUUsage        *GPUUsage                `json:"gpuUsage"`
+}
+
+// GPUUsage mirrors the measured per-pod GPU usage reported when the server
+// scrapes GPU metrics.
+type GPUUsage struct {
+	GPUs               int     `json:"gpus"`
+	UtilizationPercent float64 `json:"utilizationPercent"`
+	MemoryUsedMiB      float64 `json:"memoryUsedMiB"`
 }
 
 // ContainerStatusSummary mirrors per-container state emitted by the API.
@@ -28,8 +28,8 @@ var runtimeStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show KServe/Knative runtime status",
 	Run: func(cmd *cobra.Command, args []string) {
-		if runtimeStatusWatch && strings.EqualFold(outputFormat, "json") {
-			exitWithError(cmd, fmt.Errorf("--watch is not supported with -o json"))
+		if runtimeStatusWatch && machineOutput() {
+			exitWithError(cmd, fmt.Errorf("--watch is not supported with -o %s", selectedOutput()))
 			return
 		}
 		client, _, err := mustClient()
@@ -44,12 +44,11 @@ var runtimeStatusCmd = &cobra.Command{
 				exitWithError(cmd, err)
 				return
 			}
-			if strings.EqualFold(outputFormat, "json") {
-				if err := printJSON(status); err != nil {
-					exitWithError(cmd, err)
-					return
-				}
-			} else {
+			if err := writeOutput(cmd, status); err != nil {
+				exitWithError(cmd, err)
+				return
+			}
+			if !machineOutput() {
 				renderRuntimeStatus(cmd, status, runtimeStatusDetails)
 			}
 			if !runtimeStatusWatch {
@@ -67,6 +66,7 @@ var runtimeStatusCmd = &cobra.Command{
 var (
 	runtimeActivateWait    bool
 	runtimeActivateTimeout time.Duration
+	runtimeActivateCanary  int
 )
 
 var runtimeActivateCmd = &cobra.Command{
@@ -79,7 +79,11 @@ var runtimeActivateCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		payload := map[string]string{"modelId": args[0]}
+		payload := map[string]interface{}{"modelId": args[0]}
+		if runtimeActivateCanary > 0 {
+			payload["strategy"] = "canary"
+			payload["trafficPercent"] = runtimeActivateCanary
+		}
 		if err := postRuntimeJSON(client, "/runtime/activate", payload, "/models/activate", map[string]string{"id": args[0]}); err != nil {
 			exitWithError(cmd, err)
 			return
@@ -129,6 +133,30 @@ var runtimeSwitchCmd = &cobra.Command{
 		fmt.Fprintf(cmd.OutOrStdout(), "Promotion requested for %s\n", args[0])
 	},
 }
+var runtimeRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Re-activate the model that was running before the current one",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, _, err := mustClient()
+		if err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		var resp struct {
+			RolledBackFrom string `json:"rolledBackFrom"`
+			Model          struct {
+				ID string `json:"id"`
+			} `json:"model"`
+		}
+		if err := client.PostJSON("/runtime/rollback", map[string]string{}, &resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Rolled back from %s to %s\n", resp.RolledBackFrom, resp.Model.ID)
+	},
+}
+
 var runtimeDeactivateCmd = &cobra.Command{
 	Use:   "deactivate",
 	Short: "Deactivate the active model",
@@ -163,6 +191,7 @@ func init() {
 	runtimeStatusCmd.Flags().BoolVar(&runtimeStatusDetails, "details", false, "Show pod-level details")
 
 	runtimeActivateCmd.Flags().BoolVar(&runtimeActivateWait, "wait", false, "Wait for the activation to complete")
+	runtimeActivateCmd.Flags().IntVar(&runtimeActivateCanary, "canary", 0, "Roll out as a canary receiving this percent of traffic (1-99); promote with `runtime switch`")
 	runtimeActivateCmd.Flags().DurationVar(&runtimeActivateTimeout, "timeout", 5*time.Minute, "Timeout for --wait")
 
 	runtimeDeactivateCmd.Flags().BoolVar(&runtimeDeactivateWait, "wait", false, "Wait until the runtime fully deactivates")
@@ -173,6 +202,7 @@ func init() {
 	runtimeCmd.AddCommand(runtimeActivateCmd)
 	runtimeCmd.AddCommand(runtimeDeactivateCmd)
 	runtimeCmd.AddCommand(runtimeSwitchCmd)
+	runtimeCmd.AddCommand(runtimeRollbackCmd)
 }
 
 func renderRuntimeStatus(cmd *cobra.Command, status *RuntimeStatus, details bool) {
@@ -208,7 +238,7 @@ func renderRuntimeStatus(cmd *cobra.Command, status *RuntimeStatus, details bool
 
 	if details && len(status.Pods) > 0 {
 		tw = newTable()
-		fmt.Fprintf(tw, "Pod\tPhase\tReady\tMessage\n")
+		fmt.Fprintf(tw, "Pod\tPhase\tReady\tGPU Util\tMessage\n")
 		for _, pod := range status.Pods {
 			total := pod.TotalContainers
 			if total == 0 {
@@ -219,7 +249,11 @@ func renderRuntimeStatus(cmd *cobra.Command, status *RuntimeStatus, details bool
 			if msg == "" && len(pod.Conditions) > 0 {
 				msg = pod.Conditions[len(pod.Conditions)-1].Message
 			}
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", pod.Name, pod.Phase, ready, msg)
+			util := "-"
+			if pod.GPUUsage != nil {
+				util = fmt.Sprintf("%.0f%% (%.0f MiB)", pod.GPUUsage.UtilizationPercent, pod.GPUUsage.MemoryUsedMiB)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", pod.Name, pod.Phase, ready, util, msg)
 		}
 		flushTable(tw)
 	}
@@ -45,9 +45,7 @@ var searchCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if strings.EqualFold(outputFormat, "json") {
-			_ = printJSON(resp.Results)
-		} else {
+		if !machineOutput() {
 			renderSearchTable(cmd, resp.Results)
 		}
 
@@ -34,7 +34,7 @@ var secretsListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		if len(resp.Secrets) == 0 {
@@ -71,7 +71,7 @@ var secretsGetCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -119,7 +119,7 @@ var secretsSetCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
+		if machineOutput() {
 			return
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Secret %s updated (%d keys)\n", record.Name, len(record.Data))
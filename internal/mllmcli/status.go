@@ -23,8 +23,7 @@ var statusCmd = &cobra.Command{
 				exitWithError(cmd, err)
 				return
 			}
-			if outputFormat == "json" {
-				_ = printJSON(summary)
+			if machineOutput() {
 				return
 			}
 			printSummary(cmd, summary, ctx.Namespace)
@@ -40,8 +39,7 @@ var statusCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(info)
+		if machineOutput() {
 			return
 		}
 
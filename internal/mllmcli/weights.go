@@ -36,8 +36,7 @@ var weightsListCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(resp.Weights)
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -76,8 +75,7 @@ var weightsUsageCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(stats)
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -90,6 +88,8 @@ var weightsUsageCmd = &cobra.Command{
 	},
 }
 
+var weightsInfoFiles bool
+
 var weightsInfoCmd = &cobra.Command{
 	Use:   "info <name>",
 	Short: "Show detailed info for a weight directory",
@@ -102,6 +102,9 @@ var weightsInfoCmd = &cobra.Command{
 		}
 		query := url.Values{}
 		query.Set("name", args[0])
+		if weightsInfoFiles {
+			query.Set("files", "true")
+		}
 		var info WeightRecord
 		if err := client.GetJSON("/weights/info?"+query.Encode(), &info); err != nil {
 			exitWithError(cmd, err)
@@ -111,8 +114,7 @@ var weightsInfoCmd = &cobra.Command{
 			exitWithError(cmd, err)
 			return
 		}
-		if outputFormat == "json" {
-			_ = printJSON(info)
+		if machineOutput() {
 			return
 		}
 		tw := newTable()
@@ -120,11 +122,30 @@ var weightsInfoCmd = &cobra.Command{
 		fmt.Fprintf(tw, "Name\t%s\n", info.Name)
 		fmt.Fprintf(tw, "Path\t%s\n", info.Path)
 		fmt.Fprintf(tw, "HF Model ID\t%s\n", info.HFModelID)
+		if info.RequestedRevision != "" {
+			fmt.Fprintf(tw, "Revision\t%s (fallback for %s)\n", info.Revision, info.RequestedRevision)
+		} else if info.Revision != "" {
+			fmt.Fprintf(tw, "Revision\t%s\n", info.Revision)
+		}
 		fmt.Fprintf(tw, "Size\t%s (%d bytes)\n", info.SizeHuman, info.SizeBytes)
 		fmt.Fprintf(tw, "Files\t%d\n", info.FileCount)
 		fmt.Fprintf(tw, "Last Modified\t%s\n", info.ModifiedTime.Format(time.RFC3339))
 		fmt.Fprintf(tw, "Installed\t%s\n", info.InstalledAt.Format(time.RFC3339))
 		flushTable(tw)
+		if !weightsInfoFiles {
+			return
+		}
+		if len(info.Files) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "\nNo file manifest recorded; reinstall the weights to record one.")
+			return
+		}
+		fmt.Fprintln(cmd.OutOrStdout())
+		tw = newTable()
+		fmt.Fprintf(tw, "FILE\tSIZE\tSHA256\n")
+		for _, file := range info.Files {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", file.Path, weights.FormatBytes(file.SizeBytes), file.SHA256)
+		}
+		flushTable(tw)
 	},
 }
 
@@ -132,63 +153,115 @@ var (
 	installRevision  string
 	installTarget    string
 	installOverwrite bool
+	installPriority  int
 	installWatch     bool
 	installFiles     []string
 	installPreempt   bool
+	installFallbacks []string
 )
 
 var weightsInstallCmd = &cobra.Command{
 	Use:   "install <hf-model-id>",
 	Short: "Install Hugging Face weights onto the PVC",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		client, _, err := mustClient()
-		if err != nil {
-			exitWithError(cmd, err)
-			return
-		}
-		reactivate, err := ensureGPUCapacity(cmd, client, installPreempt)
-		if err != nil {
-			exitWithError(cmd, err)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWeightsInstall(cmd, args[0])
+	},
+}
+
+// installCmd is the top-level shortcut for "weights install".
+var installCmd = &cobra.Command{
+	Use:   "install <hf-model-id>",
+	Short: "Install Hugging Face weights (same as 'mllm weights install')",
+	Long: `Queues a weight install. With --wait the command streams the job's progress
+and log lines until it finishes and exits non-zero unless it completed, so it
+can be used in scripts.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWeightsInstall(cmd, args[0])
+	},
+}
+
+func runWeightsInstall(cmd *cobra.Command, hfModelID string) error {
+	client, _, err := mustClient()
+	if err != nil {
+		exitWithError(cmd, err)
+		return err
+	}
+	reactivate, err := ensureGPUCapacity(cmd, client, installPreempt)
+	if err != nil {
+		exitWithError(cmd, err)
+		return err
+	}
+	if reactivate != nil {
+		defer reactivate()
+	}
+	req := installWeightsPayload{
+		HFModelID:         hfModelID,
+		Revision:          installRevision,
+		Target:            installTarget,
+		Files:             installFiles,
+		Overwrite:         installOverwrite,
+		Priority:          installPriority,
+		FallbackRevisions: installFallbacks,
+	}
+	if req.Target == "" {
+		if target, err := weights.CanonicalTarget(req.HFModelID, ""); err == nil {
+			req.Target = target
 		}
-		if reactivate != nil {
-			defer reactivate()
-		}
-		req := installWeightsPayload{
-			HFModelID: args[0],
-			Revision:  installRevision,
-			Target:    installTarget,
-			Files:     installFiles,
-			Overwrite: installOverwrite,
-		}
-		if req.Target == "" {
-			if target, err := weights.CanonicalTarget(req.HFModelID, ""); err == nil {
-				req.Target = target
-			}
+	}
+	var resp weightInstallResponse
+	if err := client.PostJSON("/weights/install", req, &resp); err != nil {
+		exitWithError(cmd, err)
+		return err
+	}
+	if resp.Job != nil && resp.Job.ID != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Job %s queued to install %s into %s\n", resp.Job.ID, hfModelID, resp.Target)
+		if !installWatch {
+			fmt.Fprintf(cmd.OutOrStdout(), "Follow progress: %s/jobs/%s\n", strings.TrimRight(client.BaseURL, "/"), resp.Job.ID)
+			return nil
 		}
-		var resp weightInstallResponse
-		if err := client.PostJSON("/weights/install", req, &resp); err != nil {
+		if err := waitForInstall(cmd, client, resp.Job.ID); err != nil {
 			exitWithError(cmd, err)
-			return
-		}
-		if resp.Job != nil && resp.Job.ID != "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "Job %s queued to install %s into %s\n", resp.Job.ID, args[0], resp.Target)
-			if installWatch {
-				if err := watchJob(cmd.Context(), cmd, client, resp.Job.ID); err != nil {
-					exitWithError(cmd, err)
-				}
-			} else {
-				fmt.Fprintf(cmd.OutOrStdout(), "Follow progress: %s/jobs/%s\n", strings.TrimRight(client.BaseURL, "/"), resp.Job.ID)
-			}
-			return
+			return err
 		}
-		if resp.Weights != nil {
-			fmt.Fprintf(cmd.OutOrStdout(), "Weights installed at %s (%s)\n", resp.Weights.Path, resp.Weights.SizeHuman)
-			return
-		}
-		fmt.Fprintln(cmd.OutOrStdout(), "Installation request submitted.")
-	},
+		return nil
+	}
+	if resp.Weights != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "Weights installed at %s (%s)\n", resp.Weights.Path, resp.Weights.SizeHuman)
+		return nil
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "Installation request submitted.")
+	return nil
+}
+
+// waitForInstall follows an install job's progress and log lines until it
+// finishes, returning an error unless it completed.
+func waitForInstall(cmd *cobra.Command, client *Client, jobID string) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	logsDone := make(chan struct{})
+	go func() {
+		defer close(logsDone)
+		_ = streamJobLogs(ctx, client, jobID, cmd.OutOrStdout())
+	}()
+
+	job, err := followJob(ctx, cmd, client, jobID)
+	// The log stream ends on its own once the job finishes; give it a
+	// moment to flush the last lines.
+	select {
+	case <-logsDone:
+	case <-time.After(2 * time.Second):
+	}
+	cancel()
+	<-logsDone
+	if err != nil {
+		return err
+	}
+	if job.Status != "completed" {
+		return fmt.Errorf("install job %s %s", jobID, job.Status)
+	}
+	return nil
 }
 
 var weightsDeleteCmd = &cobra.Command{
@@ -210,30 +283,114 @@ var weightsDeleteCmd = &cobra.Command{
 	},
 }
 
+var (
+	pruneOlderThan string
+	pruneDryRun    bool
+	pruneForce     bool
+)
+
+type weightPruneResponse struct {
+	Weights    []WeightRecord    `json:"weights"`
+	Count      int               `json:"count"`
+	Skipped    map[string]string `json:"skipped"`
+	FreedBytes int64             `json:"freedBytes"`
+	FreedHuman string            `json:"freedHuman"`
+	Cutoff     time.Time         `json:"cutoff"`
+	DryRun     bool              `json:"dryRun"`
+}
+
+var weightsPruneCmd = &cobra.Command{
+	Use:   "prune --older-than <age>",
+	Short: "Delete cached weights not modified within an age such as 30d",
+	Long: `Deletes installed weight directories that have not been modified within
+--older-than (a duration like 720h or a day count like 30d). Weights referenced
+by the catalog or the active runtime are skipped unless --force is set. Use
+--dry-run to list the candidates without deleting anything.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		age, err := weights.ParseAge(pruneOlderThan)
+		if err != nil || age <= 0 {
+			exitWithError(cmd, fmt.Errorf("--older-than must be a positive age such as 30d or 720h"))
+			return
+		}
+		client, _, err := mustClient()
+		if err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		query := url.Values{}
+		query.Set("olderThan", strings.TrimSpace(pruneOlderThan))
+		if pruneDryRun {
+			query.Set("dryRun", "true")
+		}
+		if pruneForce {
+			query.Set("force", "true")
+		}
+		var resp weightPruneResponse
+		if err := client.PostJSON("/weights/prune?"+query.Encode(), nil, &resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if err := writeOutput(cmd, resp); err != nil {
+			exitWithError(cmd, err)
+			return
+		}
+		if machineOutput() {
+			return
+		}
+		verb := "Removed"
+		if resp.DryRun {
+			verb = "Would remove"
+		}
+		if len(resp.Weights) > 0 {
+			tw := newTable()
+			fmt.Fprintf(tw, "NAME\tSIZE\tUPDATED\n")
+			for _, w := range resp.Weights {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", w.Name, w.SizeHuman, relativeTime(w.ModifiedTime))
+			}
+			flushTable(tw)
+		}
+		for name, reason := range resp.Skipped {
+			fmt.Fprintf(cmd.OutOrStdout(), "Skipped %s: %s\n", name, reason)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %d weight directories older than %s, freeing %s.\n", verb, resp.Count, strings.TrimSpace(pruneOlderThan), resp.FreedHuman)
+	},
+}
+
 func init() {
+	weightsInfoCmd.Flags().BoolVar(&weightsInfoFiles, "files", false, "List each installed file with its size and SHA-256")
 	weightsInstallCmd.Flags().StringVar(&installRevision, "revision", "", "Specific Hugging Face revision to install")
+	weightsInstallCmd.Flags().StringSliceVar(&installFallbacks, "fallback-revision", nil, "Revision to try, in order, when the previous one is missing files (repeatable)")
 	weightsInstallCmd.Flags().StringVar(&installTarget, "target", "", "Override target directory name (defaults to the HF model ID)")
 	weightsInstallCmd.Flags().BoolVar(&installOverwrite, "overwrite", false, "Overwrite the target directory if it exists")
+	weightsInstallCmd.Flags().IntVar(&installPriority, "priority", 0, "Queue priority; positive installs run before normal (0) and negative ones")
 	weightsInstallCmd.Flags().StringSliceVar(&installFiles, "file", nil, "Restrict download to specific files (repeatable)")
-	weightsInstallCmd.Flags().BoolVar(&installWatch, "watch", false, "Wait for the install job to finish")
+	weightsInstallCmd.Flags().BoolVar(&installWatch, "wait", false, "Stream progress and logs until the install job finishes; exit non-zero unless it completed")
+	weightsInstallCmd.Flags().BoolVar(&installWatch, "watch", false, "Alias for --wait")
 	weightsInstallCmd.Flags().BoolVar(&installPreempt, "preempt-active", false, "Automatically deactivate the active model if GPUs are unavailable")
 	weightsCmd.AddCommand(weightsListCmd)
 	weightsCmd.AddCommand(weightsUsageCmd)
 	weightsCmd.AddCommand(weightsInfoCmd)
 	weightsCmd.AddCommand(weightsInstallCmd)
+	installCmd.Flags().AddFlagSet(weightsInstallCmd.LocalFlags())
 	weightsCmd.AddCommand(weightsDeleteCmd)
+	weightsPruneCmd.Flags().StringVar(&pruneOlderThan, "older-than", "", "Prune weights not modified within this age (e.g. 30d, 720h)")
+	weightsPruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List candidates without deleting them")
+	weightsPruneCmd.Flags().BoolVar(&pruneForce, "force", false, "Also prune weights the catalog or active runtime references")
+	weightsCmd.AddCommand(weightsPruneCmd)
 }
 
 type WeightRecord struct {
-	Path         string    `json:"path"`
-	Name         string    `json:"name"`
-	SizeBytes    int64     `json:"sizeBytes"`
-	SizeHuman    string    `json:"sizeHuman"`
-	ModifiedTime time.Time `json:"modifiedTime"`
-	FileCount    int       `json:"fileCount"`
-	HFModelID    string    `json:"hfModelId"`
-	Revision     string    `json:"revision,omitempty"`
-	InstalledAt  time.Time `json:"installedAt,omitempty"`
+	Path              string             `json:"path"`
+	Name              string             `json:"name"`
+	SizeBytes         int64              `json:"sizeBytes"`
+	SizeHuman         string             `json:"sizeHuman"`
+	ModifiedTime      time.Time          `json:"modifiedTime"`
+	FileCount         int                `json:"fileCount"`
+	HFModelID         string             `json:"hfModelId"`
+	Revision          string             `json:"revision,omitempty"`
+	RequestedRevision string             `json:"requestedRevision,omitempty"`
+	InstalledAt       time.Time          `json:"installedAt,omitempty"`
+	Files             []weights.FileInfo `json:"files,omitempty"`
 }
 
 type WeightStorageStats struct {
@@ -248,11 +405,13 @@ type WeightStorageStats struct {
 }
 
 type installWeightsPayload struct {
-	HFModelID string   `json:"hfModelId"`
-	Revision  string   `json:"revision,omitempty"`
-	Target    string   `json:"target,omitempty"`
-	Files     []string `json:"files,omitempty"`
-	Overwrite bool     `json:"overwrite"`
+	HFModelID         string   `json:"hfModelId"`
+	Revision          string   `json:"revision,omitempty"`
+	Target            string   `json:"target,omitempty"`
+	Files             []string `json:"files,omitempty"`
+	Overwrite         bool     `json:"overwrite"`
+	Priority          int      `json:"priority,omitempty"`
+	FallbackRevisions []string `json:"fallbackRevisions,omitempty"`
 }
 
 type weightInstallResponse struct {
@@ -0,0 +1,268 @@
+// Package notify delivers model manager events to configured notification
+// channels (Slack, generic webhooks, Microsoft Teams).
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+)
+
+// Supported channel types.
+const (
+	TypeSlack   = "slack"
+	TypeWebhook = "webhook"
+	TypeMSTeams = "msteams"
+)
+
+// DefaultChannel names the channel backed by SLACK_WEBHOOK_URL.
+const DefaultChannel = "default"
+
+const maxDeliveryPayload = 2048
+
+// Event is the structured payload sent to notification channels.
+type Event struct {
+	Name      string                 `json:"event"`
+	ModelID   string                 `json:"modelId,omitempty"`
+	Message   string                 `json:"message"`
+	Timestamp time.Time              `json:"timestamp"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Store lists configured channels and records delivery attempts.
+type Store interface {
+	ListNotifications() ([]store.Notification, error)
+	RecordNotificationDelivery(*store.NotificationDelivery) error
+	AppendHistory(*store.HistoryEntry) error
+}
+
+// Options configures a Dispatcher.
+type Options struct {
+	Store           Store
+	SlackWebhookURL string
+	Client          *http.Client
+}
+
+// Dispatcher formats events per channel type and delivers them.
+type Dispatcher struct {
+	store        Store
+	defaultSlack string
+	client       *http.Client
+}
+
+type formatter func(Event) (interface{}, error)
+
+var formatters = map[string]formatter{
+	TypeSlack:   slackPayload,
+	TypeWebhook: webhookPayload,
+	TypeMSTeams: teamsPayload,
+}
+
+// New creates a dispatcher.
+func New(opts Options) *Dispatcher {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Dispatcher{
+		store:        opts.Store,
+		defaultSlack: strings.TrimSpace(opts.SlackWebhookURL),
+		client:       client,
+	}
+}
+
+// NormalizeType maps a channel type onto a supported delivery format. Empty
+// types and the CLI's "slack-webhook" default are treated as Slack.
+func NormalizeType(channelType string) string {
+	switch t := strings.ToLower(strings.TrimSpace(channelType)); t {
+	case "", "slack-webhook":
+		return TypeSlack
+	case "teams", "ms-teams":
+		return TypeMSTeams
+	default:
+		return t
+	}
+}
+
+// ValidType reports whether channelType has a delivery format.
+func ValidType(channelType string) bool {
+	_, ok := formatters[NormalizeType(channelType)]
+	return ok
+}
+
+// Channels returns the default Slack channel (when configured) followed by
+// every stored channel.
+func (d *Dispatcher) Channels() ([]store.Notification, error) {
+	var channels []store.Notification
+	if d.defaultSlack != "" {
+		channels = append(channels, store.Notification{Name: DefaultChannel, Type: TypeSlack, Target: d.defaultSlack})
+	}
+	if d.store == nil {
+		return channels, nil
+	}
+	stored, err := d.store.ListNotifications()
+	if err != nil {
+		return channels, err
+	}
+	return append(channels, stored...), nil
+}
+
+// Broadcast delivers evt to every configured channel, once per target URL.
+// Failures are logged and recorded in the delivery log; one broken channel does
+// not block the rest.
+func (d *Dispatcher) Broadcast(evt Event) {
+	channels, err := d.Channels()
+	if err != nil {
+		log.Printf("notify: failed to list channels: %v", err)
+	}
+	seen := make(map[string]struct{}, len(channels))
+	for _, channel := range channels {
+		target := strings.TrimSpace(channel.Target)
+		if _, ok := seen[target]; ok && target != "" {
+			continue
+		}
+		seen[target] = struct{}{}
+		if err := d.Deliver(channel, evt); err != nil {
+			log.Printf("notify: delivery of %s to %s failed: %v", evt.Name, channel.Name, err)
+		}
+	}
+}
+
+// Deliver sends evt to a single channel and records the attempt in both the
+// delivery log and history (notification_delivery / notification_failed), which
+// feeds the notification health stats.
+func (d *Dispatcher) Deliver(channel store.Notification, evt Event) error {
+	if evt.Timestamp.IsZero() {
+		evt.Timestamp = time.Now().UTC()
+	}
+	code, err := d.send(channel, evt)
+	if d.store == nil {
+		return err
+	}
+	record := &store.NotificationDelivery{
+		Channel:   channel.Name,
+		EventType: evt.Name,
+		Status:    store.DeliverySucceeded,
+		HTTPCode:  code,
+		Payload:   truncate(evt.Message, maxDeliveryPayload),
+	}
+	history := &store.HistoryEntry{
+		Event:   "notification_delivery",
+		ModelID: evt.ModelID,
+		Metadata: map[string]interface{}{
+			"name":    channel.Name,
+			"event":   evt.Name,
+			"message": evt.Message,
+		},
+	}
+	if err != nil {
+		record.Status = store.DeliveryFailed
+		record.Error = err.Error()
+		history.Event = "notification_failed"
+		history.Metadata["error"] = err.Error()
+	}
+	if recErr := d.store.RecordNotificationDelivery(record); recErr != nil {
+		log.Printf("notify: failed to record delivery for %s: %v", channel.Name, recErr)
+	}
+	if recErr := d.store.AppendHistory(history); recErr != nil {
+		log.Printf("notify: failed to record history for %s: %v", channel.Name, recErr)
+	}
+	return err
+}
+
+func (d *Dispatcher) send(channel store.Notification, evt Event) (int, error) {
+	target := strings.TrimSpace(channel.Target)
+	if target == "" {
+		return 0, fmt.Errorf("webhook empty")
+	}
+	channelType := NormalizeType(channel.Type)
+	format, ok := formatters[channelType]
+	if !ok {
+		return 0, fmt.Errorf("unsupported notification type %q", channel.Type)
+	}
+	payload, err := format(evt)
+	if err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s webhook returned %s", channelType, resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func slackPayload(evt Event) (interface{}, error) {
+	return map[string]string{"text": evt.Message}, nil
+}
+
+func webhookPayload(evt Event) (interface{}, error) {
+	return evt, nil
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// teamsPayload renders a legacy Office 365 connector MessageCard.
+func teamsPayload(evt Event) (interface{}, error) {
+	facts := []teamsFact{{Name: "Event", Value: evt.Name}}
+	if evt.ModelID != "" {
+		facts = append(facts, teamsFact{Name: "Model", Value: evt.ModelID})
+	}
+	keys := make([]string, 0, len(evt.Metadata))
+	for key := range evt.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		facts = append(facts, teamsFact{Name: key, Value: fmt.Sprint(evt.Metadata[key])})
+	}
+	facts = append(facts, teamsFact{Name: "Time", Value: evt.Timestamp.UTC().Format(time.RFC3339)})
+	return map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    evt.Message,
+		"themeColor": teamsColor(evt.Name),
+		"title":      evt.Message,
+		"sections":   []map[string]interface{}{{"facts": facts}},
+	}, nil
+}
+
+func teamsColor(event string) string {
+	switch {
+	case strings.HasSuffix(event, ".failed"), strings.HasSuffix(event, ".triggered"):
+		return "D13438"
+	case strings.HasSuffix(event, ".completed"), strings.HasSuffix(event, ".resolved"):
+		return "2EB886"
+	default:
+		return "0078D7"
+	}
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
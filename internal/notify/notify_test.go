@@ -0,0 +1,151 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+)
+
+type fakeStore struct {
+	mu         sync.Mutex
+	channels   []store.Notification
+	deliveries []store.NotificationDelivery
+	history    []store.HistoryEntry
+}
+
+func (f *fakeStore) ListNotifications() ([]store.Notification, error) {
+	return f.channels, nil
+}
+
+func (f *fakeStore) RecordNotificationDelivery(d *store.NotificationDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deliveries = append(f.deliveries, *d)
+	return nil
+}
+
+func (f *fakeStore) AppendHistory(entry *store.HistoryEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.history = append(f.history, *entry)
+	return nil
+}
+
+func TestBroadcastFormatsPayloadPerChannelType(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Errorf("decode %s: %v", r.URL.Path, err)
+		}
+		mu.Lock()
+		bodies[r.URL.Path] = body
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	fs := &fakeStore{channels: []store.Notification{
+		{Name: "hook", Type: TypeWebhook, Target: server.URL + "/hook"},
+		{Name: "teams", Type: TypeMSTeams, Target: server.URL + "/teams"},
+	}}
+	d := New(Options{Store: fs, SlackWebhookURL: server.URL + "/slack"})
+
+	d.Broadcast(Event{
+		Name:      "model.activation.completed",
+		ModelID:   "qwen",
+		Message:   "Activated Qwen",
+		Timestamp: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Metadata:  map[string]interface{}{"action": "created"},
+	})
+
+	if got := bodies["/slack"]["text"]; got != "Activated Qwen" {
+		t.Fatalf("unexpected slack payload %+v", bodies["/slack"])
+	}
+	hook := bodies["/hook"]
+	if hook["event"] != "model.activation.completed" || hook["modelId"] != "qwen" || hook["timestamp"] != "2024-05-01T12:00:00Z" {
+		t.Fatalf("unexpected webhook payload %+v", hook)
+	}
+	if meta, _ := hook["metadata"].(map[string]interface{}); meta["action"] != "created" {
+		t.Fatalf("expected webhook metadata, got %+v", hook["metadata"])
+	}
+	teams := bodies["/teams"]
+	if teams["@type"] != "MessageCard" || teams["title"] != "Activated Qwen" {
+		t.Fatalf("unexpected teams payload %+v", teams)
+	}
+	if len(fs.deliveries) != 3 {
+		t.Fatalf("expected 3 recorded deliveries, got %+v", fs.deliveries)
+	}
+	for _, delivery := range fs.deliveries {
+		if delivery.Status != store.DeliverySucceeded || delivery.EventType != "model.activation.completed" {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+	}
+}
+
+func TestDeliverRejectsUnknownType(t *testing.T) {
+	t.Parallel()
+
+	fs := &fakeStore{}
+	d := New(Options{Store: fs})
+	err := d.Deliver(store.Notification{Name: "pager", Type: "pagerduty", Target: "http://127.0.0.1:1"}, Event{Name: "test", Message: "hi"})
+	if err == nil {
+		t.Fatalf("expected error for unsupported type")
+	}
+	if len(fs.deliveries) != 1 || fs.deliveries[0].Status != store.DeliveryFailed {
+		t.Fatalf("expected failed delivery record, got %+v", fs.deliveries)
+	}
+	if len(fs.history) != 1 || fs.history[0].Event != "notification_failed" {
+		t.Fatalf("expected notification_failed history, got %+v", fs.history)
+	}
+}
+
+func TestValidType(t *testing.T) {
+	for _, typ := range []string{"slack", "slack-webhook", "", "webhook", "MSTeams", "teams"} {
+		if !ValidType(typ) {
+			t.Fatalf("expected %q to be valid", typ)
+		}
+	}
+	if ValidType("pagerduty") {
+		t.Fatalf("expected pagerduty to be rejected")
+	}
+}
+
+func TestBroadcastDeliversOncePerTarget(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The stored channel points at the same webhook as SLACK_WEBHOOK_URL.
+	fs := &fakeStore{channels: []store.Notification{
+		{Name: "ops", Type: TypeSlack, Target: " " + server.URL + "/slack"},
+		{Name: "hook", Type: TypeWebhook, Target: server.URL + "/hook"},
+	}}
+	d := New(Options{Store: fs, SlackWebhookURL: server.URL + "/slack"})
+	d.Broadcast(Event{Name: "weights.install.completed", Message: "done"})
+
+	if hits != 2 || len(fs.deliveries) != 2 {
+		t.Fatalf("expected one delivery per target, got %d hits and %+v", hits, fs.deliveries)
+	}
+	if fs.deliveries[0].Channel != DefaultChannel || fs.deliveries[1].Channel != "hook" {
+		t.Fatalf("expected the first channel per target to win, got %+v", fs.deliveries)
+	}
+}
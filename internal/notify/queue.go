@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+
+	"github.com/oremus-labs/ol-model-manager/internal/metrics"
+)
+
+// DefaultQueueSize bounds the events waiting for delivery in a Queue.
+const DefaultQueueSize = 64
+
+// Broadcaster delivers an event to every configured channel.
+type Broadcaster interface {
+	Broadcast(Event)
+}
+
+// Queue hands events to a Broadcaster from a single goroutine so a slow
+// webhook never holds up the caller. Broadcast never blocks: when the queue is
+// full, or closed, the event is dropped and counted.
+type Queue struct {
+	target  Broadcaster
+	events  chan Event
+	done    chan struct{}
+	dropped atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewQueue starts delivering queued events to target; size <= 0 uses
+// DefaultQueueSize.
+func NewQueue(target Broadcaster, size int) *Queue {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	q := &Queue{
+		target: target,
+		events: make(chan Event, size),
+		done:   make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Broadcast queues evt for delivery.
+func (q *Queue) Broadcast(evt Event) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		q.drop(evt, "closed")
+		return
+	}
+	select {
+	case q.events <- evt:
+	default:
+		q.drop(evt, "full")
+	}
+}
+
+// Dropped returns how many events were dropped.
+func (q *Queue) Dropped() int64 {
+	return q.dropped.Load()
+}
+
+// Close stops accepting events and waits until the queued ones are delivered
+// or ctx is done.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.events)
+	}
+	q.mu.Unlock()
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for evt := range q.events {
+		q.target.Broadcast(evt)
+	}
+}
+
+func (q *Queue) drop(evt Event, reason string) {
+	q.dropped.Add(1)
+	metrics.ObserveNotificationDropped()
+	log.Printf("notify: queue %s, dropping %s for %s", reason, evt.Name, evt.ModelID)
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// stalledBroadcaster holds every event until release is closed.
+type stalledBroadcaster struct {
+	release   chan struct{}
+	delivered chan Event
+}
+
+func (b *stalledBroadcaster) Broadcast(evt Event) {
+	<-b.release
+	b.delivered <- evt
+}
+
+func TestQueueDropsAndCountsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	target := &stalledBroadcaster{release: make(chan struct{}), delivered: make(chan Event, 16)}
+	q := NewQueue(target, 2)
+
+	q.Broadcast(Event{Name: "first"})
+	// Wait for the worker to pick up the first event so exactly two fit.
+	deadline := time.Now().Add(2 * time.Second)
+	for len(q.events) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("queue worker never took the first event")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < 5; i++ {
+		q.Broadcast(Event{Name: fmt.Sprintf("burst-%d", i)})
+	}
+	if got := q.Dropped(); got != 3 {
+		t.Fatalf("dropped = %d, want 3", got)
+	}
+
+	close(target.release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := q.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	var names []string
+	for len(target.delivered) > 0 {
+		names = append(names, (<-target.delivered).Name)
+	}
+	if fmt.Sprint(names) != "[first burst-0 burst-1]" {
+		t.Fatalf("delivered %v", names)
+	}
+
+	q.Broadcast(Event{Name: "late"})
+	if got := q.Dropped(); got != 4 {
+		t.Fatalf("expected events after Close to be dropped, dropped = %d", got)
+	}
+}
+
+func TestQueueCloseGivesUpWhenDeliveryStalls(t *testing.T) {
+	t.Parallel()
+
+	target := &stalledBroadcaster{release: make(chan struct{}), delivered: make(chan Event, 1)}
+	defer close(target.release)
+	q := NewQueue(target, 1)
+	q.Broadcast(Event{Name: "stuck"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.Close(ctx); err == nil {
+		t.Fatal("expected Close to report the undelivered event")
+	}
+}
@@ -0,0 +1,264 @@
+// Package policy evaluates stored policy documents against models before they
+// are installed or activated.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/vllm"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Action is the operation a policy is evaluated for.
+type Action string
+
+// Actions policies can guard.
+const (
+	ActionInstall  Action = "install"
+	ActionActivate Action = "activate"
+)
+
+// Rule names reported in violations.
+const (
+	RuleAllowedLicenses = "allowedLicenses"
+	RuleAllowedOrgs     = "allowedOrgs"
+	RuleMaxGPUCount     = "maxGpuCount"
+	RuleRequiredTags    = "requiredTags"
+)
+
+// Document is the JSON form of a policy. Every rule is optional; a document
+// without rules never blocks anything.
+type Document struct {
+	// Actions limits the policy to these actions; empty means all of them.
+	Actions []Action `json:"actions,omitempty"`
+	// AllowedLicenses lists the licenses models may declare (case-insensitive).
+	AllowedLicenses []string `json:"allowedLicenses,omitempty"`
+	// AllowUnknownLicense lets models that declare no license through
+	// AllowedLicenses.
+	AllowUnknownLicense bool `json:"allowUnknownLicense,omitempty"`
+	// AllowedOrgs lists the Hugging Face organizations models may come from.
+	AllowedOrgs []string `json:"allowedOrgs,omitempty"`
+	// MaxGPUCount caps the GPUs a catalog entry may request on activation.
+	MaxGPUCount int64 `json:"maxGpuCount,omitempty"`
+	// RequiredTags must all be present on a catalog entry to activate it.
+	RequiredTags []string `json:"requiredTags,omitempty"`
+}
+
+// Policy is a parsed, named policy document.
+type Policy struct {
+	Name string
+	Document
+}
+
+// Model is what policies are evaluated against. Install requests only know
+// the Hugging Face metadata; activations add the catalog entry's tags and GPU
+// request.
+type Model struct {
+	ID        string
+	HFModelID string
+	Licenses  []string
+	Tags      []string
+	GPUCount  int64
+}
+
+// Violation reports the policy rule a model failed.
+type Violation struct {
+	Policy  string `json:"policy"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("policy %s rule %s: %s", v.Policy, v.Rule, v.Message)
+}
+
+// Parse decodes a policy document. Unknown fields (usually a misspelled rule
+// that would otherwise silently allow everything) and rules that would block
+// every model (an empty allowlist) or can never be satisfied are rejected.
+func Parse(name, document string) (*Policy, error) {
+	var doc Document
+	dec := json.NewDecoder(strings.NewReader(document))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("policy %s must be a JSON object of known rules: %w", name, err)
+	}
+	for _, action := range doc.Actions {
+		if action != ActionInstall && action != ActionActivate {
+			return nil, fmt.Errorf("policy %s: unknown action %q (want %s or %s)", name, action, ActionInstall, ActionActivate)
+		}
+	}
+	if doc.AllowedLicenses != nil && len(doc.AllowedLicenses) == 0 {
+		return nil, fmt.Errorf("policy %s: allowedLicenses is empty; omit it to allow any license", name)
+	}
+	if doc.AllowedOrgs != nil && len(doc.AllowedOrgs) == 0 {
+		return nil, fmt.Errorf("policy %s: allowedOrgs is empty; omit it to allow any organization", name)
+	}
+	if doc.MaxGPUCount < 0 {
+		return nil, fmt.Errorf("policy %s: maxGpuCount must not be negative", name)
+	}
+	return &Policy{Name: name, Document: doc}, nil
+}
+
+// Upgrade rewrites a document stored before policies were enforced so Parse
+// accepts it, dropping fields that are not rules. It returns the rewritten
+// document and the dropped field names. Documents that are not JSON objects,
+// or whose rules are themselves invalid, still fail.
+func Upgrade(name, document string) (string, []string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(document), &fields); err != nil {
+		return "", nil, fmt.Errorf("policy %s must be a JSON object: %w", name, err)
+	}
+	var dropped []string
+	for key := range fields {
+		if !isRule(key) {
+			dropped = append(dropped, key)
+			delete(fields, key)
+		}
+	}
+	sort.Strings(dropped)
+	upgraded, err := json.Marshal(fields)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := Parse(name, string(upgraded)); err != nil {
+		return "", nil, err
+	}
+	return string(upgraded), dropped, nil
+}
+
+// isRule reports whether key names a Document field, matching case the way
+// encoding/json does.
+func isRule(key string) bool {
+	doc := reflect.TypeOf(Document{})
+	for i := 0; i < doc.NumField(); i++ {
+		tag, _, _ := strings.Cut(doc.Field(i).Tag.Get("json"), ",")
+		if strings.EqualFold(tag, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppliesTo reports whether the policy guards action.
+func (p *Policy) AppliesTo(action Action) bool {
+	if len(p.Actions) == 0 {
+		return true
+	}
+	for _, candidate := range p.Actions {
+		if candidate == action {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsLicenses reports whether evaluating the policy for action requires the
+// model's licenses, which may cost a Hugging Face lookup.
+func (p *Policy) NeedsLicenses(action Action) bool {
+	return p.AppliesTo(action) && len(p.AllowedLicenses) > 0
+}
+
+// Evaluate checks model against every policy that applies to action and
+// returns the first violation, or nil when all of them pass. Required tags
+// and the GPU cap only apply to activation, since installs have no catalog
+// entry.
+func Evaluate(policies []*Policy, model Model, action Action) *Violation {
+	for _, p := range policies {
+		if p == nil || !p.AppliesTo(action) {
+			continue
+		}
+		if v := p.evaluate(model, action); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (p *Policy) evaluate(model Model, action Action) *Violation {
+	if len(p.AllowedOrgs) > 0 && model.HFModelID != "" {
+		org := vllm.HuggingFaceOrg(model.HFModelID)
+		if !containsFold(p.AllowedOrgs, org) {
+			return p.violation(RuleAllowedOrgs, "organization %q of %s is not allowed (allowed: %s)", org, model.HFModelID, strings.Join(p.AllowedOrgs, ", "))
+		}
+	}
+	if len(p.AllowedLicenses) > 0 {
+		if len(model.Licenses) == 0 && !p.AllowUnknownLicense {
+			return p.violation(RuleAllowedLicenses, "model %s declares no license (allowed: %s)", model.ID, strings.Join(p.AllowedLicenses, ", "))
+		}
+		for _, license := range model.Licenses {
+			if !containsFold(p.AllowedLicenses, license) {
+				return p.violation(RuleAllowedLicenses, "license %q of model %s is not allowed (allowed: %s)", license, model.ID, strings.Join(p.AllowedLicenses, ", "))
+			}
+		}
+	}
+	if action != ActionActivate {
+		return nil
+	}
+	if p.MaxGPUCount > 0 && model.GPUCount > p.MaxGPUCount {
+		return p.violation(RuleMaxGPUCount, "model %s requests %d GPUs, above the limit of %d", model.ID, model.GPUCount, p.MaxGPUCount)
+	}
+	for _, tag := range p.RequiredTags {
+		if !containsFold(model.Tags, tag) {
+			return p.violation(RuleRequiredTags, "model %s is missing required tag %q", model.ID, tag)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) violation(rule, format string, args ...interface{}) *Violation {
+	return &Violation{Policy: p.Name, Rule: rule, Message: fmt.Sprintf(format, args...)}
+}
+
+// FromHuggingFace describes a Hub model that is about to be installed.
+func FromHuggingFace(id string, hf *vllm.HuggingFaceModel) Model {
+	return Model{ID: id, HFModelID: id, Licenses: vllm.HuggingFaceLicenses(hf)}
+}
+
+// FromCatalog describes a catalog entry that is about to be activated. hf may
+// be nil when the entry has no Hugging Face source or it was not looked up.
+func FromCatalog(model *catalog.Model, hf *vllm.HuggingFaceModel) Model {
+	return Model{
+		ID:        model.ID,
+		HFModelID: model.HFModelID,
+		Licenses:  vllm.HuggingFaceLicenses(hf),
+		Tags:      model.Tags,
+		GPUCount:  gpuCount(model.Resources),
+	}
+}
+
+// gpuCount returns the largest GPU quantity in the limits or requests.
+func gpuCount(res *catalog.Resources) int64 {
+	if res == nil {
+		return 0
+	}
+	var count int64
+	for _, resources := range []map[string]string{res.Limits, res.Requests} {
+		for name, value := range resources {
+			if !strings.Contains(strings.ToLower(name), "gpu") {
+				continue
+			}
+			qty, err := resource.ParseQuantity(value)
+			if err != nil {
+				continue
+			}
+			if qty.Value() > count {
+				count = qty.Value()
+			}
+		}
+	}
+	return count
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
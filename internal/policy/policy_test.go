@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/vllm"
+)
+
+func mustParse(t *testing.T, name, document string) *Policy {
+	t.Helper()
+	p, err := Parse(name, document)
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", name, err)
+	}
+	return p
+}
+
+func TestParseRejectsUnusableDocuments(t *testing.T) {
+	for _, doc := range []string{
+		`not json`,
+		`{"allowedLicenses":[]}`,
+		`{"allowedOrgs":[]}`,
+		`{"maxGpuCount":-1}`,
+		`{"actions":["delete"]}`,
+		`{"allowedLicence":["mit"]}`,
+	} {
+		if _, err := Parse("bad", doc); err == nil {
+			t.Errorf("expected %s to be rejected", doc)
+		}
+	}
+	if _, err := Parse("empty", `{}`); err != nil {
+		t.Fatalf("expected empty document to parse: %v", err)
+	}
+}
+
+func TestUpgradeDropsUnknownFields(t *testing.T) {
+	upgraded, dropped, err := Upgrade("legacy", `{"requiredTag":["approved"],"AllowedOrgs":["qwen"],"owner":"ops"}`)
+	if err != nil {
+		t.Fatalf("Upgrade: %v", err)
+	}
+	if len(dropped) != 2 || dropped[0] != "owner" || dropped[1] != "requiredTag" {
+		t.Fatalf("dropped = %v", dropped)
+	}
+	p := mustParse(t, "legacy", upgraded)
+	if len(p.AllowedOrgs) != 1 || p.AllowedOrgs[0] != "qwen" || len(p.RequiredTags) != 0 {
+		t.Fatalf("unexpected upgraded policy %+v from %s", p.Document, upgraded)
+	}
+
+	for _, doc := range []string{`not json`, `["allowedOrgs"]`, `{"allowedOrgs":[],"owner":"ops"}`} {
+		if _, _, err := Upgrade("legacy", doc); err == nil {
+			t.Errorf("expected %s to stay invalid", doc)
+		}
+	}
+}
+
+func TestEvaluateReportsFailingRule(t *testing.T) {
+	policies := []*Policy{
+		mustParse(t, "licenses", `{"allowedLicenses":["apache-2.0","mit"]}`),
+		mustParse(t, "orgs", `{"allowedOrgs":["qwen"]}`),
+		mustParse(t, "prod", `{"actions":["activate"],"maxGpuCount":2,"requiredTags":["approved"]}`),
+	}
+	hf := &vllm.HuggingFaceModel{Tags: []string{"license:apache-2.0"}}
+	entry := &catalog.Model{
+		ID:        "qwen-small",
+		HFModelID: "Qwen/Qwen2.5-0.5B",
+		Tags:      []string{"approved"},
+		Resources: &catalog.Resources{Limits: map[string]string{"nvidia.com/gpu": "1"}},
+	}
+
+	if v := Evaluate(policies, FromCatalog(entry, hf), ActionActivate); v != nil {
+		t.Fatalf("expected compliant model to pass, got %v", v)
+	}
+
+	cases := []struct {
+		name   string
+		model  Model
+		action Action
+		policy string
+		rule   string
+	}{
+		{"license", FromHuggingFace("Qwen/Qwen2.5-0.5B", &vllm.HuggingFaceModel{Tags: []string{"license:cc-by-nc-4.0"}}), ActionInstall, "licenses", RuleAllowedLicenses},
+		{"no license", FromHuggingFace("Qwen/Qwen2.5-0.5B", nil), ActionInstall, "licenses", RuleAllowedLicenses},
+		{"org", FromHuggingFace("mistralai/Mistral-7B-v0.1", hf), ActionInstall, "orgs", RuleAllowedOrgs},
+		{"gpus", Model{ID: "big", HFModelID: "Qwen/Qwen2.5-72B", Licenses: []string{"mit"}, Tags: []string{"approved"}, GPUCount: 4}, ActionActivate, "prod", RuleMaxGPUCount},
+		{"tags", Model{ID: "untagged", HFModelID: "Qwen/Qwen2.5-0.5B", Licenses: []string{"mit"}}, ActionActivate, "prod", RuleRequiredTags},
+	}
+	for _, tc := range cases {
+		v := Evaluate(policies, tc.model, tc.action)
+		if v == nil || v.Policy != tc.policy || v.Rule != tc.rule {
+			t.Errorf("%s: expected %s/%s violation, got %+v", tc.name, tc.policy, tc.rule, v)
+		}
+	}
+
+	// Activation-only rules don't apply to installs.
+	if v := Evaluate(policies, Model{ID: "untagged", HFModelID: "Qwen/Qwen2.5-0.5B", Licenses: []string{"mit"}}, ActionInstall); v != nil {
+		t.Fatalf("expected install to ignore activation policy, got %v", v)
+	}
+}
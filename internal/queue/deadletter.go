@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// deadLetterSuffix names the dead-letter stream alongside the job stream.
+const deadLetterSuffix = ":deadletter"
+
+// DeadLetterEntry is a job message that was pulled off the main stream because
+// it can never succeed (malformed payload or retries exhausted).
+type DeadLetterEntry struct {
+	ID        string    `json:"id,omitempty"`
+	MessageID string    `json:"messageId,omitempty"`
+	JobID     string    `json:"jobId,omitempty"`
+	Data      string    `json:"data,omitempty"`
+	Reason    string    `json:"reason"`
+	FailedAt  time.Time `json:"failedAt"`
+}
+
+// MalformedMessageError is returned by Next when a stream entry can't be
+// decoded. It carries the raw payload so the entry can be dead-lettered.
+type MalformedMessageError struct {
+	Data string
+	Err  error
+}
+
+func (e *MalformedMessageError) Error() string {
+	return fmt.Sprintf("malformed job message: %v", e.Err)
+}
+
+func (e *MalformedMessageError) Unwrap() error {
+	return e.Err
+}
+
+func newDeadLetterEntry(id, data string, msg *WeightInstallMessage, reason string) DeadLetterEntry {
+	entry := DeadLetterEntry{
+		MessageID: id,
+		Data:      data,
+		Reason:    reason,
+		FailedAt:  time.Now().UTC(),
+	}
+	if msg != nil {
+		entry.JobID = msg.JobID
+		if entry.Data == "" {
+			if raw, err := json.Marshal(msg); err == nil {
+				entry.Data = string(raw)
+			}
+		}
+	}
+	return entry
+}
+
+// DeadLetter moves a delivered message to the dead-letter stream and
+// acknowledges the original so it no longer clogs the consumer group. Pass
+// the raw payload as data when msg could not be decoded.
+func (c *Consumer) DeadLetter(ctx context.Context, id string, msg *WeightInstallMessage, data, reason string) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("queue consumer not configured")
+	}
+	entry := newDeadLetterEntry(id, data, msg, reason)
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: c.stream + deadLetterSuffix,
+			ID:     "*",
+			Values: map[string]interface{}{
+				"messageId": entry.MessageID,
+				"jobId":     entry.JobID,
+				"data":      entry.Data,
+				"reason":    entry.Reason,
+				"failedAt":  entry.FailedAt.Format(time.RFC3339Nano),
+			},
+		})
+		if id != "" {
+			pipe.XAck(ctx, c.streamFor(id), c.group, id)
+		}
+		return nil
+	})
+	if err == nil {
+		c.forget(id)
+	}
+	return err
+}
+
+// ListDeadLetter returns up to limit dead-lettered messages, newest first.
+func (p *Producer) ListDeadLetter(ctx context.Context, limit int64) ([]DeadLetterEntry, error) {
+	if p == nil || p.client == nil {
+		return nil, fmt.Errorf("queue producer not configured")
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	msgs, err := p.client.XRevRangeN(ctx, p.stream+deadLetterSuffix, "+", "-", limit).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]DeadLetterEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		entry := DeadLetterEntry{ID: msg.ID}
+		entry.MessageID, _ = msg.Values["messageId"].(string)
+		entry.JobID, _ = msg.Values["jobId"].(string)
+		entry.Data, _ = msg.Values["data"].(string)
+		entry.Reason, _ = msg.Values["reason"].(string)
+		if raw, ok := msg.Values["failedAt"].(string); ok {
+			entry.FailedAt, _ = time.Parse(time.RFC3339Nano, raw)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
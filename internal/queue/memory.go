@@ -0,0 +1,155 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/oremus-labs/ol-model-manager/internal/jobs"
+)
+
+// Memory is an in-process stand-in for the Redis stream. It implements both
+// the producer and consumer sides so a server and worker can share one
+// instance in tests and test mode.
+type Memory struct {
+	mu      sync.Mutex
+	ready   []memoryEntry
+	pending map[string]*WeightInstallMessage
+	dead    []DeadLetterEntry
+	notify  chan struct{}
+}
+
+type memoryEntry struct {
+	id  string
+	msg *WeightInstallMessage
+}
+
+// NewMemory creates an empty in-memory queue.
+func NewMemory() *Memory {
+	return &Memory{
+		pending: make(map[string]*WeightInstallMessage),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// Enqueue appends a weight install request to the queue.
+func (m *Memory) Enqueue(ctx context.Context, jobID string, req jobs.InstallRequest) error {
+	if jobID == "" {
+		jobID = uuid.NewString()
+	}
+	m.push(&WeightInstallMessage{JobID: jobID, Request: req})
+	return nil
+}
+
+// Length returns the number of messages that are queued or awaiting acknowledgement.
+func (m *Memory) Length(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.ready) + len(m.pending)), nil
+}
+
+// Ping always succeeds; the in-memory queue has no backing service.
+func (m *Memory) Ping(ctx context.Context) error {
+	return nil
+}
+
+// EnsureGroup is a no-op; the in-memory queue has a single implicit group.
+func (m *Memory) EnsureGroup(ctx context.Context) error {
+	return nil
+}
+
+// Next blocks until a message is available or the context is cancelled.
+func (m *Memory) Next(ctx context.Context) (*WeightInstallMessage, string, error) {
+	for {
+		m.mu.Lock()
+		if len(m.ready) > 0 {
+			entry := m.ready[0]
+			m.ready = m.ready[1:]
+			m.pending[entry.id] = entry.msg
+			m.mu.Unlock()
+			return entry.msg, entry.id, nil
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		case <-m.notify:
+		}
+	}
+}
+
+// Ack confirms processing of a delivered message.
+func (m *Memory) Ack(ctx context.Context, id string) error {
+	if id == "" {
+		return nil
+	}
+	m.mu.Lock()
+	delete(m.pending, id)
+	m.mu.Unlock()
+	return nil
+}
+
+// Requeue re-publishes the message and acknowledges the original delivery.
+func (m *Memory) Requeue(ctx context.Context, id string, msg *WeightInstallMessage) error {
+	if msg == nil {
+		return fmt.Errorf("message is required")
+	}
+	m.mu.Lock()
+	delete(m.pending, id)
+	m.mu.Unlock()
+	clone := *msg
+	m.push(&clone)
+	return nil
+}
+
+// DeadLetter records the message as dead-lettered and acknowledges it.
+func (m *Memory) DeadLetter(ctx context.Context, id string, msg *WeightInstallMessage, data, reason string) error {
+	entry := newDeadLetterEntry(id, data, msg, reason)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, id)
+	entry.ID = uuid.NewString()
+	m.dead = append(m.dead, entry)
+	return nil
+}
+
+// ListDeadLetter returns up to limit dead-lettered messages, newest first.
+func (m *Memory) ListDeadLetter(ctx context.Context, limit int64) ([]DeadLetterEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit <= 0 {
+		limit = 50
+	}
+	entries := make([]DeadLetterEntry, 0, len(m.dead))
+	for i := len(m.dead) - 1; i >= 0 && int64(len(entries)) < limit; i-- {
+		entries = append(entries, m.dead[i])
+	}
+	return entries, nil
+}
+
+// Pending returns the number of delivered messages awaiting acknowledgement.
+func (m *Memory) Pending(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return int64(len(m.pending)), nil
+}
+
+// push queues msg behind every ready message of equal or higher priority so
+// urgent installs are delivered first, as with the Redis priority streams.
+func (m *Memory) push(msg *WeightInstallMessage) {
+	m.mu.Lock()
+	pos := len(m.ready)
+	for pos > 0 && m.ready[pos-1].msg.Request.Priority < msg.Request.Priority {
+		pos--
+	}
+	m.ready = append(m.ready, memoryEntry{})
+	copy(m.ready[pos+1:], m.ready[pos:])
+	m.ready[pos] = memoryEntry{id: uuid.NewString(), msg: msg}
+	m.mu.Unlock()
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +18,44 @@ type WeightInstallMessage struct {
 	Request jobs.InstallRequest `json:"request"`
 }
 
+// Priority tiers live on separate streams next to the base stream so
+// consumers can drain urgent installs first. Normal priority keeps the base
+// stream name, so existing deployments need no migration.
+const (
+	highPrioritySuffix = ":high"
+	lowPrioritySuffix  = ":low"
+)
+
+// tierStreams returns the streams for base in the order consumers poll them.
+func tierStreams(base string) []string {
+	return []string{base + highPrioritySuffix, base, base + lowPrioritySuffix}
+}
+
+// streamForPriority picks the tier stream for an install priority: positive is
+// high, negative is low, zero is normal.
+func streamForPriority(base string, priority int) string {
+	switch {
+	case priority > 0:
+		return base + highPrioritySuffix
+	case priority < 0:
+		return base + lowPrioritySuffix
+	}
+	return base
+}
+
+// streamsLength sums XLEN over every tier; missing streams count as empty.
+func streamsLength(ctx context.Context, client redis.UniversalClient, base string) (int64, error) {
+	var total int64
+	for _, stream := range tierStreams(base) {
+		n, err := client.XLen(ctx, stream).Result()
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
 // Producer publishes jobs onto a Redis Stream.
 type Producer struct {
 	client redis.UniversalClient
@@ -31,7 +70,7 @@ func NewProducer(client redis.UniversalClient, stream string) *Producer {
 	return &Producer{client: client, stream: stream}
 }
 
-// Enqueue pushes a weight install request to the stream.
+// Enqueue pushes a weight install request to the stream for its priority tier.
 func (p *Producer) Enqueue(ctx context.Context, jobID string, req jobs.InstallRequest) error {
 	if p == nil || p.client == nil {
 		return fmt.Errorf("queue producer not configured")
@@ -48,7 +87,7 @@ func (p *Producer) Enqueue(ctx context.Context, jobID string, req jobs.InstallRe
 		return err
 	}
 	return p.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: p.stream,
+		Stream: streamForPriority(p.stream, req.Priority),
 		ID:     "*",
 		Values: map[string]interface{}{
 			"data": data,
@@ -56,21 +95,36 @@ func (p *Producer) Enqueue(ctx context.Context, jobID string, req jobs.InstallRe
 	}).Err()
 }
 
-// Length returns the current length of the stream.
+// Length returns the combined length of the priority streams.
 func (p *Producer) Length(ctx context.Context) (int64, error) {
 	if p == nil || p.client == nil {
 		return 0, fmt.Errorf("queue producer not configured")
 	}
-	return p.client.XLen(ctx, p.stream).Result()
+	return streamsLength(ctx, p.client, p.stream)
+}
+
+// Ping checks that Redis is reachable.
+func (p *Producer) Ping(ctx context.Context) error {
+	if p == nil || p.client == nil {
+		return fmt.Errorf("queue producer not configured")
+	}
+	return p.client.Ping(ctx).Err()
 }
 
-// Consumer pulls jobs from a Redis Stream consumer group.
+// Consumer pulls jobs from a Redis Stream consumer group, preferring the
+// high-priority stream over normal and normal over low.
 type Consumer struct {
-	client   redis.UniversalClient
-	stream   string
-	group    string
-	name     string
-	blockDur time.Duration
+	client       redis.UniversalClient
+	stream       string
+	group        string
+	name         string
+	blockDur     time.Duration
+	pollInterval time.Duration
+
+	// delivered maps unacknowledged message IDs to the tier stream they were
+	// read from so Ack/Requeue/DeadLetter target the right stream.
+	mu        sync.Mutex
+	delivered map[string]string
 }
 
 // NewConsumer creates a consumer bound to a stream + group.
@@ -85,63 +139,118 @@ func NewConsumer(client redis.UniversalClient, stream, group, name string) *Cons
 		name = uuid.NewString()
 	}
 	return &Consumer{
-		client:   client,
-		stream:   stream,
-		group:    group,
-		name:     name,
-		blockDur: 5 * time.Second,
+		client:       client,
+		stream:       stream,
+		group:        group,
+		name:         name,
+		blockDur:     5 * time.Second,
+		pollInterval: 250 * time.Millisecond,
+		delivered:    make(map[string]string),
 	}
 }
 
-// EnsureGroup ensures the consumer group exists.
+// EnsureGroup ensures the consumer group exists on every priority stream.
 func (c *Consumer) EnsureGroup(ctx context.Context) error {
 	if c == nil || c.client == nil {
 		return fmt.Errorf("queue consumer not configured")
 	}
-	err := c.client.XGroupCreateMkStream(ctx, c.stream, c.group, "0").Err()
-	if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
-		return err
+	for _, stream := range tierStreams(c.stream) {
+		err := c.client.XGroupCreateMkStream(ctx, stream, c.group, "0").Err()
+		if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+			return err
+		}
 	}
 	return nil
 }
 
-// Next fetches the next message from the stream (blocking).
+// Next fetches the next message, checking the priority streams from highest
+// to lowest. When all are empty it polls them again every poll interval until
+// a message arrives or the block duration elapses, returning a nil message on
+// timeout. Each read takes at most one message from a single stream, so the
+// consumer never claims more than the message it hands out.
 func (c *Consumer) Next(ctx context.Context) (*WeightInstallMessage, string, error) {
 	if c == nil || c.client == nil {
 		return nil, "", fmt.Errorf("queue consumer not configured")
 	}
-	args := &redis.XReadGroupArgs{
+	deadline := time.Now().Add(c.blockDur)
+	for {
+		for _, stream := range tierStreams(c.stream) {
+			d, err := c.read(ctx, stream)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, "", ctx.Err()
+				}
+				return nil, "", err
+			}
+			if d != nil {
+				return d.msg, d.id, d.err
+			}
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, "", nil
+		}
+		if wait > c.pollInterval {
+			wait = c.pollInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delivery is one message read from a tier stream. err is set (with id) when
+// the payload can't be decoded.
+type delivery struct {
+	msg *WeightInstallMessage
+	id  string
+	err error
+}
+
+// read takes at most one new message from stream without blocking.
+func (c *Consumer) read(ctx context.Context, stream string) (*delivery, error) {
+	res, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    c.group,
 		Consumer: c.name,
-		Streams:  []string{c.stream, ">"},
+		Streams:  []string{stream, ">"},
 		Count:    1,
-		Block:    c.blockDur,
-	}
-	res, err := c.client.XReadGroup(ctx, args).Result()
+		Block:    -1,
+	}).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, "", nil
+			return nil, nil
 		}
-		return nil, "", err
+		return nil, err
 	}
-	for _, stream := range res {
-		for _, msg := range stream.Messages {
-			raw, ok := msg.Values["data"]
-			if !ok {
-				continue
-			}
-			bytes, ok := raw.(string)
-			if !ok {
-				continue
-			}
-			var payload WeightInstallMessage
-			if err := json.Unmarshal([]byte(bytes), &payload); err != nil {
-				return nil, msg.ID, err
-			}
-			return &payload, msg.ID, nil
+	for _, s := range res {
+		for _, msg := range s.Messages {
+			c.mu.Lock()
+			c.delivered[msg.ID] = s.Stream
+			c.mu.Unlock()
+			return decodeMessage(msg), nil
 		}
 	}
-	return nil, "", nil
+	return nil, nil
+}
+
+func decodeMessage(msg redis.XMessage) *delivery {
+	raw, ok := msg.Values["data"]
+	if !ok {
+		return &delivery{id: msg.ID, err: &MalformedMessageError{Err: fmt.Errorf("message has no data field")}}
+	}
+	data, ok := raw.(string)
+	if !ok {
+		return &delivery{id: msg.ID, err: &MalformedMessageError{Err: fmt.Errorf("message data is %T, not a string", raw)}}
+	}
+	var payload WeightInstallMessage
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return &delivery{id: msg.ID, err: &MalformedMessageError{Data: data, Err: err}}
+	}
+	return &delivery{msg: &payload, id: msg.ID}
 }
 
 // Ack confirms processing of a message.
@@ -149,17 +258,84 @@ func (c *Consumer) Ack(ctx context.Context, id string) error {
 	if c == nil || c.client == nil || id == "" {
 		return nil
 	}
-	return c.client.XAck(ctx, c.stream, c.group, id).Err()
+	if err := c.client.XAck(ctx, c.streamFor(id), c.group, id).Err(); err != nil {
+		return err
+	}
+	c.forget(id)
+	return nil
+}
+
+// streamFor returns the tier stream a delivered message came from, defaulting
+// to the base stream for IDs this consumer did not read.
+func (c *Consumer) streamFor(id string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stream, ok := c.delivered[id]; ok {
+		return stream
+	}
+	return c.stream
+}
+
+func (c *Consumer) forget(id string) {
+	c.mu.Lock()
+	delete(c.delivered, id)
+	c.mu.Unlock()
+}
+
+// Requeue re-publishes the message on its priority stream and acknowledges the
+// original so another consumer picks it up immediately instead of waiting for
+// a stale reclaim.
+func (c *Consumer) Requeue(ctx context.Context, id string, msg *WeightInstallMessage) error {
+	if c == nil || c.client == nil {
+		return fmt.Errorf("queue consumer not configured")
+	}
+	if msg == nil {
+		return fmt.Errorf("message is required")
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: streamForPriority(c.stream, msg.Request.Priority),
+			ID:     "*",
+			Values: map[string]interface{}{
+				"data": data,
+			},
+		})
+		if id != "" {
+			pipe.XAck(ctx, c.streamFor(id), c.group, id)
+		}
+		return nil
+	})
+	if err == nil {
+		c.forget(id)
+	}
+	return err
+}
+
+// Length returns the combined length of the priority streams.
+func (c *Consumer) Length(ctx context.Context) (int64, error) {
+	if c == nil || c.client == nil {
+		return 0, fmt.Errorf("queue consumer not configured")
+	}
+	return streamsLength(ctx, c.client, c.stream)
 }
 
-// Pending returns the number of entries pending acknowledgement for this group.
+// Pending returns the number of entries pending acknowledgement for this group
+// across the priority streams.
 func (c *Consumer) Pending(ctx context.Context) (int64, error) {
 	if c == nil || c.client == nil {
 		return 0, fmt.Errorf("queue consumer not configured")
 	}
-	info, err := c.client.XPending(ctx, c.stream, c.group).Result()
-	if err != nil {
-		return 0, err
+	var total int64
+	for _, stream := range tierStreams(c.stream) {
+		info, err := c.client.XPending(ctx, stream, c.group).Result()
+		if err != nil {
+			return 0, err
+		}
+		total += info.Count
 	}
-	return info.Count, nil
+	return total, nil
 }
@@ -3,10 +3,8 @@ package recommendations
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,6 +21,11 @@ type GPUProfile struct {
 	DeviceID    string            `json:"deviceId,omitempty"`
 	Features    []string          `json:"features,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
+	// MaxGPUsPerNode caps tensor parallelism on this profile (default 1).
+	MaxGPUsPerNode int `json:"maxGpusPerNode,omitempty"`
+	// CostPerHour is an optional relative price used to pick the cheapest
+	// fitting profile; MemoryGB is used when it is unset.
+	CostPerHour float64 `json:"costPerHour,omitempty"`
 }
 
 // Engine produces compatibility reports and runtime recommendations.
@@ -40,6 +43,11 @@ type CompatibilityReport struct {
 	Compatible      bool        `json:"compatible"`
 	Candidates      []Candidate `json:"candidates,omitempty"`
 	Suggestions     []string    `json:"suggestions,omitempty"`
+	// Schedulable and FreeGPUs are only set when live GPU inventory is
+	// available: whether a node matching the profile has the GPUs the model
+	// requests free right now, and how many are free across those nodes.
+	Schedulable *bool `json:"schedulable,omitempty"`
+	FreeGPUs    *int  `json:"freeGPUs,omitempty"`
 }
 
 // Candidate conveys compatibility per GPU profile.
@@ -47,6 +55,9 @@ type Candidate struct {
 	GPU        string `json:"gpu"`
 	Compatible bool   `json:"compatible"`
 	Reason     string `json:"reason,omitempty"`
+	// Schedulable and FreeGPUs mirror the report fields per profile.
+	Schedulable *bool `json:"schedulable,omitempty"`
+	FreeGPUs    *int  `json:"freeGPUs,omitempty"`
 }
 
 // Recommendation captures runtime hints for a GPU.
@@ -55,6 +66,12 @@ type Recommendation struct {
 	MemoryGB int      `json:"memoryGB,omitempty"`
 	Flags    []string `json:"flags"`
 	Notes    []string `json:"notes"`
+	// EstimatedVRAMGB is the model's requirement the sizing is based on.
+	EstimatedVRAMGB int `json:"estimatedVramGb,omitempty"`
+	// TensorParallelSize is the smallest GPU count on one node that fits the
+	// model; 0 when it does not fit (see Feasible).
+	TensorParallelSize int  `json:"tensorParallelSize,omitempty"`
+	Feasible           bool `json:"feasible"`
 }
 
 // LoadProfiles loads GPU profiles from a JSON file.
@@ -93,7 +110,13 @@ func New(profiles map[string]GPUProfile) *Engine {
 
 // Compatibility evaluates whether the model can fit on the provided GPU type.
 func (e *Engine) Compatibility(model *catalog.Model, gpuType string) CompatibilityReport {
-	required, reason := estimateModelVRAM(model)
+	return e.CompatibilityWithConfig(model, gpuType, nil)
+}
+
+// CompatibilityWithConfig is Compatibility with the model's Hugging Face config,
+// which lets EstimateVRAM size the model from its architecture.
+func (e *Engine) CompatibilityWithConfig(model *catalog.Model, gpuType string, cfg map[string]interface{}) CompatibilityReport {
+	required, reason := EstimateVRAM(model, cfg)
 	report := CompatibilityReport{
 		ModelID:         model.ID,
 		EstimatedVRAMGB: required,
@@ -158,11 +181,20 @@ func (e *Engine) RecommendForModel(model *catalog.Model, gpuType string) Recomme
 
 	var required int
 	if model != nil {
-		required, _ = estimateModelVRAM(model)
+		required, _ = EstimateVRAM(model, nil)
 	} else {
 		required = 16
 	}
-	margin := profile.MemoryGB - required
+	rec.EstimatedVRAMGB = required
+	tp, feasible := TensorParallelSize(required, profile)
+	rec.Feasible = feasible
+	maxGPUs := MaxGPUsPerNode(profile)
+	available := profile.MemoryGB * maxGPUs
+	if feasible {
+		rec.TensorParallelSize = tp
+		available = profile.MemoryGB * tp
+	}
+	margin := available - required
 
 	if hasFeature(profile, "bf16") && profile.MemoryGB >= 32 {
 		rec.Flags = append(rec.Flags, "--dtype", "bfloat16")
@@ -174,7 +206,14 @@ func (e *Engine) RecommendForModel(model *catalog.Model, gpuType string) Recomme
 		rec.Notes = append(rec.Notes, "Enough VRAM for most 70B models without quantization")
 	} else if profile.MemoryGB <= 32 {
 		rec.Notes = append(rec.Notes, "Plan for 4-bit/8-bit quantization on >7B models")
-		rec.Flags = append(rec.Flags, "--tensor-parallel-size", "2")
+	}
+
+	switch {
+	case !feasible:
+		rec.Notes = append(rec.Notes, fmt.Sprintf("infeasible: ~%d GiB required but %d× %s provide only %d GiB; quantize the model or use a larger GPU", required, maxGPUs, profile.Name, available))
+	case tp > 1:
+		rec.Flags = append(rec.Flags, "--tensor-parallel-size", strconv.Itoa(tp))
+		rec.Notes = append(rec.Notes, fmt.Sprintf("~%d GiB required; shard across %d GPUs (%d GiB total)", required, tp, available))
 	}
 
 	if model != nil {
@@ -209,37 +248,38 @@ func (e *Engine) Profiles() []GPUProfile {
 	return out
 }
 
-var sizePattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(b|m)`)
-
-func estimateModelVRAM(model *catalog.Model) (int, string) {
-	source := ""
-	if model != nil {
-		source = model.HFModelID
-		if source == "" {
-			source = model.ID
-		}
+// TensorParallelSize returns the smallest tensor-parallel size that fits
+// requiredGB on profile, preferring powers of two (vLLM needs TP to divide the
+// attention heads). It reports false when even MaxGPUsPerNode GPUs fall short.
+func TensorParallelSize(requiredGB int, profile GPUProfile) (int, bool) {
+	if profile.MemoryGB <= 0 {
+		return 0, false
 	}
-	matches := sizePattern.FindStringSubmatch(source)
-	if len(matches) == 3 {
-		value, _ := strconv.ParseFloat(matches[1], 64)
-		unit := strings.ToLower(matches[2])
-		var required float64
-		switch unit {
-		case "b":
-			required = value*2.0 + 6
-			if value >= 40 {
-				required = math.Max(required, 80)
-			}
-		case "m":
-			required = value*0.002 + 6
-		}
-		if required < 8 {
-			required = 8
-		}
-		return int(math.Ceil(required)), fmt.Sprintf("derived from %s", matches[0])
+	needed := (requiredGB + profile.MemoryGB - 1) / profile.MemoryGB
+	if needed < 1 {
+		needed = 1
+	}
+	maxGPUs := MaxGPUsPerNode(profile)
+	if needed > maxGPUs {
+		return 0, false
 	}
+	tp := 1
+	for tp < needed {
+		tp *= 2
+	}
+	if tp > maxGPUs {
+		tp = needed
+	}
+	return tp, true
+}
 
-	return 16, "default requirement"
+// MaxGPUsPerNode returns how many GPUs of profile one node can give a single
+// model, defaulting to 1.
+func MaxGPUsPerNode(profile GPUProfile) int {
+	if profile.MaxGPUsPerNode > 0 {
+		return profile.MaxGPUsPerNode
+	}
+	return 1
 }
 
 func buildSuggestions(profile GPUProfile) []string {
@@ -0,0 +1,52 @@
+package recommendations
+
+import (
+	"sort"
+	"strings"
+)
+
+// ProfileOption pairs a GPU profile with the model's sizing on it, including
+// the tensor-parallel size needed to fit.
+type ProfileOption struct {
+	Profile        GPUProfile     `json:"profile"`
+	Recommendation Recommendation `json:"recommendation"`
+}
+
+// gpus is how many GPUs of the profile the option uses.
+func (o ProfileOption) gpus() int {
+	if o.Recommendation.TensorParallelSize > 0 {
+		return o.Recommendation.TensorParallelSize
+	}
+	return 1
+}
+
+// RankFitting returns the options the model fits on (sharded across GPUs if
+// need be), cheapest first. Options are compared by CostPerHour times the
+// GPUs used; profiles without a cost always rank after those with one and are
+// compared by total memory, so the smallest fit wins. Ties break on name.
+func RankFitting(options []ProfileOption) []ProfileOption {
+	fitting := make([]ProfileOption, 0, len(options))
+	for _, option := range options {
+		if option.Recommendation.Feasible {
+			fitting = append(fitting, option)
+		}
+	}
+	sort.SliceStable(fitting, func(i, j int) bool {
+		a, b := fitting[i], fitting[j]
+		aCosted, bCosted := a.Profile.CostPerHour > 0, b.Profile.CostPerHour > 0
+		if aCosted != bCosted {
+			return aCosted
+		}
+		if aCosted {
+			aCost, bCost := a.Profile.CostPerHour*float64(a.gpus()), b.Profile.CostPerHour*float64(b.gpus())
+			if aCost != bCost {
+				return aCost < bCost
+			}
+		}
+		if aMem, bMem := a.Profile.MemoryGB*a.gpus(), b.Profile.MemoryGB*b.gpus(); aMem != bMem {
+			return aMem < bMem
+		}
+		return strings.ToLower(a.Profile.Name) < strings.ToLower(b.Profile.Name)
+	})
+	return fitting
+}
@@ -0,0 +1,158 @@
+package recommendations
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+)
+
+const (
+	// vramOverheadRatio covers KV cache and activations on top of the weights.
+	vramOverheadRatio = 0.2
+	// vramRuntimeGB is the fixed CUDA/ROCm context and vLLM runtime cost.
+	vramRuntimeGB = 4
+	minVRAMGB     = 8
+	defaultVRAMGB = 16
+)
+
+var sizePattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(b|m)`)
+
+// EstimateVRAM estimates the GiB of GPU memory needed to serve model. The
+// parameter count comes from cfg (a Hugging Face config.json) when possible:
+// num_parameters if present, otherwise hidden_size × num_hidden_layers (plus
+// vocab_size and intermediate_size when set). Without a usable config it falls
+// back to a size hint in the model ID such as "7B". Bytes per parameter follow
+// the model's quantization, then vLLM dtype, then the config's torch_dtype
+// (fp32 = 4, fp16/bf16 = 2, fp8/int8 = 1, 4-bit methods = 0.5). The returned
+// reason describes how the number was derived.
+func EstimateVRAM(model *catalog.Model, cfg map[string]interface{}) (int, string) {
+	bytesPerParam, precision := bytesPerParameter(model, cfg)
+
+	if params, source := configParameters(cfg); params > 0 {
+		weightsGB := params * bytesPerParam / (1 << 30)
+		required := weightsGB*(1+vramOverheadRatio) + vramRuntimeGB
+		return roundVRAM(required), fmt.Sprintf("%.1fB parameters (%s) at %s", params/1e9, source, precision)
+	}
+
+	source := ""
+	if model != nil {
+		source = model.HFModelID
+		if source == "" {
+			source = model.ID
+		}
+	}
+	matches := sizePattern.FindStringSubmatch(source)
+	if len(matches) == 3 {
+		value, _ := strconv.ParseFloat(matches[1], 64)
+		var required float64
+		switch strings.ToLower(matches[2]) {
+		case "b":
+			required = value*bytesPerParam + 6
+			if value >= 40 && bytesPerParam >= 2 {
+				required = math.Max(required, 80)
+			}
+		case "m":
+			required = value*bytesPerParam/1000 + 6
+		}
+		return roundVRAM(required), fmt.Sprintf("derived from %s at %s", matches[0], precision)
+	}
+
+	return defaultVRAMGB, "default requirement"
+}
+
+func roundVRAM(required float64) int {
+	if required < minVRAMGB {
+		required = minVRAMGB
+	}
+	return int(math.Ceil(required))
+}
+
+// configParameters returns the parameter count described by a Hugging Face
+// config and which fields it came from.
+func configParameters(cfg map[string]interface{}) (float64, string) {
+	if len(cfg) == 0 {
+		return 0, ""
+	}
+	if n := configNumber(cfg, "num_parameters"); n > 0 {
+		return n, "num_parameters"
+	}
+	hidden := configNumber(cfg, "hidden_size", "d_model", "n_embd")
+	layers := configNumber(cfg, "num_hidden_layers", "num_layers", "n_layer")
+	if hidden <= 0 || layers <= 0 {
+		return 0, ""
+	}
+	// Attention projections (4h²) plus a gated MLP (3·h·intermediate), or the
+	// classic 12h² per layer when the MLP width is unknown.
+	perLayer := 12 * hidden * hidden
+	if intermediate := configNumber(cfg, "intermediate_size", "ffn_dim", "n_inner"); intermediate > 0 {
+		perLayer = 4*hidden*hidden + 3*hidden*intermediate
+	}
+	params := layers * perLayer
+	if vocab := configNumber(cfg, "vocab_size"); vocab > 0 {
+		params += 2 * vocab * hidden
+	}
+	return params, "hidden_size × num_hidden_layers"
+}
+
+// configNumber returns the first positive numeric value among keys.
+func configNumber(cfg map[string]interface{}, keys ...string) float64 {
+	for _, key := range keys {
+		switch v := cfg[key].(type) {
+		case float64:
+			if v > 0 {
+				return v
+			}
+		case int:
+			if v > 0 {
+				return float64(v)
+			}
+		case int64:
+			if v > 0 {
+				return float64(v)
+			}
+		}
+	}
+	return 0
+}
+
+// bytesPerParameter picks the weight precision from the model's quantization,
+// its vLLM dtype, or the config's torch_dtype, defaulting to fp16.
+func bytesPerParameter(model *catalog.Model, cfg map[string]interface{}) (float64, string) {
+	var quantization, dtype string
+	if model != nil && model.VLLM != nil {
+		quantization = strings.ToLower(model.VLLM.Quantization)
+		dtype = strings.ToLower(model.VLLM.Dtype)
+	}
+	if quantization == "" {
+		if qc, ok := cfg["quantization_config"].(map[string]interface{}); ok {
+			if method, ok := qc["quant_method"].(string); ok {
+				quantization = strings.ToLower(method)
+			}
+		}
+	}
+	switch quantization {
+	case "":
+	case "fp8", "int8", "w8a8":
+		return 1, quantization
+	default:
+		// AWQ, GPTQ, bitsandbytes and most other methods ship 4-bit weights.
+		return 0.5, quantization
+	}
+	if dtype == "" || dtype == "auto" {
+		if torchDtype, ok := cfg["torch_dtype"].(string); ok {
+			dtype = strings.ToLower(torchDtype)
+		}
+	}
+	switch dtype {
+	case "float32", "float", "fp32":
+		return 4, "fp32"
+	case "bfloat16":
+		return 2, "bf16"
+	default:
+		return 2, "fp16"
+	}
+}
@@ -0,0 +1,119 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const keyDerivationInfo = "model-manager/secrets/v1"
+
+// ErrUnknownKey indicates a record was encrypted with a key the keyring does
+// not hold (e.g. a retired master key was dropped from the configuration).
+var ErrUnknownKey = errors.New("secret encrypted with unknown key")
+
+// Keyring encrypts secret values with AES-256-GCM keys derived from master
+// keys. Each record stores the id of the key that sealed it, so rotating the
+// master key only requires keeping the old one configured until RotateKeys has
+// re-encrypted everything.
+type Keyring struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring derives the current key from masterKey and decryption-only keys
+// from previous. Blank previous entries are ignored.
+func NewKeyring(masterKey string, previous ...string) (*Keyring, error) {
+	if strings.TrimSpace(masterKey) == "" {
+		return nil, errors.New("secrets master key is empty")
+	}
+	kr := &Keyring{keys: make(map[string]cipher.AEAD)}
+	id, err := kr.add(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	kr.current = id
+	for _, key := range previous {
+		if strings.TrimSpace(key) == "" {
+			continue
+		}
+		if _, err := kr.add(key); err != nil {
+			return nil, err
+		}
+	}
+	return kr, nil
+}
+
+func (kr *Keyring) add(masterKey string) (string, error) {
+	derived := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(masterKey), nil, []byte(keyDerivationInfo)), derived); err != nil {
+		return "", fmt.Errorf("derive secrets key: %w", err)
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return "", err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(derived)
+	id := hex.EncodeToString(sum[:4])
+	kr.keys[id] = aead
+	return id, nil
+}
+
+// CurrentKeyID returns the id of the key new records are sealed with.
+func (kr *Keyring) CurrentKeyID() string {
+	return kr.current
+}
+
+// seal encrypts every value with the current key. The secret name and map key
+// are bound as additional data so ciphertexts can't be swapped between entries.
+func (kr *Keyring) seal(name string, data map[string]string) (map[string]string, string, error) {
+	aead := kr.keys[kr.current]
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, "", err
+		}
+		sealed := aead.Seal(nonce, nonce, []byte(v), additionalData(name, k))
+		out[k] = base64.StdEncoding.EncodeToString(sealed)
+	}
+	return out, kr.current, nil
+}
+
+// open decrypts values sealed with keyID.
+func (kr *Keyring) open(name, keyID string, data map[string]string) (map[string]string, error) {
+	aead, ok := kr.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		raw, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || len(raw) < aead.NonceSize() {
+			return nil, fmt.Errorf("secret %s key %s is corrupt", name, k)
+		}
+		plain, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], additionalData(name, k))
+		if err != nil {
+			return nil, fmt.Errorf("decrypt secret %s key %s: %w", name, k, err)
+		}
+		out[k] = string(plain)
+	}
+	return out, nil
+}
+
+func additionalData(name, key string) []byte {
+	return []byte(name + "\x00" + key)
+}
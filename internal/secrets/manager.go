@@ -7,6 +7,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/oremus-labs/ol-model-manager/internal/store"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,13 +21,44 @@ const managedLabel = "model-manager.oremuslabs.app/managed-secret"
 // ErrNotFound indicates the requested secret does not exist.
 var ErrNotFound = errors.New("secret not found")
 
+// ErrNoMasterKey is returned when writing a secret to the datastore without a
+// keyring, which would store its values in plaintext.
+var ErrNoMasterKey = errors.New("refusing to store secrets in the datastore without a master key")
+
+// RecordStore keeps secret records in the datastore.
+type RecordStore interface {
+	ListSecrets() ([]store.SecretRecord, error)
+	GetSecret(name string) (*store.SecretRecord, error)
+	UpsertSecret(name, keyID string, data map[string]string) (*store.SecretRecord, error)
+	DeleteSecret(name string) error
+}
+
+// Options configures where secrets are kept.
+type Options struct {
+	Namespace string
+	// Store keeps secrets in the datastore when SyncToKube is false. It is
+	// ignored otherwise so only one backend ever holds a secret.
+	Store RecordStore
+	// SyncToKube keeps secrets in Kubernetes Secrets in Namespace so KServe
+	// pods can reference them. When false, secrets live only in Store (for
+	// air-gapped setups). It is forced on when Store is nil.
+	SyncToKube bool
+	// Keyring encrypts values before they reach Store. It is required for
+	// writes to Store; without it Upsert returns ErrNoMasterKey.
+	Keyring *Keyring
+}
+
 // Manager wraps interactions with Kubernetes Secrets for the Model Manager namespace.
 type Manager struct {
-	client    kubernetes.Interface
-	namespace string
+	client     kubernetes.Interface
+	namespace  string
+	store      RecordStore
+	syncToKube bool
+	keyring    *Keyring
 }
 
-// Meta describes a managed secret without exposing its values.
+// Meta describes a managed secret by key names and timestamps only; it never
+// carries values.
 type Meta struct {
 	Name      string    `json:"name"`
 	Keys      []string  `json:"keys"`
@@ -42,12 +74,25 @@ type Record struct {
 	UpdatedAt time.Time         `json:"updatedAt,omitempty"`
 }
 
-// NewManager constructs a Manager.
+// NewManager constructs a Manager backed only by Kubernetes Secrets.
 func NewManager(client kubernetes.Interface, namespace string) *Manager {
-	return &Manager{
-		client:    client,
-		namespace: namespace,
+	return NewManagerWithOptions(client, Options{Namespace: namespace, SyncToKube: true})
+}
+
+// NewManagerWithOptions constructs a Manager that may also keep secrets in the
+// datastore.
+func NewManagerWithOptions(client kubernetes.Interface, opts Options) *Manager {
+	m := &Manager{
+		client:     client,
+		namespace:  opts.Namespace,
+		store:      opts.Store,
+		syncToKube: opts.SyncToKube || opts.Store == nil,
+		keyring:    opts.Keyring,
+	}
+	if m.syncToKube {
+		m.store = nil
 	}
+	return m
 }
 
 func (m *Manager) secretsClient() corev1client.SecretInterface {
@@ -56,6 +101,22 @@ func (m *Manager) secretsClient() corev1client.SecretInterface {
 
 // List returns the metadata for all managed secrets.
 func (m *Manager) List(ctx context.Context) ([]Meta, error) {
+	if !m.syncToKube {
+		records, err := m.store.ListSecrets()
+		if err != nil {
+			return nil, err
+		}
+		out := make([]Meta, 0, len(records))
+		for _, rec := range records {
+			meta := Meta{Name: rec.Name, CreatedAt: rec.CreatedAt, UpdatedAt: rec.UpdatedAt}
+			for k := range rec.Data {
+				meta.Keys = append(meta.Keys, k)
+			}
+			sort.Strings(meta.Keys)
+			out = append(out, meta)
+		}
+		return out, nil
+	}
 	selector := labels.SelectorFromSet(labels.Set{managedLabel: "true"})
 	secrets, err := m.secretsClient().List(ctx, metav1.ListOptions{
 		LabelSelector: selector.String(),
@@ -88,6 +149,17 @@ func (m *Manager) List(ctx context.Context) ([]Meta, error) {
 
 // Get fetches the named secret (regardless of label) and returns its values.
 func (m *Manager) Get(ctx context.Context, name string) (*Record, error) {
+	if !m.syncToKube {
+		rec, err := m.store.GetSecret(name)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		data, err := m.decrypt(rec)
+		if err != nil {
+			return nil, err
+		}
+		return convertRecord(rec, data), nil
+	}
 	sec, err := m.secretsClient().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, translateError(err)
@@ -95,11 +167,30 @@ func (m *Manager) Get(ctx context.Context, name string) (*Record, error) {
 	return convertSecret(sec), nil
 }
 
-// Upsert creates or updates the named secret with the provided data map.
+// Upsert creates or updates the named secret with the provided data map,
+// writing it to the datastore or a Kubernetes Secret.
 func (m *Manager) Upsert(ctx context.Context, name string, data map[string]string) (*Record, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("secret data is empty")
 	}
+	if m.syncToKube {
+		return m.upsertKube(ctx, name, data)
+	}
+	if m.keyring == nil {
+		return nil, ErrNoMasterKey
+	}
+	sealed, keyID, err := m.keyring.seal(name, data)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := m.store.UpsertSecret(name, keyID, sealed)
+	if err != nil {
+		return nil, err
+	}
+	return convertRecord(rec, data), nil
+}
+
+func (m *Manager) upsertKube(ctx context.Context, name string, data map[string]string) (*Record, error) {
 	stringData := make(map[string]string, len(data))
 	for k, v := range data {
 		stringData[k] = v
@@ -139,8 +230,11 @@ func (m *Manager) Upsert(ctx context.Context, name string, data map[string]strin
 	return convertSecret(updated), nil
 }
 
-// Delete removes the named secret.
+// Delete removes the named secret from the datastore or Kubernetes.
 func (m *Manager) Delete(ctx context.Context, name string) error {
+	if !m.syncToKube {
+		return translateError(m.store.DeleteSecret(name))
+	}
 	return translateError(m.secretsClient().Delete(ctx, name, metav1.DeleteOptions{}))
 }
 
@@ -161,6 +255,62 @@ func convertSecret(sec *corev1.Secret) *Record {
 	return record
 }
 
+// RotateKeys re-encrypts datastore records sealed with an older key (or stored
+// in plaintext) under the keyring's current key and returns how many changed.
+func (m *Manager) RotateKeys() (int, error) {
+	if m.store == nil || m.keyring == nil {
+		return 0, nil
+	}
+	records, err := m.store.ListSecrets()
+	if err != nil {
+		return 0, err
+	}
+	rotated := 0
+	for i := range records {
+		rec := &records[i]
+		if rec.KeyID == m.keyring.CurrentKeyID() {
+			continue
+		}
+		data, err := m.decrypt(rec)
+		if err != nil {
+			return rotated, err
+		}
+		sealed, keyID, err := m.keyring.seal(rec.Name, data)
+		if err != nil {
+			return rotated, err
+		}
+		if _, err := m.store.UpsertSecret(rec.Name, keyID, sealed); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+// decrypt returns a record's plaintext values.
+func (m *Manager) decrypt(rec *store.SecretRecord) (map[string]string, error) {
+	if rec.KeyID == "" {
+		return rec.Data, nil
+	}
+	if m.keyring == nil {
+		return nil, fmt.Errorf("secret %s is encrypted but no master key is configured", rec.Name)
+	}
+	return m.keyring.open(rec.Name, rec.KeyID, rec.Data)
+}
+
+func convertRecord(rec *store.SecretRecord, data map[string]string) *Record {
+	record := &Record{
+		Name:      rec.Name,
+		Data:      make(map[string]string, len(data)),
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt,
+	}
+	for k, v := range data {
+		record.Data[k] = v
+	}
+	return record
+}
+
 func latestManagedTime(fields []metav1.ManagedFieldsEntry, fallback time.Time) time.Time {
 	var latest time.Time
 	for _, entry := range fields {
@@ -178,7 +328,7 @@ func translateError(err error) error {
 	if err == nil {
 		return nil
 	}
-	if apierrors.IsNotFound(err) {
+	if apierrors.IsNotFound(err) || errors.Is(err, store.ErrSecretNotFound) {
 		return ErrNotFound
 	}
 	return err
@@ -0,0 +1,147 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	s, err := store.Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestUpsertSyncsToKubernetesSecret(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	dataStore := newTestStore(t)
+	mgr := NewManagerWithOptions(client, Options{Namespace: "ai", Store: dataStore, SyncToKube: true})
+	ctx := context.Background()
+
+	if _, err := mgr.Upsert(ctx, "hf-token", map[string]string{"token": "one"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	sec, err := client.CoreV1().Secrets("ai").Get(ctx, "hf-token", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected Kubernetes Secret to be created: %v", err)
+	}
+	if sec.StringData["token"] != "one" || sec.Labels[managedLabel] != "true" {
+		t.Fatalf("unexpected secret after create: %+v", sec)
+	}
+
+	if _, err := mgr.Upsert(ctx, "hf-token", map[string]string{"token": "two"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	sec, err = client.CoreV1().Secrets("ai").Get(ctx, "hf-token", metav1.GetOptions{})
+	if err != nil || sec.StringData["token"] != "two" {
+		t.Fatalf("expected Kubernetes Secret to be updated, got %+v (%v)", sec, err)
+	}
+	if _, err := dataStore.GetSecret("hf-token"); err == nil {
+		t.Fatalf("expected no datastore copy while Kubernetes holds the secret")
+	}
+
+	if err := mgr.Delete(ctx, "hf-token"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := client.CoreV1().Secrets("ai").Get(ctx, "hf-token", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected Kubernetes Secret to be deleted, got %v", err)
+	}
+	if err := mgr.Delete(ctx, "hf-token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestDatastoreOnlyLeavesKubernetesUntouched(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	keys, err := NewKeyring("master-key")
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	mgr := NewManagerWithOptions(client, Options{Namespace: "ai", Store: newTestStore(t), SyncToKube: false, Keyring: keys})
+	ctx := context.Background()
+
+	if _, err := mgr.Upsert(ctx, "hf-token", map[string]string{"token": "one"}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	list, err := client.CoreV1().Secrets("ai").List(ctx, metav1.ListOptions{})
+	if err != nil || len(list.Items) != 0 {
+		t.Fatalf("expected no Kubernetes Secrets, got %d (%v)", len(list.Items), err)
+	}
+	rec, err := mgr.Get(ctx, "hf-token")
+	if err != nil || rec.Data["token"] != "one" {
+		t.Fatalf("expected datastore record, got %+v (%v)", rec, err)
+	}
+	metas, err := mgr.List(ctx)
+	if err != nil || len(metas) != 1 || metas[0].Keys[0] != "token" {
+		t.Fatalf("unexpected list: %+v (%v)", metas, err)
+	}
+}
+
+func TestDatastoreValuesAreEncryptedAndRotate(t *testing.T) {
+	dataStore := newTestStore(t)
+	oldKeys, err := NewKeyring("old-master-key")
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	mgr := NewManagerWithOptions(fake.NewSimpleClientset(), Options{Namespace: "ai", Store: dataStore, Keyring: oldKeys})
+	ctx := context.Background()
+
+	if _, err := mgr.Upsert(ctx, "hf-token", map[string]string{"token": "hf_secret"}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	raw, err := dataStore.GetSecret("hf-token")
+	if err != nil {
+		t.Fatalf("get raw: %v", err)
+	}
+	if raw.KeyID != oldKeys.CurrentKeyID() || raw.Data["token"] == "hf_secret" {
+		t.Fatalf("expected value encrypted under %s, got %+v", oldKeys.CurrentKeyID(), raw)
+	}
+
+	newKeys, err := NewKeyring("new-master-key", "old-master-key")
+	if err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+	mgr = NewManagerWithOptions(fake.NewSimpleClientset(), Options{Namespace: "ai", Store: dataStore, Keyring: newKeys})
+	rec, err := mgr.Get(ctx, "hf-token")
+	if err != nil || rec.Data["token"] != "hf_secret" {
+		t.Fatalf("expected old record to decrypt with previous key, got %+v (%v)", rec, err)
+	}
+	if rotated, err := mgr.RotateKeys(); err != nil || rotated != 1 {
+		t.Fatalf("expected 1 rotated record, got %d (%v)", rotated, err)
+	}
+	raw, _ = dataStore.GetSecret("hf-token")
+	if raw.KeyID != newKeys.CurrentKeyID() {
+		t.Fatalf("expected record re-encrypted under %s, got %s", newKeys.CurrentKeyID(), raw.KeyID)
+	}
+
+	onlyNew, _ := NewKeyring("new-master-key")
+	mgr = NewManagerWithOptions(fake.NewSimpleClientset(), Options{Namespace: "ai", Store: dataStore, Keyring: onlyNew})
+	if rec, err := mgr.Get(ctx, "hf-token"); err != nil || rec.Data["token"] != "hf_secret" {
+		t.Fatalf("expected rotated record to decrypt without the old key, got %+v (%v)", rec, err)
+	}
+	metas, err := mgr.List(ctx)
+	if err != nil || len(metas) != 1 || len(metas[0].Keys) != 1 {
+		t.Fatalf("unexpected list: %+v (%v)", metas, err)
+	}
+}
+
+func TestDatastoreWritesRequireMasterKey(t *testing.T) {
+	dataStore := newTestStore(t)
+	mgr := NewManagerWithOptions(fake.NewSimpleClientset(), Options{Namespace: "ai", Store: dataStore})
+	if _, err := mgr.Upsert(context.Background(), "hf-token", map[string]string{"token": "hf_secret"}); !errors.Is(err, ErrNoMasterKey) {
+		t.Fatalf("expected ErrNoMasterKey, got %v", err)
+	}
+	if _, err := dataStore.GetSecret("hf-token"); err == nil {
+		t.Fatalf("expected nothing written to the datastore")
+	}
+}
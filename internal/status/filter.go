@@ -0,0 +1,97 @@
+package status
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Summary condenses a RuntimeStatus into counts and readiness.
+type Summary struct {
+	Target           string            `json:"target,omitempty"`
+	InferenceService string            `json:"inferenceService,omitempty"`
+	Ready            string            `json:"ready"`
+	URL              string            `json:"url,omitempty"`
+	Deployments      int               `json:"deployments"`
+	ReadyDeployments int               `json:"readyDeployments"`
+	Pods             int               `json:"pods"`
+	ReadyPods        int               `json:"readyPods"`
+	Restarts         int32             `json:"restarts"`
+	PodPhases        map[string]int    `json:"podPhases,omitempty"`
+	GPUAllocations   map[string]string `json:"gpuAllocations,omitempty"`
+	Deployment       string            `json:"deployment,omitempty"`
+	Pod              string            `json:"pod,omitempty"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+}
+
+// Filter trims the status to a single deployment and/or pod. Pods are matched
+// to a deployment by the "<deployment>-" name prefix used by ReplicaSets.
+func (s RuntimeStatus) Filter(deployment, pod string) RuntimeStatus {
+	deployment = strings.TrimSpace(deployment)
+	pod = strings.TrimSpace(pod)
+	if deployment == "" && pod == "" {
+		return s
+	}
+	out := s
+	if deployment != "" {
+		out.Deployments = nil
+		for _, dep := range s.Deployments {
+			if dep.Name == deployment {
+				out.Deployments = append(out.Deployments, dep)
+			}
+		}
+	}
+	out.Pods = nil
+	out.GPUAllocations = nil
+	gpuTotals := make(map[string]resource.Quantity)
+	for _, p := range s.Pods {
+		if deployment != "" && !strings.HasPrefix(p.Name, deployment+"-") {
+			continue
+		}
+		if pod != "" && p.Name != pod {
+			continue
+		}
+		out.Pods = append(out.Pods, p)
+		sumQuantityStrings(gpuTotals, p.GPURequests)
+	}
+	if len(gpuTotals) > 0 {
+		out.GPUAllocations = quantitiesToStringMap(gpuTotals)
+	}
+	return out
+}
+
+// Summarize returns counts/readiness without the full pod and deployment lists.
+func (s RuntimeStatus) Summarize() Summary {
+	summary := Summary{
+		Target:         s.Target,
+		Ready:          "Unknown",
+		Deployments:    len(s.Deployments),
+		Pods:           len(s.Pods),
+		GPUAllocations: s.GPUAllocations,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	if s.InferenceService != nil {
+		summary.InferenceService = s.InferenceService.Name
+		summary.Ready = s.InferenceService.Ready
+		summary.URL = s.InferenceService.URL
+	}
+	for _, dep := range s.Deployments {
+		if dep.Replicas > 0 && dep.ReadyReplicas >= dep.Replicas {
+			summary.ReadyDeployments++
+		}
+	}
+	for _, p := range s.Pods {
+		if p.TotalContainers > 0 && p.ReadyContainers == p.TotalContainers {
+			summary.ReadyPods++
+		}
+		summary.Restarts += p.Restarts
+		if p.Phase != "" {
+			if summary.PodPhases == nil {
+				summary.PodPhases = make(map[string]int)
+			}
+			summary.PodPhases[p.Phase]++
+		}
+	}
+	return summary
+}
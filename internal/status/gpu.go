@@ -0,0 +1,132 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// DCGM exporter metrics read by GPUMetricsSource.
+const (
+	dcgmGPUUtil = "DCGM_FI_DEV_GPU_UTIL"
+	dcgmFBUsed  = "DCGM_FI_DEV_FB_USED"
+)
+
+// GPUUsage is the measured GPU usage of a pod, as opposed to the GPUs its spec
+// requests. UtilizationPercent is averaged over the pod's GPUs and
+// MemoryUsedMiB is summed.
+type GPUUsage struct {
+	GPUs               int       `json:"gpus"`
+	UtilizationPercent float64   `json:"utilizationPercent"`
+	MemoryUsedMiB      float64   `json:"memoryUsedMiB"`
+	ScrapedAt          time.Time `json:"scrapedAt"`
+}
+
+func (u GPUUsage) sameReading(other GPUUsage) bool {
+	return u.GPUs == other.GPUs && u.UtilizationPercent == other.UtilizationPercent && u.MemoryUsedMiB == other.MemoryUsedMiB
+}
+
+// GPUMetricsSource scrapes per-pod GPU usage from a DCGM exporter's
+// Prometheus endpoint. The exporter must run with Kubernetes pod mapping so
+// samples carry pod and namespace labels.
+type GPUMetricsSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewGPUMetricsSource returns a source scraping url.
+func NewGPUMetricsSource(url string, timeout time.Duration) *GPUMetricsSource {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &GPUMetricsSource{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Scrape returns usage keyed by "namespace/pod". GPUs not assigned to a pod
+// are skipped.
+func (s *GPUMetricsSource) Scrape(ctx context.Context) (map[string]GPUUsage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape GPU metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scrape GPU metrics: %s returned %s", s.url, resp.Status)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse GPU metrics: %w", err)
+	}
+	return podGPUUsage(families, time.Now().UTC()), nil
+}
+
+// podGPUUsage aggregates the DCGM utilization and framebuffer gauges per pod.
+func podGPUUsage(families map[string]*dto.MetricFamily, scrapedAt time.Time) map[string]GPUUsage {
+	type accumulator struct {
+		gpus    map[string]bool
+		utilSum float64
+		utilN   int
+		memory  float64
+	}
+	pods := make(map[string]*accumulator)
+	visit := func(name string, fn func(acc *accumulator, value float64)) {
+		family := families[name]
+		if family == nil {
+			return
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			pod := firstLabel(labels, "pod", "pod_name")
+			namespace := firstLabel(labels, "namespace", "pod_namespace")
+			if pod == "" || namespace == "" || metric.GetGauge() == nil {
+				continue
+			}
+			key := namespace + "/" + pod
+			acc := pods[key]
+			if acc == nil {
+				acc = &accumulator{gpus: make(map[string]bool)}
+				pods[key] = acc
+			}
+			acc.gpus[firstLabel(labels, "UUID", "gpu")] = true
+			fn(acc, metric.GetGauge().GetValue())
+		}
+	}
+	visit(dcgmGPUUtil, func(acc *accumulator, value float64) {
+		acc.utilSum += value
+		acc.utilN++
+	})
+	visit(dcgmFBUsed, func(acc *accumulator, value float64) {
+		acc.memory += value
+	})
+
+	usage := make(map[string]GPUUsage, len(pods))
+	for key, acc := range pods {
+		u := GPUUsage{GPUs: len(acc.gpus), MemoryUsedMiB: acc.memory, ScrapedAt: scrapedAt}
+		if acc.utilN > 0 {
+			u.UtilizationPercent = acc.utilSum / float64(acc.utilN)
+		}
+		usage[key] = u
+	}
+	return usage
+}
+
+func firstLabel(labels map[string]string, names ...string) string {
+	for _, name := range names {
+		if value := labels[name]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
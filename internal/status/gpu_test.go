@@ -0,0 +1,131 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+// dcgmTypes declares the gauges the way the DCGM exporter does; samples of
+// untyped families carry no gauge value.
+const dcgmTypes = `# TYPE DCGM_FI_DEV_GPU_UTIL gauge
+# TYPE DCGM_FI_DEV_FB_USED gauge
+# TYPE DCGM_FI_DEV_SM_CLOCK gauge`
+
+func TestPodGPUUsage(t *testing.T) {
+	scrapedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		name    string
+		metrics string
+		want    map[string]GPUUsage
+	}{
+		{
+			name: "pod and namespace labels",
+			metrics: dcgmTypes + `
+DCGM_FI_DEV_GPU_UTIL{gpu="0",UUID="GPU-a",pod="llm-0",namespace="ai"} 80
+DCGM_FI_DEV_GPU_UTIL{gpu="1",UUID="GPU-b",pod="llm-0",namespace="ai"} 40
+DCGM_FI_DEV_FB_USED{gpu="0",UUID="GPU-a",pod="llm-0",namespace="ai"} 1000
+DCGM_FI_DEV_FB_USED{gpu="1",UUID="GPU-b",pod="llm-0",namespace="ai"} 500
+`,
+			want: map[string]GPUUsage{"ai/llm-0": {GPUs: 2, UtilizationPercent: 60, MemoryUsedMiB: 1500}},
+		},
+		{
+			name: "legacy pod_name and pod_namespace labels",
+			metrics: dcgmTypes + `
+DCGM_FI_DEV_GPU_UTIL{gpu="0",pod_name="llm-0",pod_namespace="ai"} 25
+DCGM_FI_DEV_FB_USED{gpu="0",pod_name="llm-0",pod_namespace="ai"} 2048
+`,
+			want: map[string]GPUUsage{"ai/llm-0": {GPUs: 1, UtilizationPercent: 25, MemoryUsedMiB: 2048}},
+		},
+		{
+			name: "same pod name in two namespaces",
+			metrics: dcgmTypes + `
+DCGM_FI_DEV_GPU_UTIL{gpu="0",pod="llm-0",namespace="ai"} 10
+DCGM_FI_DEV_GPU_UTIL{gpu="1",pod="llm-0",namespace="lab"} 90
+`,
+			want: map[string]GPUUsage{
+				"ai/llm-0":  {GPUs: 1, UtilizationPercent: 10},
+				"lab/llm-0": {GPUs: 1, UtilizationPercent: 90},
+			},
+		},
+		{
+			name: "GPUs without a pod or namespace are skipped",
+			metrics: dcgmTypes + `
+DCGM_FI_DEV_GPU_UTIL{gpu="0",pod="",namespace=""} 99
+DCGM_FI_DEV_GPU_UTIL{gpu="1",pod="llm-0"} 99
+DCGM_FI_DEV_GPU_UTIL{gpu="2",namespace="ai"} 99
+DCGM_FI_DEV_GPU_UTIL{gpu="3",pod="llm-0",namespace="ai"} 50
+`,
+			want: map[string]GPUUsage{"ai/llm-0": {GPUs: 1, UtilizationPercent: 50}},
+		},
+		{
+			name: "missing utilization metric",
+			metrics: dcgmTypes + `
+DCGM_FI_DEV_FB_USED{gpu="0",pod="llm-0",namespace="ai"} 4096
+`,
+			want: map[string]GPUUsage{"ai/llm-0": {GPUs: 1, MemoryUsedMiB: 4096}},
+		},
+		{
+			name: "unrelated metrics only",
+			metrics: dcgmTypes + `
+DCGM_FI_DEV_SM_CLOCK{gpu="0",pod="llm-0",namespace="ai"} 1410
+`,
+			want: map[string]GPUUsage{},
+		},
+		{
+			name:    "no metrics",
+			metrics: "",
+			want:    map[string]GPUUsage{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parser expfmt.TextParser
+			families, err := parser.TextToMetricFamilies(strings.NewReader(tc.metrics))
+			if err != nil {
+				t.Fatalf("parse metrics: %v", err)
+			}
+			got := podGPUUsage(families, scrapedAt)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d pods %+v, want %d", len(got), got, len(tc.want))
+			}
+			for key, want := range tc.want {
+				usage, ok := got[key]
+				if !ok {
+					t.Fatalf("no usage for %s in %+v", key, got)
+				}
+				if !usage.sameReading(want) || !usage.ScrapedAt.Equal(scrapedAt) {
+					t.Errorf("%s = %+v, want %+v", key, usage, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGPUMetricsSourceScrape(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(dcgmTypes + "\nDCGM_FI_DEV_GPU_UTIL{gpu=\"0\",pod=\"llm-0\",namespace=\"ai\"} 70\n"))
+	}))
+	defer srv.Close()
+
+	usage, err := NewGPUMetricsSource(srv.URL+"/metrics", time.Second).Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if got := usage["ai/llm-0"]; got.GPUs != 1 || got.UtilizationPercent != 70 {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+
+	if _, err := NewGPUMetricsSource(srv.URL+"/missing", time.Second).Scrape(context.Background()); err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+}
@@ -23,8 +23,14 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-// RuntimeStatus captures the live state of the active InferenceService runtime.
+func init() {
+	events.Register(events.TypeStatusUpdated, func() interface{} { return &RuntimeStatus{} })
+}
+
+// RuntimeStatus captures the live state of a watched InferenceService runtime.
 type RuntimeStatus struct {
+	Namespace        string                  `json:"namespace,omitempty"`
+	Target           string                  `json:"target,omitempty"`
 	InferenceService *InferenceServiceStatus `json:"inferenceService,omitempty"`
 	Deployments      []DeploymentStatus      `json:"deployments,omitempty"`
 	Pods             []PodStatus             `json:"pods,omitempty"`
@@ -78,6 +84,8 @@ type PodStatus struct {
 	Containers      []ContainerStatusSummary `json:"containers,omitempty"`
 	GPURequests     map[string]string        `json:"gpuRequests,omitempty"`
 	GPULimits       map[string]string        `json:"gpuLimits,omitempty"`
+	// GPUUsage is the measured usage when GPU metrics are configured.
+	GPUUsage *GPUUsage `json:"gpuUsage,omitempty"`
 }
 
 // ContainerStatusSummary details container state.
@@ -97,10 +105,39 @@ type Provider interface {
 	CurrentStatus() RuntimeStatus
 }
 
-// Manager wires informers and maintains cached status.
+// Target identifies an InferenceService the manager watches.
+type Target struct {
+	Namespace        string
+	InferenceService string
+}
+
+// Key is the "namespace/name" form used to look up a target's status.
+func (t Target) Key() string {
+	return t.Namespace + "/" + t.InferenceService
+}
+
+// ParseTarget parses "namespace/name"; a bare name uses defaultNamespace.
+func ParseTarget(value, defaultNamespace string) (Target, error) {
+	value = strings.TrimSpace(value)
+	namespace, name := defaultNamespace, value
+	if idx := strings.Index(value, "/"); idx >= 0 {
+		namespace, name = value[:idx], value[idx+1:]
+	}
+	if namespace == "" || name == "" || strings.Contains(name, "/") {
+		return Target{}, fmt.Errorf("invalid status target %q (want namespace/name)", value)
+	}
+	return Target{Namespace: namespace, InferenceService: name}, nil
+}
+
+var inferenceServiceGVR = schema.GroupVersionResource{
+	Group:    "serving.kserve.io",
+	Version:  "v1beta1",
+	Resource: "inferenceservices",
+}
+
+// Manager wires informers and maintains cached status per target.
 type Manager struct {
-	namespace string
-	isvcName  string
+	targets []Target
 
 	dynClient  dynamic.Interface
 	kubeClient kubernetes.Interface
@@ -108,7 +145,18 @@ type Manager struct {
 
 	eventBus eventsPublisher
 
-	mu          sync.RWMutex
+	gpuSource   *GPUMetricsSource
+	gpuInterval time.Duration
+	gpuFailing  bool
+
+	mu       sync.RWMutex
+	states   map[string]*targetState
+	gpuUsage map[string]GPUUsage
+}
+
+// targetState is the cached status of one InferenceService.
+type targetState struct {
+	target      Target
 	isvcStatus  *InferenceServiceStatus
 	deployments map[string]DeploymentStatus
 	pods        map[string]PodStatus
@@ -119,8 +167,12 @@ type eventsPublisher interface {
 	Publish(context.Context, events.Event) error
 }
 
-// NewManager constructs a manager for the active runtime.
-func NewManager(cfg *rest.Config, namespace, isvcName string, bus eventsPublisher) (*Manager, error) {
+// NewManager constructs a manager for the given targets. The first target is
+// the active runtime reported by CurrentStatus; duplicates are ignored.
+func NewManager(cfg *rest.Config, targets []Target, bus eventsPublisher) (*Manager, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("status manager needs at least one target")
+	}
 	dyn, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
@@ -129,26 +181,72 @@ func NewManager(cfg *rest.Config, namespace, isvcName string, bus eventsPublishe
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
-	gvr := schema.GroupVersionResource{
-		Group:    "serving.kserve.io",
-		Version:  "v1beta1",
-		Resource: "inferenceservices",
+	return newManager(dyn, kubeClient, targets, bus), nil
+}
+
+func newManager(dyn dynamic.Interface, kubeClient kubernetes.Interface, targets []Target, bus eventsPublisher) *Manager {
+	m := &Manager{
+		dynClient:  dyn,
+		kubeClient: kubeClient,
+		gvr:        inferenceServiceGVR,
+		eventBus:   bus,
+		states:     make(map[string]*targetState),
+	}
+	for _, target := range targets {
+		if _, ok := m.states[target.Key()]; ok {
+			continue
+		}
+		m.targets = append(m.targets, target)
+		m.states[target.Key()] = &targetState{
+			target:      target,
+			deployments: make(map[string]DeploymentStatus),
+			pods:        make(map[string]PodStatus),
+		}
 	}
-	return &Manager{
-		namespace:   namespace,
-		isvcName:    isvcName,
-		dynClient:   dyn,
-		kubeClient:  kubeClient,
-		gvr:         gvr,
-		eventBus:    bus,
-		deployments: make(map[string]DeploymentStatus),
-		pods:        make(map[string]PodStatus),
-	}, nil
+	return m
 }
 
-// Run starts informers until context cancellation.
+// EnableGPUMetrics makes Run scrape source every interval and attach the
+// measured usage to pod statuses. Call it before Run.
+func (m *Manager) EnableGPUMetrics(source *GPUMetricsSource, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	m.gpuSource = source
+	m.gpuInterval = interval
+}
+
+// Targets returns the watched targets, the active runtime first.
+func (m *Manager) Targets() []Target {
+	return append([]Target(nil), m.targets...)
+}
+
+// Run starts informers for every target namespace until context cancellation.
 func (m *Manager) Run(ctx context.Context) error {
-	dynFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(m.dynClient, 0, m.namespace, nil)
+	var synced []cache.InformerSynced
+	seen := make(map[string]bool)
+	for _, target := range m.targets {
+		if seen[target.Namespace] {
+			continue
+		}
+		seen[target.Namespace] = true
+		synced = append(synced, m.startNamespace(ctx, target.Namespace)...)
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		return fmt.Errorf("status manager cache sync failed")
+	}
+	if m.gpuSource != nil {
+		go m.scrapeGPUMetrics(ctx)
+	}
+
+	<-ctx.Done()
+	log.Println("status manager stopped")
+	return ctx.Err()
+}
+
+func (m *Manager) startNamespace(ctx context.Context, namespace string) []cache.InformerSynced {
+	dynFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(m.dynClient, 0, namespace, nil)
 	isvcInformer := dynFactory.ForResource(m.gvr).Informer()
 	isvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    m.onISVC,
@@ -156,7 +254,7 @@ func (m *Manager) Run(ctx context.Context) error {
 		DeleteFunc: m.onISVCDelete,
 	})
 
-	sharedFactory := informers.NewSharedInformerFactoryWithOptions(m.kubeClient, 0, informers.WithNamespace(m.namespace))
+	sharedFactory := informers.NewSharedInformerFactoryWithOptions(m.kubeClient, 0, informers.WithNamespace(namespace))
 	depInformer := sharedFactory.Apps().V1().Deployments().Informer()
 	depInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    m.onDeployment,
@@ -172,64 +270,137 @@ func (m *Manager) Run(ctx context.Context) error {
 
 	dynFactory.Start(ctx.Done())
 	sharedFactory.Start(ctx.Done())
+	return []cache.InformerSynced{isvcInformer.HasSynced, depInformer.HasSynced, podInformer.HasSynced}
+}
 
-	if !cache.WaitForCacheSync(ctx.Done(), isvcInformer.HasSynced, depInformer.HasSynced, podInformer.HasSynced) {
-		return fmt.Errorf("status manager cache sync failed")
+// CurrentStatus returns a snapshot of the active runtime (the first target).
+func (m *Manager) CurrentStatus() RuntimeStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.states[m.targets[0].Key()].snapshot(m.gpuUsage)
+}
+
+// Statuses returns a snapshot of every target, the active runtime first.
+func (m *Manager) Statuses() []RuntimeStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]RuntimeStatus, 0, len(m.targets))
+	for _, target := range m.targets {
+		out = append(out, m.states[target.Key()].snapshot(m.gpuUsage))
 	}
+	return out
+}
 
-	<-ctx.Done()
-	log.Println("status manager stopped")
-	return ctx.Err()
+// TargetStatus returns the snapshot for the "namespace/name" key.
+func (m *Manager) TargetStatus(key string) (RuntimeStatus, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	state, ok := m.states[key]
+	if !ok {
+		return RuntimeStatus{}, false
+	}
+	return state.snapshot(m.gpuUsage), true
 }
 
-// CurrentStatus returns a snapshot of the runtime state.
-func (m *Manager) CurrentStatus() RuntimeStatus {
+// stateLocked returns the state of the target named by namespace and the
+// InferenceService name, or nil when it isn't watched.
+func (m *Manager) stateLocked(namespace, isvcName string) *targetState {
+	if isvcName == "" {
+		return nil
+	}
+	return m.states[Target{Namespace: namespace, InferenceService: isvcName}.Key()]
+}
+
+func (m *Manager) watches(namespace, isvcName string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return m.stateLocked(namespace, isvcName) != nil
+}
 
-	status := RuntimeStatus{
-		UpdatedAt: m.lastUpdate,
+// update applies fn to the target's state under the lock and publishes the
+// resulting snapshot. It is a no-op for targets that aren't watched.
+func (m *Manager) update(namespace, isvcName string, fn func(*targetState)) {
+	m.mu.Lock()
+	state := m.stateLocked(namespace, isvcName)
+	if state == nil {
+		m.mu.Unlock()
+		return
 	}
-	if m.isvcStatus != nil {
-		copyISVC := *m.isvcStatus
-		status.InferenceService = &copyISVC
+	fn(state)
+	snapshot := state.snapshot(m.gpuUsage)
+	m.mu.Unlock()
+	m.publish(snapshot)
+}
+
+func (m *Manager) scrapeGPUMetrics(ctx context.Context) {
+	ticker := time.NewTicker(m.gpuInterval)
+	defer ticker.Stop()
+	for {
+		m.refreshGPUUsage(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
-	if len(m.deployments) > 0 {
-		deps := make([]DeploymentStatus, 0, len(m.deployments))
-		for _, d := range m.deployments {
-			deps = append(deps, d)
+}
+
+// refreshGPUUsage scrapes the GPU metrics and publishes the targets whose pod
+// readings changed. When the source is unavailable the readings are dropped
+// rather than served stale, and pod statuses fall back to allocations only.
+func (m *Manager) refreshGPUUsage(ctx context.Context) {
+	scrapeCtx, cancel := context.WithTimeout(ctx, m.gpuInterval)
+	usage, err := m.gpuSource.Scrape(scrapeCtx)
+	cancel()
+	if err != nil {
+		if !m.gpuFailing && ctx.Err() == nil {
+			log.Printf("status manager: GPU metrics unavailable: %v", err)
 		}
-		status.Deployments = deps
+		m.gpuFailing = true
+		usage = nil
+	} else if m.gpuFailing {
+		log.Println("status manager: GPU metrics available again")
+		m.gpuFailing = false
 	}
-	if len(m.pods) > 0 {
-		pods := make([]PodStatus, 0, len(m.pods))
-		for _, p := range m.pods {
-			pods = append(pods, p)
+
+	now := time.Now().UTC()
+	var snapshots []RuntimeStatus
+	m.mu.Lock()
+	previous := m.gpuUsage
+	m.gpuUsage = usage
+	for _, target := range m.targets {
+		state := m.states[target.Key()]
+		changed := false
+		for name := range state.pods {
+			key := target.Namespace + "/" + name
+			before, hadBefore := previous[key]
+			after, hasAfter := usage[key]
+			if hadBefore != hasAfter || !before.sameReading(after) {
+				changed = true
+				break
+			}
+		}
+		if changed {
+			state.lastUpdate = now
+			snapshots = append(snapshots, state.snapshot(usage))
 		}
-		status.Pods = pods
 	}
-	return status
+	m.mu.Unlock()
+	for _, snapshot := range snapshots {
+		m.publish(snapshot)
+	}
 }
 
 func (m *Manager) onISVC(obj interface{}) {
 	unstr, ok := toUnstructured(obj)
-	if !ok {
-		return
-	}
-	if unstr.GetName() != m.isvcName {
+	if !ok || !m.watches(unstr.GetNamespace(), unstr.GetName()) {
 		return
 	}
 	status := parseInferenceService(unstr)
-	m.mu.Lock()
-	if status == nil {
-		m.isvcStatus = nil
-	} else {
-		m.isvcStatus = status
-	}
-	m.lastUpdate = time.Now().UTC()
-	snapshot := m.snapshotLocked()
-	m.mu.Unlock()
-	m.publish(snapshot)
+	m.update(unstr.GetNamespace(), unstr.GetName(), func(state *targetState) {
+		state.isvcStatus = status
+		state.lastUpdate = time.Now().UTC()
+	})
 }
 
 func (m *Manager) onISVCDelete(obj interface{}) {
@@ -237,15 +408,10 @@ func (m *Manager) onISVCDelete(obj interface{}) {
 	if !ok {
 		return
 	}
-	if unstr.GetName() != m.isvcName {
-		return
-	}
-	m.mu.Lock()
-	m.isvcStatus = nil
-	m.lastUpdate = time.Now().UTC()
-	snapshot := m.snapshotLocked()
-	m.mu.Unlock()
-	m.publish(snapshot)
+	m.update(unstr.GetNamespace(), unstr.GetName(), func(state *targetState) {
+		state.isvcStatus = nil
+		state.lastUpdate = time.Now().UTC()
+	})
 }
 
 func (m *Manager) onDeployment(obj interface{}) {
@@ -253,26 +419,25 @@ func (m *Manager) onDeployment(obj interface{}) {
 	if !ok {
 		return
 	}
-	if dep.Labels["serving.kserve.io/inferenceservice"] != m.isvcName {
+	isvcName := dep.Labels["serving.kserve.io/inferenceservice"]
+	if !m.watches(dep.Namespace, isvcName) {
 		return
 	}
 	conds := convertDeploymentConditions(dep.Status.Conditions)
 	now := time.Now().UTC()
-	m.mu.Lock()
-	m.deployments[dep.Name] = DeploymentStatus{
-		Name:                dep.Name,
-		ReadyReplicas:       dep.Status.ReadyReplicas,
-		AvailableReplicas:   dep.Status.AvailableReplicas,
-		Replicas:            dep.Status.Replicas,
-		UpdatedReplicas:     dep.Status.UpdatedReplicas,
-		ObservedGeneration:  dep.Status.ObservedGeneration,
-		Conditions:          conds,
-		LastUpdateTimestamp: now,
-	}
-	m.lastUpdate = now
-	snapshot := m.snapshotLocked()
-	m.mu.Unlock()
-	m.publish(snapshot)
+	m.update(dep.Namespace, isvcName, func(state *targetState) {
+		state.deployments[dep.Name] = DeploymentStatus{
+			Name:                dep.Name,
+			ReadyReplicas:       dep.Status.ReadyReplicas,
+			AvailableReplicas:   dep.Status.AvailableReplicas,
+			Replicas:            dep.Status.Replicas,
+			UpdatedReplicas:     dep.Status.UpdatedReplicas,
+			ObservedGeneration:  dep.Status.ObservedGeneration,
+			Conditions:          conds,
+			LastUpdateTimestamp: now,
+		}
+		state.lastUpdate = now
+	})
 }
 
 func (m *Manager) onDeploymentDelete(obj interface{}) {
@@ -283,15 +448,13 @@ func (m *Manager) onDeploymentDelete(obj interface{}) {
 			dep, _ = tombstone.Obj.(*appsv1.Deployment)
 		}
 	}
-	if dep == nil || dep.Labels["serving.kserve.io/inferenceservice"] != m.isvcName {
+	if dep == nil {
 		return
 	}
-	m.mu.Lock()
-	delete(m.deployments, dep.Name)
-	m.lastUpdate = time.Now().UTC()
-	snapshot := m.snapshotLocked()
-	m.mu.Unlock()
-	m.publish(snapshot)
+	m.update(dep.Namespace, dep.Labels["serving.kserve.io/inferenceservice"], func(state *targetState) {
+		delete(state.deployments, dep.Name)
+		state.lastUpdate = time.Now().UTC()
+	})
 }
 
 func (m *Manager) onPod(obj interface{}) {
@@ -299,7 +462,8 @@ func (m *Manager) onPod(obj interface{}) {
 	if !ok {
 		return
 	}
-	if pod.Labels["serving.kserve.io/inferenceservice"] != m.isvcName {
+	isvcName := pod.Labels["serving.kserve.io/inferenceservice"]
+	if !m.watches(pod.Namespace, isvcName) {
 		return
 	}
 	ready := int32(0)
@@ -320,28 +484,26 @@ func (m *Manager) onPod(obj interface{}) {
 	conditions := convertPodConditions(pod.Status.Conditions)
 	containers := summarizeContainers(pod.Status.ContainerStatuses)
 	now := time.Now().UTC()
-	m.mu.Lock()
-	m.pods[pod.Name] = PodStatus{
-		Name:            pod.Name,
-		Phase:           string(pod.Status.Phase),
-		ReadyContainers: ready,
-		TotalContainers: total,
-		Restarts:        restarts,
-		HostIP:          pod.Status.HostIP,
-		PodIP:           pod.Status.PodIP,
-		NodeName:        pod.Spec.NodeName,
-		Reason:          pod.Status.Reason,
-		Message:         pod.Status.Message,
-		StartTime:       startTime,
-		Conditions:      conditions,
-		Containers:      containers,
-		GPURequests:     reqs,
-		GPULimits:       limits,
-	}
-	m.lastUpdate = now
-	snapshot := m.snapshotLocked()
-	m.mu.Unlock()
-	m.publish(snapshot)
+	m.update(pod.Namespace, isvcName, func(state *targetState) {
+		state.pods[pod.Name] = PodStatus{
+			Name:            pod.Name,
+			Phase:           string(pod.Status.Phase),
+			ReadyContainers: ready,
+			TotalContainers: total,
+			Restarts:        restarts,
+			HostIP:          pod.Status.HostIP,
+			PodIP:           pod.Status.PodIP,
+			NodeName:        pod.Spec.NodeName,
+			Reason:          pod.Status.Reason,
+			Message:         pod.Status.Message,
+			StartTime:       startTime,
+			Conditions:      conditions,
+			Containers:      containers,
+			GPURequests:     reqs,
+			GPULimits:       limits,
+		}
+		state.lastUpdate = now
+	})
 }
 
 func (m *Manager) onPodDelete(obj interface{}) {
@@ -352,34 +514,41 @@ func (m *Manager) onPodDelete(obj interface{}) {
 			pod, _ = tombstone.Obj.(*corev1.Pod)
 		}
 	}
-	if pod == nil || pod.Labels["serving.kserve.io/inferenceservice"] != m.isvcName {
+	if pod == nil {
 		return
 	}
-	m.mu.Lock()
-	delete(m.pods, pod.Name)
-	m.lastUpdate = time.Now().UTC()
-	snapshot := m.snapshotLocked()
-	m.mu.Unlock()
-	m.publish(snapshot)
+	m.update(pod.Namespace, pod.Labels["serving.kserve.io/inferenceservice"], func(state *targetState) {
+		delete(state.pods, pod.Name)
+		state.lastUpdate = time.Now().UTC()
+	})
 }
 
-func (m *Manager) snapshotLocked() RuntimeStatus {
-	status := RuntimeStatus{UpdatedAt: m.lastUpdate}
-	if m.isvcStatus != nil {
-		copyISVC := *m.isvcStatus
+// snapshot copies the state and attaches measured GPU usage to its pods; GPU
+// allocations only sum this target's pods. Callers hold the manager lock.
+func (s *targetState) snapshot(gpuUsage map[string]GPUUsage) RuntimeStatus {
+	status := RuntimeStatus{
+		Namespace: s.target.Namespace,
+		Target:    s.target.Key(),
+		UpdatedAt: s.lastUpdate,
+	}
+	if s.isvcStatus != nil {
+		copyISVC := *s.isvcStatus
 		status.InferenceService = &copyISVC
 	}
-	if len(m.deployments) > 0 {
-		deps := make([]DeploymentStatus, 0, len(m.deployments))
-		for _, d := range m.deployments {
+	if len(s.deployments) > 0 {
+		deps := make([]DeploymentStatus, 0, len(s.deployments))
+		for _, d := range s.deployments {
 			deps = append(deps, d)
 		}
 		status.Deployments = deps
 	}
-	if len(m.pods) > 0 {
-		pods := make([]PodStatus, 0, len(m.pods))
+	if len(s.pods) > 0 {
+		pods := make([]PodStatus, 0, len(s.pods))
 		gpuTotals := make(map[string]resource.Quantity)
-		for _, p := range m.pods {
+		for _, p := range s.pods {
+			if usage, ok := gpuUsage[s.target.Namespace+"/"+p.Name]; ok {
+				p.GPUUsage = &usage
+			}
 			pods = append(pods, p)
 			sumQuantityStrings(gpuTotals, p.GPURequests)
 		}
@@ -547,7 +716,7 @@ func (m *Manager) publish(status RuntimeStatus) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 	if err := m.eventBus.Publish(ctx, events.Event{
-		Type:      "model.status.updated",
+		Type:      events.TypeStatusUpdated,
 		Timestamp: status.UpdatedAt,
 		Data:      status,
 	}); err != nil {
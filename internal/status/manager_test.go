@@ -0,0 +1,269 @@
+package status
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type recordingBus struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (b *recordingBus) Publish(_ context.Context, evt events.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, evt)
+	return nil
+}
+
+func (b *recordingBus) targets() map[string]bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make(map[string]bool)
+	for _, evt := range b.events {
+		if status, ok := evt.Data.(RuntimeStatus); ok {
+			out[status.Target] = true
+		}
+	}
+	return out
+}
+
+// startFakeManager runs a manager over fake clients until the test ends.
+func startFakeManager(t *testing.T, targets []Target, bus eventsPublisher) (*Manager, *fake.Clientset, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+	kube := fake.NewSimpleClientset()
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		inferenceServiceGVR: "InferenceServiceList",
+	})
+	m := newManager(dyn, kube, targets, bus)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = m.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return m, kube, dyn
+}
+
+func testPod(namespace, name, isvc string, gpus int64) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "kserve-container",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				"nvidia.com/gpu": *resource.NewQuantity(gpus, resource.DecimalSI),
+			}},
+		}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if isvc != "" {
+		pod.Labels = map[string]string{"serving.kserve.io/inferenceservice": isvc}
+	}
+	return pod
+}
+
+func testDeployment(namespace, name, isvc string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"serving.kserve.io/inferenceservice": isvc},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1, Replicas: 1},
+	}
+}
+
+func podNames(status RuntimeStatus) []string {
+	names := make([]string, 0, len(status.Pods))
+	for _, pod := range status.Pods {
+		names = append(names, pod.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func deploymentNames(status RuntimeStatus) []string {
+	names := make([]string, 0, len(status.Deployments))
+	for _, dep := range status.Deployments {
+		names = append(names, dep.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestManagerRoutesObjectsByNamespaceAndName(t *testing.T) {
+	targets := []Target{
+		{Namespace: "ai", InferenceService: "active-llm"},
+		{Namespace: "ai", InferenceService: "other-llm"},
+		{Namespace: "lab", InferenceService: "active-llm"},
+	}
+	bus := &recordingBus{}
+	m, kube, dyn := startFakeManager(t, targets, bus)
+	ctx := context.Background()
+
+	for _, pod := range []*corev1.Pod{
+		testPod("ai", "active-pod", "active-llm", 2),
+		testPod("ai", "other-pod", "other-llm", 1),
+		testPod("lab", "lab-pod", "active-llm", 4),
+		testPod("ai", "unwatched-pod", "unwatched-llm", 1),
+		testPod("ai", "unlabeled-pod", "", 1),
+	} {
+		if _, err := kube.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create pod: %v", err)
+		}
+	}
+	for _, dep := range []*appsv1.Deployment{
+		testDeployment("ai", "active-predictor", "active-llm"),
+		testDeployment("lab", "lab-predictor", "active-llm"),
+		testDeployment("ai", "unwatched-predictor", "unwatched-llm"),
+	} {
+		if _, err := kube.AppsV1().Deployments(dep.Namespace).Create(ctx, dep, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create deployment: %v", err)
+		}
+	}
+	isvc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1beta1",
+		"kind":       "InferenceService",
+		"metadata":   map[string]interface{}{"namespace": "ai", "name": "active-llm"},
+		"status": map[string]interface{}{
+			"url":        "http://active-llm.ai",
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+		},
+	}}
+	if _, err := dyn.Resource(inferenceServiceGVR).Namespace("ai").Create(ctx, isvc, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create isvc: %v", err)
+	}
+
+	status := func(key string) RuntimeStatus {
+		s, ok := m.TargetStatus(key)
+		if !ok {
+			t.Fatalf("target %s is not watched", key)
+		}
+		return s
+	}
+	waitFor(t, "every target to see its objects", func() bool {
+		active, other, lab := status("ai/active-llm"), status("ai/other-llm"), status("lab/active-llm")
+		return len(active.Pods) > 0 && len(active.Deployments) > 0 && active.InferenceService != nil &&
+			len(other.Pods) > 0 && len(lab.Pods) > 0 && len(lab.Deployments) > 0
+	})
+
+	cases := []struct {
+		key         string
+		pods        []string
+		deployments []string
+		gpus        string
+		isvcReady   string
+	}{
+		{"ai/active-llm", []string{"active-pod"}, []string{"active-predictor"}, "2", "True"},
+		{"ai/other-llm", []string{"other-pod"}, []string{}, "1", ""},
+		{"lab/active-llm", []string{"lab-pod"}, []string{"lab-predictor"}, "4", ""},
+	}
+	for _, tc := range cases {
+		got := status(tc.key)
+		if names := podNames(got); !equalStrings(names, tc.pods) {
+			t.Errorf("%s pods = %v, want %v", tc.key, names, tc.pods)
+		}
+		if names := deploymentNames(got); !equalStrings(names, tc.deployments) {
+			t.Errorf("%s deployments = %v, want %v", tc.key, names, tc.deployments)
+		}
+		if alloc := got.GPUAllocations["nvidia.com/gpu"]; alloc != tc.gpus {
+			t.Errorf("%s GPU allocation = %q, want %q", tc.key, alloc, tc.gpus)
+		}
+		ready := ""
+		if got.InferenceService != nil {
+			ready = got.InferenceService.Ready
+		}
+		if ready != tc.isvcReady {
+			t.Errorf("%s InferenceService ready = %q, want %q", tc.key, ready, tc.isvcReady)
+		}
+	}
+	if current := m.CurrentStatus(); current.Target != "ai/active-llm" {
+		t.Errorf("CurrentStatus reported %s, want the first target", current.Target)
+	}
+	published := bus.targets()
+	for _, target := range targets {
+		if !published[target.Key()] {
+			t.Errorf("no status event published for %s", target.Key())
+		}
+	}
+	if len(published) != len(targets) {
+		t.Errorf("published events for unwatched targets: %v", published)
+	}
+}
+
+func TestManagerDeletesOnlyFromTheOwningTarget(t *testing.T) {
+	targets := []Target{
+		{Namespace: "ai", InferenceService: "active-llm"},
+		{Namespace: "lab", InferenceService: "active-llm"},
+	}
+	m, kube, _ := startFakeManager(t, targets, nil)
+	ctx := context.Background()
+
+	// Same pod name in both namespaces: deleting one must not touch the other.
+	for _, pod := range []*corev1.Pod{
+		testPod("ai", "predictor-0", "active-llm", 1),
+		testPod("lab", "predictor-0", "active-llm", 1),
+	} {
+		if _, err := kube.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("create pod: %v", err)
+		}
+	}
+	podCount := func(key string) int {
+		s, _ := m.TargetStatus(key)
+		return len(s.Pods)
+	}
+	waitFor(t, "both pods to be cached", func() bool {
+		return podCount("ai/active-llm") == 1 && podCount("lab/active-llm") == 1
+	})
+
+	if err := kube.CoreV1().Pods("ai").Delete(ctx, "predictor-0", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("delete pod: %v", err)
+	}
+	waitFor(t, "the ai pod to be removed", func() bool { return podCount("ai/active-llm") == 0 })
+	if n := podCount("lab/active-llm"); n != 1 {
+		t.Fatalf("lab target lost its pod after an ai delete: %d pods", n)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,9 @@
 package store
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"database/sql"
@@ -8,8 +11,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -44,10 +49,14 @@ type Job struct {
 	Error       string                 `json:"error,omitempty"`
 	Attempt     int                    `json:"attempt,omitempty"`
 	MaxAttempts int                    `json:"maxAttempts,omitempty"`
-	CancelledAt *time.Time             `json:"cancelledAt,omitempty"`
-	Logs        []JobLogEntry          `json:"logs,omitempty"`
-	CreatedAt   time.Time              `json:"createdAt"`
-	UpdatedAt   time.Time              `json:"updatedAt"`
+	// Priority orders pending jobs: higher values are claimed first.
+	Priority    int        `json:"priority,omitempty"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+	// NextAttemptAt delays a retried job until its backoff has elapsed.
+	NextAttemptAt *time.Time    `json:"nextAttemptAt,omitempty"`
+	Logs          []JobLogEntry `json:"logs,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
 }
 
 // JobLogEntry captures per-job log lines.
@@ -86,6 +95,24 @@ type NotificationStats struct {
 	LastEvent *time.Time `json:"lastEvent,omitempty"`
 }
 
+// Notification delivery outcomes.
+const (
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// NotificationDelivery records a single attempt to deliver a notification.
+type NotificationDelivery struct {
+	ID        int64     `json:"id"`
+	Channel   string    `json:"channel"`
+	EventType string    `json:"eventType"`
+	Status    string    `json:"status"`
+	HTTPCode  int       `json:"httpCode,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Payload   string    `json:"payload,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 // APIToken represents an issued token with optional scopes.
 type APIToken struct {
 	ID         string     `json:"id"`
@@ -131,6 +158,17 @@ type Playbook struct {
 	UpdatedAt   time.Time       `json:"updatedAt"`
 }
 
+// SecretRecord is a secret kept in the datastore rather than (or in addition
+// to) a Kubernetes Secret. KeyID names the key Data was encrypted with; it is
+// empty for plaintext records.
+type SecretRecord struct {
+	Name      string            `json:"name"`
+	KeyID     string            `json:"keyId,omitempty"`
+	Data      map[string]string `json:"data"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
 // Store wraps the persistence database used for jobs + history.
 type Store struct {
 	db     *sql.DB
@@ -140,6 +178,9 @@ type Store struct {
 // ErrPlaybookNotFound indicates that the requested playbook does not exist.
 var ErrPlaybookNotFound = errors.New("playbook not found")
 
+// ErrSecretNotFound indicates that the requested secret record does not exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
 // Open initializes the datastore using the supplied DSN/file path and driver.
 func Open(dsn string, driver string) (*Store, error) {
 	if driver == "" {
@@ -193,7 +234,9 @@ func initSchema(db *sql.DB, driver string) error {
 			error TEXT,
 			attempt INTEGER DEFAULT 0,
 			max_attempts INTEGER DEFAULT 1,
+			priority INTEGER DEFAULT 0,
 			cancelled_at TIMESTAMP,
+			next_attempt_at TIMESTAMP,
 			logs TEXT,
 			created_at TIMESTAMP NOT NULL,
 			updated_at TIMESTAMP NOT NULL
@@ -218,6 +261,16 @@ func initSchema(db *sql.DB, driver string) error {
 			created_at TIMESTAMP NOT NULL,
 			updated_at TIMESTAMP NOT NULL
 		);`
+	deliveriesTable := `CREATE TABLE IF NOT EXISTS notification_deliveries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			channel TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			status TEXT NOT NULL,
+			http_code INTEGER DEFAULT 0,
+			error TEXT,
+			payload TEXT,
+			created_at TIMESTAMP NOT NULL
+		);`
 	tokensTable := `CREATE TABLE IF NOT EXISTS api_tokens (
 			id TEXT PRIMARY KEY,
 			name TEXT NOT NULL,
@@ -254,6 +307,24 @@ func initSchema(db *sql.DB, driver string) error {
 			notes TEXT,
 			created_at TIMESTAMP NOT NULL
 		);`
+	secretsTable := `CREATE TABLE IF NOT EXISTS secrets (
+			name TEXT PRIMARY KEY,
+			key_id TEXT,
+			data TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);`
+	locksTable := `CREATE TABLE IF NOT EXISTS locks (
+			name TEXT PRIMARY KEY,
+			holder TEXT NOT NULL,
+			expires_at TIMESTAMP NOT NULL
+		);`
+	idempotencyTable := `CREATE TABLE IF NOT EXISTS idempotency (
+			key TEXT PRIMARY KEY,
+			request_hash TEXT NOT NULL DEFAULT '',
+			job_id TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		);`
 	if driver == "postgres" {
 		jobTable = `CREATE TABLE IF NOT EXISTS jobs (
 			id TEXT PRIMARY KEY,
@@ -267,7 +338,9 @@ func initSchema(db *sql.DB, driver string) error {
 			error TEXT,
 			attempt INTEGER DEFAULT 0,
 			max_attempts INTEGER DEFAULT 1,
+			priority INTEGER DEFAULT 0,
 			cancelled_at TIMESTAMPTZ,
+			next_attempt_at TIMESTAMPTZ,
 			logs TEXT,
 			created_at TIMESTAMPTZ NOT NULL,
 			updated_at TIMESTAMPTZ NOT NULL
@@ -292,6 +365,16 @@ func initSchema(db *sql.DB, driver string) error {
 			created_at TIMESTAMPTZ NOT NULL,
 			updated_at TIMESTAMPTZ NOT NULL
 		);`
+		deliveriesTable = `CREATE TABLE IF NOT EXISTS notification_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			channel TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			status TEXT NOT NULL,
+			http_code INTEGER DEFAULT 0,
+			error TEXT,
+			payload TEXT,
+			created_at TIMESTAMPTZ NOT NULL
+		);`
 		tokensTable = `CREATE TABLE IF NOT EXISTS api_tokens (
 			id TEXT PRIMARY KEY,
 			name TEXT NOT NULL,
@@ -328,24 +411,58 @@ func initSchema(db *sql.DB, driver string) error {
 			notes TEXT,
 			created_at TIMESTAMPTZ NOT NULL
 		);`
+		secretsTable = `CREATE TABLE IF NOT EXISTS secrets (
+			name TEXT PRIMARY KEY,
+			key_id TEXT,
+			data TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);`
+		locksTable = `CREATE TABLE IF NOT EXISTS locks (
+			name TEXT PRIMARY KEY,
+			holder TEXT NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL
+		);`
+		idempotencyTable = `CREATE TABLE IF NOT EXISTS idempotency (
+			key TEXT PRIMARY KEY,
+			request_hash TEXT NOT NULL DEFAULT '',
+			job_id TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		);`
 	}
 	stmts = append(stmts,
 		jobTable,
 		`CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);`,
 		`CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs(type);`,
 		historyTable,
+		`CREATE INDEX IF NOT EXISTS idx_history_event ON history(event, id);`,
 		hfModelsTable,
 		notificationsTable,
+		deliveriesTable,
+		`CREATE INDEX IF NOT EXISTS idx_notification_deliveries_channel ON notification_deliveries(channel, created_at);`,
 		tokensTable,
 		policiesTable,
 		policyVersionsTable,
 		playbooksTable,
 		backupsTable,
+		secretsTable,
+		locksTable,
+		idempotencyTable,
 		`CREATE TABLE IF NOT EXISTS catalog_cache (
 			id INTEGER PRIMARY KEY CHECK (id = 1),
 			snapshot TEXT NOT NULL,
 			updated_at TIMESTAMP NOT NULL
 		);`,
+		`CREATE TABLE IF NOT EXISTS vllm_architectures (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			snapshot TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS discovered_models (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			snapshot TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);`,
 	)
 	for _, stmt := range stmts {
 		if _, err := db.Exec(stmt); err != nil {
@@ -358,18 +475,28 @@ func initSchema(db *sql.DB, driver string) error {
 			`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS attempt INTEGER DEFAULT 0`,
 			`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS max_attempts INTEGER DEFAULT 1`,
 			`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ`,
+			`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ`,
 			`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS logs TEXT`,
+			`ALTER TABLE jobs ADD COLUMN IF NOT EXISTS priority INTEGER DEFAULT 0`,
 			`ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ`,
 			`ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ`,
+			`ALTER TABLE secrets ADD COLUMN IF NOT EXISTS key_id TEXT`,
+			`ALTER TABLE catalog_cache ADD COLUMN IF NOT EXISTS hash TEXT`,
+			`ALTER TABLE idempotency ADD COLUMN IF NOT EXISTS request_hash TEXT NOT NULL DEFAULT ''`,
 		}
 	} else {
 		alterStatements = []string{
 			`ALTER TABLE jobs ADD COLUMN attempt INTEGER DEFAULT 0`,
 			`ALTER TABLE jobs ADD COLUMN max_attempts INTEGER DEFAULT 1`,
 			`ALTER TABLE jobs ADD COLUMN cancelled_at TIMESTAMP`,
+			`ALTER TABLE jobs ADD COLUMN next_attempt_at TIMESTAMP`,
 			`ALTER TABLE jobs ADD COLUMN logs TEXT`,
+			`ALTER TABLE jobs ADD COLUMN priority INTEGER DEFAULT 0`,
 			`ALTER TABLE api_tokens ADD COLUMN expires_at TIMESTAMP`,
 			`ALTER TABLE api_tokens ADD COLUMN last_used_at TIMESTAMP`,
+			`ALTER TABLE secrets ADD COLUMN key_id TEXT`,
+			`ALTER TABLE catalog_cache ADD COLUMN hash TEXT`,
+			`ALTER TABLE idempotency ADD COLUMN request_hash TEXT NOT NULL DEFAULT ''`,
 		}
 	}
 	for _, stmt := range alterStatements {
@@ -409,6 +536,21 @@ func (s *Store) rebind(query string) string {
 	return builder.String()
 }
 
+func nullableTime(t *time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
+
+// Ping checks that the database is reachable.
+func (s *Store) Ping(ctx context.Context) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	return s.db.PingContext(ctx)
+}
+
 // Close shuts down the datastore.
 func (s *Store) Close() error {
 	if s == nil || s.db == nil {
@@ -447,9 +589,9 @@ func (s *Store) CreateJob(job *Job) error {
 	if job.CancelledAt != nil && !job.CancelledAt.IsZero() {
 		cancelled = *job.CancelledAt
 	}
-	_, err = s.db.Exec(s.rebind(`INSERT INTO jobs (id, type, status, stage, progress, message, payload, result, error, attempt, max_attempts, cancelled_at, logs, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
-		job.ID, job.Type, job.Status, job.Stage, job.Progress, job.Message, string(payload), string(result), job.Error, job.Attempt, job.MaxAttempts, cancelled, string(logs), job.CreatedAt, job.UpdatedAt,
+	_, err = s.db.Exec(s.rebind(`INSERT INTO jobs (id, type, status, stage, progress, message, payload, result, error, attempt, max_attempts, priority, cancelled_at, next_attempt_at, logs, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		job.ID, job.Type, job.Status, job.Stage, job.Progress, job.Message, string(payload), string(result), job.Error, job.Attempt, job.MaxAttempts, job.Priority, cancelled, nullableTime(job.NextAttemptAt), string(logs), job.CreatedAt, job.UpdatedAt,
 	)
 	return err
 }
@@ -484,10 +626,10 @@ func (s *Store) UpdateJob(job *Job) error {
 		}
 		logsJSON = string(data)
 	}
-	query := `UPDATE jobs SET type=?, status=?, stage=?, progress=?, message=?, payload=?, result=?, error=?, attempt=?, max_attempts=?, cancelled_at=?`
+	query := `UPDATE jobs SET type=?, status=?, stage=?, progress=?, message=?, payload=?, result=?, error=?, attempt=?, max_attempts=?, priority=?, cancelled_at=?, next_attempt_at=?`
 	args := []interface{}{
 		job.Type, job.Status, job.Stage, job.Progress, job.Message,
-		string(payload), string(result), job.Error, job.Attempt, job.MaxAttempts, cancelled,
+		string(payload), string(result), job.Error, job.Attempt, job.MaxAttempts, job.Priority, cancelled, nullableTime(job.NextAttemptAt),
 	}
 	if updateLogs {
 		query += `, logs=?`
@@ -502,15 +644,16 @@ func (s *Store) UpdateJob(job *Job) error {
 
 // GetJob loads a job by ID.
 func (s *Store) GetJob(id string) (*Job, error) {
-	row := s.db.QueryRow(s.rebind(`SELECT id, type, status, stage, progress, message, payload, result, error, attempt, max_attempts, cancelled_at, logs, created_at, updated_at FROM jobs WHERE id=?`), id)
+	row := s.db.QueryRow(s.rebind(`SELECT id, type, status, stage, progress, message, payload, result, error, attempt, max_attempts, priority, cancelled_at, next_attempt_at, logs, created_at, updated_at FROM jobs WHERE id=?`), id)
 	var (
 		job       Job
 		payload   sql.NullString
 		result    sql.NullString
 		logs      sql.NullString
 		cancelled sql.NullTime
+		next      sql.NullTime
 	)
-	if err := row.Scan(&job.ID, &job.Type, &job.Status, &job.Stage, &job.Progress, &job.Message, &payload, &result, &job.Error, &job.Attempt, &job.MaxAttempts, &cancelled, &logs, &job.CreatedAt, &job.UpdatedAt); err != nil {
+	if err := row.Scan(&job.ID, &job.Type, &job.Status, &job.Stage, &job.Progress, &job.Message, &payload, &result, &job.Error, &job.Attempt, &job.MaxAttempts, &job.Priority, &cancelled, &next, &logs, &job.CreatedAt, &job.UpdatedAt); err != nil {
 		return nil, err
 	}
 	if payload.Valid {
@@ -526,16 +669,60 @@ func (s *Store) GetJob(id string) (*Job, error) {
 		t := cancelled.Time
 		job.CancelledAt = &t
 	}
+	if next.Valid {
+		t := next.Time
+		job.NextAttemptAt = &t
+	}
 	return &job, nil
 }
 
 // ListJobs returns recent jobs sorted from newest to oldest.
 func (s *Store) ListJobs(limit int) ([]Job, error) {
-	query := `SELECT id, type, status, stage, progress, message, payload, result, error, attempt, max_attempts, cancelled_at, logs, created_at, updated_at FROM jobs ORDER BY created_at DESC`
-	if limit > 0 {
-		query = fmt.Sprintf("%s LIMIT %d", query, limit)
+	return s.ListJobsFiltered(JobListOptions{Limit: limit})
+}
+
+// JobListOptions narrows ListJobsFiltered. Zero values are ignored; Status and
+// Type match case-insensitively, and Since/Until bound created_at inclusively.
+type JobListOptions struct {
+	Status JobStatus
+	Type   string
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+// ListJobsFiltered returns jobs matching opts sorted from newest to oldest. The
+// filters are applied in SQL so Limit counts matching jobs only.
+func (s *Store) ListJobsFiltered(opts JobListOptions) ([]Job, error) {
+	query := `SELECT id, type, status, stage, progress, message, payload, result, error, attempt, max_attempts, priority, cancelled_at, next_attempt_at, logs, created_at, updated_at FROM jobs`
+	var (
+		clauses []string
+		args    []interface{}
+	)
+	if status := strings.ToLower(strings.TrimSpace(string(opts.Status))); status != "" {
+		clauses = append(clauses, "LOWER(status) = ?")
+		args = append(args, status)
 	}
-	rows, err := s.db.Query(s.rebind(query))
+	if jobType := strings.ToLower(strings.TrimSpace(opts.Type)); jobType != "" {
+		clauses = append(clauses, "LOWER(type) = ?")
+		args = append(args, jobType)
+	}
+	if !opts.Since.IsZero() {
+		clauses = append(clauses, "created_at >= ?")
+		args = append(args, opts.Since.UTC())
+	}
+	if !opts.Until.IsZero() {
+		clauses = append(clauses, "created_at <= ?")
+		args = append(args, opts.Until.UTC())
+	}
+	if len(clauses) > 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if opts.Limit > 0 {
+		query = fmt.Sprintf("%s LIMIT %d", query, opts.Limit)
+	}
+	rows, err := s.db.Query(s.rebind(query), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -544,8 +731,8 @@ func (s *Store) ListJobs(limit int) ([]Job, error) {
 	for rows.Next() {
 		var j Job
 		var payload, result, logs sql.NullString
-		var cancelled sql.NullTime
-		if err := rows.Scan(&j.ID, &j.Type, &j.Status, &j.Stage, &j.Progress, &j.Message, &payload, &result, &j.Error, &j.Attempt, &j.MaxAttempts, &cancelled, &logs, &j.CreatedAt, &j.UpdatedAt); err != nil {
+		var cancelled, next sql.NullTime
+		if err := rows.Scan(&j.ID, &j.Type, &j.Status, &j.Stage, &j.Progress, &j.Message, &payload, &result, &j.Error, &j.Attempt, &j.MaxAttempts, &j.Priority, &cancelled, &next, &logs, &j.CreatedAt, &j.UpdatedAt); err != nil {
 			return nil, err
 		}
 		if payload.Valid {
@@ -561,11 +748,153 @@ func (s *Store) ListJobs(limit int) ([]Job, error) {
 			t := cancelled.Time
 			j.CancelledAt = &t
 		}
+		if next.Valid {
+			t := next.Time
+			j.NextAttemptAt = &t
+		}
 		jobs = append(jobs, j)
 	}
 	return jobs, rows.Err()
 }
 
+// ClaimNextPendingJob atomically moves the oldest pending job whose backoff has
+// elapsed to running and returns it, or nil when nothing is waiting. Postgres uses
+// SELECT ... FOR UPDATE SKIP LOCKED so concurrent workers never claim the same
+// row; sqlite guards the update with a status check inside a transaction.
+func (s *Store) ClaimNextPendingJob(ctx context.Context, workerID string) (*Job, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("store not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT id FROM jobs WHERE status=? AND (next_attempt_at IS NULL OR next_attempt_at <= ?) ORDER BY priority DESC, created_at ASC LIMIT 1`
+	if s.driver == "postgres" {
+		query += ` FOR UPDATE SKIP LOCKED`
+	}
+	var id string
+	if err := tx.QueryRowContext(ctx, s.rebind(query), JobPending, time.Now().UTC()).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, s.rebind(`UPDATE jobs SET status=?, stage=?, message=?, updated_at=? WHERE id=? AND status=?`),
+		JobRunning, "claimed", claimMessage(workerID), time.Now().UTC(), id, JobPending,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		// Another worker claimed the job between the read and the update.
+		return nil, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetJob(id)
+}
+
+// ClaimJob atomically moves the given job from pending to running and returns
+// it, or nil when the job is no longer pending because a worker (or another
+// caller) claimed it first. Callers that run a job outside the worker claim
+// it here so the job is never executed twice.
+func (s *Store) ClaimJob(ctx context.Context, id, workerID string) (*Job, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("store not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, s.rebind(`UPDATE jobs SET status=?, stage=?, message=?, updated_at=? WHERE id=? AND status=? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)`),
+		JobRunning, "claimed", claimMessage(workerID), now, id, JobPending, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, nil
+	}
+	return s.GetJob(id)
+}
+
+// DueRetryJobs returns pending jobs whose retry backoff (next_attempt_at) has
+// elapsed, highest priority and oldest first.
+func (s *Store) DueRetryJobs(ctx context.Context) ([]*Job, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("store not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT id FROM jobs WHERE status=? AND next_attempt_at IS NOT NULL AND next_attempt_at <= ? ORDER BY priority DESC, created_at ASC`),
+		JobPending, time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.GetJob(id)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// ReleaseDueRetry clears the elapsed backoff of a pending job so exactly one
+// caller re-enqueues it. It reports whether this caller released the job.
+func (s *Store) ReleaseDueRetry(ctx context.Context, id string) (bool, error) {
+	if s == nil || s.db == nil {
+		return false, errors.New("store not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, s.rebind(`UPDATE jobs SET next_attempt_at=NULL, updated_at=? WHERE id=? AND status=? AND next_attempt_at IS NOT NULL AND next_attempt_at <= ?`),
+		now, id, JobPending, now,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func claimMessage(workerID string) string {
+	if workerID == "" {
+		return "Claimed by worker"
+	}
+	return fmt.Sprintf("Claimed by worker %s", workerID)
+}
+
 // AppendJobLog appends a log entry to the job's log list.
 func (s *Store) AppendJobLog(jobID string, entry JobLogEntry) error {
 	if s == nil || s.db == nil {
@@ -787,6 +1116,67 @@ func (s *Store) ListHistory(limit int) ([]HistoryEntry, error) {
 	return entries, rows.Err()
 }
 
+// LatestHistory returns the newest history entry recorded for event, or nil
+// when there is none.
+func (s *Store) LatestHistory(event string) (*HistoryEntry, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("datastore not configured")
+	}
+	query := s.rebind(`SELECT id, event, model_id, metadata, created_at FROM history WHERE event=? ORDER BY id DESC LIMIT 1`)
+	var e HistoryEntry
+	var metadata sql.NullString
+	var id int64
+	err := s.db.QueryRow(query, event).Scan(&id, &e.Event, &e.ModelID, &metadata, &e.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.ID = fmt.Sprintf("%d", id)
+	if metadata.Valid {
+		_ = json.Unmarshal([]byte(metadata.String), &e.Metadata)
+	}
+	return &e, nil
+}
+
+// FilterHistory narrows entries to a case-insensitive event and model ID match
+// and to entries created at or after since. Empty filters and a zero since are
+// ignored.
+func FilterHistory(entries []HistoryEntry, event, modelID string, since time.Time) []HistoryEntry {
+	event = strings.TrimSpace(strings.ToLower(event))
+	modelID = strings.TrimSpace(strings.ToLower(modelID))
+	if event == "" && modelID == "" && since.IsZero() {
+		return entries
+	}
+	result := make([]HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if event != "" && strings.ToLower(entry.Event) != event {
+			continue
+		}
+		if modelID != "" && strings.ToLower(entry.ModelID) != modelID {
+			continue
+		}
+		if !since.IsZero() && entry.CreatedAt.Before(since) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// ParseSince accepts a duration relative to now (e.g. "24h") or an RFC3339
+// timestamp.
+func ParseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid since value")
+}
+
 // DeleteJobs removes jobs optionally filtered by status.
 func (s *Store) DeleteJobs(status string) error {
 	if s == nil || s.db == nil {
@@ -847,7 +1237,32 @@ func (s *Store) CleanupHistoryBefore(ts time.Time) (int64, error) {
 	return rows, nil
 }
 
+// snapshotGzipPrefix marks catalog snapshots stored as base64-encoded gzip.
+// Snapshots without it are plain JSON written by older releases.
+const snapshotGzipPrefix = "gz1:"
+
+// CatalogHash returns the content hash SaveCatalogSnapshot records for models,
+// so callers can tell whether a snapshot write would change anything. Models
+// are hashed in ID order, so the result doesn't depend on the order they
+// were listed in.
+func CatalogHash(models []*catalog.Model) (string, error) {
+	sorted := append([]*catalog.Model(nil), models...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal catalog snapshot: %w", err)
+	}
+	return hashSnapshot(data), nil
+}
+
+func hashSnapshot(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%x", sum[:])
+}
+
 // SaveCatalogSnapshot persists the catalog contents for reuse when git-sync is cold.
+// The JSON is gzip-compressed so large catalogs stay small on disk, and its
+// hash is stored alongside it.
 func (s *Store) SaveCatalogSnapshot(models []*catalog.Model) error {
 	if s == nil || s.db == nil {
 		return errors.New("datastore not configured")
@@ -856,14 +1271,70 @@ func (s *Store) SaveCatalogSnapshot(models []*catalog.Model) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal catalog snapshot: %w", err)
 	}
-	_, err = s.db.Exec(s.rebind(`INSERT INTO catalog_cache (id, snapshot, updated_at)
-		VALUES (1, ?, ?)
-		ON CONFLICT(id) DO UPDATE SET snapshot=excluded.snapshot, updated_at=excluded.updated_at`),
-		string(data), time.Now().UTC(),
+	hash, err := CatalogHash(models)
+	if err != nil {
+		return err
+	}
+	encoded, err := compressSnapshot(data)
+	if err != nil {
+		return fmt.Errorf("failed to compress catalog snapshot: %w", err)
+	}
+	_, err = s.db.Exec(s.rebind(`INSERT INTO catalog_cache (id, snapshot, hash, updated_at)
+		VALUES (1, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET snapshot=excluded.snapshot, hash=excluded.hash, updated_at=excluded.updated_at`),
+		encoded, hash, time.Now().UTC(),
 	)
 	return err
 }
 
+// CatalogSnapshotHash returns the hash of the persisted catalog snapshot, or
+// an empty string when none has been saved (or it predates hashing).
+func (s *Store) CatalogSnapshotHash() (string, error) {
+	if s == nil || s.db == nil {
+		return "", errors.New("datastore not configured")
+	}
+	var hash sql.NullString
+	err := s.db.QueryRow(s.rebind(`SELECT hash FROM catalog_cache WHERE id = 1`)).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return hash.String, nil
+}
+
+// compressSnapshot gzips data and base64-encodes it so it fits the TEXT column.
+func compressSnapshot(data []byte) (string, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return snapshotGzipPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decompressSnapshot reverses compressSnapshot, passing legacy uncompressed
+// snapshots through unchanged.
+func decompressSnapshot(snapshot string) ([]byte, error) {
+	if !strings.HasPrefix(snapshot, snapshotGzipPrefix) {
+		return []byte(snapshot), nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(snapshot, snapshotGzipPrefix))
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
 // LoadCatalogSnapshot pulls the last catalog snapshot.
 func (s *Store) LoadCatalogSnapshot() ([]*catalog.Model, time.Time, error) {
 	if s == nil || s.db == nil {
@@ -875,13 +1346,90 @@ func (s *Store) LoadCatalogSnapshot() ([]*catalog.Model, time.Time, error) {
 	if err := row.Scan(&snapshot, &updated); err != nil {
 		return nil, time.Time{}, err
 	}
+	data, err := decompressSnapshot(snapshot)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decompress catalog snapshot: %w", err)
+	}
 	var models []*catalog.Model
-	if err := json.Unmarshal([]byte(snapshot), &models); err != nil {
+	if err := json.Unmarshal(data, &models); err != nil {
 		return nil, time.Time{}, fmt.Errorf("failed to decode catalog snapshot: %w", err)
 	}
 	return models, updated, nil
 }
 
+// SaveDiscoveredModels replaces the draft catalog entries generated from
+// compatible Hugging Face discoveries. They are install candidates and are kept
+// apart from the catalog snapshot.
+func (s *Store) SaveDiscoveredModels(models []*catalog.Model) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	data, err := json.Marshal(models)
+	if err != nil {
+		return fmt.Errorf("failed to marshal discovered models: %w", err)
+	}
+	_, err = s.db.Exec(s.rebind(`INSERT INTO discovered_models (id, snapshot, updated_at)
+		VALUES (1, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET snapshot=excluded.snapshot, updated_at=excluded.updated_at`),
+		string(data), time.Now().UTC(),
+	)
+	return err
+}
+
+// LoadDiscoveredModels returns the last set of draft catalog entries saved by
+// the sync service.
+func (s *Store) LoadDiscoveredModels() ([]*catalog.Model, time.Time, error) {
+	if s == nil || s.db == nil {
+		return nil, time.Time{}, errors.New("datastore not configured")
+	}
+	row := s.db.QueryRow(s.rebind(`SELECT snapshot, updated_at FROM discovered_models WHERE id = 1`))
+	var snapshot string
+	var updated time.Time
+	if err := row.Scan(&snapshot, &updated); err != nil {
+		return nil, time.Time{}, err
+	}
+	var models []*catalog.Model
+	if err := json.Unmarshal([]byte(snapshot), &models); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode discovered models: %w", err)
+	}
+	return models, updated, nil
+}
+
+// SaveArchitectures persists the vLLM architecture list for cold starts.
+func (s *Store) SaveArchitectures(archs []vllm.ModelArchitecture) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	data, err := json.Marshal(archs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal architecture snapshot: %w", err)
+	}
+	_, err = s.db.Exec(s.rebind(`INSERT INTO vllm_architectures (id, snapshot, updated_at)
+		VALUES (1, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET snapshot=excluded.snapshot, updated_at=excluded.updated_at`),
+		string(data), time.Now().UTC(),
+	)
+	return err
+}
+
+// LoadArchitectures returns the last persisted vLLM architecture list.
+func (s *Store) LoadArchitectures() ([]vllm.ModelArchitecture, time.Time, error) {
+	if s == nil || s.db == nil {
+		return nil, time.Time{}, errors.New("datastore not configured")
+	}
+	row := s.db.QueryRow(s.rebind(`SELECT snapshot, updated_at FROM vllm_architectures WHERE id = 1`))
+	var snapshot string
+	var updated time.Time
+	if err := row.Scan(&snapshot, &updated); err != nil {
+		return nil, time.Time{}, err
+	}
+	var archs []vllm.ModelArchitecture
+	if err := json.Unmarshal([]byte(snapshot), &archs); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to decode architecture snapshot: %w", err)
+	}
+	return archs, updated, nil
+}
+
 // UpsertNotification creates or updates a notification channel.
 func (s *Store) UpsertNotification(n *Notification) error {
 	if s == nil || s.db == nil {
@@ -957,6 +1505,25 @@ func (s *Store) GetNotification(name string) (*Notification, error) {
 	return &rec, nil
 }
 
+// aggregateTime converts a MAX(timestamp) result into a time. Postgres returns a
+// time.Time, but SQLite loses the column type in aggregates and returns the
+// driver's text encoding.
+func aggregateTime(raw interface{}) time.Time {
+	switch v := raw.(type) {
+	case time.Time:
+		return v
+	case []byte:
+		return aggregateTime(string(v))
+	case string:
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999 -0700 MST", time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05"} {
+			if parsed, err := time.Parse(layout, v); err == nil {
+				return parsed
+			}
+		}
+	}
+	return time.Time{}
+}
+
 // NotificationHealth aggregates delivery stats across history.
 func (s *Store) NotificationHealth() (NotificationStats, error) {
 	stats := NotificationStats{}
@@ -974,10 +1541,11 @@ func (s *Store) NotificationHealth() (NotificationStats, error) {
 	for rows.Next() {
 		var event string
 		var count int
-		var last time.Time
-		if err := rows.Scan(&event, &count, &last); err != nil {
+		var raw interface{}
+		if err := rows.Scan(&event, &count, &raw); err != nil {
 			return stats, err
 		}
+		last := aggregateTime(raw)
 		switch event {
 		case "notification_test":
 			stats.Tested = count
@@ -995,6 +1563,56 @@ func (s *Store) NotificationHealth() (NotificationStats, error) {
 	return stats, rows.Err()
 }
 
+// RecordNotificationDelivery appends a delivery attempt to the delivery log.
+func (s *Store) RecordNotificationDelivery(d *NotificationDelivery) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	if d == nil || d.Channel == "" {
+		return errors.New("invalid delivery record")
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now().UTC()
+	}
+	res, err := s.db.Exec(s.rebind(`INSERT INTO notification_deliveries (channel, event_type, status, http_code, error, payload, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		d.Channel, d.EventType, d.Status, d.HTTPCode, d.Error, d.Payload, d.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if id, err := res.LastInsertId(); err == nil {
+		d.ID = id
+	}
+	return nil
+}
+
+// ListNotificationDeliveries returns recent delivery attempts for a channel, newest first.
+func (s *Store) ListNotificationDeliveries(channel string, limit int) ([]NotificationDelivery, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("datastore not configured")
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.db.Query(s.rebind(`SELECT id, channel, event_type, status, http_code, error, payload, created_at FROM notification_deliveries WHERE channel = ? ORDER BY created_at DESC, id DESC LIMIT ?`), channel, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var records []NotificationDelivery
+	for rows.Next() {
+		var rec NotificationDelivery
+		var errText, payload sql.NullString
+		if err := rows.Scan(&rec.ID, &rec.Channel, &rec.EventType, &rec.Status, &rec.HTTPCode, &errText, &payload, &rec.CreatedAt); err != nil {
+			return nil, err
+		}
+		rec.Error = errText.String
+		rec.Payload = payload.String
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
 // DeleteNotification removes a notification channel.
 func (s *Store) DeleteNotification(name string) error {
 	if s == nil || s.db == nil {
@@ -1197,8 +1815,9 @@ func (s *Store) ListPolicyVersions(name string, limit int) ([]PolicyVersion, err
 	return versions, rows.Err()
 }
 
-// RollbackPolicy restores a prior revision.
-func (s *Store) RollbackPolicy(name string, version int) (*Policy, error) {
+// GetPolicyVersion returns a stored revision of a policy, or the newest one
+// when version is not positive.
+func (s *Store) GetPolicyVersion(name string, version int) (*PolicyVersion, error) {
 	if s == nil || s.db == nil {
 		return nil, errors.New("datastore not configured")
 	}
@@ -1209,9 +1828,17 @@ func (s *Store) RollbackPolicy(name string, version int) (*Policy, error) {
 		args = append(args, version)
 	}
 	query += " ORDER BY version DESC LIMIT 1"
-	row := s.db.QueryRow(s.rebind(query), args...)
-	var selected PolicyVersion
-	if err := row.Scan(&selected.Version, &selected.Document, &selected.CreatedAt); err != nil {
+	selected := PolicyVersion{Name: name}
+	if err := s.db.QueryRow(s.rebind(query), args...).Scan(&selected.Version, &selected.Document, &selected.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &selected, nil
+}
+
+// RollbackPolicy restores a prior revision.
+func (s *Store) RollbackPolicy(name string, version int) (*Policy, error) {
+	selected, err := s.GetPolicyVersion(name, version)
+	if err != nil {
 		return nil, err
 	}
 	policy := &Policy{Name: name, Document: selected.Document, UpdatedAt: time.Now().UTC()}
@@ -1221,6 +1848,19 @@ func (s *Store) RollbackPolicy(name string, version int) (*Policy, error) {
 	return policy, nil
 }
 
+// ArchivePolicy moves a policy's current document into its version history
+// and removes it, so it is no longer enforced but can still be inspected.
+func (s *Store) ArchivePolicy(name string) error {
+	current, err := s.GetPolicy(name)
+	if err != nil {
+		return err
+	}
+	if err := s.snapshotPolicyVersion(current); err != nil {
+		return err
+	}
+	return s.DeletePolicy(name)
+}
+
 // ListPolicies returns stored policies.
 func (s *Store) ListPolicies() ([]Policy, error) {
 	if s == nil || s.db == nil {
@@ -1412,3 +2052,196 @@ func (s *Store) DeletePlaybook(name string) error {
 	}
 	return nil
 }
+
+// ListSecrets returns all secret records sorted by name.
+func (s *Store) ListSecrets() ([]SecretRecord, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("datastore not configured")
+	}
+	rows, err := s.db.Query(`SELECT name, key_id, data, created_at, updated_at FROM secrets ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []SecretRecord
+	for rows.Next() {
+		var (
+			rec   SecretRecord
+			keyID sql.NullString
+			data  string
+		)
+		if err := rows.Scan(&rec.Name, &keyID, &data, &rec.CreatedAt, &rec.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rec.KeyID = keyID.String
+		if err := json.Unmarshal([]byte(data), &rec.Data); err != nil {
+			return nil, fmt.Errorf("decode secret %s: %w", rec.Name, err)
+		}
+		items = append(items, rec)
+	}
+	return items, rows.Err()
+}
+
+// GetSecret fetches a secret record by name.
+func (s *Store) GetSecret(name string) (*SecretRecord, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("datastore not configured")
+	}
+	row := s.db.QueryRow(s.rebind(`SELECT name, key_id, data, created_at, updated_at FROM secrets WHERE name=?`), name)
+	var (
+		rec   SecretRecord
+		keyID sql.NullString
+		data  string
+	)
+	if err := row.Scan(&rec.Name, &keyID, &data, &rec.CreatedAt, &rec.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, err
+	}
+	rec.KeyID = keyID.String
+	if err := json.Unmarshal([]byte(data), &rec.Data); err != nil {
+		return nil, fmt.Errorf("decode secret %s: %w", rec.Name, err)
+	}
+	return &rec, nil
+}
+
+// UpsertSecret creates or replaces the data of a secret record. keyID
+// identifies the key data was encrypted with ("" for plaintext).
+func (s *Store) UpsertSecret(name, keyID string, data map[string]string) (*SecretRecord, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("datastore not configured")
+	}
+	if name == "" {
+		return nil, errors.New("secret name is required")
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	_, err = s.db.Exec(s.rebind(`INSERT INTO secrets (name, key_id, data, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET key_id=excluded.key_id, data=excluded.data, updated_at=excluded.updated_at`),
+		name, keyID, string(payload), now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSecret(name)
+}
+
+// DeleteSecret removes a secret record.
+func (s *Store) DeleteSecret(name string) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	result, err := s.db.Exec(s.rebind(`DELETE FROM secrets WHERE name=?`), name)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrSecretNotFound
+	}
+	return nil
+}
+
+// AcquireLock takes the named lease for holder until ttl elapses. It reports
+// false when another holder has an unexpired lease; the same holder may
+// re-acquire to extend its lease.
+func (s *Store) AcquireLock(name, holder string, ttl time.Duration) (bool, error) {
+	if s == nil || s.db == nil {
+		return false, errors.New("datastore not configured")
+	}
+	if name == "" || holder == "" {
+		return false, errors.New("lock name and holder are required")
+	}
+	now := time.Now().UTC()
+	result, err := s.db.Exec(s.rebind(`INSERT INTO locks (name, holder, expires_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET holder=excluded.holder, expires_at=excluded.expires_at
+		WHERE locks.expires_at < ? OR locks.holder = excluded.holder`),
+		name, holder, now.Add(ttl), now,
+	)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// ReleaseLock drops the named lease if holder still owns it.
+func (s *Store) ReleaseLock(name, holder string) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	_, err := s.db.Exec(s.rebind(`DELETE FROM locks WHERE name=? AND holder=?`), name, holder)
+	return err
+}
+
+// IdempotencyRecord is what an Idempotency-Key maps to.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	// JobID is empty while the request that claimed the key is still
+	// creating its job.
+	JobID     string
+	CreatedAt time.Time
+}
+
+// ClaimIdempotencyKey records key for a request whose body hashes to
+// requestHash, unless a record younger than ttl already exists. Expired keys
+// are pruned first. It returns claimed=true when the caller now owns the key,
+// and otherwise the record of the request that got there first.
+func (s *Store) ClaimIdempotencyKey(key, requestHash string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	if s == nil || s.db == nil {
+		return nil, false, errors.New("datastore not configured")
+	}
+	now := time.Now().UTC()
+	if _, err := s.db.Exec(s.rebind(`DELETE FROM idempotency WHERE created_at < ?`), now.Add(-ttl)); err != nil {
+		return nil, false, err
+	}
+	res, err := s.db.Exec(s.rebind(`INSERT INTO idempotency (key, request_hash, job_id, created_at)
+		VALUES (?, ?, '', ?)
+		ON CONFLICT(key) DO NOTHING`),
+		key, requestHash, now,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, false, err
+	} else if n == 1 {
+		return &IdempotencyRecord{Key: key, RequestHash: requestHash, CreatedAt: now}, true, nil
+	}
+	rec := &IdempotencyRecord{Key: key}
+	err = s.db.QueryRow(s.rebind(`SELECT request_hash, job_id, created_at FROM idempotency WHERE key=?`), key).
+		Scan(&rec.RequestHash, &rec.JobID, &rec.CreatedAt)
+	if err != nil {
+		return nil, false, err
+	}
+	return rec, false, nil
+}
+
+// SetIdempotencyJob records the job created by the request that claimed key.
+func (s *Store) SetIdempotencyJob(key, jobID string) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	_, err := s.db.Exec(s.rebind(`UPDATE idempotency SET job_id=? WHERE key=?`), jobID, key)
+	return err
+}
+
+// ReleaseIdempotencyKey drops a claim whose request failed before creating a
+// job, so a retry with the same key runs instead of waiting out the TTL.
+func (s *Store) ReleaseIdempotencyKey(key string) error {
+	if s == nil || s.db == nil {
+		return errors.New("datastore not configured")
+	}
+	_, err := s.db.Exec(s.rebind(`DELETE FROM idempotency WHERE key=? AND job_id=''`), key)
+	return err
+}
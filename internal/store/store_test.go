@@ -1,10 +1,17 @@
 package store
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/vllm"
 )
 
 func TestStoreJobsAndHistory(t *testing.T) {
@@ -112,6 +119,103 @@ func TestCatalogSnapshotRoundTrip(t *testing.T) {
 	}
 }
 
+func TestCatalogHashIgnoresModelOrder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	foo := &catalog.Model{ID: "foo", HFModelID: "org/foo"}
+	bar := &catalog.Model{ID: "bar", HFModelID: "org/bar"}
+	baz := &catalog.Model{ID: "baz", HFModelID: "org/baz"}
+	first, err := CatalogHash([]*catalog.Model{foo, bar, baz})
+	if err != nil {
+		t.Fatalf("CatalogHash: %v", err)
+	}
+	second, err := CatalogHash([]*catalog.Model{baz, foo, bar})
+	if err != nil {
+		t.Fatalf("CatalogHash: %v", err)
+	}
+	if first != second {
+		t.Fatalf("expected the same hash regardless of order, got %s and %s", first, second)
+	}
+
+	if err := s.SaveCatalogSnapshot([]*catalog.Model{bar, baz, foo}); err != nil {
+		t.Fatalf("SaveCatalogSnapshot: %v", err)
+	}
+	if stored, err := s.CatalogSnapshotHash(); err != nil || stored != first {
+		t.Fatalf("expected the snapshot hash to match CatalogHash, got %q (%v)", stored, err)
+	}
+
+	changed, _ := CatalogHash([]*catalog.Model{foo, bar, {ID: "baz", HFModelID: "org/other"}})
+	if changed == first {
+		t.Fatalf("expected a content change to change the hash")
+	}
+}
+
+func TestCatalogSnapshotCompressesLargeCatalog(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	models := make([]*catalog.Model, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		models = append(models, &catalog.Model{
+			ID:          fmt.Sprintf("model-%d", i),
+			DisplayName: fmt.Sprintf("Model %d", i),
+			HFModelID:   fmt.Sprintf("org/model-%d", i),
+			Tags:        []string{"chat", "instruct"},
+		})
+	}
+	if err := s.SaveCatalogSnapshot(models); err != nil {
+		t.Fatalf("SaveCatalogSnapshot: %v", err)
+	}
+
+	var stored string
+	if err := s.db.QueryRow(`SELECT snapshot FROM catalog_cache WHERE id = 1`).Scan(&stored); err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	if !strings.HasPrefix(stored, snapshotGzipPrefix) {
+		t.Fatalf("expected compressed snapshot, got prefix %q", stored[:10])
+	}
+
+	loaded, _, err := s.LoadCatalogSnapshot()
+	if err != nil {
+		t.Fatalf("LoadCatalogSnapshot: %v", err)
+	}
+	if len(loaded) != len(models) {
+		t.Fatalf("expected %d models, got %d", len(models), len(loaded))
+	}
+	if loaded[1999].HFModelID != "org/model-1999" || len(loaded[1999].Tags) != 2 {
+		t.Fatalf("unexpected last model: %+v", loaded[1999])
+	}
+
+	// Snapshots written before compression are plain JSON and must still load.
+	if _, err := s.db.Exec(`UPDATE catalog_cache SET snapshot = ? WHERE id = 1`, `[{"id":"legacy"}]`); err != nil {
+		t.Fatalf("write legacy snapshot: %v", err)
+	}
+	loaded, _, err = s.LoadCatalogSnapshot()
+	if err != nil {
+		t.Fatalf("LoadCatalogSnapshot legacy: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != "legacy" {
+		t.Fatalf("unexpected legacy snapshot: %+v", loaded)
+	}
+}
+
 func TestDeleteJobsAndHistory(t *testing.T) {
 	t.Parallel()
 
@@ -147,6 +251,94 @@ func TestDeleteJobsAndHistory(t *testing.T) {
 	}
 }
 
+func TestLatestHistoryReturnsNewestEntryForEvent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	if entry, err := s.LatestHistory("model_activated"); err != nil || entry != nil {
+		t.Fatalf("expected no entry before any activation, got %+v err=%v", entry, err)
+	}
+	for _, e := range []HistoryEntry{
+		{Event: "model_activated", ModelID: "llama"},
+		{Event: "model_activated", ModelID: "qwen", Metadata: map[string]interface{}{"previousModelId": "llama"}},
+		{Event: "weight_install_completed", ModelID: "mistral"},
+	} {
+		if err := s.AppendHistory(&e); err != nil {
+			t.Fatalf("AppendHistory: %v", err)
+		}
+	}
+
+	entry, err := s.LatestHistory("model_activated")
+	if err != nil {
+		t.Fatalf("LatestHistory: %v", err)
+	}
+	if entry == nil || entry.ModelID != "qwen" || entry.Metadata["previousModelId"] != "llama" {
+		t.Fatalf("expected the qwen activation, got %+v", entry)
+	}
+}
+
+func TestListJobsFilteredByRange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	if err := s.CreateJob(&Job{ID: "old", Type: "weight_install", Status: JobFailed}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	boundary := time.Now().UTC()
+	time.Sleep(5 * time.Millisecond)
+	for _, job := range []*Job{
+		{ID: "new-failed", Type: "weight_install", Status: JobFailed},
+		{ID: "new-done", Type: "Weight_Install", Status: JobDone},
+	} {
+		if err := s.CreateJob(job); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+	}
+
+	ids := func(opts JobListOptions) []string {
+		t.Helper()
+		jobs, err := s.ListJobsFiltered(opts)
+		if err != nil {
+			t.Fatalf("ListJobsFiltered(%+v): %v", opts, err)
+		}
+		out := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			out = append(out, job.ID)
+		}
+		return out
+	}
+
+	if got := ids(JobListOptions{Until: boundary}); len(got) != 1 || got[0] != "old" {
+		t.Fatalf("expected only old job before boundary, got %v", got)
+	}
+	if got := ids(JobListOptions{Since: boundary, Status: "FAILED"}); len(got) != 1 || got[0] != "new-failed" {
+		t.Fatalf("expected new failed job, got %v", got)
+	}
+	if got := ids(JobListOptions{Since: boundary, Type: "weight_install", Limit: 1}); len(got) != 1 || got[0] != "new-done" {
+		t.Fatalf("expected newest matching job, got %v", got)
+	}
+	if got := ids(JobListOptions{Since: boundary, Until: boundary}); len(got) != 0 {
+		t.Fatalf("expected empty range, got %v", got)
+	}
+}
+
 func TestAppendJobLogAndCounts(t *testing.T) {
 	t.Parallel()
 
@@ -184,3 +376,407 @@ func TestAppendJobLogAndCounts(t *testing.T) {
 		t.Fatalf("expected pending=1 got %+v", counts)
 	}
 }
+
+func TestNotificationDeliveriesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	if err := s.RecordNotificationDelivery(&NotificationDelivery{Channel: "ops", EventType: "test", Status: DeliverySucceeded, HTTPCode: 200}); err != nil {
+		t.Fatalf("RecordNotificationDelivery: %v", err)
+	}
+	if err := s.RecordNotificationDelivery(&NotificationDelivery{Channel: "ops", EventType: "test", Status: DeliveryFailed, HTTPCode: 500, Error: "slack webhook returned 500"}); err != nil {
+		t.Fatalf("RecordNotificationDelivery: %v", err)
+	}
+	if err := s.RecordNotificationDelivery(&NotificationDelivery{Channel: "other", EventType: "test", Status: DeliverySucceeded}); err != nil {
+		t.Fatalf("RecordNotificationDelivery: %v", err)
+	}
+
+	deliveries, err := s.ListNotificationDeliveries("ops", 10)
+	if err != nil {
+		t.Fatalf("ListNotificationDeliveries: %v", err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("expected 2 deliveries got %d", len(deliveries))
+	}
+	if deliveries[0].Status != DeliveryFailed || deliveries[0].HTTPCode != 500 || deliveries[0].Error == "" {
+		t.Fatalf("expected newest failed delivery first, got %+v", deliveries[0])
+	}
+}
+
+func TestNotificationHealthCountsHistory(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	for _, event := range []string{"notification_delivery", "notification_delivery", "notification_failed"} {
+		if err := s.AppendHistory(&HistoryEntry{Event: event}); err != nil {
+			t.Fatalf("AppendHistory: %v", err)
+		}
+	}
+
+	stats, err := s.NotificationHealth()
+	if err != nil {
+		t.Fatalf("NotificationHealth: %v", err)
+	}
+	if stats.Delivered != 2 || stats.Failed != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.LastEvent == nil || time.Since(*stats.LastEvent) > time.Minute {
+		t.Fatalf("expected recent last event, got %v", stats.LastEvent)
+	}
+}
+
+func TestClaimNextPendingJob(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	for _, id := range []string{"job-old", "job-new"} {
+		if err := s.CreateJob(&Job{ID: id, Type: "weight_install"}); err != nil {
+			t.Fatalf("CreateJob %s: %v", id, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := s.CreateJob(&Job{ID: "job-done", Type: "weight_install", Status: JobDone}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+
+	for _, want := range []string{"job-old", "job-new"} {
+		job, err := s.ClaimNextPendingJob(context.Background(), "worker-a")
+		if err != nil {
+			t.Fatalf("ClaimNextPendingJob: %v", err)
+		}
+		if job == nil || job.ID != want {
+			t.Fatalf("expected to claim %s got %+v", want, job)
+		}
+		if job.Status != JobRunning {
+			t.Fatalf("expected claimed job to be running got %s", job.Status)
+		}
+	}
+
+	job, err := s.ClaimNextPendingJob(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("ClaimNextPendingJob: %v", err)
+	}
+	if job != nil {
+		t.Fatalf("expected no pending jobs, claimed %s", job.ID)
+	}
+}
+
+func TestClaimJobClaimsOnlyOnce(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	if err := s.CreateJob(&Job{ID: "job-a", Type: "weight_install"}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	job, err := s.ClaimJob(context.Background(), "job-a", "api")
+	if err != nil {
+		t.Fatalf("ClaimJob: %v", err)
+	}
+	if job == nil || job.Status != JobRunning {
+		t.Fatalf("expected job-a to be claimed as running, got %+v", job)
+	}
+	if again, err := s.ClaimJob(context.Background(), "job-a", "api"); err != nil || again != nil {
+		t.Fatalf("expected second claim to fail, got %+v, %v", again, err)
+	}
+	if next, err := s.ClaimNextPendingJob(context.Background(), "worker-a"); err != nil || next != nil {
+		t.Fatalf("expected the worker to find nothing to claim, got %+v, %v", next, err)
+	}
+}
+
+func TestDueRetriesAreReleasedOnce(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	future := time.Now().UTC().Add(time.Hour)
+	past := time.Now().UTC().Add(-time.Second)
+	for _, job := range []*Job{
+		{ID: "job-later", Type: "weight_install", NextAttemptAt: &future},
+		{ID: "job-due", Type: "weight_install", NextAttemptAt: &past},
+		{ID: "job-fresh", Type: "weight_install"},
+	} {
+		if err := s.CreateJob(job); err != nil {
+			t.Fatalf("CreateJob: %v", err)
+		}
+	}
+	if claimed, err := s.ClaimJob(context.Background(), "job-later", "api"); err != nil || claimed != nil {
+		t.Fatalf("expected a job in backoff not to be claimable, got %+v (%v)", claimed, err)
+	}
+
+	due, err := s.DueRetryJobs(context.Background())
+	if err != nil {
+		t.Fatalf("DueRetryJobs: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != "job-due" {
+		t.Fatalf("expected only job-due, got %+v", due)
+	}
+	if ok, err := s.ReleaseDueRetry(context.Background(), "job-due"); err != nil || !ok {
+		t.Fatalf("expected the first release to win, got %v (%v)", ok, err)
+	}
+	if ok, err := s.ReleaseDueRetry(context.Background(), "job-due"); err != nil || ok {
+		t.Fatalf("expected the second release to lose, got %v (%v)", ok, err)
+	}
+	if ok, err := s.ReleaseDueRetry(context.Background(), "job-later"); err != nil || ok {
+		t.Fatalf("expected a job still in backoff not to be released, got %v (%v)", ok, err)
+	}
+	if due, err := s.DueRetryJobs(context.Background()); err != nil || len(due) != 0 {
+		t.Fatalf("expected no due retries after release, got %+v (%v)", due, err)
+	}
+}
+
+func TestClaimNextPendingJobSkipsBackoff(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	next := time.Now().UTC().Add(time.Hour)
+	if err := s.CreateJob(&Job{ID: "job-delayed", Type: "weight_install", NextAttemptAt: &next}); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	stored, err := s.GetJob("job-delayed")
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if stored.NextAttemptAt == nil || !stored.NextAttemptAt.Equal(next) {
+		t.Fatalf("expected next attempt %s got %v", next, stored.NextAttemptAt)
+	}
+
+	job, err := s.ClaimNextPendingJob(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("ClaimNextPendingJob: %v", err)
+	}
+	if job != nil {
+		t.Fatalf("expected delayed job to be skipped, claimed %s", job.ID)
+	}
+
+	past := time.Now().UTC().Add(-time.Second)
+	stored.NextAttemptAt = &past
+	if err := s.UpdateJob(stored); err != nil {
+		t.Fatalf("UpdateJob: %v", err)
+	}
+	job, err = s.ClaimNextPendingJob(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("ClaimNextPendingJob: %v", err)
+	}
+	if job == nil || job.ID != "job-delayed" {
+		t.Fatalf("expected job-delayed once backoff elapsed, got %+v", job)
+	}
+}
+
+func TestArchitectureSnapshotRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	if _, _, err := s.LoadArchitectures(); err == nil {
+		t.Fatalf("expected error when no snapshot exists")
+	}
+	archs := []vllm.ModelArchitecture{{Name: "qwen2", ClassName: "Qwen2"}, {Name: "llama", ClassName: "Llama"}}
+	if err := s.SaveArchitectures(archs); err != nil {
+		t.Fatalf("SaveArchitectures: %v", err)
+	}
+	if err := s.SaveArchitectures(archs[:1]); err != nil {
+		t.Fatalf("SaveArchitectures overwrite: %v", err)
+	}
+	loaded, updated, err := s.LoadArchitectures()
+	if err != nil {
+		t.Fatalf("LoadArchitectures: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Name != "qwen2" || updated.IsZero() {
+		t.Fatalf("unexpected snapshot %+v updated=%s", loaded, updated)
+	}
+}
+
+func TestDiscoveredModelsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	if _, _, err := s.LoadDiscoveredModels(); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows before the first save, got %v", err)
+	}
+	drafts := []*catalog.Model{{ID: "qwen2-5-0-5b", HFModelID: "Qwen/Qwen2.5-0.5B"}}
+	if err := s.SaveDiscoveredModels(drafts); err != nil {
+		t.Fatalf("SaveDiscoveredModels: %v", err)
+	}
+	if err := s.SaveDiscoveredModels(append(drafts, &catalog.Model{ID: "phi-3", HFModelID: "microsoft/phi-3"})); err != nil {
+		t.Fatalf("SaveDiscoveredModels overwrite: %v", err)
+	}
+	loaded, updated, err := s.LoadDiscoveredModels()
+	if err != nil {
+		t.Fatalf("LoadDiscoveredModels: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].HFModelID != "microsoft/phi-3" || updated.IsZero() {
+		t.Fatalf("unexpected drafts %+v updated=%s", loaded, updated)
+	}
+	if _, _, err := s.LoadCatalogSnapshot(); err == nil {
+		t.Fatalf("expected discovered models to stay out of the catalog snapshot")
+	}
+}
+
+func TestAcquireLockExcludesOtherHolders(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	if ok, err := s.AcquireLock("activation", "a", time.Minute); err != nil || !ok {
+		t.Fatalf("expected first holder to acquire, got %v (%v)", ok, err)
+	}
+	if ok, err := s.AcquireLock("activation", "b", time.Minute); err != nil || ok {
+		t.Fatalf("expected second holder to be refused, got %v (%v)", ok, err)
+	}
+	if ok, err := s.AcquireLock("activation", "a", time.Minute); err != nil || !ok {
+		t.Fatalf("expected holder to extend its own lease, got %v (%v)", ok, err)
+	}
+	if err := s.ReleaseLock("activation", "b"); err != nil {
+		t.Fatalf("release by non-holder: %v", err)
+	}
+	if ok, _ := s.AcquireLock("activation", "b", time.Minute); ok {
+		t.Fatalf("release by a non-holder must not free the lock")
+	}
+	if err := s.ReleaseLock("activation", "a"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if ok, err := s.AcquireLock("activation", "b", -time.Second); err != nil || !ok {
+		t.Fatalf("expected lock to be free after release, got %v (%v)", ok, err)
+	}
+	if ok, err := s.AcquireLock("activation", "c", time.Minute); err != nil || !ok {
+		t.Fatalf("expected expired lease to be taken over, got %v (%v)", ok, err)
+	}
+}
+
+func TestIdempotencyKeysExpire(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	rec, claimed, err := s.ClaimIdempotencyKey("k1", "hash-a", time.Hour)
+	if err != nil || !claimed || rec.JobID != "" {
+		t.Fatalf("expected first claim to succeed, got %+v claimed=%v (%v)", rec, claimed, err)
+	}
+	rec, claimed, err = s.ClaimIdempotencyKey("k1", "hash-b", time.Hour)
+	if err != nil || claimed || rec.RequestHash != "hash-a" || rec.JobID != "" {
+		t.Fatalf("expected the pending claim to be returned, got %+v claimed=%v (%v)", rec, claimed, err)
+	}
+	if err := s.SetIdempotencyJob("k1", "job-1"); err != nil {
+		t.Fatalf("SetIdempotencyJob: %v", err)
+	}
+	if err := s.ReleaseIdempotencyKey("k1"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	rec, claimed, err = s.ClaimIdempotencyKey("k1", "hash-a", time.Hour)
+	if err != nil || claimed || rec.JobID != "job-1" {
+		t.Fatalf("expected completed claim to survive release, got %+v claimed=%v (%v)", rec, claimed, err)
+	}
+	if rec, claimed, err = s.ClaimIdempotencyKey("k1", "hash-c", -time.Second); err != nil || !claimed || rec.RequestHash != "hash-c" {
+		t.Fatalf("expected expired key to be claimable again, got %+v claimed=%v (%v)", rec, claimed, err)
+	}
+
+	if _, claimed, _ := s.ClaimIdempotencyKey("k2", "hash-a", time.Hour); !claimed {
+		t.Fatalf("expected k2 to be claimed")
+	}
+	if err := s.ReleaseIdempotencyKey("k2"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	if _, claimed, _ := s.ClaimIdempotencyKey("k2", "hash-b", time.Hour); !claimed {
+		t.Fatalf("expected a released key to be claimable again")
+	}
+}
+
+func TestClaimNextPendingJobPrefersHigherPriority(t *testing.T) {
+	t.Parallel()
+
+	s, err := Open(filepath.Join(t.TempDir(), "state.db"), "sqlite")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	for _, job := range []*Job{
+		{ID: "background", Type: "weight_install", Priority: -1},
+		{ID: "normal-1", Type: "weight_install"},
+		{ID: "urgent", Type: "weight_install", Priority: 10},
+		{ID: "normal-2", Type: "weight_install"},
+	} {
+		if err := s.CreateJob(job); err != nil {
+			t.Fatalf("CreateJob %s: %v", job.ID, err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	want := []string{"urgent", "normal-1", "normal-2", "background"}
+	for _, id := range want {
+		job, err := s.ClaimNextPendingJob(context.Background(), "w1")
+		if err != nil || job == nil {
+			t.Fatalf("claim: %v (%v)", job, err)
+		}
+		if job.ID != id {
+			t.Fatalf("expected %s next, got %s", id, job.ID)
+		}
+	}
+	job, _ := s.GetJob("urgent")
+	if job.Priority != 10 {
+		t.Fatalf("expected priority to round-trip, got %d", job.Priority)
+	}
+}
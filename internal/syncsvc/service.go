@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
 	"github.com/oremus-labs/ol-model-manager/internal/events"
 	"github.com/oremus-labs/ol-model-manager/internal/logutil"
 	"github.com/oremus-labs/ol-model-manager/internal/metrics"
@@ -17,6 +19,10 @@ type cacheProvider interface {
 	Save(context.Context, []vllm.HuggingFaceModel) error
 }
 
+type draftStore interface {
+	SaveDiscoveredModels([]*catalog.Model) error
+}
+
 type eventPublisher interface {
 	Publish(context.Context, events.Event) error
 }
@@ -29,6 +35,8 @@ type Service struct {
 	logger    *log.Logger
 	interval  time.Duration
 	queries   []vllm.SearchOptions
+	drafts    draftStore
+	seed      bool
 }
 
 // Options configure the Service.
@@ -39,6 +47,10 @@ type Options struct {
 	Logger    *log.Logger
 	Interval  time.Duration
 	Queries   []vllm.SearchOptions
+	// SeedDrafts generates draft catalog entries for compatible discoveries
+	// and saves them to Drafts after each refresh.
+	SeedDrafts bool
+	Drafts     draftStore
 }
 
 // New creates a new sync service.
@@ -64,6 +76,8 @@ func New(opts Options) *Service {
 		logger:    opts.Logger,
 		interval:  interval,
 		queries:   queries,
+		drafts:    opts.Drafts,
+		seed:      opts.SeedDrafts && opts.Drafts != nil,
 	}
 }
 
@@ -100,6 +114,7 @@ func (s *Service) refresh(ctx context.Context) error {
 		"queryCount": len(s.queries),
 	})
 	seen := make(map[string]vllm.HuggingFaceModel)
+	compatible := make(map[string]string)
 	for _, query := range s.queries {
 		results, err := s.discovery.SearchModels(query)
 		if err != nil {
@@ -118,6 +133,13 @@ func (s *Service) refresh(ctx context.Context) error {
 				continue
 			}
 			seen[key] = *model.HFModel
+			if model.Compatible {
+				id := model.HFModel.ModelID
+				if id == "" {
+					id = model.HFModel.ID
+				}
+				compatible[key] = id
+			}
 		}
 	}
 	if len(seen) == 0 {
@@ -145,10 +167,19 @@ func (s *Service) refresh(ctx context.Context) error {
 		})
 		return err
 	}
-	s.emitEvent(ctx, "hf.refresh.completed", map[string]interface{}{
+	completed := map[string]interface{}{
 		"count":    len(models),
 		"duration": time.Since(started).String(),
-	})
+	}
+	if s.seed {
+		drafts, err := s.saveDrafts(compatible)
+		if err != nil {
+			s.logger.Printf("failed to save discovered models: %v", err)
+		} else {
+			completed["drafts"] = drafts
+		}
+	}
+	s.emitEvent(ctx, "hf.refresh.completed", completed)
 	metrics.ObserveHFRefresh(time.Since(started), len(models), true)
 	s.logger.Printf("refreshed %d Hugging Face models", len(models))
 	logutil.Info("hf_refresh_completed", map[string]interface{}{
@@ -158,6 +189,29 @@ func (s *Service) refresh(ctx context.Context) error {
 	return nil
 }
 
+// saveDrafts generates a catalog entry for each compatible discovery and
+// replaces the stored draft set. Models that fail to generate are skipped.
+func (s *Service) saveDrafts(compatible map[string]string) (int, error) {
+	ids := make([]string, 0, len(compatible))
+	for _, id := range compatible {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	drafts := make([]*catalog.Model, 0, len(ids))
+	for _, id := range ids {
+		model, err := s.discovery.GenerateModelConfig(vllm.GenerateRequest{HFModelID: id, AutoDetect: true})
+		if err != nil {
+			s.logger.Printf("failed to generate draft for %s: %v", id, err)
+			continue
+		}
+		drafts = append(drafts, model)
+	}
+	if err := s.drafts.SaveDiscoveredModels(drafts); err != nil {
+		return 0, err
+	}
+	return len(drafts), nil
+}
+
 func (s *Service) emitEvent(ctx context.Context, eventType string, data map[string]interface{}) {
 	if s.events == nil || eventType == "" {
 		return
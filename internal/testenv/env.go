@@ -0,0 +1,239 @@
+// Package testenv wires a fully functional model manager (HTTP API, job
+// manager, worker, KServe client) against in-memory and on-disk stubs so the
+// install → job → worker → activate flow can run without Hugging Face, GitHub,
+// Redis, or Kubernetes.
+package testenv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/oremus-labs/ol-model-manager/internal/api"
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/graphqlapi"
+	"github.com/oremus-labs/ol-model-manager/internal/handlers"
+	"github.com/oremus-labs/ol-model-manager/internal/jobs"
+	"github.com/oremus-labs/ol-model-manager/internal/kserve"
+	"github.com/oremus-labs/ol-model-manager/internal/queue"
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+	"github.com/oremus-labs/ol-model-manager/internal/weights"
+	"github.com/oremus-labs/ol-model-manager/internal/worker"
+)
+
+// DefaultAPIToken is used for protected routes when Options.APIToken is empty.
+const DefaultAPIToken = "test-token"
+
+// Options configure the test environment.
+type Options struct {
+	// Dir holds the catalog, weights, and datastore. A temporary directory is
+	// created (and removed on Close) when empty.
+	Dir string
+	// CatalogRoot overrides the catalog location; defaults to Dir/catalog.
+	CatalogRoot      string
+	CatalogModelsDir string
+	// Models are written into the catalog before it is loaded.
+	Models []*catalog.Model
+
+	APIToken             string
+	Namespace            string
+	InferenceServiceName string
+	WeightsPVCName       string
+	InferenceModelRoot   string
+	Logger               *log.Logger
+}
+
+// Env bundles every wired component so tests can drive the HTTP API and
+// inspect state directly.
+type Env struct {
+	Dir      string
+	APIToken string
+
+	Catalog   *catalog.Catalog
+	Store     *store.Store
+	Events    *events.Bus
+	Queue     *queue.Memory
+	KServe    *kserve.Client
+	Weights   *weights.Manager
+	Discovery *Discovery
+	Jobs      *jobs.Manager
+	Handler   *handlers.Handler
+	Server    *api.Server
+	Worker    *worker.Runner
+
+	ownsDir bool
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// New builds an environment. Call StartWorker to begin consuming queued jobs.
+func New(opts Options) (*Env, error) {
+	if opts.APIToken == "" {
+		opts.APIToken = DefaultAPIToken
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "ai"
+	}
+	if opts.InferenceServiceName == "" {
+		opts.InferenceServiceName = "active-llm"
+	}
+	if opts.WeightsPVCName == "" {
+		opts.WeightsPVCName = "venus-model-storage"
+	}
+	if opts.InferenceModelRoot == "" {
+		opts.InferenceModelRoot = "/mnt/models"
+	}
+	if opts.CatalogModelsDir == "" {
+		opts.CatalogModelsDir = "models"
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.Default()
+	}
+
+	env := &Env{Dir: opts.Dir, APIToken: opts.APIToken}
+	if env.Dir == "" {
+		dir, err := os.MkdirTemp("", "model-manager-testenv-")
+		if err != nil {
+			return nil, err
+		}
+		env.Dir = dir
+		env.ownsDir = true
+	}
+
+	catalogRoot := opts.CatalogRoot
+	if catalogRoot == "" {
+		catalogRoot = filepath.Join(env.Dir, "catalog")
+	}
+	weightsPath := filepath.Join(env.Dir, "weights")
+	statePath := filepath.Join(env.Dir, "state")
+	for _, dir := range []string{filepath.Join(catalogRoot, opts.CatalogModelsDir), weightsPath, statePath} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			env.cleanup()
+			return nil, err
+		}
+	}
+	for _, model := range opts.Models {
+		if err := writeModel(filepath.Join(catalogRoot, opts.CatalogModelsDir), model); err != nil {
+			env.cleanup()
+			return nil, err
+		}
+	}
+
+	env.Catalog = catalog.New(catalogRoot, opts.CatalogModelsDir)
+	if err := env.Catalog.Load(); err != nil {
+		env.cleanup()
+		return nil, fmt.Errorf("load catalog: %w", err)
+	}
+
+	dsn := filepath.Join(statePath, "state.db")
+	stateStore, err := store.Open(dsn, "sqlite")
+	if err != nil {
+		env.cleanup()
+		return nil, err
+	}
+	env.Store = stateStore
+
+	env.Events = events.NewBus(events.Options{Logger: opts.Logger})
+	env.Queue = queue.NewMemory()
+	env.KServe = NewKServe(opts.Namespace, opts.InferenceServiceName, opts.InferenceModelRoot)
+	env.Weights = weights.New(weightsPath, weights.WithHFDownloader(FakeDownload))
+	env.Discovery = NewDiscovery()
+	env.Jobs = jobs.New(jobs.Options{
+		Store:              stateStore,
+		Weights:            env.Weights,
+		WeightsPVCName:     opts.WeightsPVCName,
+		InferenceModelRoot: opts.InferenceModelRoot,
+		EventPublisher:     env.Events,
+	})
+
+	env.Handler = handlers.New(env.Catalog, env.KServe, env.Weights, env.Discovery, nil, nil, nil, stateStore, env.Jobs, env.Events, env.Queue, nil, nil, nil, handlers.Options{
+		WeightsPVCName:     opts.WeightsPVCName,
+		InferenceModelRoot: opts.InferenceModelRoot,
+		Version:            "testenv",
+		CatalogRoot:        catalogRoot,
+		CatalogModelsDir:   opts.CatalogModelsDir,
+		WeightsPath:        weightsPath,
+		StatePath:          statePath,
+		AuthEnabled:        true,
+		DataStoreDriver:    "sqlite",
+		DataStoreDSN:       dsn,
+	})
+	gql, err := graphqlapi.NewHandler(graphqlapi.Config{
+		Catalog:   env.Catalog,
+		Store:     stateStore,
+		Installer: env.Handler,
+		Events:    env.Events,
+	})
+	if err != nil {
+		env.cleanup()
+		return nil, fmt.Errorf("graphql: %w", err)
+	}
+	env.Server = api.NewServer(env.Handler, api.Options{APIToken: opts.APIToken, GraphQLHandler: gql})
+	env.Worker = worker.New(worker.Options{
+		Store:  stateStore,
+		Jobs:   env.Jobs,
+		Logger: opts.Logger,
+		Queue:  env.Queue,
+	})
+	return env, nil
+}
+
+// StartWorker runs the worker loop in the background until Close is called.
+func (e *Env) StartWorker() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	go func() {
+		defer close(e.done)
+		_ = e.Worker.Run(ctx)
+	}()
+}
+
+// Close stops the worker, closes the datastore, and removes the temporary
+// directory when New created it.
+func (e *Env) Close() error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel = nil
+	e.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return e.cleanup()
+}
+
+func (e *Env) cleanup() error {
+	var err error
+	if e.Store != nil {
+		err = e.Store.Close()
+	}
+	if e.ownsDir {
+		if rmErr := os.RemoveAll(e.Dir); rmErr != nil && err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+func writeModel(modelsPath string, model *catalog.Model) error {
+	if model == nil || model.ID == "" {
+		return fmt.Errorf("catalog model requires an id")
+	}
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(modelsPath, model.ID+".json"), data, 0o644)
+}
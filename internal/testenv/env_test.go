@@ -0,0 +1,555 @@
+package testenv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/status"
+	"github.com/oremus-labs/ol-model-manager/internal/store"
+)
+
+func TestInstallJobWorkerActivateFlow(t *testing.T) {
+	env, err := New(Options{
+		Dir: t.TempDir(),
+		Models: []*catalog.Model{{
+			ID:          "qwen2.5-0.5b",
+			DisplayName: "Qwen 2.5 0.5B",
+			HFModelID:   "Qwen/Qwen2.5-0.5B",
+			StorageURI:  "pvc://venus-model-storage/Qwen/Qwen2.5-0.5B",
+			Runtime:     "vllm-runtime",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+	env.StartWorker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder, err := RecordEvents(ctx, env.Events)
+	if err != nil {
+		t.Fatalf("RecordEvents: %v", err)
+	}
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	var install struct {
+		Job store.Job `json:"job"`
+	}
+	doJSON(t, env, srv, http.MethodPost, "/weights/install", `{"hfModelId":"Qwen/Qwen2.5-0.5B"}`, http.StatusAccepted, &install)
+	if install.Job.ID == "" {
+		t.Fatalf("expected queued job in install response")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var job store.Job
+		doJSON(t, env, srv, http.MethodGet, "/jobs/"+install.Job.ID, "", http.StatusOK, &job)
+		if job.Status == store.JobDone {
+			break
+		}
+		if job.Status == store.JobFailed || time.Now().After(deadline) {
+			t.Fatalf("job did not complete: status=%s error=%s", job.Status, job.Error)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if _, err := env.Weights.Get("Qwen/Qwen2.5-0.5B"); err != nil {
+		t.Fatalf("expected installed weights: %v", err)
+	}
+
+	doJSON(t, env, srv, http.MethodPost, "/models/activate", `{"id":"qwen2.5-0.5b"}`, http.StatusOK, nil)
+	if _, err := recorder.Wait("model.activation.completed", 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	active, err := env.KServe.GetActive()
+	if err != nil {
+		t.Fatalf("GetActive: %v", err)
+	}
+	if active == nil {
+		t.Fatalf("expected InferenceService to exist after activation")
+	}
+}
+
+func doJSON(t *testing.T, env *Env, srv *httptest.Server, method, path, body string, wantStatus int, out interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+env.APIToken)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		var payload map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&payload)
+		t.Fatalf("%s %s: expected status %d got %d body=%v", method, path, wantStatus, resp.StatusCode, payload)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode %s: %v", path, err)
+		}
+	}
+}
+
+func TestScopedTokensAreEnforced(t *testing.T) {
+	env, err := New(Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	issue := func(id string, scopes ...string) string {
+		t.Helper()
+		plain, hash, err := store.GenerateToken(16)
+		if err != nil {
+			t.Fatalf("GenerateToken: %v", err)
+		}
+		if err := env.Store.CreateAPIToken(&store.APIToken{ID: id, Name: id, Hash: hash, Scopes: scopes}); err != nil {
+			t.Fatalf("CreateAPIToken: %v", err)
+		}
+		return plain
+	}
+	call := func(token, method, path, body string) (int, map[string]interface{}) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		var payload map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&payload)
+		return resp.StatusCode, payload
+	}
+
+	reader := issue("reader", "jobs:read")
+	code, body := call(reader, http.MethodPost, "/weights/install", `{"hfModelId":"Qwen/Qwen2.5-0.5B"}`)
+	if code != http.StatusForbidden || body["requiredScope"] != "weights:write" {
+		t.Fatalf("expected 403 naming weights:write, got %d %v", code, body)
+	}
+	if code, body := call(reader, http.MethodGet, "/jobs", ""); code != http.StatusOK {
+		t.Fatalf("expected jobs:read token to list jobs, got %d %v", code, body)
+	}
+	if code, _ := call(reader, http.MethodPost, "/jobs/missing/cancel", ""); code != http.StatusForbidden {
+		t.Fatalf("expected jobs:read token to be denied cancel, got %d", code)
+	}
+
+	writer := issue("writer", "jobs:write")
+	if code, body := call(writer, http.MethodGet, "/jobs", ""); code != http.StatusOK {
+		t.Fatalf("expected jobs:write to imply jobs:read, got %d %v", code, body)
+	}
+
+	legacy := issue("legacy")
+	if code, body := call(legacy, http.MethodGet, "/tokens", ""); code != http.StatusOK {
+		t.Fatalf("expected unscoped token to keep full access, got %d %v", code, body)
+	}
+
+	tokens, err := env.Store.ListAPITokens()
+	if err != nil {
+		t.Fatalf("ListAPITokens: %v", err)
+	}
+	for _, token := range tokens {
+		if token.LastUsedAt == nil {
+			t.Fatalf("expected last-used timestamp on token %s", token.ID)
+		}
+	}
+}
+
+func TestGraphQLInstallWeightsMutation(t *testing.T) {
+	env, err := New(Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	mutate := func(token, hfModelID string) (map[string]interface{}, string) {
+		t.Helper()
+		query := `mutation($id: String!) { installWeights(hfModelId: $id) { id type status } }`
+		body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": map[string]string{"id": hfModelID}})
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/graphql", strings.NewReader(string(body)))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("POST /graphql: %v", err)
+		}
+		defer resp.Body.Close()
+		var payload struct {
+			Data struct {
+				InstallWeights map[string]interface{} `json:"installWeights"`
+			} `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode graphql response: %v", err)
+		}
+		if len(payload.Errors) > 0 {
+			return nil, payload.Errors[0].Message
+		}
+		return payload.Data.InstallWeights, ""
+	}
+
+	if _, msg := mutate("", "Qwen/Qwen2.5-0.5B"); !strings.Contains(msg, "unauthorized") {
+		t.Fatalf("expected anonymous mutation to be rejected, got %q", msg)
+	}
+
+	plain, hash, err := store.GenerateToken(16)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if err := env.Store.CreateAPIToken(&store.APIToken{ID: "reader", Name: "reader", Hash: hash, Scopes: []string{"weights:read"}}); err != nil {
+		t.Fatalf("CreateAPIToken: %v", err)
+	}
+	if _, msg := mutate(plain, "Qwen/Qwen2.5-0.5B"); !strings.Contains(msg, "weights:write") {
+		t.Fatalf("expected scope error naming weights:write, got %q", msg)
+	}
+
+	if _, msg := mutate(env.APIToken, "not a model id"); !strings.Contains(msg, "invalid Hugging Face model id") {
+		t.Fatalf("expected model id validation error, got %q", msg)
+	}
+
+	job, msg := mutate(env.APIToken, "Qwen/Qwen2.5-0.5B")
+	if msg != "" {
+		t.Fatalf("installWeights failed: %s", msg)
+	}
+	id, _ := job["id"].(string)
+	if id == "" || job["type"] != "weight_install" {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if _, err := env.Store.GetJob(id); err != nil {
+		t.Fatalf("expected job %s persisted: %v", id, err)
+	}
+}
+
+func TestGraphQLRuntimeStatusSubscription(t *testing.T) {
+	env, err := New(Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body := `{"query":"subscription { runtimeStatus { inferenceService { name ready } } }"}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/graphql", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("POST /graphql: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("expected event stream, got %q", ct)
+	}
+
+	lines := make(chan string, 16)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				lines <- data
+			}
+		}
+	}()
+
+	publish := func(ready string) {
+		t.Helper()
+		err := env.Events.Publish(context.Background(), events.Event{
+			Type: "model.status.updated",
+			Data: status.RuntimeStatus{
+				InferenceService: &status.InferenceServiceStatus{Name: "active-llm", Ready: ready},
+				UpdatedAt:        time.Now().UTC(),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	next := func() string {
+		t.Helper()
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("subscription closed early")
+			}
+			return line
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for subscription result")
+		}
+		return ""
+	}
+
+	// The subscription registers asynchronously, so publish until it arrives.
+	deadline := time.Now().Add(5 * time.Second)
+	var first string
+	for first == "" {
+		publish("False")
+		select {
+		case first = <-lines:
+		case <-time.After(50 * time.Millisecond):
+			if time.Now().After(deadline) {
+				t.Fatalf("no subscription result received")
+			}
+		}
+	}
+	if !strings.Contains(first, `"ready":"False"`) {
+		t.Fatalf("unexpected first result %s", first)
+	}
+
+	publish("False")
+	publish("True")
+	if line := next(); !strings.Contains(line, `"ready":"True"`) {
+		t.Fatalf("expected unchanged status to be skipped and the change pushed, got %s", line)
+	}
+}
+
+func TestGraphQLHistoryQuery(t *testing.T) {
+	env, err := New(Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+
+	for _, entry := range []store.HistoryEntry{
+		{Event: "model_activated", ModelID: "qwen", Metadata: map[string]interface{}{"action": "created"}},
+		{Event: "model_activated", ModelID: "llama"},
+		{Event: "weights_installed", ModelID: "qwen"},
+	} {
+		entry := entry
+		if err := env.Store.AppendHistory(&entry); err != nil {
+			t.Fatalf("AppendHistory: %v", err)
+		}
+	}
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	query := func(q string) ([]map[string]interface{}, []string) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]string{"query": q})
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/graphql", strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+env.APIToken)
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("POST /graphql: %v", err)
+		}
+		defer resp.Body.Close()
+		var payload struct {
+			Data struct {
+				History []map[string]interface{} `json:"history"`
+			} `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode graphql response: %v", err)
+		}
+		var errs []string
+		for _, e := range payload.Errors {
+			errs = append(errs, e.Message)
+		}
+		return payload.Data.History, errs
+	}
+
+	entries, errs := query(`{ history(event: "MODEL_ACTIVATED", modelId: "qwen", since: "1h") { event modelId metadata createdAt } }`)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(entries) != 1 || entries[0]["event"] != "model_activated" || entries[0]["modelId"] != "qwen" {
+		t.Fatalf("unexpected history %+v", entries)
+	}
+	if meta, _ := entries[0]["metadata"].(map[string]interface{}); meta["action"] != "created" {
+		t.Fatalf("expected metadata, got %+v", entries[0]["metadata"])
+	}
+	if _, err := time.Parse(time.RFC3339, entries[0]["createdAt"].(string)); err != nil {
+		t.Fatalf("createdAt not RFC3339: %v", err)
+	}
+
+	if entries, _ := query(`{ history(limit: 2) { id } }`); len(entries) != 2 {
+		t.Fatalf("expected limit to apply, got %+v", entries)
+	}
+	if _, errs := query(`{ history(since: "yesterday") { id } }`); len(errs) == 0 {
+		t.Fatalf("expected invalid since to be rejected")
+	}
+}
+
+func TestRequestIDPropagatesToEventsHistoryAndJobs(t *testing.T) {
+	env, err := New(Options{
+		Dir: t.TempDir(),
+		Models: []*catalog.Model{{
+			ID:         "qwen2.5-0.5b",
+			HFModelID:  "Qwen/Qwen2.5-0.5B",
+			StorageURI: "pvc://venus-model-storage/Qwen/Qwen2.5-0.5B",
+			Runtime:    "vllm-runtime",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder, err := RecordEvents(ctx, env.Events)
+	if err != nil {
+		t.Fatalf("RecordEvents: %v", err)
+	}
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	send := func(method, path, body, requestID string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+env.APIToken)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", requestID)
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		if got := resp.Header.Get("X-Request-ID"); got != requestID {
+			t.Fatalf("expected X-Request-ID %q echoed, got %q", requestID, got)
+		}
+		return resp
+	}
+
+	resp := send(http.MethodPost, "/models/activate", `{"id":"qwen2.5-0.5b"}`, "req-activate")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("activate: expected 200 got %d", resp.StatusCode)
+	}
+	evt, err := recorder.Wait("model.activation.completed", 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evt.RequestID != "req-activate" {
+		t.Fatalf("expected event requestId req-activate, got %q", evt.RequestID)
+	}
+
+	history, err := env.Store.ListHistory(10)
+	if err != nil {
+		t.Fatalf("ListHistory: %v", err)
+	}
+	found := false
+	for _, entry := range history {
+		if entry.Event == "model_activated" && entry.Metadata["requestId"] == "req-activate" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected model_activated history stamped with request ID, got %+v", history)
+	}
+
+	resp = send(http.MethodPost, "/weights/install", `{"hfModelId":"Qwen/Qwen2.5-0.5B"}`, "req-install")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("install: expected 202 got %d", resp.StatusCode)
+	}
+	var install struct {
+		Job store.Job `json:"job"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&install); err != nil {
+		t.Fatalf("decode install: %v", err)
+	}
+	if install.Job.Payload["requestId"] != "req-install" {
+		t.Fatalf("expected job payload requestId req-install, got %+v", install.Job.Payload)
+	}
+}
+
+func TestOversizedBodiesAreRejected(t *testing.T) {
+	env, err := New(Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = env.Close()
+	})
+
+	srv := httptest.NewServer(env.Server.Engine())
+	defer srv.Close()
+
+	put := func(body string) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPut, srv.URL+"/playbooks/nightly", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+env.APIToken)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("PUT: %v", err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	huge := `{"description":"` + strings.Repeat("x", 17<<20) + `"}`
+	if code := put(huge); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for oversized body, got %d", code)
+	}
+	if code := put(`{"description":"small"}`); code == http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected small body to pass the size limit")
+	}
+}
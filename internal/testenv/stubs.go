@@ -0,0 +1,203 @@
+package testenv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"github.com/oremus-labs/ol-model-manager/internal/events"
+	"github.com/oremus-labs/ol-model-manager/internal/kserve"
+	"github.com/oremus-labs/ol-model-manager/internal/vllm"
+	"github.com/oremus-labs/ol-model-manager/internal/weights"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// NewKServe returns a KServe client backed by an in-memory fake cluster, so
+// activations create real InferenceService objects that GetActive can read back.
+func NewKServe(namespace, isvcName, inferenceModelRoot string) *kserve.Client {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		kserve.InferenceServiceGVR(): "InferenceServiceList",
+	})
+	return kserve.NewClientWithDynamic(client, namespace, isvcName, inferenceModelRoot)
+}
+
+// Discovery stubs Hugging Face and vLLM lookups. Unknown models are
+// synthesized with a config.json and a single safetensors shard.
+type Discovery struct {
+	mu     sync.Mutex
+	models map[string]*vllm.HuggingFaceModel
+}
+
+// NewDiscovery creates an empty discovery stub.
+func NewDiscovery() *Discovery {
+	return &Discovery{models: make(map[string]*vllm.HuggingFaceModel)}
+}
+
+// AddModel registers Hugging Face metadata returned for model.ModelID.
+func (d *Discovery) AddModel(model *vllm.HuggingFaceModel) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.models[model.ModelID] = model
+}
+
+// ListSupportedArchitectures reports no architectures.
+func (d *Discovery) ListSupportedArchitectures() ([]vllm.ModelArchitecture, error) {
+	return nil, nil
+}
+
+// GetArchitectureDetail always reports the architecture as unknown.
+func (d *Discovery) GetArchitectureDetail(name string, inline bool) (*vllm.ArchitectureDetail, error) {
+	return nil, fmt.Errorf("architecture %s not found", name)
+}
+
+// GenerateModelConfig returns a minimal catalog entry for the request.
+func (d *Discovery) GenerateModelConfig(req vllm.GenerateRequest) (*catalog.Model, error) {
+	return &catalog.Model{
+		ID:          strings.ToLower(strings.NewReplacer("/", "-", ".", "-").Replace(req.HFModelID)),
+		DisplayName: req.DisplayName,
+		HFModelID:   req.HFModelID,
+	}, nil
+}
+
+// GetHuggingFaceModel returns registered metadata or a synthesized model.
+func (d *Discovery) GetHuggingFaceModel(id string) (*vllm.HuggingFaceModel, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if model, ok := d.models[id]; ok {
+		copy := *model
+		return &copy, nil
+	}
+	return &vllm.HuggingFaceModel{
+		ID:       id,
+		ModelID:  id,
+		Siblings: []vllm.HFSibling{{RFileName: "config.json"}, {RFileName: "model.safetensors"}},
+	}, nil
+}
+
+// GetHuggingFaceModelRevision ignores the revision and behaves like GetHuggingFaceModel.
+func (d *Discovery) GetHuggingFaceModelRevision(id, revision string) (*vllm.HuggingFaceModel, error) {
+	return d.GetHuggingFaceModel(id)
+}
+
+// DescribeModel reports every model as compatible.
+func (d *Discovery) DescribeModel(id string, autoDetect bool) (*vllm.ModelInsight, error) {
+	model, err := d.GetHuggingFaceModel(id)
+	if err != nil {
+		return nil, err
+	}
+	return &vllm.ModelInsight{
+		HFModel:          model,
+		Compatible:       true,
+		RecommendedFiles: vllm.CollectHuggingFaceFiles(model),
+	}, nil
+}
+
+// DescribeModelWithCard adds a fixed model card summary.
+func (d *Discovery) DescribeModelWithCard(id string, autoDetect bool) (*vllm.ModelInsight, error) {
+	insight, err := d.DescribeModel(id, autoDetect)
+	if err != nil {
+		return nil, err
+	}
+	insight.CardSummary = "Stub model card for " + id + "."
+	return insight, nil
+}
+
+// SearchModels returns no results.
+func (d *Discovery) SearchModels(opts vllm.SearchOptions) ([]*vllm.ModelInsight, error) {
+	return nil, nil
+}
+
+// FakeDownload stands in for the Hugging Face CLI: it writes a small
+// placeholder for each requested file so the real weights.Manager can finish
+// its install bookkeeping. Pass it via weights.WithHFDownloader.
+func FakeDownload(ctx context.Context, opts weights.InstallOptions, tmpPath, revision string) error {
+	files := opts.Files
+	if len(files) == 0 {
+		files = []string{"config.json"}
+	}
+	for idx, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		path := filepath.Join(tmpPath, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		content := fmt.Sprintf("placeholder for %s@%s/%s\n", opts.ModelID, revision, file)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+		if opts.Progress != nil {
+			opts.Progress(file, idx+1, len(files))
+		}
+	}
+	return nil
+}
+
+// EventRecorder captures events published on a bus for later assertions.
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.Event
+	notify chan struct{}
+	cancel func()
+}
+
+// RecordEvents subscribes to the bus until ctx is cancelled or Stop is called.
+func RecordEvents(ctx context.Context, bus *events.Bus) (*EventRecorder, error) {
+	ch, cancel, err := bus.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rec := &EventRecorder{notify: make(chan struct{}, 1), cancel: cancel}
+	go func() {
+		for evt := range ch {
+			rec.mu.Lock()
+			rec.events = append(rec.events, evt)
+			rec.mu.Unlock()
+			select {
+			case rec.notify <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return rec, nil
+}
+
+// Events returns a snapshot of the recorded events.
+func (r *EventRecorder) Events() []events.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]events.Event, len(r.events))
+	copy(out, r.events)
+	return out
+}
+
+// Wait blocks until an event of the given type is recorded or the timeout elapses.
+func (r *EventRecorder) Wait(eventType string, timeout time.Duration) (*events.Event, error) {
+	deadline := time.After(timeout)
+	for {
+		for _, evt := range r.Events() {
+			if evt.Type == eventType {
+				found := evt
+				return &found, nil
+			}
+		}
+		select {
+		case <-deadline:
+			return nil, fmt.Errorf("timed out waiting for event %s", eventType)
+		case <-r.notify:
+		}
+	}
+}
+
+// Stop unsubscribes from the bus.
+func (r *EventRecorder) Stop() {
+	r.cancel()
+}
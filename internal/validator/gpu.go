@@ -16,6 +16,9 @@ func (v *Validator) checkGPU(ctx context.Context, model *catalog.Model) CheckRes
 	if required == 0 || resourceName == "" {
 		return CheckResult{Name: "gpu-capacity", Status: StatusPass, Message: "no GPU requirement detected"}
 	}
+	if v.offline {
+		return skipped("gpu-capacity")
+	}
 
 	if v.kube == nil {
 		return CheckResult{Name: "gpu-capacity", Status: StatusWarn, Message: "kubernetes client not configured"}
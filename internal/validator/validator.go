@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
 	"github.com/xeipuuv/gojsonschema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -22,6 +24,8 @@ const (
 	StatusPass Status = "pass"
 	StatusWarn Status = "warn"
 	StatusFail Status = "fail"
+	// StatusSkip marks checks an offline validator did not run.
+	StatusSkip Status = "skip"
 )
 
 type Options struct {
@@ -31,6 +35,10 @@ type Options struct {
 	WeightsPVCName     string
 	InferenceModelRoot string
 	GPUProfilePath     string
+	// Offline skips the checks that need the cluster or the weights volume
+	// (PVC, cached weights, secrets, configmaps, GPU capacity) and reports
+	// them as skipped, so catalog files can be linted anywhere.
+	Offline bool
 }
 
 type Validator struct {
@@ -40,6 +48,7 @@ type Validator struct {
 	weightsPVC         string
 	inferenceModelRoot string
 	gpuProfiles        map[string]GPUProfile
+	offline            bool
 }
 
 type Result struct {
@@ -69,6 +78,7 @@ func New(opts Options) (*Validator, error) {
 		weightsPVC:         opts.WeightsPVCName,
 		inferenceModelRoot: opts.InferenceModelRoot,
 		gpuProfiles:        map[string]GPUProfile{},
+		offline:            opts.Offline,
 	}
 
 	if opts.SchemaPath != "" {
@@ -137,8 +147,11 @@ func (v *Validator) Validate(ctx context.Context, payload []byte, model *catalog
 		}
 	}
 
+	result.Checks = append(result.Checks, checkLifecycle(model))
+	result.Checks = append(result.Checks, checkResources(model))
 	result.Checks = append(result.Checks, v.checkStorage(ctx, model))
 	result.Checks = append(result.Checks, v.checkLocalWeights(model))
+	result.Checks = append(result.Checks, checkEnvVars(model)...)
 	result.Checks = append(result.Checks, v.checkSecretRefs(ctx, model)...)
 	result.Checks = append(result.Checks, v.checkConfigMapRefs(ctx, model)...)
 	result.Checks = append(result.Checks, v.checkGPU(ctx, model))
@@ -153,6 +166,27 @@ func (v *Validator) Validate(ctx context.Context, payload []byte, model *catalog
 	return result
 }
 
+// checkLifecycle rejects unknown lifecycle values and warns about deprecated or
+// retired entries.
+func checkLifecycle(model *catalog.Model) CheckResult {
+	if !catalog.ValidLifecycle(model.Lifecycle) {
+		return CheckResult{
+			Name:    "lifecycle",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("unknown lifecycle %q (expected %s, %s, or %s)", model.Lifecycle, catalog.LifecycleActive, catalog.LifecycleDeprecated, catalog.LifecycleRetired),
+		}
+	}
+	state := model.LifecycleState()
+	if state == catalog.LifecycleActive {
+		return CheckResult{Name: "lifecycle", Status: StatusPass, Message: "model is active"}
+	}
+	check := CheckResult{Name: "lifecycle", Status: StatusWarn, Message: "model is " + state}
+	if model.DeprecationMessage != "" {
+		check.Message += ": " + model.DeprecationMessage
+	}
+	return check
+}
+
 func (v *Validator) checkStorage(ctx context.Context, model *catalog.Model) CheckResult {
 	if model.StorageURI == "" {
 		return CheckResult{Name: "storage", Status: StatusWarn, Message: "model has no storageUri configured"}
@@ -163,6 +197,9 @@ func (v *Validator) checkStorage(ctx context.Context, model *catalog.Model) Chec
 		return CheckResult{Name: "storage", Status: StatusPass, Message: "storageUri does not reference a PVC"}
 	}
 
+	if v.offline {
+		return skipped("storage")
+	}
 	if v.kube == nil {
 		return CheckResult{Name: "storage", Status: StatusWarn, Message: "kubernetes client not configured"}
 	}
@@ -189,6 +226,9 @@ func (v *Validator) checkStorage(ctx context.Context, model *catalog.Model) Chec
 }
 
 func (v *Validator) checkLocalWeights(model *catalog.Model) CheckResult {
+	if v.offline {
+		return skipped("local-cache")
+	}
 	if v.inferenceModelRoot == "" {
 		return CheckResult{Name: "local-cache", Status: StatusWarn, Message: "inference model root not configured"}
 	}
@@ -220,6 +260,9 @@ func (v *Validator) checkSecretRefs(ctx context.Context, model *catalog.Model) [
 	if len(refs) == 0 {
 		return nil
 	}
+	if v.offline {
+		return []CheckResult{skipped("secrets")}
+	}
 
 	if v.kube == nil {
 		return []CheckResult{{Name: "secrets", Status: StatusWarn, Message: "kubernetes client not configured"}}
@@ -227,7 +270,7 @@ func (v *Validator) checkSecretRefs(ctx context.Context, model *catalog.Model) [
 
 	var results []CheckResult
 	for name, optional := range refs {
-		_, err := v.kube.CoreV1().Secrets(v.namespace).Get(ctx, name, metav1.GetOptions{})
+		secret, err := v.kube.CoreV1().Secrets(v.namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				status := StatusFail
@@ -244,17 +287,133 @@ func (v *Validator) checkSecretRefs(ctx context.Context, model *catalog.Model) [
 			results = append(results, CheckResult{Name: "secret:" + name, Status: StatusWarn, Message: fmt.Sprintf("failed to read secret %s: %v", name, err)})
 			continue
 		}
+		if missing, required := missingSecretKeys(model, name, secret.Data, secret.StringData); len(missing) > 0 {
+			status := StatusWarn
+			if required {
+				status = StatusFail
+			}
+			msg := fmt.Sprintf("secret %s has no key(s) %s", name, strings.Join(missing, ", "))
+			results = append(results, CheckResult{Name: "secret:" + name, Status: status, Message: msg})
+			continue
+		}
 		results = append(results, CheckResult{Name: "secret:" + name, Status: StatusPass, Message: "secret present"})
 	}
 
 	return results
 }
 
+// missingSecretKeys lists the secretKeyRef keys for secret name that the
+// secret doesn't contain, and whether any of them is non-optional.
+func missingSecretKeys(model *catalog.Model, name string, data map[string][]byte, stringData map[string]string) ([]string, bool) {
+	var missing []string
+	required := false
+	for _, env := range model.Env {
+		if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		ref := env.ValueFrom.SecretKeyRef
+		if ref.Name != name || ref.Key == "" {
+			continue
+		}
+		if _, ok := data[ref.Key]; ok {
+			continue
+		}
+		if _, ok := stringData[ref.Key]; ok {
+			continue
+		}
+		missing = append(missing, ref.Key)
+		if ref.Optional == nil || !*ref.Optional {
+			required = true
+		}
+	}
+	return missing, required
+}
+
+// skipped reports a cluster-dependent check an offline validator did not run.
+func skipped(name string) CheckResult {
+	return CheckResult{Name: name, Status: StatusSkip, Message: "skipped: requires cluster access"}
+}
+
+// checkResources fails quantities Kubernetes can't parse and requests above
+// the matching limit.
+func checkResources(model *catalog.Model) CheckResult {
+	if model.Resources == nil || (len(model.Resources.Limits) == 0 && len(model.Resources.Requests) == 0) {
+		return CheckResult{Name: "resources", Status: StatusWarn, Message: "model declares no resource requests or limits"}
+	}
+	var problems []string
+	limits := make(map[string]resource.Quantity, len(model.Resources.Limits))
+	for _, name := range sortedKeys(model.Resources.Limits) {
+		qty, err := resource.ParseQuantity(model.Resources.Limits[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("limit %s=%q is not a quantity", name, model.Resources.Limits[name]))
+			continue
+		}
+		limits[name] = qty
+	}
+	for _, name := range sortedKeys(model.Resources.Requests) {
+		qty, err := resource.ParseQuantity(model.Resources.Requests[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("request %s=%q is not a quantity", name, model.Resources.Requests[name]))
+			continue
+		}
+		if limit, ok := limits[name]; ok && qty.Cmp(limit) > 0 {
+			problems = append(problems, fmt.Sprintf("request %s=%s exceeds limit %s", name, qty.String(), limit.String()))
+		}
+	}
+	if len(problems) > 0 {
+		return CheckResult{Name: "resources", Status: StatusFail, Message: strings.Join(problems, "; ")}
+	}
+	return CheckResult{Name: "resources", Status: StatusPass, Message: "resource quantities are valid"}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkEnvVars reports env entries KServe would reject: each needs a name and
+// either a value or a valueFrom with exactly one secretKeyRef/configMapKeyRef
+// naming both the object and the key.
+func checkEnvVars(model *catalog.Model) []CheckResult {
+	var results []CheckResult
+	for i, env := range model.Env {
+		name := env.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		var problem string
+		switch {
+		case env.Name == "":
+			problem = "env var has no name"
+		case env.ValueFrom == nil:
+		case env.Value != "":
+			problem = "value and valueFrom are mutually exclusive"
+		case (env.ValueFrom.SecretKeyRef == nil) == (env.ValueFrom.ConfigMapKeyRef == nil):
+			problem = "valueFrom needs exactly one of secretKeyRef or configMapKeyRef"
+		case env.ValueFrom.SecretKeyRef != nil && (env.ValueFrom.SecretKeyRef.Name == "" || env.ValueFrom.SecretKeyRef.Key == ""):
+			problem = "secretKeyRef needs name and key"
+		case env.ValueFrom.ConfigMapKeyRef != nil && (env.ValueFrom.ConfigMapKeyRef.Name == "" || env.ValueFrom.ConfigMapKeyRef.Key == ""):
+			problem = "configMapKeyRef needs name and key"
+		}
+		if problem != "" {
+			results = append(results, CheckResult{Name: "env:" + name, Status: StatusFail, Message: problem})
+		}
+	}
+	return results
+}
+
 func (v *Validator) checkConfigMapRefs(ctx context.Context, model *catalog.Model) []CheckResult {
 	refs := collectConfigMapRefs(model)
 	if len(refs) == 0 {
 		return nil
 	}
+	if v.offline {
+		return []CheckResult{skipped("configmaps")}
+	}
 	if v.kube == nil {
 		return []CheckResult{{Name: "configmaps", Status: StatusWarn, Message: "kubernetes client not configured"}}
 	}
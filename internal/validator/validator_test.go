@@ -16,7 +16,10 @@ import (
 func TestValidatorPassesWhenResourcesExist(t *testing.T) {
 	client := fake.NewSimpleClientset(
 		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "venus", Namespace: "ai"}},
-		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "ai"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "ai"},
+			Data:       map[string][]byte{"token": []byte("hf_xxx")},
+		},
 		&corev1.Node{
 			ObjectMeta: metav1.ObjectMeta{Name: "venus"},
 			Status: corev1.NodeStatus{
@@ -100,3 +103,120 @@ func TestValidatorFailsWhenSecretMissing(t *testing.T) {
 		t.Fatalf("expected validation to fail due to missing secret")
 	}
 }
+
+func TestValidatorChecksSecretKeysAndEnvShape(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "ai"},
+			Data:       map[string][]byte{"token": []byte("hf_xxx")},
+		},
+	)
+	v, err := New(Options{Namespace: "ai", KubernetesClient: client})
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	model := &catalog.Model{
+		ID: "test",
+		Env: []catalog.EnvVar{
+			{
+				Name: "HF_TOKEN",
+				ValueFrom: &catalog.EnvVarSource{
+					SecretKeyRef: &catalog.SecretKeySelector{Name: "hf-token", Key: "wrong-key"},
+				},
+			},
+			{
+				Name:      "BROKEN",
+				Value:     "inline",
+				ValueFrom: &catalog.EnvVarSource{SecretKeyRef: &catalog.SecretKeySelector{Name: "hf-token", Key: "token"}},
+			},
+		},
+	}
+
+	res := v.Validate(context.Background(), nil, model)
+	if res.Valid {
+		t.Fatalf("expected validation to fail")
+	}
+	statuses := map[string]Status{}
+	for _, check := range res.Checks {
+		statuses[check.Name] = check.Status
+	}
+	if statuses["secret:hf-token"] != StatusFail {
+		t.Fatalf("expected missing secret key to fail, got %+v", res.Checks)
+	}
+	if statuses["env:BROKEN"] != StatusFail {
+		t.Fatalf("expected value+valueFrom to fail, got %+v", res.Checks)
+	}
+}
+
+func TestValidatorChecksLifecycle(t *testing.T) {
+	v, err := New(Options{Namespace: "ai", KubernetesClient: fake.NewSimpleClientset()})
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	cases := map[string]Status{
+		"":           StatusPass,
+		"active":     StatusPass,
+		"deprecated": StatusWarn,
+		"retired":    StatusWarn,
+		"archived":   StatusFail,
+	}
+	for lifecycle, want := range cases {
+		result := v.Validate(context.Background(), nil, &catalog.Model{ID: "demo", Lifecycle: lifecycle})
+		var got Status
+		for _, check := range result.Checks {
+			if check.Name == "lifecycle" {
+				got = check.Status
+			}
+		}
+		if got != want {
+			t.Fatalf("lifecycle %q: expected %s, got %s", lifecycle, want, got)
+		}
+		if want == StatusFail && result.Valid {
+			t.Fatalf("lifecycle %q: expected result to be invalid", lifecycle)
+		}
+	}
+}
+
+func TestOfflineValidatorSkipsClusterChecks(t *testing.T) {
+	v, err := New(Options{Offline: true})
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	model := &catalog.Model{
+		ID:         "offline",
+		StorageURI: "pvc://venus/my-model",
+		Env: []catalog.EnvVar{{
+			Name:      "HF_TOKEN",
+			ValueFrom: &catalog.EnvVarSource{SecretKeyRef: &catalog.SecretKeySelector{Name: "hf-token", Key: "token"}},
+		}},
+		Resources: &catalog.Resources{Limits: map[string]string{"nvidia.com/gpu": "1"}},
+	}
+
+	result := v.Validate(context.Background(), nil, model)
+	if !result.Valid {
+		t.Fatalf("expected offline validation to pass, got %+v", result)
+	}
+	statuses := map[string]Status{}
+	for _, check := range result.Checks {
+		statuses[check.Name] = check.Status
+	}
+	for _, name := range []string{"storage", "local-cache", "secrets", "gpu-capacity"} {
+		if statuses[name] != StatusSkip {
+			t.Fatalf("expected %s to be skipped, got %q", name, statuses[name])
+		}
+	}
+	if statuses["resources"] != StatusPass {
+		t.Fatalf("expected resources to pass, got %q", statuses["resources"])
+	}
+
+	model.Resources = &catalog.Resources{
+		Limits:   map[string]string{"memory": "16Gi", "cpu": "lots"},
+		Requests: map[string]string{"memory": "32Gi"},
+	}
+	result = v.Validate(context.Background(), nil, model)
+	if result.Valid {
+		t.Fatalf("expected invalid quantities to fail, got %+v", result)
+	}
+}
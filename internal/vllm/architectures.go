@@ -0,0 +1,148 @@
+package vllm
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// architectureSuffixes are the Transformers task heads appended to a model
+// class name. They are stripped before matching so LlamaForCausalLM and
+// LlamaForSequenceClassification both resolve to llama. Longer suffixes come
+// first so "LMHeadModel" wins over "Model".
+var architectureSuffixes = []string{
+	"ForConditionalGeneration",
+	"ForSequenceClassification",
+	"ForTokenClassification",
+	"ForQuestionAnswering",
+	"ForCausalLM",
+	"ForMaskedLM",
+	"ForEmbedding",
+	"LMHeadModel",
+	"Model",
+}
+
+// knownArchitectureAliases maps vLLM model files to the Hugging Face
+// architecture names they implement whose normalized form doesn't match the
+// file name.
+var knownArchitectureAliases = map[string][]string{
+	"commandr":    {"CohereForCausalLM"},
+	"deci":        {"DeciLMForCausalLM"},
+	"deepseek_v2": {"DeepseekV3ForCausalLM"},
+	"falcon":      {"RWForCausalLM"},
+	"llama":       {"InternLMForCausalLM", "YiForCausalLM"},
+	"stablelm":    {"StableLMEpochForCausalLM"},
+}
+
+// withKnownAliases adds the known Hugging Face names for arch to its Aliases.
+func withKnownAliases(arch ModelArchitecture) ModelArchitecture {
+	known := knownArchitectureAliases[strings.ToLower(arch.Name)]
+	if len(known) == 0 {
+		return arch
+	}
+	seen := make(map[string]struct{}, len(arch.Aliases)+len(known))
+	aliases := make([]string, 0, len(arch.Aliases)+len(known))
+	for _, alias := range append(append([]string{}, arch.Aliases...), known...) {
+		if _, ok := seen[alias]; ok {
+			continue
+		}
+		seen[alias] = struct{}{}
+		aliases = append(aliases, alias)
+	}
+	arch.Aliases = aliases
+	return arch
+}
+
+// normalizeArchitecture reduces an architecture or file name to lowercase
+// letters and digits with any task suffix removed, so "Qwen2MoeForCausalLM"
+// and "qwen2_moe" both become "qwen2moe".
+func normalizeArchitecture(name string) string {
+	name = strings.TrimSpace(name)
+	for _, suffix := range architectureSuffixes {
+		if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+			name = strings.TrimSuffix(name, suffix)
+			break
+		}
+	}
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// minPrefixMatch is the shortest normalized name the prefix fallback accepts,
+// so short files like "opt" or "mpt" don't swallow unrelated architectures.
+const minPrefixMatch = 4
+
+// matchArchitectures resolves the architectures declared in the model's
+// config to supported vLLM architectures. Each name is tried, in order,
+// against the normalized file and class names, then the known aliases, and
+// finally the longest supported name it starts with (for variants such as
+// LlavaNextForConditionalGeneration when only llava is listed).
+func matchArchitectures(model *HuggingFaceModel, supported map[string]ModelArchitecture) []string {
+	architectures := extractArchitectures(model)
+	if len(architectures) == 0 {
+		return nil
+	}
+
+	found := make(map[string]struct{})
+	for _, arch := range architectures {
+		for _, name := range matchArchitecture(arch, supported) {
+			found[name] = struct{}{}
+		}
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(found))
+	for arch := range found {
+		result = append(result, arch)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// matchArchitecture returns the supported architectures arch resolves to, or
+// nil when none does.
+func matchArchitecture(arch string, supported map[string]ModelArchitecture) []string {
+	normalized := normalizeArchitecture(arch)
+	if normalized == "" {
+		return nil
+	}
+
+	var exact, aliased []string
+	bestPrefix, prefixLen := "", 0
+	for _, candidate := range supported {
+		name := normalizeArchitecture(candidate.Name)
+		if name == normalized || normalizeArchitecture(candidate.ClassName) == normalized {
+			exact = append(exact, candidate.Name)
+			continue
+		}
+		for _, alias := range candidate.Aliases {
+			if strings.EqualFold(alias, arch) || normalizeArchitecture(alias) == normalized {
+				aliased = append(aliased, candidate.Name)
+				break
+			}
+		}
+		if len(name) >= minPrefixMatch && strings.HasPrefix(normalized, name) {
+			if len(name) > prefixLen || (len(name) == prefixLen && candidate.Name < bestPrefix) {
+				bestPrefix, prefixLen = candidate.Name, len(name)
+			}
+		}
+	}
+
+	switch {
+	case len(exact) > 0:
+		return exact
+	case len(aliased) > 0:
+		return aliased
+	case bestPrefix != "":
+		return []string{bestPrefix}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package vllm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func supportedFixture(t *testing.T) map[string]ModelArchitecture {
+	t.Helper()
+	archs, err := parseArchitectureList(embeddedArchitectures)
+	if err != nil {
+		t.Fatalf("parse embedded architectures: %v", err)
+	}
+	supported := make(map[string]ModelArchitecture, len(archs))
+	for _, arch := range archs {
+		supported[arch.Name] = withKnownAliases(arch)
+	}
+	return supported
+}
+
+func hfModelWithArchitectures(names ...string) *HuggingFaceModel {
+	raw := make([]interface{}, len(names))
+	for i, name := range names {
+		raw[i] = name
+	}
+	return &HuggingFaceModel{Config: map[string]interface{}{"architectures": raw}}
+}
+
+func TestMatchArchitecturesRealNames(t *testing.T) {
+	supported := supportedFixture(t)
+	cases := []struct {
+		arch string
+		want []string
+	}{
+		{"LlamaForCausalLM", []string{"llama"}},
+		{"MistralForCausalLM", []string{"mistral"}},
+		{"MixtralForCausalLM", []string{"mixtral"}},
+		{"Qwen2ForCausalLM", []string{"qwen2"}},
+		{"Qwen2MoeForCausalLM", []string{"qwen2_moe"}},
+		{"Qwen2VLForConditionalGeneration", []string{"qwen2_vl"}},
+		{"QWenLMHeadModel", []string{"qwen"}},
+		{"GPTNeoXForCausalLM", []string{"gpt_neox"}},
+		{"GPTBigCodeForCausalLM", []string{"gpt_bigcode"}},
+		{"GPT2LMHeadModel", []string{"gpt2"}},
+		{"DeepseekV2ForCausalLM", []string{"deepseek_v2"}},
+		{"DeepseekV3ForCausalLM", []string{"deepseek_v2"}},
+		{"Phi3VForCausalLM", []string{"phi3v"}},
+		{"ChatGLMModel", []string{"chatglm"}},
+		{"CohereForCausalLM", []string{"commandr"}},
+		{"RWForCausalLM", []string{"falcon"}},
+		{"InternLMForCausalLM", []string{"llama"}},
+		{"LlavaNextForConditionalGeneration", []string{"llava"}},
+		{"MllamaForConditionalGeneration", []string{"mllama"}},
+		{"BertModel", nil},
+		{"T5ForConditionalGeneration", nil},
+	}
+	for _, tc := range cases {
+		got := matchArchitectures(hfModelWithArchitectures(tc.arch), supported)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.arch, tc.want, got)
+		}
+	}
+}
+
+func TestNormalizeArchitecture(t *testing.T) {
+	cases := map[string]string{
+		"Qwen2MoeForCausalLM":           "qwen2moe",
+		"qwen2_moe":                     "qwen2moe",
+		"GPT2LMHeadModel":               "gpt2",
+		"BertForMaskedLM":               "bert",
+		"Model":                         "model",
+		" gpt-neox ":                    "gptneox",
+		"LlavaForConditionalGeneration": "llava",
+	}
+	for in, want := range cases {
+		if got := normalizeArchitecture(in); got != want {
+			t.Errorf("normalizeArchitecture(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWithKnownAliasesKeepsExisting(t *testing.T) {
+	arch := withKnownAliases(ModelArchitecture{Name: "falcon", Aliases: []string{"RWForCausalLM", "FalconMambaForCausalLM"}})
+	want := []string{"RWForCausalLM", "FalconMambaForCausalLM"}
+	if !reflect.DeepEqual(arch.Aliases, want) {
+		t.Fatalf("expected %v, got %v", want, arch.Aliases)
+	}
+}
@@ -4,6 +4,7 @@ package vllm
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,11 +17,28 @@ import (
 	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/catalog"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+var errArchitecturesNotModified = errors.New("vLLM architecture listing not modified")
+
+var (
+	// ErrModelNotFound is returned when the Hub has no repository for the model ID.
+	ErrModelNotFound = errors.New("model not found on HuggingFace")
+	// ErrGatedModel is returned when the Hub refuses access because the model
+	// is gated or private and the configured token (if any) lacks access.
+	ErrGatedModel = errors.New("model is gated on HuggingFace")
 )
 
 const (
 	vllmModelsURL = "https://api.github.com/repos/vllm-project/vllm/contents/vllm/model_executor/models"
+	vllmRawURL    = "https://raw.githubusercontent.com/vllm-project/vllm/main"
 	hfAPIURL      = "https://huggingface.co/api/models"
+
+	// defaultInlineSourceLimit is the largest architecture source (in bytes)
+	// GetArchitectureDetail inlines without being asked to.
+	defaultInlineSourceLimit = 32 * 1024
 )
 
 // Discovery handles vLLM model discovery and auto-configuration.
@@ -33,6 +51,13 @@ type Discovery struct {
 	supportedSync time.Time
 	archCacheTTL  time.Duration
 
+	archSources    []string
+	archFile       string
+	archSourceUsed string
+	archSnapshot   ArchitectureSnapshot
+	// archETag is the ETag of the last GitHub listing, sent as If-None-Match.
+	archETag string
+
 	hfCacheTTL   time.Duration
 	hfMu         sync.RWMutex
 	hfModels     map[string]hfModelCacheEntry
@@ -40,6 +65,15 @@ type Discovery struct {
 	insightCache map[string]insightCacheEntry
 	searchMu     sync.RWMutex
 	searchCache  map[string]searchCacheEntry
+
+	// describeConcurrency bounds parallel DescribeModel calls in SearchModels.
+	describeConcurrency int
+	// limiter throttles outbound API calls; nil means unlimited.
+	limiter    *rate.Limiter
+	maxRetries int
+	// inlineSourceLimit is the size up to which architecture sources are
+	// inlined by default.
+	inlineSourceLimit int
 }
 
 // Option configures the discovery client.
@@ -73,6 +107,23 @@ func WithVLLMCacheTTL(ttl time.Duration) Option {
 	}
 }
 
+// WithDescribeConcurrency sets how many candidates SearchModels describes in
+// parallel (default 5).
+func WithDescribeConcurrency(n int) Option {
+	return func(d *Discovery) {
+		d.describeConcurrency = n
+	}
+}
+
+// WithInlineSourceLimit sets the size in bytes up to which
+// GetArchitectureDetail inlines the source without being asked to (default
+// 32KiB).
+func WithInlineSourceLimit(bytes int) Option {
+	return func(d *Discovery) {
+		d.inlineSourceLimit = bytes
+	}
+}
+
 // SearchOptions fine-tunes Hugging Face search behavior.
 type SearchOptions struct {
 	Query          string
@@ -96,12 +147,15 @@ type ModelArchitecture struct {
 	Size        int      `json:"size,omitempty"`
 	Aliases     []string `json:"aliases,omitempty"`
 	Description string   `json:"description,omitempty"`
+	Source      string   `json:"source,omitempty"`
 }
 
-// ArchitectureDetail includes file source content for UI previews.
+// ArchitectureDetail includes file source content for UI previews. Source is
+// empty unless Inlined; otherwise clients fetch DownloadURL themselves.
 type ArchitectureDetail struct {
 	ModelArchitecture
-	Source string `json:"source"`
+	Source  string `json:"source"`
+	Inlined bool   `json:"inlined"`
 }
 
 // HuggingFaceModel represents a model from HuggingFace.
@@ -121,6 +175,8 @@ type HuggingFaceModel struct {
 // HFSibling represents a file in a HuggingFace model repo.
 type HFSibling struct {
 	RFileName string `json:"rfilename"`
+	// Size is reported by the Hub when the model is fetched with blobs=true.
+	Size int64 `json:"size,omitempty"`
 }
 
 // ModelInsight summarizes Hugging Face metadata + vLLM compatibility.
@@ -131,6 +187,9 @@ type ModelInsight struct {
 	SuggestedCatalog     *catalog.Model    `json:"suggestedCatalog,omitempty"`
 	RecommendedFiles     []string          `json:"recommendedFiles,omitempty"`
 	Notes                []string          `json:"notes,omitempty"`
+	// CardSummary is the model card body (front matter removed, truncated to
+	// 4KB). Only DescribeModelWithCard fills it.
+	CardSummary string `json:"cardSummary,omitempty"`
 }
 
 // GenerateRequest is a request to generate model configuration.
@@ -150,6 +209,7 @@ func New(opts ...Option) *Discovery {
 		hfModels:      make(map[string]hfModelCacheEntry),
 		insightCache:  make(map[string]insightCacheEntry),
 		searchCache:   make(map[string]searchCacheEntry),
+		maxRetries:    defaultMaxRetries,
 	}
 	for _, opt := range opts {
 		opt(d)
@@ -160,6 +220,12 @@ func New(opts ...Option) *Discovery {
 	if d.archCacheTTL <= 0 {
 		d.archCacheTTL = 10 * time.Minute
 	}
+	if d.describeConcurrency <= 0 {
+		d.describeConcurrency = 5
+	}
+	if d.inlineSourceLimit <= 0 {
+		d.inlineSourceLimit = defaultInlineSourceLimit
+	}
 	return d
 }
 
@@ -169,6 +235,35 @@ func (d *Discovery) ListSupportedArchitectures() ([]ModelArchitecture, error) {
 		return archs, nil
 	}
 
+	architectures, source, err := d.loadArchitectures()
+	if errors.Is(err, errArchitecturesNotModified) {
+		d.supportedMu.Lock()
+		d.supportedSync = time.Now()
+		d.supportedMu.Unlock()
+		return d.cachedArchitectures(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cache := make(map[string]ModelArchitecture, len(architectures))
+	for i, arch := range architectures {
+		arch = withKnownAliases(arch)
+		architectures[i] = arch
+		cache[strings.ToLower(arch.Name)] = arch
+	}
+
+	d.supportedMu.Lock()
+	d.supportedArch = cache
+	d.supportedSync = time.Now()
+	d.archSourceUsed = source
+	d.supportedMu.Unlock()
+
+	return architectures, nil
+}
+
+// fetchGitHubArchitectures lists model modules from the vLLM GitHub repository.
+func (d *Discovery) fetchGitHubArchitectures() ([]ModelArchitecture, error) {
 	req, err := http.NewRequest("GET", vllmModelsURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -178,13 +273,19 @@ func (d *Discovery) ListSupportedArchitectures() ([]ModelArchitecture, error) {
 	if d.githubToken != "" {
 		req.Header.Set("Authorization", "Bearer "+d.githubToken)
 	}
+	if etag := d.cachedGitHubETag(); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
-	resp, err := d.client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch vLLM models: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errArchitecturesNotModified
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
@@ -203,7 +304,6 @@ func (d *Discovery) ListSupportedArchitectures() ([]ModelArchitecture, error) {
 	}
 
 	architectures := make([]ModelArchitecture, 0, len(files))
-	cache := make(map[string]ModelArchitecture)
 
 	for _, file := range files {
 		if file.Type != "file" || !strings.HasSuffix(file.Name, ".py") {
@@ -224,25 +324,34 @@ func (d *Discovery) ListSupportedArchitectures() ([]ModelArchitecture, error) {
 			Size:        file.Size,
 		}
 		architectures = append(architectures, arch)
-		cache[strings.ToLower(name)] = arch
 	}
 
-	d.supportedMu.Lock()
-	d.supportedArch = cache
-	d.supportedSync = time.Now()
-	d.supportedMu.Unlock()
-
+	if len(architectures) > 0 {
+		d.supportedMu.Lock()
+		d.archETag = resp.Header.Get("ETag")
+		d.supportedMu.Unlock()
+	}
 	return architectures, nil
 }
 
 // GetHuggingFaceModel fetches model information from HuggingFace.
 func (d *Discovery) GetHuggingFaceModel(modelID string) (*HuggingFaceModel, error) {
-	if cached := d.cachedHFModel(modelID); cached != nil {
+	return d.GetHuggingFaceModelRevision(modelID, "")
+}
+
+// GetHuggingFaceModelRevision fetches model information (including file sizes)
+// for a specific revision. An empty revision or "main" resolves the default branch.
+func (d *Discovery) GetHuggingFaceModelRevision(modelID, revision string) (*HuggingFaceModel, error) {
+	cacheKey := modelID
+	url := fmt.Sprintf("%s/%s?blobs=true", hfAPIURL, modelID)
+	if revision != "" && revision != "main" {
+		cacheKey = modelID + "@" + revision
+		url = fmt.Sprintf("%s/%s/revision/%s?blobs=true", hfAPIURL, modelID, revision)
+	}
+	if cached := d.cachedHFModel(cacheKey); cached != nil {
 		return cached, nil
 	}
 
-	url := fmt.Sprintf("%s/%s", hfAPIURL, modelID)
-
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -251,18 +360,23 @@ func (d *Discovery) GetHuggingFaceModel(modelID string) (*HuggingFaceModel, erro
 		req.Header.Set("Authorization", "Bearer "+d.hfToken)
 	}
 
-	resp, err := d.client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch HuggingFace model: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("model not found on HuggingFace: %s", modelID)
+		return nil, fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			if err := classifyHFAuthError(modelID, body); err != nil {
+				return nil, err
+			}
+		}
 		return nil, fmt.Errorf("HuggingFace API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
@@ -271,10 +385,39 @@ func (d *Discovery) GetHuggingFaceModel(modelID string) (*HuggingFaceModel, erro
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	d.storeHFModel(modelID, &model)
+	d.storeHFModel(cacheKey, &model)
 	return cloneHuggingFaceModel(&model), nil
 }
 
+// classifyHFAuthError maps a 401/403 body to ErrGatedModel or ErrModelNotFound.
+// The Hub answers 401 for both gated repos and missing repos when the caller is
+// anonymous, so the message text is the only way to tell them apart.
+func classifyHFAuthError(modelID string, body []byte) error {
+	var payload struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error != "" {
+		message = payload.Error
+	}
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "repository not found"):
+		return fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+	case strings.Contains(lower, "gated"),
+		strings.Contains(lower, "restricted"),
+		strings.Contains(lower, "authorized list"),
+		strings.Contains(lower, "authenticated"),
+		strings.Contains(lower, "invalid credentials"),
+		strings.Contains(lower, "invalid username or password"):
+		if message == "" {
+			return fmt.Errorf("%w: %s", ErrGatedModel, modelID)
+		}
+		return fmt.Errorf("%w: %s (%s)", ErrGatedModel, modelID, message)
+	}
+	return nil
+}
+
 // GenerateModelConfig generates a model configuration from a HuggingFace model.
 func (d *Discovery) GenerateModelConfig(req GenerateRequest) (*catalog.Model, error) {
 	hfModel, err := d.GetHuggingFaceModel(req.HFModelID)
@@ -284,8 +427,11 @@ func (d *Discovery) GenerateModelConfig(req GenerateRequest) (*catalog.Model, er
 	return d.buildCatalogModel(hfModel, req), nil
 }
 
-// GetArchitectureDetail fetches and returns the source for an architecture file.
-func (d *Discovery) GetArchitectureDetail(name string) (*ArchitectureDetail, error) {
+// GetArchitectureDetail returns an architecture with the raw download URL and
+// size of its source file. The source itself is fetched and inlined when
+// inline is set or the file is known to be within the inline limit, so
+// browsing large files doesn't spend GitHub API quota.
+func (d *Discovery) GetArchitectureDetail(name string, inline bool) (*ArchitectureDetail, error) {
 	if name == "" {
 		return nil, fmt.Errorf("architecture name is required")
 	}
@@ -293,6 +439,12 @@ func (d *Discovery) GetArchitectureDetail(name string) (*ArchitectureDetail, err
 	if err != nil {
 		return nil, err
 	}
+	if arch.DownloadURL == "" && arch.FilePath != "" {
+		arch.DownloadURL = vllmRawURL + "/" + arch.FilePath
+	}
+	if !inline && (arch.Size <= 0 || arch.Size > d.inlineSourceLimit) {
+		return &ArchitectureDetail{ModelArchitecture: arch}, nil
+	}
 
 	url := fmt.Sprintf("https://api.github.com/repos/vllm-project/vllm/contents/%s", arch.FilePath)
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -304,7 +456,7 @@ func (d *Discovery) GetArchitectureDetail(name string) (*ArchitectureDetail, err
 		req.Header.Set("Authorization", "Bearer "+d.githubToken)
 	}
 
-	resp, err := d.client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -318,6 +470,7 @@ func (d *Discovery) GetArchitectureDetail(name string) (*ArchitectureDetail, err
 	var payload struct {
 		Content  string `json:"content"`
 		Encoding string `json:"encoding"`
+		Size     int    `json:"size"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		return nil, err
@@ -329,9 +482,13 @@ func (d *Discovery) GetArchitectureDetail(name string) (*ArchitectureDetail, err
 		}
 	}
 
+	if arch.Size <= 0 {
+		arch.Size = payload.Size
+	}
 	return &ArchitectureDetail{
 		ModelArchitecture: arch,
 		Source:            source,
+		Inlined:           true,
 	}, nil
 }
 
@@ -344,7 +501,7 @@ func (d *Discovery) buildCatalogModel(hfModel *HuggingFaceModel, req GenerateReq
 	}
 
 	vllmConfig := &catalog.VLLMConfig{}
-	if req.AutoDetect && hfModel.Config != nil {
+	if req.AutoDetect && (hfModel.Config != nil || len(hfModel.Tags) > 0) {
 		vllmConfig = d.detectVLLMSettings(hfModel)
 	}
 
@@ -395,9 +552,47 @@ func (d *Discovery) detectVLLMSettings(hfModel *HuggingFaceModel) *catalog.VLLMC
 		config.MaxModelLen = &maxLen
 	}
 
+	// Quantized checkpoints only load when vLLM is told the method.
+	config.Quantization = detectQuantization(hfModel)
+
 	return config
 }
 
+// quantizationTags maps Hugging Face repo tags to vLLM --quantization methods.
+var quantizationTags = map[string]string{
+	"awq":                "awq",
+	"gptq":               "gptq",
+	"fp8":                "fp8",
+	"bitsandbytes":       "bitsandbytes",
+	"4bit":               "bitsandbytes",
+	"4-bit":              "bitsandbytes",
+	"8bit":               "bitsandbytes",
+	"8-bit":              "bitsandbytes",
+	"compressed-tensors": "compressed-tensors",
+}
+
+// detectQuantization returns the vLLM quantization method for a model, read
+// from config.quantization_config first and repo tags second. Unquantized
+// models return "".
+func detectQuantization(hfModel *HuggingFaceModel) string {
+	if qc, ok := hfModel.Config["quantization_config"].(map[string]interface{}); ok {
+		if method, ok := qc["quant_method"].(string); ok && strings.TrimSpace(method) != "" {
+			return strings.ToLower(strings.TrimSpace(method))
+		}
+		for _, key := range []string{"load_in_4bit", "load_in_8bit"} {
+			if enabled, ok := qc[key].(bool); ok && enabled {
+				return "bitsandbytes"
+			}
+		}
+	}
+	for _, tag := range hfModel.Tags {
+		if method, ok := quantizationTags[strings.ToLower(strings.TrimSpace(tag))]; ok {
+			return method
+		}
+	}
+	return ""
+}
+
 // DescribeModel returns HuggingFace metadata plus vLLM compatibility info.
 func (d *Discovery) DescribeModel(hfModelID string, autoDetect bool) (*ModelInsight, error) {
 	cacheKey := describeCacheKey(hfModelID, autoDetect)
@@ -478,7 +673,7 @@ func (d *Discovery) SearchModels(opts SearchOptions) ([]*ModelInsight, error) {
 		req.Header.Set("Authorization", "Bearer "+d.hfToken)
 	}
 
-	resp, err := d.client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +689,7 @@ func (d *Discovery) SearchModels(opts SearchOptions) ([]*ModelInsight, error) {
 		return nil, err
 	}
 
-	results := make([]*ModelInsight, 0, opts.Limit)
+	ids := make([]string, 0, len(models))
 	for _, model := range models {
 		if !opts.matches(&model) {
 			continue
@@ -506,16 +701,32 @@ func (d *Discovery) SearchModels(opts SearchOptions) ([]*ModelInsight, error) {
 		if id == "" {
 			continue
 		}
-		insight, err := d.DescribeModel(id, true)
-		if err != nil {
-			continue
-		}
-		if opts.OnlyCompatible && (insight == nil || !insight.Compatible) {
-			continue
+		ids = append(ids, id)
+	}
+
+	// Warm the architecture cache once so workers don't all fetch it.
+	_, _ = d.getSupportedArchitectures()
+
+	// Describe candidates in batches sized to the results still needed so a
+	// search doesn't describe far more models than it returns.
+	results := make([]*ModelInsight, 0, opts.Limit)
+	for len(ids) > 0 && len(results) < opts.Limit {
+		batch := ids
+		if need := opts.Limit - len(results); len(batch) > need {
+			batch = batch[:need]
 		}
-		results = append(results, insight)
-		if len(results) >= opts.Limit {
-			break
+		ids = ids[len(batch):]
+		for _, insight := range d.describeAll(batch) {
+			if insight == nil {
+				continue
+			}
+			if opts.OnlyCompatible && !insight.Compatible {
+				continue
+			}
+			results = append(results, insight)
+			if len(results) >= opts.Limit {
+				break
+			}
 		}
 	}
 
@@ -523,6 +734,26 @@ func (d *Discovery) SearchModels(opts SearchOptions) ([]*ModelInsight, error) {
 	return results, nil
 }
 
+// describeAll runs DescribeModel for each id with bounded concurrency. Results
+// keep the order of ids; models that fail to describe are left nil.
+func (d *Discovery) describeAll(ids []string) []*ModelInsight {
+	insights := make([]*ModelInsight, len(ids))
+	var g errgroup.Group
+	g.SetLimit(d.describeConcurrency)
+	for i, id := range ids {
+		g.Go(func() error {
+			insight, err := d.DescribeModel(id, true)
+			if err != nil {
+				return nil
+			}
+			insights[i] = insight
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return insights
+}
+
 func requiresTrustRemoteCode(architecture string) bool {
 	// Architectures that typically require trust_remote_code
 	requireTrust := []string{
@@ -626,6 +857,17 @@ func (d *Discovery) cachedArchitectures() []ModelArchitecture {
 	return archs
 }
 
+// cachedGitHubETag returns the stored ETag only while the cache still holds the
+// GitHub listing it belongs to; a 304 is useless otherwise.
+func (d *Discovery) cachedGitHubETag() string {
+	d.supportedMu.RLock()
+	defer d.supportedMu.RUnlock()
+	if d.archSourceUsed != SourceGitHub || len(d.supportedArch) == 0 {
+		return ""
+	}
+	return d.archETag
+}
+
 func (d *Discovery) archCacheExpired() bool {
 	if len(d.supportedArch) == 0 {
 		return true
@@ -661,6 +903,24 @@ func (d *Discovery) getSupportedArchitectures() (map[string]ModelArchitecture, e
 	return out, nil
 }
 
+var hfModelIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*/[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidHuggingFaceModelID reports whether id has the "owner/name" shape the
+// Hugging Face Hub accepts.
+func ValidHuggingFaceModelID(id string) bool {
+	return hfModelIDPattern.MatchString(id)
+}
+
+// HuggingFaceOrg returns the owner segment of an "owner/name" model id, or ""
+// if id has no owner.
+func HuggingFaceOrg(id string) string {
+	org, _, ok := strings.Cut(id, "/")
+	if !ok {
+		return ""
+	}
+	return org
+}
+
 // CollectHuggingFaceFiles lists downloadable files for a model.
 func CollectHuggingFaceFiles(model *HuggingFaceModel) []string {
 	files := make([]string, 0, len(model.Siblings))
@@ -685,32 +945,32 @@ func CollectHuggingFaceFiles(model *HuggingFaceModel) []string {
 	return files
 }
 
-func matchArchitectures(model *HuggingFaceModel, supported map[string]ModelArchitecture) []string {
-	architectures := extractArchitectures(model)
-	if len(architectures) == 0 {
-		return nil
+// EstimateDownloadBytes sums the sibling sizes for the requested files (all
+// siblings when files is empty). known is false when the Hub reported no
+// sizes for any of them, in which case the total should not be trusted.
+func EstimateDownloadBytes(model *HuggingFaceModel, files []string) (total int64, known bool) {
+	if model == nil {
+		return 0, false
 	}
-
-	found := make(map[string]struct{})
-	for _, arch := range architectures {
-		archLower := strings.ToLower(arch)
-		for key, value := range supported {
-			if strings.Contains(archLower, key) {
-				found[value.Name] = struct{}{}
-			}
+	var wanted map[string]struct{}
+	if len(files) > 0 {
+		wanted = make(map[string]struct{}, len(files))
+		for _, f := range files {
+			wanted[f] = struct{}{}
 		}
 	}
-
-	if len(found) == 0 {
-		return nil
-	}
-
-	result := make([]string, 0, len(found))
-	for arch := range found {
-		result = append(result, arch)
+	for _, sibling := range model.Siblings {
+		if wanted != nil {
+			if _, ok := wanted[sibling.RFileName]; !ok {
+				continue
+			}
+		}
+		if sibling.Size > 0 {
+			total += sibling.Size
+			known = true
+		}
 	}
-	sort.Strings(result)
-	return result
+	return total, known
 }
 
 func extractArchitectures(model *HuggingFaceModel) []string {
@@ -984,19 +1244,44 @@ func licenseMatches(model *HuggingFaceModel, license string) bool {
 		return true
 	}
 	target := strings.ToLower(license)
-	if model.Config != nil {
-		if value, ok := model.Config["license"].(string); ok && strings.EqualFold(value, license) {
+	for _, value := range HuggingFaceLicenses(model) {
+		if value == target {
 			return true
 		}
 	}
+	return false
+}
+
+// HuggingFaceLicenses returns the lower-cased licenses a model declares via
+// its config "license" field and "license:" tags, without duplicates.
+func HuggingFaceLicenses(model *HuggingFaceModel) []string {
+	if model == nil {
+		return nil
+	}
+	var licenses []string
+	seen := make(map[string]struct{})
+	add := func(value string) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			return
+		}
+		if _, ok := seen[value]; ok {
+			return
+		}
+		seen[value] = struct{}{}
+		licenses = append(licenses, value)
+	}
+	if model.Config != nil {
+		if value, ok := model.Config["license"].(string); ok {
+			add(value)
+		}
+	}
 	for _, tag := range model.Tags {
 		if strings.HasPrefix(strings.ToLower(tag), "license:") {
-			if strings.TrimPrefix(strings.ToLower(tag), "license:") == target {
-				return true
-			}
+			add(tag[len("license:"):])
 		}
 	}
-	return false
+	return licenses
 }
 
 func decodeBase64(value string) (string, error) {
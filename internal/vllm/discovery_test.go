@@ -0,0 +1,252 @@
+package vllm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// redirectTransport sends every request to the test server, keeping the path.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	clone.URL.Scheme = t.target.Scheme
+	clone.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(clone)
+}
+
+func TestGetArchitectureDetailInlinesSmallSources(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"content":  base64.StdEncoding.EncodeToString([]byte("class Qwen: pass")),
+			"encoding": "base64",
+			"size":     16,
+		})
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	d := New(WithInlineSourceLimit(1024))
+	d.client = &http.Client{Transport: redirectTransport{target: target}}
+	d.supportedArch["small"] = ModelArchitecture{Name: "small", FilePath: "vllm/model_executor/models/small.py", Size: 16}
+	d.supportedArch["large"] = ModelArchitecture{Name: "large", FilePath: "vllm/model_executor/models/large.py", Size: 4096, DownloadURL: "https://example.com/large.py"}
+	d.supportedArch["unsized"] = ModelArchitecture{Name: "unsized", FilePath: "vllm/model_executor/models/unsized.py"}
+
+	cases := []struct {
+		name        string
+		inline      bool
+		wantInlined bool
+		wantURL     string
+		wantSize    int
+	}{
+		{"small", false, true, vllmRawURL + "/vllm/model_executor/models/small.py", 16},
+		{"large", false, false, "https://example.com/large.py", 4096},
+		{"unsized", false, false, vllmRawURL + "/vllm/model_executor/models/unsized.py", 0},
+		{"large", true, true, "https://example.com/large.py", 4096},
+		{"unsized", true, true, vllmRawURL + "/vllm/model_executor/models/unsized.py", 16},
+	}
+	for _, tc := range cases {
+		before := fetches.Load()
+		detail, err := d.GetArchitectureDetail(tc.name, tc.inline)
+		if err != nil {
+			t.Fatalf("%s inline=%v: %v", tc.name, tc.inline, err)
+		}
+		fetched := fetches.Load() != before
+		if detail.Inlined != tc.wantInlined || fetched != tc.wantInlined {
+			t.Errorf("%s inline=%v: expected inlined=%v, got inlined=%v fetched=%v", tc.name, tc.inline, tc.wantInlined, detail.Inlined, fetched)
+		}
+		if tc.wantInlined && detail.Source != "class Qwen: pass" {
+			t.Errorf("%s inline=%v: expected the decoded source, got %q", tc.name, tc.inline, detail.Source)
+		}
+		if !tc.wantInlined && detail.Source != "" {
+			t.Errorf("%s inline=%v: expected no source, got %q", tc.name, tc.inline, detail.Source)
+		}
+		if detail.DownloadURL != tc.wantURL || detail.Size != tc.wantSize {
+			t.Errorf("%s inline=%v: expected url %s size %d, got %s %d", tc.name, tc.inline, tc.wantURL, tc.wantSize, detail.DownloadURL, detail.Size)
+		}
+	}
+
+	detail, err := d.GetArchitectureDetail("large", false)
+	if err != nil {
+		t.Fatalf("large: %v", err)
+	}
+	raw, _ := json.Marshal(detail)
+	var body map[string]interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if source, ok := body["source"]; !ok || source != "" {
+		t.Fatalf("expected source to be present and empty when not inlined, got %s", raw)
+	}
+}
+
+func TestSearchModelsDescribesConcurrentlyInOrder(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/models" {
+			var models []map[string]string
+			for i := 0; i < 8; i++ {
+				models = append(models, map[string]string{"modelId": fmt.Sprintf("org/m%d", i)})
+			}
+			_ = json.NewEncoder(w).Encode(models)
+			return
+		}
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/models/")
+		switch id {
+		case "org/m2":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case "org/m1", "org/m4":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"modelId": id, "config": map[string]interface{}{"architectures": []string{"UnknownForCausalLM"}}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"modelId": id, "config": map[string]interface{}{"architectures": []string{"Qwen2ForCausalLM"}}})
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	d := New(WithDescribeConcurrency(3))
+	d.client = &http.Client{Transport: redirectTransport{target: target}}
+	d.supportedArch["qwen2"] = ModelArchitecture{Name: "qwen2", ClassName: "Qwen2ForCausalLM"}
+	d.supportedSync = time.Now()
+
+	results, err := d.SearchModels(SearchOptions{Query: "m", Limit: 4, OnlyCompatible: true})
+	if err != nil {
+		t.Fatalf("SearchModels: %v", err)
+	}
+	var ids []string
+	for _, insight := range results {
+		ids = append(ids, insight.HFModel.ModelID)
+	}
+	if got := strings.Join(ids, ","); got != "org/m0,org/m3,org/m5,org/m6" {
+		t.Fatalf("expected compatible models in search order despite the failing one, got %s", got)
+	}
+	if peak < 2 || peak > 3 {
+		t.Fatalf("expected between 2 and 3 concurrent describes, saw %d", peak)
+	}
+}
+
+func TestListSupportedArchitecturesRevalidatesWithETag(t *testing.T) {
+	var (
+		listings     atomic.Int32
+		noneMatchHdr atomic.Value
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		noneMatchHdr.Store(r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		listings.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"name": "qwen2.py", "path": "vllm/model_executor/models/qwen2.py", "type": "file"},
+		})
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	d := New(WithArchitectureSources(SourceGitHub))
+	d.client = &http.Client{Transport: redirectTransport{target: target}}
+	if _, err := d.ListSupportedArchitectures(); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if got := noneMatchHdr.Load(); got != "" {
+		t.Fatalf("first fetch sent If-None-Match %q", got)
+	}
+
+	stale := time.Now().Add(-time.Hour)
+	d.supportedSync = stale
+	archs, err := d.ListSupportedArchitectures()
+	if err != nil {
+		t.Fatalf("revalidation: %v", err)
+	}
+	if got := noneMatchHdr.Load(); got != `"v1"` {
+		t.Fatalf("expected the stored ETag to be sent, got %q", got)
+	}
+	if listings.Load() != 1 {
+		t.Fatalf("expected the 304 to skip re-downloading the listing, got %d listings", listings.Load())
+	}
+	if len(archs) != 1 || archs[0].Name != "qwen2" {
+		t.Fatalf("expected the cached architectures after a 304, got %+v", archs)
+	}
+	if !d.supportedSync.After(stale) {
+		t.Fatal("expected a 304 to refresh the cache timestamp")
+	}
+	if d.ArchitectureSource() != SourceGitHub {
+		t.Fatalf("expected the GitHub source to stay recorded, got %q", d.ArchitectureSource())
+	}
+
+	// An ETag is only useful while the cache holds the GitHub listing.
+	d.supportedMu.Lock()
+	d.archSourceUsed = SourceEmbedded
+	d.supportedMu.Unlock()
+	if etag := d.cachedGitHubETag(); etag != "" {
+		t.Fatalf("expected no ETag for a cache filled from another source, got %q", etag)
+	}
+}
+
+func TestDetectQuantization(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		tags   []string
+		want   string
+	}{
+		{"unquantized", map[string]interface{}{"torch_dtype": "bfloat16"}, []string{"text-generation"}, ""},
+		{"quant_method", map[string]interface{}{"quantization_config": map[string]interface{}{"quant_method": " AWQ "}}, nil, "awq"},
+		{"quant_method wins over tags", map[string]interface{}{"quantization_config": map[string]interface{}{"quant_method": "gptq"}}, []string{"awq"}, "gptq"},
+		{"load_in_4bit", map[string]interface{}{"quantization_config": map[string]interface{}{"load_in_4bit": true}}, nil, "bitsandbytes"},
+		{"load_in_8bit disabled", map[string]interface{}{"quantization_config": map[string]interface{}{"load_in_8bit": false}}, nil, ""},
+		{"awq tag", nil, []string{"transformers", "AWQ"}, "awq"},
+		{"4bit tag", nil, []string{"4bit"}, "bitsandbytes"},
+		{"compressed-tensors tag", nil, []string{"compressed-tensors"}, "compressed-tensors"},
+	}
+	for _, tc := range cases {
+		if got := detectQuantization(&HuggingFaceModel{Config: tc.config, Tags: tc.tags}); got != tc.want {
+			t.Errorf("%s: detectQuantization = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestBuildCatalogModelDetectsQuantizationFromTags(t *testing.T) {
+	d := New()
+	hfModel := &HuggingFaceModel{ModelID: "org/model-awq", Tags: []string{"awq"}}
+
+	model := d.buildCatalogModel(hfModel, GenerateRequest{HFModelID: "org/model-awq", AutoDetect: true})
+	if model.VLLM == nil || model.VLLM.Quantization != "awq" {
+		t.Fatalf("expected awq quantization from tags alone, got %+v", model.VLLM)
+	}
+	model = d.buildCatalogModel(hfModel, GenerateRequest{HFModelID: "org/model-awq"})
+	if model.VLLM == nil || model.VLLM.Quantization != "" {
+		t.Fatalf("expected no detection without autoDetect, got %+v", model.VLLM)
+	}
+}
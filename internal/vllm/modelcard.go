@@ -0,0 +1,104 @@
+package vllm
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	hfBaseURL = "https://huggingface.co"
+	// maxCardSummary bounds ModelInsight.CardSummary.
+	maxCardSummary = 4096
+	// maxCardFetch bounds how much of the README is read before trimming.
+	maxCardFetch = 256 << 10
+)
+
+// DescribeModelWithCard behaves like DescribeModel and also fills CardSummary
+// from the model card (README.md). The card costs an extra Hub request, so it
+// is opt-in; a missing or unreadable card is reported in Notes rather than
+// failing the describe.
+func (d *Discovery) DescribeModelWithCard(hfModelID string, autoDetect bool) (*ModelInsight, error) {
+	cacheKey := describeCacheKey(hfModelID, autoDetect) + ":card"
+	if cached := d.cachedInsight(cacheKey); cached != nil {
+		return cached, nil
+	}
+
+	insight, err := d.DescribeModel(hfModelID, autoDetect)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := d.fetchModelCard(hfModelID)
+	if err != nil {
+		insight.Notes = append(insight.Notes, fmt.Sprintf("model card unavailable: %v", err))
+	} else {
+		insight.CardSummary = summary
+	}
+
+	d.storeInsight(cacheKey, insight)
+	return cloneInsight(insight), nil
+}
+
+// fetchModelCard downloads README.md from the main branch and returns its body
+// without YAML front matter, truncated to maxCardSummary bytes.
+func (d *Discovery) fetchModelCard(hfModelID string) (string, error) {
+	url := fmt.Sprintf("%s/%s/raw/main/README.md", hfBaseURL, hfModelID)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if d.hfToken != "" {
+		req.Header.Set("Authorization", "Bearer "+d.hfToken)
+	}
+
+	resp, err := d.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HuggingFace returned %d for README.md", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCardFetch))
+	if err != nil {
+		return "", err
+	}
+	return truncateCard(stripFrontMatter(string(body)), maxCardSummary), nil
+}
+
+// stripFrontMatter removes a leading "---" delimited YAML block.
+func stripFrontMatter(card string) string {
+	card = strings.TrimPrefix(card, "\ufeff")
+	card = strings.ReplaceAll(card, "\r\n", "\n")
+	if !strings.HasPrefix(card, "---\n") {
+		return strings.TrimSpace(card)
+	}
+	rest := card[len("---"):]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return strings.TrimSpace(card)
+	}
+	rest = rest[end+len("\n---"):]
+	if nl := strings.IndexByte(rest, '\n'); nl >= 0 {
+		rest = rest[nl+1:]
+	} else {
+		rest = ""
+	}
+	return strings.TrimSpace(rest)
+}
+
+// truncateCard cuts card to at most max bytes without splitting a UTF-8
+// sequence.
+func truncateCard(card string, max int) string {
+	if len(card) <= max {
+		return card
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(card[cut]) {
+		cut--
+	}
+	return strings.TrimSpace(card[:cut])
+}
@@ -0,0 +1,93 @@
+package vllm
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxRetries = 3
+	retryBaseDelay    = time.Second
+	retryMaxDelay     = 30 * time.Second
+)
+
+// WithRateLimit caps outbound Hugging Face and GitHub calls at rps requests per
+// second with the given burst. A non-positive rps disables limiting.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(d *Discovery) {
+		if rps <= 0 {
+			d.limiter = nil
+			return
+		}
+		if burst <= 0 {
+			burst = 1
+		}
+		d.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithMaxRetries sets how many times a 429 response is retried before it is
+// returned to the caller (default 3). Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(d *Discovery) {
+		if n < 0 {
+			n = 0
+		}
+		d.maxRetries = n
+	}
+}
+
+// do sends req through the rate limiter and retries 429 responses, waiting for
+// Retry-After when the server sends it and capped exponential backoff
+// otherwise. Requests must be bodiless so they can be resent.
+func (d *Discovery) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if d.limiter != nil {
+			if err := d.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := d.client.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= d.maxRetries {
+			return resp, err
+		}
+		delay := retryDelay(resp.Header.Get("Retry-After"), attempt)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay honors a Retry-After header (seconds or HTTP date) and falls back
+// to exponential backoff from retryBaseDelay. Both are capped at retryMaxDelay.
+func retryDelay(retryAfter string, attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 5 {
+		delay = retryBaseDelay << attempt
+	}
+	if retryAfter = strings.TrimSpace(retryAfter); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+			delay = time.Duration(secs) * time.Second
+		} else if at, err := http.ParseTime(retryAfter); err == nil {
+			delay = time.Until(at)
+		}
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
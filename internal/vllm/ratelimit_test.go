@@ -0,0 +1,94 @@
+package vllm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	cases := []struct {
+		name       string
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{"first backoff", "", 0, retryBaseDelay},
+		{"exponential backoff", "", 3, 8 * retryBaseDelay},
+		{"backoff is capped", "", 10, retryMaxDelay},
+		{"retry-after seconds", "2", 4, 2 * time.Second},
+		{"retry-after zero", "0", 2, 0},
+		{"retry-after is capped", "3600", 0, retryMaxDelay},
+		{"retry-after in the past", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+		{"unparseable retry-after", "soon", 1, 2 * retryBaseDelay},
+	}
+	for _, tc := range cases {
+		if got := retryDelay(tc.retryAfter, tc.attempt); got != tc.want {
+			t.Errorf("%s: retryDelay(%q, %d) = %s, want %s", tc.name, tc.retryAfter, tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestDoRetriesTooManyRequests(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxRetries int
+		failures   int32
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"recovers after retries", 3, 2, http.StatusOK, 3},
+		{"gives up after max retries", 1, 5, http.StatusTooManyRequests, 2},
+		{"retries disabled", 0, 5, http.StatusTooManyRequests, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tc.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			d := New(WithMaxRetries(tc.maxRetries))
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err := d.do(req)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus || calls.Load() != tc.wantCalls {
+				t.Fatalf("got status %d after %d calls, want %d after %d", resp.StatusCode, calls.Load(), tc.wantStatus, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoWaitsForRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	d := New(WithRateLimit(20, 1))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := d.do(req)
+		if err != nil {
+			t.Fatalf("do: %v", err)
+		}
+		resp.Body.Close()
+	}
+	// A burst of one at 20 rps spaces the second and third calls 50ms apart.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected the limiter to pace requests, three took %s", elapsed)
+	}
+	if New(WithRateLimit(0, 5)).limiter != nil {
+		t.Fatal("expected a non-positive rate to disable limiting")
+	}
+}
@@ -0,0 +1,201 @@
+package vllm
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Architecture sources understood by WithArchitectureSources.
+const (
+	SourceGitHub   = "github"
+	SourceEmbedded = "embedded"
+	SourceFile     = "file"
+	// SourceSnapshot marks architectures restored from the persisted snapshot
+	// after the GitHub source failed.
+	SourceSnapshot = "snapshot"
+)
+
+// ArchitectureSnapshot persists the last architecture list fetched from GitHub
+// so cold starts survive GitHub rate limits and outages.
+type ArchitectureSnapshot interface {
+	SaveArchitectures([]ModelArchitecture) error
+	LoadArchitectures() ([]ModelArchitecture, time.Time, error)
+}
+
+//go:embed data/architectures.json
+var embeddedArchitectures []byte
+
+// WithArchitectureSources sets the order in which supported architectures are resolved.
+func WithArchitectureSources(sources ...string) Option {
+	return func(d *Discovery) {
+		d.archSources = normalizeSources(sources)
+	}
+}
+
+// WithArchitectureFile points the "file" source at a JSON architecture list.
+func WithArchitectureFile(path string) Option {
+	return func(d *Discovery) {
+		d.archFile = path
+	}
+}
+
+// WithArchitectureSnapshot saves successful GitHub fetches to snapshot and
+// restores from it when GitHub is unavailable.
+func WithArchitectureSnapshot(snapshot ArchitectureSnapshot) Option {
+	return func(d *Discovery) {
+		d.archSnapshot = snapshot
+	}
+}
+
+// ArchitectureSource reports which source populated the current architecture cache.
+func (d *Discovery) ArchitectureSource() string {
+	d.supportedMu.RLock()
+	defer d.supportedMu.RUnlock()
+	return d.archSourceUsed
+}
+
+func normalizeSources(sources []string) []string {
+	seen := make(map[string]struct{}, len(sources))
+	out := make([]string, 0, len(sources))
+	for _, source := range sources {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if source == "" {
+			continue
+		}
+		if _, ok := seen[source]; ok {
+			continue
+		}
+		seen[source] = struct{}{}
+		out = append(out, source)
+	}
+	return out
+}
+
+// loadArchitectures walks the configured sources and returns the first list that resolves.
+func (d *Discovery) loadArchitectures() ([]ModelArchitecture, string, error) {
+	sources := d.archSources
+	if len(sources) == 0 {
+		sources = []string{SourceGitHub, SourceEmbedded}
+	}
+	var errs []error
+	for _, source := range sources {
+		var (
+			archs []ModelArchitecture
+			err   error
+		)
+		switch source {
+		case SourceGitHub:
+			archs, err = d.fetchGitHubArchitectures()
+			if errors.Is(err, errArchitecturesNotModified) {
+				return nil, SourceGitHub, err
+			}
+			if err == nil && len(archs) > 0 {
+				d.saveArchitectureSnapshot(archs)
+			} else if restored, ok := d.restoreArchitectureSnapshot(err); ok {
+				return restored, SourceSnapshot, nil
+			}
+		case SourceEmbedded:
+			archs, err = parseArchitectureList(embeddedArchitectures)
+		case SourceFile:
+			archs, err = d.readArchitectureFile()
+		default:
+			err = fmt.Errorf("unknown architecture source %q", source)
+		}
+		if err == nil && len(archs) == 0 {
+			err = errors.New("no architectures returned")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+			continue
+		}
+		for i := range archs {
+			archs[i].Source = source
+		}
+		return archs, source, nil
+	}
+	return nil, "", errors.Join(errs...)
+}
+
+func (d *Discovery) saveArchitectureSnapshot(archs []ModelArchitecture) {
+	if d.archSnapshot == nil {
+		return
+	}
+	if err := d.archSnapshot.SaveArchitectures(archs); err != nil {
+		log.Printf("vllm: failed to persist architecture snapshot: %v", err)
+	}
+}
+
+func (d *Discovery) restoreArchitectureSnapshot(cause error) ([]ModelArchitecture, bool) {
+	if d.archSnapshot == nil {
+		return nil, false
+	}
+	archs, updatedAt, err := d.archSnapshot.LoadArchitectures()
+	if err != nil || len(archs) == 0 {
+		return nil, false
+	}
+	if cause == nil {
+		cause = errors.New("no architectures returned")
+	}
+	log.Printf("vllm: GitHub architecture fetch failed (%v); using %d architectures from snapshot saved at %s", cause, len(archs), updatedAt.Format(time.RFC3339))
+	for i := range archs {
+		archs[i].Source = SourceSnapshot
+	}
+	return archs, true
+}
+
+func (d *Discovery) readArchitectureFile() ([]ModelArchitecture, error) {
+	if d.archFile == "" {
+		return nil, errors.New("architecture file not configured")
+	}
+	data, err := os.ReadFile(filepath.Clean(d.archFile))
+	if err != nil {
+		return nil, err
+	}
+	return parseArchitectureList(data)
+}
+
+// parseArchitectureList accepts either a list of module names or full ModelArchitecture objects.
+func parseArchitectureList(data []byte) ([]ModelArchitecture, error) {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		archs := make([]ModelArchitecture, 0, len(names))
+		for _, name := range names {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			archs = append(archs, ModelArchitecture{
+				Name:      name,
+				ClassName: toPascalCase(name),
+				FilePath:  fmt.Sprintf("vllm/model_executor/models/%s.py", name),
+			})
+		}
+		return archs, nil
+	}
+
+	var archs []ModelArchitecture
+	if err := json.Unmarshal(data, &archs); err != nil {
+		return nil, fmt.Errorf("failed to decode architecture list: %w", err)
+	}
+	out := archs[:0]
+	for _, arch := range archs {
+		if arch.Name == "" {
+			continue
+		}
+		if arch.ClassName == "" {
+			arch.ClassName = toPascalCase(arch.Name)
+		}
+		if arch.FilePath == "" {
+			arch.FilePath = fmt.Sprintf("vllm/model_executor/models/%s.py", arch.Name)
+		}
+		out = append(out, arch)
+	}
+	return out, nil
+}
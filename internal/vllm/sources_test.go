@@ -0,0 +1,147 @@
+package vllm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// githubServer serves the vLLM models directory listing, or fails with status
+// when it is non-zero.
+func githubServer(t *testing.T, status int) *http.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"name": "__init__.py", "path": "vllm/model_executor/models/__init__.py", "type": "file"},
+			{"name": "qwen2.py", "path": "vllm/model_executor/models/qwen2.py", "type": "file", "size": 100},
+			{"name": "utils", "path": "vllm/model_executor/models/utils", "type": "dir"},
+		})
+	}))
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	return &http.Client{Transport: redirectTransport{target: target}}
+}
+
+func TestLoadArchitecturesFollowsSourceOrder(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "architectures.json")
+	if err := os.WriteFile(file, []byte(`["custom_arch"]`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cases := []struct {
+		name       string
+		sources    []string
+		github     int
+		archFile   string
+		wantSource string
+		wantArch   string
+	}{
+		{"github first", []string{"github", "embedded"}, 0, "", SourceGitHub, "qwen2"},
+		{"github down falls back to embedded", []string{"github", "embedded"}, http.StatusServiceUnavailable, "", SourceEmbedded, "llama"},
+		{"default order", nil, http.StatusForbidden, "", SourceEmbedded, "llama"},
+		{"file before github", []string{"file", "github"}, 0, file, SourceFile, "custom_arch"},
+		{"missing file falls through", []string{"file", "github"}, 0, "", SourceGitHub, "qwen2"},
+		{"sources are normalized", []string{" Embedded ", "github"}, 0, "", SourceEmbedded, "llama"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := New(WithArchitectureSources(tc.sources...), WithArchitectureFile(tc.archFile))
+			d.client = githubServer(t, tc.github)
+			archs, source, err := d.loadArchitectures()
+			if err != nil {
+				t.Fatalf("loadArchitectures: %v", err)
+			}
+			if source != tc.wantSource {
+				t.Fatalf("source = %q, want %q", source, tc.wantSource)
+			}
+			found := false
+			for _, arch := range archs {
+				if arch.Source != tc.wantSource {
+					t.Fatalf("%s is tagged with source %q", arch.Name, arch.Source)
+				}
+				found = found || arch.Name == tc.wantArch
+			}
+			if !found {
+				t.Fatalf("expected %s among %d architectures", tc.wantArch, len(archs))
+			}
+		})
+	}
+}
+
+func TestLoadArchitecturesReportsEverySourceError(t *testing.T) {
+	d := New(WithArchitectureSources("file", "github", "pypi"))
+	d.client = githubServer(t, http.StatusInternalServerError)
+	_, _, err := d.loadArchitectures()
+	if err == nil {
+		t.Fatal("expected an error when every source fails")
+	}
+	for _, want := range []string{"file: architecture file not configured", "github: GitHub API returned status 500", `pypi: unknown architecture source "pypi"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestParseArchitectureList(t *testing.T) {
+	cases := []struct {
+		name      string
+		data      string
+		want      []ModelArchitecture
+		wantError bool
+	}{
+		{
+			name: "names",
+			data: `["qwen2", " ", "gpt_neox"]`,
+			want: []ModelArchitecture{
+				{Name: "qwen2", ClassName: "Qwen2", FilePath: "vllm/model_executor/models/qwen2.py"},
+				{Name: "gpt_neox", ClassName: "GptNeox", FilePath: "vllm/model_executor/models/gpt_neox.py"},
+			},
+		},
+		{
+			name: "objects",
+			data: `[{"name": "qwen2", "className": "Qwen2ForCausalLM"}, {"className": "Nameless"}]`,
+			want: []ModelArchitecture{
+				{Name: "qwen2", ClassName: "Qwen2ForCausalLM", FilePath: "vllm/model_executor/models/qwen2.py"},
+			},
+		},
+		{name: "malformed", data: `{"qwen2": true}`, wantError: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseArchitectureList([]byte(tc.data))
+			if tc.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseArchitectureList: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+			for i := range got {
+				if got[i].Name != tc.want[i].Name || got[i].ClassName != tc.want[i].ClassName || got[i].FilePath != tc.want[i].FilePath {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedArchitecturesParse(t *testing.T) {
+	archs, err := parseArchitectureList(embeddedArchitectures)
+	if err != nil || len(archs) == 0 {
+		t.Fatalf("embedded architecture list is unusable: %d entries, %v", len(archs), err)
+	}
+}
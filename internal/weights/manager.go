@@ -2,9 +2,9 @@
 package weights
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -14,10 +14,17 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// ErrInstallCancelled is the cancellation cause callers attach (via
+// context.WithCancelCause) when an operator cancels an install. Unlike a plain
+// context cancellation, the partial download is discarded rather than kept for
+// a resume.
+var ErrInstallCancelled = errors.New("install cancelled")
+
 // Manager handles model weight operations on the Venus PVC.
 type Manager struct {
 	storagePath   string
@@ -47,7 +54,15 @@ type WeightInfo struct {
 	FileCount    int       `json:"fileCount"`
 	HFModelID    string    `json:"hfModelId,omitempty"`
 	Revision     string    `json:"revision,omitempty"`
-	InstalledAt  time.Time `json:"installedAt,omitempty"`
+	// RequestedRevision is the revision the install asked for when Revision
+	// is a fallback that was installed instead.
+	RequestedRevision string    `json:"requestedRevision,omitempty"`
+	InstalledAt       time.Time `json:"installedAt,omitempty"`
+	// Files is the manifest recorded after install: each file's path, size,
+	// and SHA-256. Only the weight info endpoint fills it, on request (see
+	// Manager.Manifest), and it is empty for weights installed before
+	// manifests were recorded.
+	Files []FileInfo `json:"files,omitempty"`
 }
 
 // StorageStats provides overall storage statistics.
@@ -65,21 +80,37 @@ type StorageStats struct {
 const metadataFilename = ".model-manager"
 
 type weightMetadata struct {
-	ModelID     string    `json:"modelId"`
-	Revision    string    `json:"revision,omitempty"`
-	InstalledAt time.Time `json:"installedAt"`
+	ModelID string `json:"modelId"`
+	// Revision is the revision that was downloaded; RequestedRevision is set
+	// when that was a fallback for a revision missing the requested files.
+	Revision          string    `json:"revision,omitempty"`
+	RequestedRevision string    `json:"requestedRevision,omitempty"`
+	InstalledAt       time.Time `json:"installedAt"`
+	// Files lists the repository files requested at install time; empty
+	// means the default file set was downloaded.
+	Files []string `json:"files,omitempty"`
 }
 
 // InstallOptions controls how weights are installed for a model.
 type InstallOptions struct {
-	ModelID       string
-	Revision      string
-	Target        string
-	Files         []string
-	Token         string
-	Overwrite     bool
+	ModelID  string
+	Revision string
+	// RequestedRevision is the revision the caller first asked for when
+	// Revision is one of its fallbacks; it is recorded in the metadata.
+	RequestedRevision string
+	Target            string
+	Files             []string
+	Token             string
+	Overwrite         bool
+	// Resume keeps a partial <dest>.tmp download and asks the CLI to continue
+	// it. InstallFromHuggingFace enables it when partial content is found and
+	// Overwrite is false.
+	Resume        bool
 	Progress      func(file string, completed, total int)
 	ProgressBytes func(file string, fileIndex, totalFiles int, downloaded, totalBytes int64)
+	// HashProgress reports the checksum manifest being recorded after the
+	// download, once per hashed file.
+	HashProgress func(file string, hashed, total int)
 }
 
 // New creates a new weight manager.
@@ -189,6 +220,20 @@ func (m *Manager) Get(modelName string) (*WeightInfo, error) {
 	return m.getWeightInfo(modelPath, rel)
 }
 
+// Manifest returns the file manifest recorded when modelName was installed.
+// The error wraps fs.ErrNotExist for weights installed before manifests were
+// recorded.
+func (m *Manager) Manifest(modelName string) ([]FileInfo, error) {
+	rel, err := normalizeRelativePath(modelName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid model path: %w", err)
+	}
+	if m.isReserved(rel) {
+		return nil, fmt.Errorf("model weights not found: %s", rel)
+	}
+	return readManifest(filepath.Join(m.storagePath, toFilesystemPath(rel)))
+}
+
 // Delete removes a model's weights from storage.
 func (m *Manager) Delete(modelName string) error {
 	rel, err := normalizeRelativePath(modelName)
@@ -236,8 +281,9 @@ func (m *Manager) Delete(modelName string) error {
 	return nil
 }
 
-// PruneOlderThan deletes cached weights that have not been modified within the provided age.
-func (m *Manager) PruneOlderThan(maxAge time.Duration) ([]string, error) {
+// PruneCandidates lists cached weights that have not been modified within
+// maxAge, oldest first. A non-positive maxAge matches nothing.
+func (m *Manager) PruneCandidates(maxAge time.Duration) ([]WeightInfo, error) {
 	if maxAge <= 0 {
 		return nil, nil
 	}
@@ -246,11 +292,27 @@ func (m *Manager) PruneOlderThan(maxAge time.Duration) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	var removed []string
+	var candidates []WeightInfo
 	for _, info := range weights {
 		if info.ModifiedTime.After(cutoff) {
 			continue
 		}
+		candidates = append(candidates, info)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ModifiedTime.Before(candidates[j].ModifiedTime)
+	})
+	return candidates, nil
+}
+
+// PruneOlderThan deletes cached weights that have not been modified within the provided age.
+func (m *Manager) PruneOlderThan(maxAge time.Duration) ([]string, error) {
+	candidates, err := m.PruneCandidates(maxAge)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, info := range candidates {
 		if err := m.Delete(info.Name); err != nil {
 			log.Printf("weights: failed to prune %s: %v", info.Name, err)
 			continue
@@ -260,6 +322,84 @@ func (m *Manager) PruneOlderThan(maxAge time.Duration) ([]string, error) {
 	return removed, nil
 }
 
+// ParseAge parses a prune age: a Go duration such as 720h, or a whole number
+// of days or weeks such as 30d or 2w.
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit > 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q: expected a duration like 720h or a day count like 30d", value)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q: expected a duration like 720h or a day count like 30d", value)
+	}
+	return age, nil
+}
+
+// ErrCannotFreeSpace is returned by EvictToFree when evicting every
+// unprotected weight directory would still not free the requested bytes.
+var ErrCannotFreeSpace = errors.New("not enough evictable weights to free the requested space")
+
+// EvictToFree deletes the least-recently-modified cached weights, skipping
+// protected names, until at least bytes have been freed. Nothing is deleted
+// when the unprotected weights can't cover the request; in that case the error
+// wraps ErrCannotFreeSpace. It returns the evicted weights, oldest first.
+func (m *Manager) EvictToFree(bytes int64, protected []string) ([]WeightInfo, error) {
+	if bytes <= 0 {
+		return nil, nil
+	}
+	weights, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	keep := make(map[string]struct{}, len(protected))
+	for _, name := range protected {
+		if rel, err := normalizeRelativePath(name); err == nil {
+			keep[rel] = struct{}{}
+		}
+	}
+	sort.SliceStable(weights, func(i, j int) bool {
+		return weights[i].ModifiedTime.Before(weights[j].ModifiedTime)
+	})
+
+	var candidates []WeightInfo
+	var freeable int64
+	for _, info := range weights {
+		if _, ok := keep[info.Name]; ok {
+			continue
+		}
+		if freeable >= bytes {
+			break
+		}
+		candidates = append(candidates, info)
+		freeable += info.SizeBytes
+	}
+	if freeable < bytes {
+		return nil, fmt.Errorf("%w: need %s, only %s evictable", ErrCannotFreeSpace, FormatBytes(bytes), FormatBytes(freeable))
+	}
+
+	var evicted []WeightInfo
+	for _, info := range candidates {
+		if err := m.Delete(info.Name); err != nil {
+			return evicted, fmt.Errorf("evict %s: %w", info.Name, err)
+		}
+		log.Printf("weights: evicted %s (%s, last modified %s)", info.Name, info.SizeHuman, info.ModifiedTime.Format(time.RFC3339))
+		evicted = append(evicted, info)
+	}
+	return evicted, nil
+}
+
 // GetStats returns overall storage statistics.
 func (m *Manager) GetStats() (*StorageStats, error) {
 	weights, err := m.List()
@@ -280,7 +420,7 @@ func (m *Manager) GetStats() (*StorageStats, error) {
 			TotalBytes:     0,
 			TotalHuman:     "unknown",
 			UsedBytes:      totalUsed,
-			UsedHuman:      formatBytes(totalUsed),
+			UsedHuman:      FormatBytes(totalUsed),
 			AvailableBytes: 0,
 			AvailableHuman: "unknown",
 			ModelCount:     len(weights),
@@ -293,11 +433,11 @@ func (m *Manager) GetStats() (*StorageStats, error) {
 
 	return &StorageStats{
 		TotalBytes:     totalBytes,
-		TotalHuman:     formatBytes(totalBytes),
+		TotalHuman:     FormatBytes(totalBytes),
 		UsedBytes:      totalUsed,
-		UsedHuman:      formatBytes(totalUsed),
+		UsedHuman:      FormatBytes(totalUsed),
 		AvailableBytes: availBytes,
-		AvailableHuman: formatBytes(availBytes),
+		AvailableHuman: FormatBytes(availBytes),
 		ModelCount:     len(weights),
 		Models:         weights,
 	}, nil
@@ -334,30 +474,63 @@ func (m *Manager) InstallFromHuggingFace(ctx context.Context, opts InstallOption
 	}
 
 	tmpPath := destPath + ".tmp"
-	_ = os.RemoveAll(tmpPath)
+	if opts.Overwrite {
+		opts.Resume = false
+	} else if partial, err := hasAnyFiles(tmpPath); err == nil && partial {
+		opts.Resume = true
+	}
+	if opts.Resume {
+		log.Printf("weights: resuming partial download for %s from %s", target, tmpPath)
+	} else {
+		_ = os.RemoveAll(tmpPath)
+	}
 
 	if err := os.MkdirAll(tmpPath, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
 	if err := m.hfDownloader(ctx, opts, tmpPath, revision); err != nil {
-		_ = os.RemoveAll(tmpPath)
+		if errors.Is(context.Cause(ctx), ErrInstallCancelled) {
+			_ = os.RemoveAll(tmpPath)
+			return nil, ErrInstallCancelled
+		}
+		// Keep partial content so the next attempt can resume instead of
+		// starting over; only clear out a directory that holds nothing useful.
+		// Files from a revision that turned out to be missing are never
+		// resumed, and would mix with another revision's download.
+		if partial, _ := hasAnyFiles(tmpPath); !partial || IsRevisionNotFound(err) {
+			_ = os.RemoveAll(tmpPath)
+		}
 		return nil, err
 	}
 
+	hasFiles, err := hasAnyFiles(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify download contents: %w", err)
+	}
+	if !hasFiles {
+		_ = os.RemoveAll(tmpPath)
+		return nil, fmt.Errorf("download finished but no files were written to %s", tmpPath)
+	}
+
 	if err := os.Rename(tmpPath, destPath); err != nil {
 		_ = os.RemoveAll(tmpPath)
 		return nil, fmt.Errorf("failed to finalize weights: %w", err)
 	}
 
 	meta := weightMetadata{
-		ModelID:     opts.ModelID,
-		Revision:    revision,
-		InstalledAt: time.Now().UTC(),
+		ModelID:           opts.ModelID,
+		Revision:          revision,
+		RequestedRevision: requestedRevision(opts.RequestedRevision, revision),
+		InstalledAt:       time.Now().UTC(),
+		Files:             opts.Files,
 	}
 	if err := writeMetadata(destPath, meta); err != nil {
 		log.Printf("weights: failed to write metadata for %s: %v", target, err)
 	}
+	if err := writeManifest(ctx, destPath, opts.HashProgress); err != nil {
+		log.Printf("weights: failed to write file manifest for %s: %v", target, err)
+	}
 
 	info, err := m.getWeightInfo(destPath, target)
 	if err != nil {
@@ -396,7 +569,7 @@ func (m *Manager) getWeightInfo(path, name string) (*WeightInfo, error) {
 			return err
 		}
 		if !info.IsDir() {
-			if info.Name() == metadataFilename {
+			if info.Name() == metadataFilename || info.Name() == manifestFilename {
 				return nil
 			}
 			totalSize += info.Size()
@@ -416,7 +589,7 @@ func (m *Manager) getWeightInfo(path, name string) (*WeightInfo, error) {
 		Path:         path,
 		Name:         name,
 		SizeBytes:    totalSize,
-		SizeHuman:    formatBytes(totalSize),
+		SizeHuman:    FormatBytes(totalSize),
 		ModifiedTime: modTime,
 		FileCount:    fileCount,
 	}
@@ -424,6 +597,7 @@ func (m *Manager) getWeightInfo(path, name string) (*WeightInfo, error) {
 	if meta, err := readMetadata(path); err == nil && meta != nil {
 		info.HFModelID = meta.ModelID
 		info.Revision = meta.Revision
+		info.RequestedRevision = meta.RequestedRevision
 		info.InstalledAt = meta.InstalledAt
 	}
 
@@ -448,6 +622,8 @@ func (m *Manager) downloadWithHFCLI(ctx context.Context, opts InstallOptions, tm
 	}
 	if opts.Overwrite {
 		args = append(args, "--force-download")
+	} else if opts.Resume {
+		args = append(args, "--resume-download")
 	}
 	if len(opts.Files) > 0 {
 		args = append(args, "--include", strings.Join(opts.Files, ","))
@@ -467,10 +643,13 @@ func (m *Manager) downloadWithHFCLI(ctx context.Context, opts InstallOptions, tm
 	if opts.Token != "" {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("HUGGING_FACE_HUB_TOKEN=%s", opts.Token))
 	}
-	out, err := cmd.CombinedOutput()
-	combinedOut = out
+	output := newProgressWriter(opts)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	err := cmd.Run()
+	combinedOut = []byte(output.String())
 	if err != nil {
-		return fmt.Errorf("%s download failed: %w\n%s", filepath.Base(bin), err, string(out))
+		return downloadError(filepath.Base(bin), err, output.String())
 	}
 
 	var fileCount int64
@@ -493,7 +672,8 @@ func (m *Manager) downloadWithHFCLI(ctx context.Context, opts InstallOptions, tm
 	return nil
 }
 
-func formatBytes(bytes int64) string {
+// FormatBytes renders a byte count using binary units (KiB, MiB, ...).
+func FormatBytes(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
@@ -606,7 +786,10 @@ func runHFDownload(ctx context.Context, opts InstallOptions, tmpPath, revision s
 	if err != nil {
 		return err
 	}
-	args := []string{"download", opts.ModelID, "--local-dir", tmpPath, "--revision", revision, "--resume-download"}
+	args := []string{"download", opts.ModelID, "--local-dir", tmpPath, "--revision", revision}
+	if opts.Resume {
+		args = append(args, "--resume-download")
+	}
 	if len(opts.Files) > 0 {
 		args = append(args, opts.Files...)
 	}
@@ -620,12 +803,13 @@ func runHFDownload(ctx context.Context, opts InstallOptions, tmpPath, revision s
 	}
 	cmd.Env = env
 
-	var output bytes.Buffer
-	cmd.Stdout = &output
-	cmd.Stderr = &output
+	// Sharing one writer for stdout and stderr keeps progress callbacks serialized.
+	output := newProgressWriter(opts)
+	cmd.Stdout = output
+	cmd.Stderr = output
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s download failed: %w\n%s", filepath.Base(bin), err, output.String())
+		return downloadError(filepath.Base(bin), err, output.String())
 	}
 
 	hasFiles, err := hasAnyFiles(tmpPath)
@@ -638,6 +822,15 @@ func runHFDownload(ctx context.Context, opts InstallOptions, tmpPath, revision s
 	return nil
 }
 
+// downloadError wraps a failed CLI run with its output, marking it with
+// ErrRevisionNotFound when the output says the revision or a file is missing.
+func downloadError(bin string, err error, output string) error {
+	if missingRevisionOutput(output) {
+		return fmt.Errorf("%s download failed: %w: %w\n%s", bin, ErrRevisionNotFound, err, output)
+	}
+	return fmt.Errorf("%s download failed: %w\n%s", bin, err, output)
+}
+
 func findHFCommand() (string, error) {
 	if bin, err := exec.LookPath("hf"); err == nil {
 		return bin, nil
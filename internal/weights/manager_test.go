@@ -2,10 +2,14 @@ package weights
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestInstallFromHuggingFaceDownloadsFiles(t *testing.T) {
@@ -45,6 +49,82 @@ func TestInstallFromHuggingFaceDownloadsFiles(t *testing.T) {
 	}
 }
 
+func TestInstallFromHuggingFaceRecordsFileManifest(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manager := New(tmpDir, WithHFDownloader(func(ctx context.Context, opts InstallOptions, tmpPath, revision string) error {
+		if err := os.MkdirAll(filepath.Join(tmpPath, "subdir"), 0o755); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(tmpPath, ".cache"), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(tmpPath, ".cache", "lock"), []byte("x"), 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(tmpPath, "config.json"), []byte("{}"), 0o644); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(tmpPath, "subdir", "model.safetensors"), []byte("tiny-model"), 0o644)
+	}))
+
+	var hashed []int
+	installed, err := manager.InstallFromHuggingFace(context.Background(), InstallOptions{
+		ModelID:      "Qwen/Qwen2.5-0.5B",
+		HashProgress: func(file string, done, total int) { hashed = append(hashed, done, total) },
+	})
+	if err != nil {
+		t.Fatalf("InstallFromHuggingFace() error = %v", err)
+	}
+	if !reflect.DeepEqual(hashed, []int{1, 2, 2, 2}) {
+		t.Fatalf("expected hashing progress per file, got %v", hashed)
+	}
+	info, err := manager.Get("Qwen/Qwen2.5-0.5B")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if info.Files != nil {
+		t.Fatalf("Get should not load the manifest, got %v", info.Files)
+	}
+	files, err := manager.Manifest("Qwen/Qwen2.5-0.5B")
+	if err != nil {
+		t.Fatalf("Manifest() error = %v", err)
+	}
+	want := []FileInfo{
+		{Path: "config.json", SizeBytes: 2, SHA256: "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"},
+		{Path: "subdir/model.safetensors", SizeBytes: 10, SHA256: "ccdbfb9993be88c536b0b7cd2abe60eda83c7ce1ad530c6a2ada81510ff1548c"},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Fatalf("expected manifest %v, got %v", want, files)
+	}
+	if installed.FileCount != info.FileCount {
+		t.Fatalf("manifest should not count as a weight file: install %d, get %d", installed.FileCount, info.FileCount)
+	}
+}
+
+func TestWriteManifestStopsWhenCancelled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.safetensors", "b.safetensors"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := writeManifest(ctx, dir, func(file string, hashed, total int) { cancel() })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancellation to stop hashing, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, manifestFilename)); !os.IsNotExist(err) {
+		t.Fatalf("expected no manifest after cancellation, got %v", err)
+	}
+	if _, err := New(dir).Manifest("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist without a manifest, got %v", err)
+	}
+}
+
 func TestListSkipsReservedAndHiddenDirs(t *testing.T) {
 	t.Parallel()
 
@@ -94,3 +174,256 @@ func TestListSkipsReservedAndHiddenDirs(t *testing.T) {
 		t.Fatalf("expected error when getting reserved directory")
 	}
 }
+
+func TestInstallFromHuggingFaceResumesPartialDownload(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	files := []string{"config.json", "model-00001.safetensors", "model-00002.safetensors", "tokenizer.json"}
+	calls := 0
+	var resumeFlags []bool
+	manager := New(tmpDir, WithHFDownloader(func(ctx context.Context, opts InstallOptions, tmpPath, revision string) error {
+		calls++
+		resumeFlags = append(resumeFlags, opts.Resume)
+		for i, name := range opts.Files {
+			if calls == 1 && i >= len(opts.Files)/2 {
+				return errors.New("connection reset")
+			}
+			path := filepath.Join(tmpPath, name)
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+			if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	opts := InstallOptions{ModelID: "Qwen/Qwen2.5-0.5B", Files: files}
+	if _, err := manager.InstallFromHuggingFace(context.Background(), opts); err == nil {
+		t.Fatalf("expected first install attempt to fail")
+	}
+	partial := filepath.Join(tmpDir, "Qwen", "Qwen2.5-0.5B.tmp", "config.json")
+	if _, err := os.Stat(partial); err != nil {
+		t.Fatalf("expected partial download to be kept: %v", err)
+	}
+
+	info, err := manager.InstallFromHuggingFace(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("InstallFromHuggingFace() resume error = %v", err)
+	}
+	if len(resumeFlags) != 2 || resumeFlags[0] || !resumeFlags[1] {
+		t.Fatalf("expected resume only on the second attempt, got %v", resumeFlags)
+	}
+	if info.FileCount != len(files) {
+		t.Fatalf("expected %d files after resume, got %d", len(files), info.FileCount)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "Qwen", "Qwen2.5-0.5B.tmp")); !os.IsNotExist(err) {
+		t.Fatalf("expected temp directory to be finalized, stat err = %v", err)
+	}
+}
+
+func TestInstallFromHuggingFaceOverwriteDiscardsPartialDownload(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	stale := filepath.Join(tmpDir, "Qwen", "Qwen2.5-0.5B.tmp")
+	if err := os.MkdirAll(stale, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(stale, "stale.bin"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manager := New(tmpDir, WithHFDownloader(func(ctx context.Context, opts InstallOptions, tmpPath, revision string) error {
+		if opts.Resume {
+			t.Errorf("overwrite must not resume")
+		}
+		return os.WriteFile(filepath.Join(tmpPath, "config.json"), []byte("{}"), 0o644)
+	}))
+
+	info, err := manager.InstallFromHuggingFace(context.Background(), InstallOptions{ModelID: "Qwen/Qwen2.5-0.5B", Overwrite: true})
+	if err != nil {
+		t.Fatalf("InstallFromHuggingFace() error = %v", err)
+	}
+	if info.FileCount != 1 {
+		t.Fatalf("expected stale partial files to be discarded, got %d files", info.FileCount)
+	}
+}
+
+func TestInstallFromHuggingFaceCancelDiscardsPartialDownload(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	manager := New(tmpDir, WithHFDownloader(func(ctx context.Context, opts InstallOptions, tmpPath, revision string) error {
+		if err := os.WriteFile(filepath.Join(tmpPath, "config.json"), []byte("{}"), 0o644); err != nil {
+			return err
+		}
+		cancel(ErrInstallCancelled)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	_, err := manager.InstallFromHuggingFace(ctx, InstallOptions{ModelID: "Qwen/Qwen2.5-0.5B"})
+	if !errors.Is(err, ErrInstallCancelled) {
+		t.Fatalf("expected ErrInstallCancelled, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "Qwen", "Qwen2.5-0.5B.tmp")); !os.IsNotExist(err) {
+		t.Fatalf("expected cancelled download to be removed, stat err = %v", err)
+	}
+}
+
+func TestEvictToFreeRemovesOldestUnprotectedWeights(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	base := time.Now().Add(-72 * time.Hour)
+	for i, name := range []string{"org/oldest", "org/active", "org/middle", "org/newest"} {
+		dir := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(dir, "model.safetensors")
+		if err := os.WriteFile(file, make([]byte, 100), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeMetadata(dir, weightMetadata{ModelID: name}); err != nil {
+			t.Fatal(err)
+		}
+		stamp := base.Add(time.Duration(i) * time.Hour)
+		for _, path := range []string{file, filepath.Join(dir, metadataFilename), dir} {
+			if err := os.Chtimes(path, stamp, stamp); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	manager := New(tmpDir)
+
+	if _, err := manager.EvictToFree(1000, nil); !errors.Is(err, ErrCannotFreeSpace) {
+		t.Fatalf("expected ErrCannotFreeSpace, got %v", err)
+	}
+	if list, _ := manager.List(); len(list) != 4 {
+		t.Fatalf("expected nothing evicted when the request can't be met, got %d weights left", len(list))
+	}
+
+	evicted, err := manager.EvictToFree(150, []string{"org/active"})
+	if err != nil {
+		t.Fatalf("EvictToFree: %v", err)
+	}
+	var names []string
+	for _, info := range evicted {
+		names = append(names, info.Name)
+	}
+	if strings.Join(names, ",") != "org/oldest,org/middle" {
+		t.Fatalf("expected oldest unprotected weights evicted, got %v", names)
+	}
+	if _, err := manager.Get("org/active"); err != nil {
+		t.Fatalf("expected protected weights to remain: %v", err)
+	}
+	if _, err := manager.Get("org/newest"); err != nil {
+		t.Fatalf("expected newest weights to remain: %v", err)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]time.Duration{
+		"30d":  30 * 24 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"720h": 720 * time.Hour,
+		" 0d ": 0,
+	}
+	for input, want := range cases {
+		got, err := ParseAge(input)
+		if err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %s, %v; want %s", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "d", "-1d", "1.5d", "soon", "-5h"} {
+		if _, err := ParseAge(input); err == nil {
+			t.Errorf("expected ParseAge(%q) to fail", input)
+		}
+	}
+}
+
+func TestInstallFromHuggingFaceMissingRevisionDiscardsPartialDownload(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manager := New(tmpDir, WithHFDownloader(func(ctx context.Context, opts InstallOptions, tmpPath, revision string) error {
+		if err := os.WriteFile(filepath.Join(tmpPath, "config.json"), []byte("{}"), 0o644); err != nil {
+			return err
+		}
+		return downloadError("hf", errors.New("exit status 1"), "huggingface_hub.errors.EntryNotFoundError: 404 Client Error. Entry Not Found")
+	}))
+
+	_, err := manager.InstallFromHuggingFace(context.Background(), InstallOptions{ModelID: "Qwen/Qwen2.5-0.5B", Revision: "v2"})
+	if !IsRevisionNotFound(err) {
+		t.Fatalf("expected ErrRevisionNotFound, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "Qwen", "Qwen2.5-0.5B.tmp")); !os.IsNotExist(err) {
+		t.Fatalf("expected partial download of a missing revision to be removed, stat err = %v", err)
+	}
+}
+
+func TestInstallFromHuggingFaceRecordsFallbackRevision(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	manager := New(tmpDir, WithHFDownloader(func(ctx context.Context, opts InstallOptions, tmpPath, revision string) error {
+		return os.WriteFile(filepath.Join(tmpPath, "config.json"), []byte("{}"), 0o644)
+	}))
+
+	if _, err := manager.InstallFromHuggingFace(context.Background(), InstallOptions{
+		ModelID:           "Qwen/Qwen2.5-0.5B",
+		Revision:          "v1.0",
+		RequestedRevision: "main",
+	}); err != nil {
+		t.Fatalf("InstallFromHuggingFace() error = %v", err)
+	}
+	meta, err := readMetadata(filepath.Join(tmpDir, "Qwen", "Qwen2.5-0.5B"))
+	if err != nil {
+		t.Fatalf("readMetadata: %v", err)
+	}
+	if meta.Revision != "v1.0" || meta.RequestedRevision != "main" {
+		t.Fatalf("expected metadata to record v1.0 installed for main, got %+v", meta)
+	}
+	info, err := manager.Get("Qwen/Qwen2.5-0.5B")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if info.Revision != "v1.0" || info.RequestedRevision != "main" {
+		t.Fatalf("expected v1.0 installed for main, got revision %q requested %q", info.Revision, info.RequestedRevision)
+	}
+
+	if _, err := manager.InstallFromHuggingFace(context.Background(), InstallOptions{
+		ModelID:           "Qwen/Qwen2.5-0.5B",
+		Revision:          "main",
+		RequestedRevision: "main",
+		Overwrite:         true,
+	}); err != nil {
+		t.Fatalf("InstallFromHuggingFace() error = %v", err)
+	}
+	if info, err := manager.Get("Qwen/Qwen2.5-0.5B"); err != nil || info.Revision != "main" || info.RequestedRevision != "" {
+		t.Fatalf("expected no requested revision when the first candidate installed, got %+v, %v", info, err)
+	}
+}
+
+func TestRevisionCandidates(t *testing.T) {
+	cases := []struct {
+		primary   string
+		fallbacks []string
+		want      []string
+	}{
+		{"", nil, []string{"main"}},
+		{"v1.1", []string{"v1.0", " main ", "v1.1", ""}, []string{"v1.1", "v1.0", "main"}},
+		{"", []string{"main", "v2"}, []string{"main", "v2"}},
+	}
+	for _, tc := range cases {
+		if got := RevisionCandidates(tc.primary, tc.fallbacks); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("RevisionCandidates(%q, %v) = %v, want %v", tc.primary, tc.fallbacks, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package weights
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const manifestFilename = ".model-manager-manifest"
+
+// FileInfo is one downloaded file recorded in a weight directory's manifest.
+type FileInfo struct {
+	// Path is relative to the weight directory, with forward slashes.
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"sizeBytes"`
+	SHA256    string `json:"sha256"`
+}
+
+type fileManifest struct {
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Files       []FileInfo `json:"files"`
+}
+
+// writeManifest hashes every file under dir and records the result next to
+// the metadata file, so an install can later be reproduced or checked.
+// Hidden files and directories (the metadata, CLI caches) are skipped.
+// Hashing a large install takes a while, so progress (which may be nil) is
+// called after each file and a cancelled ctx stops it without writing.
+func writeManifest(ctx context.Context, dir string, progress func(file string, hashed, total int)) error {
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files := make([]FileInfo, 0, len(paths))
+	for i, p := range paths {
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		size, sum, err := hashFile(ctx, p)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", rel, err)
+		}
+		rel = filepath.ToSlash(rel)
+		files = append(files, FileInfo{Path: rel, SizeBytes: size, SHA256: sum})
+		if progress != nil {
+			progress(rel, i+1, len(paths))
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	data, err := json.MarshalIndent(fileManifest{GeneratedAt: time.Now().UTC(), Files: files}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFilename), data, 0o644)
+}
+
+func readManifest(dir string) ([]FileInfo, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFilename))
+	if err != nil {
+		return nil, err
+	}
+	var manifest fileManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	return manifest.Files, nil
+}
+
+func hashFile(ctx context.Context, path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, contextReader{ctx: ctx, r: f})
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// contextReader fails reads once ctx is done, so hashing a multi-gigabyte
+// file stops promptly when the install is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,114 @@
+package weights
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// Fetching 4 files:  50%|█████     | 2/4 [00:10<00:10,  5.00s/it]
+	fetchProgressPattern = regexp.MustCompile(`Fetching (\d+) files:\s+\d+%.*?\|\s*(\d+)/(\d+)`)
+	// model-00001-of-00002.safetensors:  45%|████▌     | 450M/1.00G [00:10<00:12, 45.0MB/s]
+	fileProgressPattern = regexp.MustCompile(`^(\S+?):\s+\d+%.*?\|\s*([\d.]+)([kKMGTP]?)B?/([\d.]+)([kKMGTP]?)B?`)
+)
+
+// progressWriter captures Hugging Face CLI output while translating its tqdm
+// progress bars into InstallOptions progress callbacks.
+type progressWriter struct {
+	opts InstallOptions
+
+	mu         sync.Mutex
+	output     bytes.Buffer
+	pending    []byte
+	fileIndex  map[string]int
+	totalFiles int
+}
+
+func newProgressWriter(opts InstallOptions) *progressWriter {
+	return &progressWriter{
+		opts:       opts,
+		fileIndex:  make(map[string]int),
+		totalFiles: len(opts.Files),
+	}
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.output.Write(p)
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexAny(w.pending, "\r\n")
+		if idx < 0 {
+			break
+		}
+		line := strings.TrimSpace(string(w.pending[:idx]))
+		w.pending = w.pending[idx+1:]
+		if line != "" {
+			w.parseLine(line)
+		}
+	}
+	return len(p), nil
+}
+
+// String returns everything the CLI printed, for error reporting.
+func (w *progressWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.output.String()
+}
+
+func (w *progressWriter) parseLine(line string) {
+	if m := fetchProgressPattern.FindStringSubmatch(line); m != nil {
+		total, _ := strconv.Atoi(m[3])
+		completed, _ := strconv.Atoi(m[2])
+		if total > 0 {
+			w.totalFiles = total
+		}
+		if w.opts.Progress != nil {
+			w.opts.Progress("", completed, w.totalFiles)
+		}
+		return
+	}
+	if m := fileProgressPattern.FindStringSubmatch(line); m != nil {
+		file := m[1]
+		downloaded := parseSizeWithUnit(m[2], m[3])
+		total := parseSizeWithUnit(m[4], m[5])
+		idx, ok := w.fileIndex[file]
+		if !ok {
+			idx = len(w.fileIndex) + 1
+			w.fileIndex[file] = idx
+		}
+		totalFiles := w.totalFiles
+		if totalFiles < idx {
+			totalFiles = idx
+		}
+		if w.opts.ProgressBytes != nil && total > 0 {
+			w.opts.ProgressBytes(file, idx, totalFiles, downloaded, total)
+		}
+	}
+}
+
+func parseSizeWithUnit(value, unit string) int64 {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	multiplier := float64(1)
+	switch strings.ToUpper(unit) {
+	case "K":
+		multiplier = 1e3
+	case "M":
+		multiplier = 1e6
+	case "G":
+		multiplier = 1e9
+	case "T":
+		multiplier = 1e12
+	case "P":
+		multiplier = 1e15
+	}
+	return int64(n * multiplier)
+}
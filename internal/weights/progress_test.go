@@ -0,0 +1,55 @@
+package weights
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProgressWriterParsesHFCLIOutput(t *testing.T) {
+	t.Parallel()
+
+	type fileCall struct{ completed, total int }
+	type byteCall struct {
+		file              string
+		index, totalFiles int
+		downloaded, total int64
+	}
+	var files []fileCall
+	var bytesCalls []byteCall
+	w := newProgressWriter(InstallOptions{
+		Files: []string{"config.json", "model.safetensors"},
+		Progress: func(file string, completed, total int) {
+			files = append(files, fileCall{completed, total})
+		},
+		ProgressBytes: func(file string, index, totalFiles int, downloaded, total int64) {
+			bytesCalls = append(bytesCalls, byteCall{file, index, totalFiles, downloaded, total})
+		},
+	})
+
+	output := "Fetching 2 files:   0%|          | 0/2 [00:00<?, ?it/s]\r" +
+		"model.safetensors:  45%|████▌     | 450M/1.00G [00:10<00:12, 45.0MB/s]\r" +
+		"model.safetensors: 100%|██████████| 1.00G/1.00G [00:22<00:00, 45.0MB/s]\n" +
+		"Fetching 2 files:  50%|█████     | 1/2 [00:22<00:22, 22.0s/it]\n"
+	// Split the payload to make sure partial lines are buffered across writes.
+	mid := len(output) / 2
+	if _, err := w.Write([]byte(output[:mid])); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(output[mid:])); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 || files[0] != (fileCall{0, 2}) || files[1] != (fileCall{1, 2}) {
+		t.Fatalf("unexpected file progress calls: %+v", files)
+	}
+	if len(bytesCalls) != 2 {
+		t.Fatalf("expected 2 byte progress calls, got %+v", bytesCalls)
+	}
+	first := bytesCalls[0]
+	if first.file != "model.safetensors" || first.index != 1 || first.totalFiles != 2 || first.downloaded != 450e6 || first.total != 1e9 {
+		t.Fatalf("unexpected byte progress: %+v", first)
+	}
+	if !strings.Contains(w.String(), "Fetching 2 files") {
+		t.Fatalf("expected raw output to be retained")
+	}
+}
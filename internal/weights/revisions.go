@@ -0,0 +1,71 @@
+package weights
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrRevisionNotFound marks a download that failed because the requested
+// revision, or files the install asked for, don't exist in the repository.
+// Callers can retry such an install with another revision.
+var ErrRevisionNotFound = errors.New("revision or files not found")
+
+// missingRevisionMarkers are the huggingface_hub error names and messages the
+// CLI prints when a revision or file is missing, as opposed to auth, network,
+// or repository errors that another revision wouldn't fix.
+var missingRevisionMarkers = []string{
+	"RevisionNotFoundError",
+	"EntryNotFoundError",
+	"Revision Not Found",
+	"Entry Not Found",
+	"Invalid rev id",
+}
+
+func missingRevisionOutput(output string) bool {
+	for _, marker := range missingRevisionMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevisionNotFound reports whether err means the revision or its files are
+// missing.
+func IsRevisionNotFound(err error) bool {
+	return errors.Is(err, ErrRevisionNotFound)
+}
+
+// RevisionCandidates returns the revisions to try in order: primary
+// (defaulting to main) followed by the fallbacks, trimmed and without
+// duplicates.
+func RevisionCandidates(primary string, fallbacks []string) []string {
+	primary = strings.TrimSpace(primary)
+	if primary == "" {
+		primary = "main"
+	}
+	revisions := []string{primary}
+	seen := map[string]struct{}{primary: {}}
+	for _, revision := range fallbacks {
+		revision = strings.TrimSpace(revision)
+		if revision == "" {
+			continue
+		}
+		if _, ok := seen[revision]; ok {
+			continue
+		}
+		seen[revision] = struct{}{}
+		revisions = append(revisions, revision)
+	}
+	return revisions
+}
+
+// requestedRevision returns requested when it differs from the installed
+// revision, and "" otherwise.
+func requestedRevision(requested, installed string) string {
+	requested = strings.TrimSpace(requested)
+	if requested == "" || requested == installed {
+		return ""
+	}
+	return requested
+}
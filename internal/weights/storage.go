@@ -0,0 +1,136 @@
+package weights
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Supported storage backends for the URIs handed to KServe.
+const (
+	StorageBackendPVC = "pvc"
+	StorageBackendS3  = "s3"
+	StorageBackendGCS = "gcs"
+)
+
+// StorageLayout describes where KServe should load installed weights from.
+// Weights are always cached on the local PVC; the layout only decides which
+// storage URI and in-container model path the catalog entry should use.
+type StorageLayout struct {
+	Backend   string
+	PVCName   string
+	Bucket    string
+	Prefix    string
+	ModelRoot string
+}
+
+// ValidStorageBackend reports whether backend is one of the supported values.
+func ValidStorageBackend(backend string) bool {
+	switch normalizeBackend(backend) {
+	case StorageBackendPVC, StorageBackendS3, StorageBackendGCS:
+		return true
+	}
+	return false
+}
+
+// StorageURI returns the KServe storage URI for an installed weight directory,
+// or an empty string when the layout lacks the PVC name or bucket it needs.
+func (l StorageLayout) StorageURI(name string) string {
+	name = strings.Trim(name, "/")
+	if name == "" {
+		return ""
+	}
+	switch normalizeBackend(l.Backend) {
+	case StorageBackendS3, StorageBackendGCS:
+		if l.Bucket == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s://%s/%s", bucketScheme(l.Backend), l.Bucket, path.Join(strings.Trim(l.Prefix, "/"), name))
+	default:
+		if l.PVCName == "" {
+			return ""
+		}
+		return fmt.Sprintf("pvc://%s/%s", l.PVCName, name)
+	}
+}
+
+// InferencePath returns the path the runtime should load the model from.
+// PVC mounts expose the whole volume, so the weight directory sits below the
+// model root; object-store initializers download a single model straight into
+// the model root.
+func (l StorageLayout) InferencePath(name string) string {
+	if l.ModelRoot == "" || name == "" {
+		return ""
+	}
+	switch normalizeBackend(l.Backend) {
+	case StorageBackendS3, StorageBackendGCS:
+		return l.ModelRoot
+	default:
+		return path.Join(l.ModelRoot, name)
+	}
+}
+
+// StorageLocation is a storage URI resolved against a StorageLayout.
+type StorageLocation struct {
+	// Backend is StorageBackendPVC, StorageBackendS3 or StorageBackendGCS.
+	Backend string
+	// Volume is the PVC name or bucket the URI points into.
+	Volume string
+	// Name is the installed weight directory, empty when the URI stops at
+	// the volume or prefix.
+	Name string
+}
+
+// Resolve maps a storage URI back to the installed weight directory it
+// serves from. Any supported scheme is accepted regardless of l.Backend, so
+// entries written before a backend switch still resolve; it reports false for
+// other schemes and for URIs outside the configured PVC, bucket or prefix.
+func (l StorageLayout) Resolve(uri string) (StorageLocation, bool) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(uri), "://")
+	if !ok {
+		return StorageLocation{}, false
+	}
+	volume, key, _ := strings.Cut(rest, "/")
+	if volume == "" {
+		return StorageLocation{}, false
+	}
+	key = strings.Trim(key, "/")
+	switch strings.ToLower(scheme) {
+	case "pvc":
+		if l.PVCName != "" && volume != l.PVCName {
+			return StorageLocation{}, false
+		}
+		return StorageLocation{Backend: StorageBackendPVC, Volume: volume, Name: key}, true
+	case "s3", "gs", "gcs":
+		backend := StorageBackendS3
+		if strings.ToLower(scheme) != "s3" {
+			backend = StorageBackendGCS
+		}
+		if normalizeBackend(l.Backend) != backend || volume != l.Bucket {
+			return StorageLocation{}, false
+		}
+		if prefix := strings.Trim(l.Prefix, "/"); prefix != "" {
+			if key != prefix && !strings.HasPrefix(key, prefix+"/") {
+				return StorageLocation{}, false
+			}
+			key = strings.Trim(strings.TrimPrefix(key, prefix), "/")
+		}
+		return StorageLocation{Backend: backend, Volume: volume, Name: key}, true
+	}
+	return StorageLocation{}, false
+}
+
+func normalizeBackend(backend string) string {
+	backend = strings.ToLower(strings.TrimSpace(backend))
+	if backend == "" {
+		return StorageBackendPVC
+	}
+	return backend
+}
+
+func bucketScheme(backend string) string {
+	if normalizeBackend(backend) == StorageBackendGCS {
+		return "gs"
+	}
+	return "s3"
+}
@@ -0,0 +1,84 @@
+package weights
+
+import "testing"
+
+func TestStorageLayoutURIs(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		layout   StorageLayout
+		wantURI  string
+		wantPath string
+	}{
+		{
+			name:     "pvc",
+			layout:   StorageLayout{PVCName: "venus-model-storage", ModelRoot: "/mnt/models"},
+			wantURI:  "pvc://venus-model-storage/Qwen/Qwen2.5-0.5B",
+			wantPath: "/mnt/models/Qwen/Qwen2.5-0.5B",
+		},
+		{
+			name:     "s3",
+			layout:   StorageLayout{Backend: "s3", Bucket: "models", Prefix: "/cache/", ModelRoot: "/mnt/models"},
+			wantURI:  "s3://models/cache/Qwen/Qwen2.5-0.5B",
+			wantPath: "/mnt/models",
+		},
+		{
+			name:     "gcs",
+			layout:   StorageLayout{Backend: "GCS", Bucket: "models", ModelRoot: "/mnt/models"},
+			wantURI:  "gs://models/Qwen/Qwen2.5-0.5B",
+			wantPath: "/mnt/models",
+		},
+		{
+			name:     "s3 without bucket",
+			layout:   StorageLayout{Backend: "s3", ModelRoot: "/mnt/models"},
+			wantURI:  "",
+			wantPath: "/mnt/models",
+		},
+	}
+	for _, tc := range cases {
+		if got := tc.layout.StorageURI("Qwen/Qwen2.5-0.5B"); got != tc.wantURI {
+			t.Fatalf("%s: StorageURI = %q want %q", tc.name, got, tc.wantURI)
+		}
+		if got := tc.layout.InferencePath("Qwen/Qwen2.5-0.5B"); got != tc.wantPath {
+			t.Fatalf("%s: InferencePath = %q want %q", tc.name, got, tc.wantPath)
+		}
+	}
+	if ValidStorageBackend("azure") {
+		t.Fatalf("expected azure to be rejected")
+	}
+}
+
+func TestStorageLayoutResolve(t *testing.T) {
+	t.Parallel()
+
+	pvc := StorageLayout{PVCName: "venus-model-storage"}
+	s3 := StorageLayout{Backend: "s3", PVCName: "venus-model-storage", Bucket: "models", Prefix: "cache"}
+	gcs := StorageLayout{Backend: "gcs", Bucket: "models"}
+	cases := []struct {
+		name   string
+		layout StorageLayout
+		uri    string
+		want   StorageLocation
+		ok     bool
+	}{
+		{"pvc", pvc, "pvc://venus-model-storage/Qwen/Qwen2.5-0.5B/", StorageLocation{Backend: "pvc", Volume: "venus-model-storage", Name: "Qwen/Qwen2.5-0.5B"}, true},
+		{"pvc root", pvc, "pvc://venus-model-storage", StorageLocation{Backend: "pvc", Volume: "venus-model-storage"}, true},
+		{"other pvc", pvc, "pvc://scratch/Qwen/Qwen2.5-0.5B", StorageLocation{}, false},
+		{"s3", s3, "s3://models/cache/Qwen/Qwen2.5-0.5B", StorageLocation{Backend: "s3", Volume: "models", Name: "Qwen/Qwen2.5-0.5B"}, true},
+		{"s3 outside prefix", s3, "s3://models/cached/Qwen/Qwen2.5-0.5B", StorageLocation{}, false},
+		{"s3 other bucket", s3, "s3://archive/cache/Qwen/Qwen2.5-0.5B", StorageLocation{}, false},
+		{"pvc on s3 layout", s3, "pvc://venus-model-storage/Qwen/Qwen2.5-0.5B", StorageLocation{Backend: "pvc", Volume: "venus-model-storage", Name: "Qwen/Qwen2.5-0.5B"}, true},
+		{"gs", gcs, "gs://models/Qwen/Qwen2.5-0.5B", StorageLocation{Backend: "gcs", Volume: "models", Name: "Qwen/Qwen2.5-0.5B"}, true},
+		{"gcs alias", gcs, "gcs://models/Qwen/Qwen2.5-0.5B", StorageLocation{Backend: "gcs", Volume: "models", Name: "Qwen/Qwen2.5-0.5B"}, true},
+		{"s3 on gcs layout", gcs, "s3://models/Qwen/Qwen2.5-0.5B", StorageLocation{}, false},
+		{"hf", pvc, "hf://Qwen/Qwen2.5-0.5B", StorageLocation{}, false},
+		{"bare path", pvc, "/mnt/models/Qwen", StorageLocation{}, false},
+	}
+	for _, tc := range cases {
+		got, ok := tc.layout.Resolve(tc.uri)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("%s: Resolve(%q) = %+v, %v want %+v, %v", tc.name, tc.uri, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package weights
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oremus-labs/ol-model-manager/internal/vllm"
+)
+
+// VerifyResult compares an installed weight directory with the files Hugging
+// Face reports for the same model revision.
+type VerifyResult struct {
+	Name           string         `json:"name"`
+	HFModelID      string         `json:"hfModelId,omitempty"`
+	Revision       string         `json:"revision,omitempty"`
+	OK             bool           `json:"ok"`
+	Checked        int            `json:"checked"`
+	Missing        []string       `json:"missing,omitempty"`
+	SizeMismatches []SizeMismatch `json:"sizeMismatches,omitempty"`
+	Extra          []string       `json:"extra,omitempty"`
+}
+
+// SizeMismatch records a file whose on-disk size differs from the Hub.
+type SizeMismatch struct {
+	File          string `json:"file"`
+	ExpectedBytes int64  `json:"expectedBytes"`
+	ActualBytes   int64  `json:"actualBytes"`
+}
+
+// Verify checks the installed files for modelName against the expected Hub
+// siblings. When the install requested specific files only those are
+// expected, with glob patterns such as "*.safetensors" expanded against the
+// Hub file list. Sizes are compared only when the Hub reported one; extra files are
+// reported but do not fail verification.
+func (m *Manager) Verify(modelName string, expected []vllm.HFSibling) (*VerifyResult, error) {
+	modelPath, rel, err := m.resolve(modelName)
+	if err != nil {
+		return nil, err
+	}
+	result := &VerifyResult{Name: rel}
+	meta, _ := readMetadata(modelPath)
+	if meta != nil {
+		result.HFModelID = meta.ModelID
+		result.Revision = meta.Revision
+	}
+
+	want := make(map[string]int64, len(expected))
+	for _, sibling := range expected {
+		want[sibling.RFileName] = sibling.Size
+	}
+	if meta != nil && len(meta.Files) > 0 {
+		want = requestedFiles(meta.Files, want)
+	}
+
+	actual := make(map[string]int64)
+	err = filepath.WalkDir(modelPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == modelPath {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(modelPath, p)
+		if err != nil {
+			return err
+		}
+		actual[filepath.ToSlash(relPath)] = info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	for file, size := range want {
+		got, ok := actual[file]
+		if !ok {
+			result.Missing = append(result.Missing, file)
+			continue
+		}
+		result.Checked++
+		if size > 0 && got != size {
+			result.SizeMismatches = append(result.SizeMismatches, SizeMismatch{File: file, ExpectedBytes: size, ActualBytes: got})
+		}
+	}
+	for file := range actual {
+		if _, ok := want[file]; !ok {
+			result.Extra = append(result.Extra, file)
+		}
+	}
+	sort.Strings(result.Missing)
+	sort.Strings(result.Extra)
+	sort.Slice(result.SizeMismatches, func(i, j int) bool {
+		return result.SizeMismatches[i].File < result.SizeMismatches[j].File
+	})
+	result.OK = len(result.Missing) == 0 && len(result.SizeMismatches) == 0
+	return result, nil
+}
+
+// requestedFiles narrows the Hub files to those an install asked for. Entries
+// with glob characters are expanded with path.Match; a pattern that matches
+// nothing on the Hub is kept as-is so it shows up as missing.
+func requestedFiles(files []string, hub map[string]int64) map[string]int64 {
+	requested := make(map[string]int64, len(files))
+	for _, pattern := range files {
+		if !strings.ContainsAny(pattern, "*?[") {
+			requested[pattern] = hub[pattern]
+			continue
+		}
+		matched := false
+		for file, size := range hub {
+			if ok, _ := path.Match(pattern, file); ok {
+				requested[file] = size
+				matched = true
+			}
+		}
+		if !matched {
+			requested[pattern] = 0
+		}
+	}
+	return requested
+}
+
+func (m *Manager) resolve(modelName string) (string, string, error) {
+	rel, err := normalizeRelativePath(modelName)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid model path: %w", err)
+	}
+	if m.isReserved(rel) {
+		return "", "", fmt.Errorf("model weights not found: %s", rel)
+	}
+	modelPath := filepath.Join(m.storagePath, toFilesystemPath(rel))
+	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
+		return "", "", fmt.Errorf("model weights not found: %s", rel)
+	}
+	return modelPath, rel, nil
+}
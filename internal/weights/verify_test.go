@@ -0,0 +1,124 @@
+package weights
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oremus-labs/ol-model-manager/internal/vllm"
+)
+
+func TestVerifyReportsMissingMismatchedAndExtraFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	modelDir := filepath.Join(root, "Qwen", "Qwen2.5-0.5B")
+	files := map[string]string{
+		"config.json":             "{}",
+		"model.safetensors":       "truncated",
+		"notes.txt":               "local",
+		".cache/huggingface/lock": "",
+	}
+	for name, content := range files {
+		p := filepath.Join(modelDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := writeMetadata(modelDir, weightMetadata{ModelID: "Qwen/Qwen2.5-0.5B", Revision: "main"}); err != nil {
+		t.Fatalf("writeMetadata: %v", err)
+	}
+
+	result, err := New(root).Verify("Qwen/Qwen2.5-0.5B", []vllm.HFSibling{
+		{RFileName: "config.json", Size: 2},
+		{RFileName: "model.safetensors", Size: 1024},
+		{RFileName: "tokenizer.json", Size: 10},
+	})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if result.OK {
+		t.Fatalf("expected verification to fail")
+	}
+	if result.HFModelID != "Qwen/Qwen2.5-0.5B" || result.Checked != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !reflect.DeepEqual(result.Missing, []string{"tokenizer.json"}) {
+		t.Fatalf("missing = %v", result.Missing)
+	}
+	if len(result.SizeMismatches) != 1 || result.SizeMismatches[0].File != "model.safetensors" || result.SizeMismatches[0].ActualBytes != 9 {
+		t.Fatalf("size mismatches = %+v", result.SizeMismatches)
+	}
+	if !reflect.DeepEqual(result.Extra, []string{"notes.txt"}) {
+		t.Fatalf("extra = %v", result.Extra)
+	}
+}
+
+func TestVerifyOnlyExpectsRequestedFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	modelDir := filepath.Join(root, "Qwen", "Qwen2.5-0.5B")
+	if err := os.MkdirAll(modelDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "config.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := writeMetadata(modelDir, weightMetadata{ModelID: "Qwen/Qwen2.5-0.5B", Files: []string{"config.json"}}); err != nil {
+		t.Fatalf("writeMetadata: %v", err)
+	}
+
+	result, err := New(root).Verify("Qwen/Qwen2.5-0.5B", []vllm.HFSibling{
+		{RFileName: "config.json", Size: 2},
+		{RFileName: "model.safetensors", Size: 1024},
+	})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !result.OK || len(result.Missing) != 0 {
+		t.Fatalf("expected only requested files to be checked, got %+v", result)
+	}
+}
+
+func TestVerifyExpandsRequestedGlobPatterns(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	modelDir := filepath.Join(root, "Qwen", "Qwen2.5-0.5B")
+	if err := os.MkdirAll(modelDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, data := range map[string]string{"config.json": "{}", "model-00001.safetensors": "abcd"} {
+		if err := os.WriteFile(filepath.Join(modelDir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := writeMetadata(modelDir, weightMetadata{
+		ModelID: "Qwen/Qwen2.5-0.5B",
+		Files:   []string{"config.json", "*.safetensors", "*.gguf"},
+	}); err != nil {
+		t.Fatalf("writeMetadata: %v", err)
+	}
+
+	result, err := New(root).Verify("Qwen/Qwen2.5-0.5B", []vllm.HFSibling{
+		{RFileName: "config.json", Size: 2},
+		{RFileName: "model-00001.safetensors", Size: 4},
+		{RFileName: "model-00002.safetensors", Size: 8},
+		{RFileName: "onnx/model.safetensors", Size: 16},
+		{RFileName: "README.md", Size: 10},
+	})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if want := []string{"*.gguf", "model-00002.safetensors"}; !reflect.DeepEqual(result.Missing, want) {
+		t.Fatalf("missing = %v, want %v", result.Missing, want)
+	}
+	if result.Checked != 2 || len(result.SizeMismatches) != 0 || len(result.Extra) != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
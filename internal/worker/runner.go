@@ -2,31 +2,85 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/oremus-labs/ol-model-manager/internal/jobs"
+	"github.com/oremus-labs/ol-model-manager/internal/logutil"
 	"github.com/oremus-labs/ol-model-manager/internal/metrics"
 	"github.com/oremus-labs/ol-model-manager/internal/queue"
 	"github.com/oremus-labs/ol-model-manager/internal/store"
 )
 
+// Queue is the consumer side of the job stream the runner reads from.
+// *queue.Consumer satisfies it for Redis; *queue.Memory for tests.
+type Queue interface {
+	EnsureGroup(context.Context) error
+	Next(context.Context) (*queue.WeightInstallMessage, string, error)
+	Ack(context.Context, string) error
+	Requeue(context.Context, string, *queue.WeightInstallMessage) error
+	DeadLetter(ctx context.Context, id string, msg *queue.WeightInstallMessage, data, reason string) error
+	Length(context.Context) (int64, error)
+	Pending(context.Context) (int64, error)
+}
+
 // Options configure the background worker process.
 type Options struct {
 	Store    *store.Store
 	Jobs     *jobs.Manager
 	Logger   *log.Logger
-	Queue    *queue.Consumer
+	Queue    Queue
 	Interval time.Duration
+	// ShutdownGrace is the drain timeout: how long an in-flight job may keep
+	// running after shutdown starts before it is interrupted and handed back.
+	ShutdownGrace time.Duration
+	// WorkerID identifies this replica when claiming jobs from the datastore.
+	WorkerID string
+	// PollInterval controls how often the datastore is polled for pending
+	// jobs when no queue is configured.
+	PollInterval time.Duration
+	// MetricsInterval controls how often queue depth and pending counts are
+	// sampled for Prometheus (default 15s).
+	MetricsInterval time.Duration
 }
 
 // Runner processes queued jobs.
 type Runner struct {
-	store    *store.Store
-	jobs     *jobs.Manager
-	logger   *log.Logger
-	queue    *queue.Consumer
-	interval time.Duration
+	store         *store.Store
+	jobs          *jobs.Manager
+	logger        *log.Logger
+	queue         Queue
+	interval      time.Duration
+	shutdownGrace time.Duration
+	workerID      string
+	pollInterval  time.Duration
+	metricsEvery  time.Duration
+}
+
+// ShutdownReport summarizes what happened to in-flight work when the worker stopped.
+type ShutdownReport struct {
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+	Completed []string  `json:"completed,omitempty"`
+	HandedOff []string  `json:"handedOff,omitempty"`
+	Failed    []string  `json:"failed,omitempty"`
+}
+
+// interruptWait is how long a drained job gets to stop after its context is
+// cancelled before the worker logs that it is still waiting. The job is never
+// handed off while its goroutine may still be writing.
+const interruptWait = 10 * time.Second
+
+type inflightJob struct {
+	job    *store.Job
+	msg    *queue.WeightInstallMessage
+	msgID  string
+	done   chan struct{}
+	cancel context.CancelFunc
+	err    error
 }
 
 // New creates a new Runner.
@@ -38,12 +92,32 @@ func New(opts Options) *Runner {
 	if opts.Logger == nil {
 		opts.Logger = log.Default()
 	}
+	grace := opts.ShutdownGrace
+	if grace <= 0 {
+		grace = 20 * time.Second
+	}
+	poll := opts.PollInterval
+	if poll <= 0 {
+		poll = 2 * time.Second
+	}
+	metricsEvery := opts.MetricsInterval
+	if metricsEvery <= 0 {
+		metricsEvery = 15 * time.Second
+	}
+	workerID := opts.WorkerID
+	if workerID == "" {
+		workerID, _ = os.Hostname()
+	}
 	return &Runner{
-		store:    opts.Store,
-		jobs:     opts.Jobs,
-		logger:   opts.Logger,
-		queue:    opts.Queue,
-		interval: interval,
+		store:         opts.Store,
+		jobs:          opts.Jobs,
+		logger:        opts.Logger,
+		queue:         opts.Queue,
+		interval:      interval,
+		shutdownGrace: grace,
+		workerID:      workerID,
+		pollInterval:  poll,
+		metricsEvery:  metricsEvery,
 	}
 }
 
@@ -53,6 +127,9 @@ func (r *Runner) Run(ctx context.Context) error {
 		r.logger = log.Default()
 	}
 
+	if r.queue == nil && r.store != nil && r.jobs != nil {
+		return r.runClaimLoop(ctx)
+	}
 	if r.queue == nil {
 		r.logger.Println("worker queue not configured; falling back to heartbeat")
 		ticker := time.NewTicker(r.interval)
@@ -73,6 +150,10 @@ func (r *Runner) Run(ctx context.Context) error {
 	}
 	r.logger.Println("worker connected to Redis queue; waiting for jobs")
 	r.observeQueueDepth(ctx)
+	go r.monitorQueue(ctx)
+	if r.store != nil {
+		go r.enqueueDueRetries(ctx)
+	}
 
 	for {
 		select {
@@ -85,6 +166,11 @@ func (r *Runner) Run(ctx context.Context) error {
 				if ctx.Err() != nil {
 					return ctx.Err()
 				}
+				var malformed *queue.MalformedMessageError
+				if errors.As(err, &malformed) && msgID != "" {
+					r.deadLetter(ctx, msgID, nil, malformed.Data, "", err.Error())
+					continue
+				}
 				r.logger.Printf("worker queue read error: %v", err)
 				time.Sleep(time.Second)
 				continue
@@ -115,18 +201,255 @@ func (r *Runner) Run(ctx context.Context) error {
 				continue
 			}
 
+			if reason := poisonReason(job, msg.Request); reason != "" {
+				r.deadLetter(ctx, msgID, msg, "", job.ID, reason)
+				continue
+			}
+
+			if job.NextAttemptAt != nil && time.Now().Before(*job.NextAttemptAt) {
+				// The backoff is persisted on the job; enqueueDueRetries
+				// puts it back on the stream once it elapses.
+				r.logger.Printf("worker: job %s retries at %s; deferring", job.ID, job.NextAttemptAt.Format(time.RFC3339))
+				_ = r.queue.Ack(ctx, msgID)
+				continue
+			}
+
 			r.logger.Printf("worker: processing job %s (%s)", msg.JobID, msg.Request.ModelID)
-			r.jobs.ProcessJob(job, msg.Request)
+			inflight := r.start(job, msg, msgID)
+			select {
+			case <-inflight.done:
+				r.ack(ctx, inflight.msgID)
+			case <-ctx.Done():
+				r.shutdown(inflight)
+				return ctx.Err()
+			}
+		}
+	}
+}
 
-			if err := r.queue.Ack(ctx, msgID); err != nil {
-				r.logger.Printf("worker: failed to ack message %s: %v", msgID, err)
-			} else {
-				r.observeQueueDepth(ctx)
+// runClaimLoop claims pending jobs straight from the datastore. It is used when
+// Redis is unavailable so replicas sharing a Postgres datastore can split work
+// without double-processing.
+func (r *Runner) runClaimLoop(ctx context.Context) error {
+	r.logger.Printf("worker queue not configured; claiming jobs from the datastore as %s", r.workerID)
+	for {
+		job, err := r.store.ClaimNextPendingJob(ctx, r.workerID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+			r.logger.Printf("worker: failed to claim job: %v", err)
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				r.logger.Println("worker shutting down")
+				return ctx.Err()
+			case <-time.After(r.pollInterval):
+			}
+			continue
+		}
+
+		req, err := jobs.InstallRequestFromPayload(job.Payload)
+		reason := ""
+		if err != nil {
+			reason = fmt.Sprintf("invalid payload: %v", err)
+		} else {
+			reason = poisonReason(job, req)
+		}
+		if reason != "" {
+			r.logger.Printf("worker: dead-lettering job %s: %s", job.ID, reason)
+			if _, err := r.jobs.DeadLetterJob(job.ID, reason); err != nil {
+				r.logger.Printf("worker: failed to dead-letter job %s: %v", job.ID, err)
+			}
+			continue
+		}
+
+		r.logger.Printf("worker: processing claimed job %s (%s)", job.ID, req.ModelID)
+		inflight := r.start(job, &queue.WeightInstallMessage{JobID: job.ID, Request: req}, "")
+		select {
+		case <-inflight.done:
+		case <-ctx.Done():
+			r.shutdown(inflight)
+			return ctx.Err()
 		}
 	}
 }
 
+// poisonReason explains why a job can never succeed, or returns "" when it is
+// safe to process.
+func poisonReason(job *store.Job, req jobs.InstallRequest) string {
+	if req.ModelID == "" {
+		return "invalid payload: missing hfModelId"
+	}
+	if job.MaxAttempts > 0 && job.Attempt >= job.MaxAttempts {
+		return fmt.Sprintf("exceeded max attempts (%d/%d)", job.Attempt, job.MaxAttempts)
+	}
+	return ""
+}
+
+// deadLetter moves a poison message to the dead-letter stream and marks its
+// job failed so neither the stream nor the retry path keeps picking it up.
+func (r *Runner) deadLetter(ctx context.Context, msgID string, msg *queue.WeightInstallMessage, data, jobID, reason string) {
+	r.logger.Printf("worker: dead-lettering message %s: %s", msgID, reason)
+	if err := r.queue.DeadLetter(ctx, msgID, msg, data, reason); err != nil {
+		r.logger.Printf("worker: failed to dead-letter message %s: %v", msgID, err)
+		return
+	}
+	if jobID != "" {
+		if _, err := r.jobs.DeadLetterJob(jobID, reason); err != nil {
+			r.logger.Printf("worker: failed to mark job %s dead-lettered: %v", jobID, err)
+		}
+	}
+	r.observeQueueDepth(ctx)
+}
+
+// enqueueDueRetries re-publishes retried jobs once their persisted backoff
+// elapses, checking every poll interval. ReleaseDueRetry clears the backoff
+// first so only one replica enqueues each job; if publishing fails the
+// backoff is restored and the job is tried again on a later pass.
+func (r *Runner) enqueueDueRetries(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		due, err := r.store.DueRetryJobs(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Printf("worker: failed to list due retries: %v", err)
+			}
+			continue
+		}
+		for _, job := range due {
+			req, err := jobs.InstallRequestFromPayload(job.Payload)
+			if err != nil {
+				r.logger.Printf("worker: retry %s has an invalid payload: %v", job.ID, err)
+				continue
+			}
+			released, err := r.store.ReleaseDueRetry(ctx, job.ID)
+			if err != nil || !released {
+				continue
+			}
+			if err := r.queue.Requeue(ctx, "", &queue.WeightInstallMessage{JobID: job.ID, Request: req}); err != nil {
+				r.logger.Printf("worker: failed to enqueue retry %s: %v", job.ID, err)
+				next := time.Now().UTC().Add(r.pollInterval)
+				job.NextAttemptAt = &next
+				if err := r.store.UpdateJob(job); err != nil {
+					r.logger.Printf("worker: failed to restore backoff for %s: %v", job.ID, err)
+				}
+				continue
+			}
+			r.observeQueueDepth(ctx)
+		}
+	}
+}
+
+func (r *Runner) start(job *store.Job, msg *queue.WeightInstallMessage, msgID string) *inflightJob {
+	// The job runs on its own context so shutdown can drain it instead of
+	// killing it the moment the worker's context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	inflight := &inflightJob{
+		job:    job,
+		msg:    msg,
+		msgID:  msgID,
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	go func() {
+		defer close(inflight.done)
+		defer cancel()
+		started := time.Now()
+		inflight.err = r.jobs.ProcessJobContext(ctx, job, msg.Request)
+		metrics.ObserveJobProcessing(job.Type, jobOutcome(inflight.err), time.Since(started))
+	}()
+	return inflight
+}
+
+func (r *Runner) ack(ctx context.Context, msgID string) {
+	if r.queue == nil {
+		return
+	}
+	if err := r.queue.Ack(ctx, msgID); err != nil {
+		r.logger.Printf("worker: failed to ack message %s: %v", msgID, err)
+		return
+	}
+	r.observeQueueDepth(ctx)
+}
+
+// shutdown drains the in-flight job: it waits up to the grace period for the
+// job to finish, then interrupts it (partial downloads are kept), waits for it
+// to stop and hands it back to the queue so another worker can resume it right
+// away.
+func (r *Runner) shutdown(inflight *inflightJob) ShutdownReport {
+	report := ShutdownReport{StartedAt: time.Now().UTC()}
+	r.logger.Printf("worker shutting down; waiting up to %s for job %s", r.shutdownGrace, inflight.job.ID)
+
+	timer := time.NewTimer(r.shutdownGrace)
+	defer timer.Stop()
+
+	select {
+	case <-inflight.done:
+	case <-timer.C:
+		r.logger.Printf("worker: drain timeout reached; interrupting job %s", inflight.job.ID)
+		inflight.cancel()
+		select {
+		case <-inflight.done:
+		case <-time.After(interruptWait):
+			r.logger.Printf("worker: job %s did not stop within %s; still waiting before handing it off", inflight.job.ID, interruptWait)
+			<-inflight.done
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if finished(inflight) {
+		r.ack(ctx, inflight.msgID)
+		report.Completed = append(report.Completed, inflight.job.ID)
+	} else {
+		if err := r.handoff(ctx, inflight); err != nil {
+			r.logger.Printf("worker: failed to hand off job %s: %v", inflight.job.ID, err)
+			report.Failed = append(report.Failed, inflight.job.ID)
+		} else {
+			report.HandedOff = append(report.HandedOff, inflight.job.ID)
+		}
+	}
+
+	report.Duration = time.Since(report.StartedAt).String()
+	logutil.Info("worker_shutdown_report", map[string]interface{}{
+		"startedAt": report.StartedAt,
+		"duration":  report.Duration,
+		"completed": report.Completed,
+		"handedOff": report.HandedOff,
+		"failed":    report.Failed,
+	})
+	return report
+}
+
+// finished reports whether the stopped job ran to completion (successfully or
+// not) rather than being interrupted.
+func finished(inflight *inflightJob) bool {
+	<-inflight.done
+	return !errors.Is(inflight.err, jobs.ErrJobInterrupted)
+}
+
+func (r *Runner) handoff(ctx context.Context, inflight *inflightJob) error {
+	if _, err := r.jobs.ReleaseJob(inflight.job.ID, "Worker shutting down; job returned to the queue"); err != nil {
+		return err
+	}
+	if r.queue == nil {
+		// Released jobs are pending again, so the next claim picks them up.
+		return nil
+	}
+	// Requeue re-publishes and acks atomically; if it fails the original
+	// delivery stays unacknowledged in the group rather than being dropped.
+	return r.queue.Requeue(ctx, inflight.msgID, inflight.msg)
+}
+
 func (r *Runner) pendingJobs() int {
 	if r.store == nil {
 		return 0
@@ -141,6 +464,21 @@ func (r *Runner) pendingJobs() int {
 	return len(jobs)
 }
 
+// monitorQueue samples the stream on a fixed interval so the gauges stay
+// current while the worker is idle or stuck on a long job.
+func (r *Runner) monitorQueue(ctx context.Context) {
+	ticker := time.NewTicker(r.metricsEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.observeQueueDepth(ctx)
+		}
+	}
+}
+
 func (r *Runner) observeQueueDepth(ctx context.Context) {
 	if r.queue == nil {
 		return
@@ -148,10 +486,27 @@ func (r *Runner) observeQueueDepth(ctx context.Context) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	depth, err := r.queue.Pending(ctx)
-	if err != nil {
+	if depth, err := r.queue.Length(ctx); err != nil {
 		r.logger.Printf("worker: failed to inspect queue depth: %v", err)
-		return
+	} else {
+		metrics.SetQueueDepth(depth)
+	}
+	if pending, err := r.queue.Pending(ctx); err != nil {
+		r.logger.Printf("worker: failed to inspect pending entries: %v", err)
+	} else {
+		metrics.SetQueuePending(pending)
+	}
+}
+
+func jobOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "completed"
+	case errors.Is(err, jobs.ErrJobInterrupted):
+		return "interrupted"
+	case errors.Is(err, jobs.ErrJobCancelled):
+		return "cancelled"
+	default:
+		return "failed"
 	}
-	metrics.SetJobQueueDepth(depth)
 }
@@ -0,0 +1,139 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/oremus-labs/ol-model-manager/internal/jobs"
+	"github.com/oremus-labs/ol-model-manager/internal/metrics"
+	"github.com/oremus-labs/ol-model-manager/internal/queue"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// gathered returns the metric named name whose labels include labels, or nil.
+func gathered(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, pair := range metric.GetLabel() {
+				if value, ok := labels[pair.GetName()]; ok && value == pair.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return metric
+			}
+		}
+	}
+	return nil
+}
+
+func gaugeValue(t *testing.T, name string) float64 {
+	t.Helper()
+	metric := gathered(t, name, nil)
+	if metric == nil {
+		t.Fatalf("metric %s not registered", name)
+	}
+	return metric.GetGauge().GetValue()
+}
+
+// lengthlessQueue fails Length so Pending must still be reported.
+type lengthlessQueue struct {
+	*queue.Memory
+}
+
+func (q lengthlessQueue) Length(context.Context) (int64, error) {
+	return 0, errors.New("XLEN failed")
+}
+
+// The gauges are process-wide, so these tests don't run in parallel.
+
+func TestMonitorQueueSamplesDepthAndPending(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := queue.NewMemory()
+	for i := 0; i < 3; i++ {
+		if err := q.Enqueue(ctx, fmt.Sprintf("job-%d", i), jobs.InstallRequest{}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	r := New(Options{Queue: q, MetricsInterval: 10 * time.Millisecond, Logger: log.New(io.Discard, "", 0)})
+	go r.monitorQueue(ctx)
+
+	if _, _, err := q.Next(ctx); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for gaugeValue(t, "model_manager_queue_depth") != 3 || gaugeValue(t, "model_manager_queue_pending") != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("gauges not sampled: depth=%v pending=%v",
+				gaugeValue(t, "model_manager_queue_depth"), gaugeValue(t, "model_manager_queue_pending"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestObserveQueueDepthReportsPendingWhenLengthFails(t *testing.T) {
+	ctx := context.Background()
+	metrics.SetQueueDepth(7)
+	q := queue.NewMemory()
+	for i := 0; i < 2; i++ {
+		if err := q.Enqueue(ctx, fmt.Sprintf("job-%d", i), jobs.InstallRequest{}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+		if _, _, err := q.Next(ctx); err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+	}
+	r := New(Options{Queue: lengthlessQueue{q}, Logger: log.New(io.Discard, "", 0)})
+	r.observeQueueDepth(ctx)
+
+	if got := gaugeValue(t, "model_manager_queue_pending"); got != 2 {
+		t.Fatalf("pending = %v, want 2", got)
+	}
+	if got := gaugeValue(t, "model_manager_queue_depth"); got != 7 {
+		t.Fatalf("a failed XLEN should leave the last depth in place, got %v", got)
+	}
+}
+
+func TestJobOutcome(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{nil, "completed"},
+		{fmt.Errorf("install: %w", jobs.ErrJobInterrupted), "interrupted"},
+		{errors.New("download failed"), "failed"},
+	}
+	for _, tc := range cases {
+		if got := jobOutcome(tc.err); got != tc.want {
+			t.Errorf("jobOutcome(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+
+	labels := map[string]string{"type": "worker-test", "outcome": jobOutcome(nil)}
+	before := uint64(0)
+	if metric := gathered(t, "model_manager_job_processing_seconds", labels); metric != nil {
+		before = metric.GetHistogram().GetSampleCount()
+	}
+	metrics.ObserveJobProcessing("worker-test", jobOutcome(nil), 2*time.Second)
+	metric := gathered(t, "model_manager_job_processing_seconds", labels)
+	if metric == nil || metric.GetHistogram().GetSampleCount() != before+1 {
+		t.Fatalf("expected one processing sample for %v, got %v", labels, metric)
+	}
+}
@@ -37,8 +37,8 @@ export async function getSystemInfo(): Promise<SystemInfo | null> {
 }
 
 export async function getModels(): Promise<Model[]> {
-  const data = await fetchJSON<Model[]>('/models');
-  return data ?? [];
+  const data = await fetchJSON<{ models: Model[] }>('/models');
+  return data?.models ?? [];
 }
 
 export async function getWeights(): Promise<WeightInfo[]> {